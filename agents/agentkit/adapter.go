@@ -12,6 +12,7 @@ import (
 	multiagentspec "github.com/agentplexus/multi-agent-spec/sdk/go"
 
 	"github.com/agentplexus/assistantkit/agents/core"
+	"github.com/agentplexus/assistantkit/models"
 )
 
 func init() {
@@ -55,6 +56,30 @@ func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 	return append(data, '\n'), nil
 }
 
+// Lossiness reports the canonical fields that the agentkit format drops or degrades.
+// Model names are written as full model strings and are not mapped back.
+func (a *Adapter) Lossiness(agent *core.Agent) []core.Loss {
+	losses := core.DroppedFields(a.Name(), agent,
+		core.FieldName, core.FieldDescription, core.FieldModel, core.FieldTools,
+		core.FieldInstructions)
+	losses = append(losses, core.DegradedModel(a.Name(), agent, mapModelToAgentKit,
+		func(model string) core.Model { return core.Model(model) })...)
+	return append(losses, core.DegradedTools(a.Name(), agent, core.FieldTools, agent.Tools, agentKitTool, func(tool string) string {
+		if mapped, ok := reverseAgentKitToolMapping[tool]; ok {
+			return mapped
+		}
+		return tool
+	})...)
+}
+
+// agentKitTool maps a single canonical tool name to its agentkit name.
+func agentKitTool(tool string) string {
+	if mapped := mapToolToAgentKit(tool); mapped != "" {
+		return mapped
+	}
+	return strings.ToLower(tool)
+}
+
 // ReadFile reads from path and returns canonical Agent.
 func (a *Adapter) ReadFile(path string) (*core.Agent, error) {
 	data, err := os.ReadFile(path)
@@ -129,25 +154,15 @@ type TimeoutConfig struct {
 	ParallelTotal string `json:"parallel_total"`
 }
 
-// agentKitModelMapping maps canonical models to agentkit local model strings.
-// AgentKit uses full model strings rather than Bedrock ARNs.
-var agentKitModelMapping = map[string]string{
-	"haiku":  "claude-3-haiku-20240307",
-	"sonnet": "claude-3-5-sonnet-20241022",
-	"opus":   "claude-3-opus-20240229",
-}
-
 // mapToolToAgentKit converts a canonical tool string to AgentKit tool using multi-agent-spec.
 func mapToolToAgentKit(tool string) string {
 	return multiagentspec.MapToolToAgentKit(multiagentspec.Tool(tool))
 }
 
 // mapModelToAgentKit converts a canonical model to AgentKit model string.
+// AgentKit uses full model strings rather than Bedrock ARNs.
 func mapModelToAgentKit(model core.Model) string {
-	if mapped, ok := agentKitModelMapping[string(model)]; ok {
-		return mapped
-	}
-	return string(model)
+	return models.AgentKit.Write(model)
 }
 
 func agentToConfig(agent *core.Agent) *AgentConfig {
@@ -47,6 +47,7 @@ type (
 	Agent   = core.Agent
 	Adapter = core.Adapter
 	Model   = core.Model
+	Loss    = core.Loss
 )
 
 // Re-export model constants
@@ -70,6 +71,12 @@ var (
 	MarshalMarkdownAgent = core.MarshalMarkdownAgent
 )
 
+// Lossiness returns the fields of agent that the named adapter's format drops
+// or degrades. Example: Lossiness(agent, "kiro")
+func Lossiness(agent *Agent, to string) ([]Loss, error) {
+	return core.DefaultRegistry.Lossiness(agent, to)
+}
+
 // Re-export error types
 type (
 	ParseError   = core.ParseError
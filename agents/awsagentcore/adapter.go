@@ -11,9 +11,8 @@ import (
 	"strings"
 	"text/template"
 
-	multiagentspec "github.com/agentplexus/multi-agent-spec/sdk/go"
-
 	"github.com/agentplexus/assistantkit/agents/core"
+	"github.com/agentplexus/assistantkit/models"
 )
 
 func init() {
@@ -48,6 +47,13 @@ func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 	return generateAgentConstruct(agent)
 }
 
+// Lossiness reports the canonical fields that the AgentCore CDK output drops.
+func (a *Adapter) Lossiness(agent *core.Agent) []core.Loss {
+	return core.DroppedFields(a.Name(), agent,
+		core.FieldName, core.FieldDescription, core.FieldModel, core.FieldTools,
+		core.FieldInstructions)
+}
+
 // ReadFile is not typically used for CDK output.
 func (a *Adapter) ReadFile(path string) (*core.Agent, error) {
 	return nil, &core.ReadError{Path: path, Err: fmt.Errorf("reading CDK files not supported")}
@@ -90,7 +96,8 @@ func DefaultAgentCoreConfig() *AgentCoreConfig {
 	}
 }
 
-// Model mapping is delegated to multi-agent-spec BedrockModels.
+// Model mapping is delegated to the models package, which uses the
+// multi-agent-spec BedrockModels.
 
 // Tool to Lambda action mapping.
 var toolToAction = map[string]string{
@@ -162,14 +169,13 @@ func escapeString(s string) string {
 	return s
 }
 
+// getFoundationModel returns the Bedrock model ID for a model, falling back
+// to Sonnet for models Bedrock does not know.
 func getFoundationModel(model core.Model) string {
-	// Use multi-agent-spec mapping for Bedrock models
-	mapped := multiagentspec.MapModelToBedrock(model)
-	if mapped == string(model) {
-		// Fallback to sonnet if unknown model
-		return multiagentspec.MapModelToBedrock(multiagentspec.ModelSonnet)
+	if id, err := models.AWSAgentCore.Resolve(model); err == nil {
+		return id
 	}
-	return mapped
+	return models.AWSAgentCore.Write(core.ModelSonnet)
 }
 
 func getActions(tools []string) []string {
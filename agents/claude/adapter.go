@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/agentplexus/assistantkit/agents/core"
+	"github.com/agentplexus/assistantkit/vocab"
 )
 
 func init() {
@@ -98,6 +99,14 @@ func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// Lossiness reports the canonical fields that the Claude agent format drops or degrades.
+func (a *Adapter) Lossiness(agent *core.Agent) []core.Loss {
+	losses := core.DroppedFields(a.Name(), agent,
+		core.FieldName, core.FieldDescription, core.FieldModel, core.FieldTools,
+		core.FieldSkills, core.FieldDependencies, core.FieldInstructions)
+	return append(losses, core.UnsupportedTools(a.Name(), agent, core.FieldTools, agent.Tools, vocab.Claude.Has)...)
+}
+
 // ReadFile reads a Claude agent Markdown file and returns canonical Agent.
 func (a *Adapter) ReadFile(path string) (*core.Agent, error) {
 	data, err := os.ReadFile(path)
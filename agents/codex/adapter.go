@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/agentplexus/assistantkit/agents/core"
+	"github.com/agentplexus/assistantkit/models"
 )
 
 func init() {
@@ -98,6 +99,14 @@ func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// Lossiness reports the canonical fields that the Codex agent format drops or degrades.
+func (a *Adapter) Lossiness(agent *core.Agent) []core.Loss {
+	losses := core.DroppedFields(a.Name(), agent,
+		core.FieldName, core.FieldDescription, core.FieldModel, core.FieldTools,
+		core.FieldSkills, core.FieldDependencies, core.FieldInstructions)
+	return append(losses, core.DegradedModel(a.Name(), agent, mapCanonicalModelToCodex, mapCodexModelToCanonical)...)
+}
+
 // ReadFile reads a Codex agent Markdown file and returns canonical Agent.
 func (a *Adapter) ReadFile(path string) (*core.Agent, error) {
 	data, err := os.ReadFile(path)
@@ -197,28 +206,10 @@ func parseList(s string) []string {
 
 // mapCodexModelToCanonical maps Codex model names to canonical names.
 func mapCodexModelToCanonical(codexModel string) core.Model {
-	switch strings.ToLower(codexModel) {
-	case "gpt-4o-mini", "gpt-4-mini":
-		return core.ModelHaiku
-	case "gpt-4o", "gpt-4":
-		return core.ModelSonnet
-	case "o1", "o1-preview":
-		return core.ModelOpus
-	default:
-		return core.Model(codexModel)
-	}
+	return models.Codex.Canonical(codexModel)
 }
 
 // mapCanonicalModelToCodex maps canonical model names to Codex/OpenAI names.
 func mapCanonicalModelToCodex(model core.Model) string {
-	switch model {
-	case core.ModelHaiku:
-		return "gpt-4o-mini"
-	case core.ModelSonnet:
-		return "gpt-4o"
-	case core.ModelOpus:
-		return "o1"
-	default:
-		return string(model)
-	}
+	return models.Codex.Write(model)
 }
@@ -0,0 +1,188 @@
+package core
+
+import "fmt"
+
+// LossKind describes how a field is affected when written to a target format.
+type LossKind string
+
+const (
+	// LossDropped means the field is not written at all.
+	LossDropped LossKind = "dropped"
+
+	// LossDegraded means the field is written but reads back with a different value.
+	LossDegraded LossKind = "degraded"
+
+	// LossUnsupported means the field names something the target does not
+	// have, such as a tool, and is written unchanged.
+	LossUnsupported LossKind = "unsupported"
+)
+
+// Canonical field names used in Loss paths. They match the JSON names of
+// the Agent fields.
+const (
+	FieldName         = "name"
+	FieldNamespace    = "namespace"
+	FieldDescription  = "description"
+	FieldIcon         = "icon"
+	FieldModel        = "model"
+	FieldTools        = "tools"
+	FieldAllowedTools = "allowedTools"
+	FieldSkills       = "skills"
+	FieldDependencies = "dependencies"
+	FieldRequires     = "requires"
+	FieldInstructions = "instructions"
+	FieldTasks        = "tasks"
+)
+
+// Loss describes a canonical field that a target format cannot represent exactly.
+type Loss struct {
+	// Adapter is the name of the target adapter.
+	Adapter string `json:"adapter"`
+
+	// Agent is the name of the agent.
+	Agent string `json:"agent"`
+
+	// Path locates the field in the canonical agent (e.g., "allowedTools").
+	Path string `json:"path"`
+
+	// Kind is whether the field is dropped, degraded or unsupported.
+	Kind LossKind `json:"kind"`
+
+	// Detail explains the loss.
+	Detail string `json:"detail,omitempty"`
+}
+
+// String returns a one-line description of the loss.
+func (l Loss) String() string {
+	s := fmt.Sprintf("%s: agent %s: %s %s", l.Adapter, l.Agent, l.Path, l.Kind)
+	if l.Detail != "" {
+		s += ": " + l.Detail
+	}
+	return s
+}
+
+// LossReporter is implemented by adapters that can report which fields of a
+// canonical agent they drop or degrade when marshaling.
+type LossReporter interface {
+	// Lossiness returns the losses incurred by marshaling agent with this adapter.
+	Lossiness(agent *Agent) []Loss
+}
+
+// Lossiness returns the losses incurred by marshaling agent with the given
+// adapter. It returns nil if the adapter does not implement LossReporter.
+func Lossiness(adapter Adapter, agent *Agent) []Loss {
+	if r, ok := adapter.(LossReporter); ok {
+		return r.Lossiness(agent)
+	}
+	return nil
+}
+
+// Lossiness returns the losses incurred by marshaling agent with the named adapter.
+func (r *Registry) Lossiness(agent *Agent, to string) ([]Loss, error) {
+	adapter, ok := r.GetAdapter(to)
+	if !ok {
+		return nil, fmt.Errorf("unknown adapter: %s", to)
+	}
+	return Lossiness(adapter, agent), nil
+}
+
+// SetFields returns the names of the fields that are set on the agent.
+func SetFields(agent *Agent) []string {
+	var fields []string
+	add := func(set bool, name string) {
+		if set {
+			fields = append(fields, name)
+		}
+	}
+	add(agent.Name != "", FieldName)
+	add(agent.Namespace != "", FieldNamespace)
+	add(agent.Description != "", FieldDescription)
+	add(agent.Icon != "", FieldIcon)
+	add(agent.Model != "", FieldModel)
+	add(len(agent.Tools) > 0, FieldTools)
+	add(len(agent.AllowedTools) > 0, FieldAllowedTools)
+	add(len(agent.Skills) > 0, FieldSkills)
+	add(len(agent.Dependencies) > 0, FieldDependencies)
+	add(len(agent.Requires) > 0, FieldRequires)
+	add(agent.Instructions != "", FieldInstructions)
+	add(len(agent.Tasks) > 0, FieldTasks)
+	return fields
+}
+
+// DroppedFields returns a LossDropped entry for every field set on agent
+// that is not in supported.
+func DroppedFields(adapter string, agent *Agent, supported ...string) []Loss {
+	keep := make(map[string]bool, len(supported))
+	for _, f := range supported {
+		keep[f] = true
+	}
+
+	var losses []Loss
+	for _, field := range SetFields(agent) {
+		if !keep[field] {
+			losses = append(losses, Loss{
+				Adapter: adapter,
+				Agent:   agent.Name,
+				Path:    field,
+				Kind:    LossDropped,
+			})
+		}
+	}
+	return losses
+}
+
+// DegradedModel returns a LossDegraded entry if the agent's model does not
+// survive mapping to the tool's model name and back.
+func DegradedModel(adapter string, agent *Agent, toTool func(Model) string, fromTool func(string) Model) []Loss {
+	if agent.Model == "" {
+		return nil
+	}
+	written := toTool(agent.Model)
+	if back := fromTool(written); back != agent.Model {
+		return []Loss{{
+			Adapter: adapter,
+			Agent:   agent.Name,
+			Path:    FieldModel,
+			Kind:    LossDegraded,
+			Detail:  fmt.Sprintf("model %q is written as %q and read back as %q", agent.Model, written, back),
+		}}
+	}
+	return nil
+}
+
+// DegradedTools returns a LossDegraded entry for the given tool list field
+// if any tool does not survive mapping to the tool's name and back.
+func DegradedTools(adapter string, agent *Agent, field string, tools []string, toTool, fromTool func(string) string) []Loss {
+	var losses []Loss
+	for _, tool := range tools {
+		written := toTool(tool)
+		if back := fromTool(written); back != tool {
+			losses = append(losses, Loss{
+				Adapter: adapter,
+				Agent:   agent.Name,
+				Path:    field,
+				Kind:    LossDegraded,
+				Detail:  fmt.Sprintf("tool %q is written as %q and read back as %q", tool, written, back),
+			})
+		}
+	}
+	return losses
+}
+
+// UnsupportedTools returns a LossUnsupported entry for every tool in the
+// given tool list field that the target does not have.
+func UnsupportedTools(adapter string, agent *Agent, field string, tools []string, has func(string) bool) []Loss {
+	var losses []Loss
+	for _, tool := range tools {
+		if !has(tool) {
+			losses = append(losses, Loss{
+				Adapter: adapter,
+				Agent:   agent.Name,
+				Path:    field,
+				Kind:    LossUnsupported,
+				Detail:  fmt.Sprintf("tool %q does not exist on %s", tool, adapter),
+			})
+		}
+	}
+	return losses
+}
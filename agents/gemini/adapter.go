@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/agentplexus/assistantkit/agents/core"
+	"github.com/agentplexus/assistantkit/models"
+	"github.com/agentplexus/assistantkit/vocab"
 	"github.com/pelletier/go-toml/v2"
 )
 
@@ -59,7 +61,7 @@ func (a *Adapter) Parse(data []byte) (*core.Agent, error) {
 		Name:         ga.Agent.Name,
 		Description:  ga.Agent.Description,
 		Model:        mapGeminiModelToCanonical(ga.Agent.Model),
-		Tools:        ga.Agent.Tools,
+		Tools:        canonicalTools(ga.Agent.Tools),
 		Skills:       ga.Agent.Skills,
 		Dependencies: ga.Agent.Dependencies,
 		Instructions: ga.Instructions,
@@ -75,7 +77,7 @@ func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 			Name:         agent.Name,
 			Description:  agent.Description,
 			Model:        mapCanonicalModelToGemini(agent.Model),
-			Tools:        agent.Tools,
+			Tools:        geminiTools(agent.Tools),
 			Skills:       agent.Skills,
 			Dependencies: agent.Dependencies,
 		},
@@ -90,6 +92,40 @@ func (a *Adapter) Marshal(agent *core.Agent) ([]byte, error) {
 	return data, nil
 }
 
+// Lossiness reports the canonical fields that the Gemini agent format drops or degrades.
+func (a *Adapter) Lossiness(agent *core.Agent) []core.Loss {
+	losses := core.DroppedFields(a.Name(), agent,
+		core.FieldName, core.FieldDescription, core.FieldModel, core.FieldTools,
+		core.FieldSkills, core.FieldDependencies, core.FieldInstructions)
+	losses = append(losses, core.DegradedModel(a.Name(), agent, mapCanonicalModelToGemini, mapGeminiModelToCanonical)...)
+	losses = append(losses, core.DegradedTools(a.Name(), agent, core.FieldTools, agent.Tools, geminiTool, canonicalTool)...)
+	return append(losses, core.UnsupportedTools(a.Name(), agent, core.FieldTools, agent.Tools, vocab.Gemini.Has)...)
+}
+
+// geminiTools maps canonical tool names to Gemini CLI names.
+func geminiTools(tools []string) []string {
+	names, _ := vocab.Gemini.NativeList(tools)
+	return names
+}
+
+// canonicalTools maps Gemini CLI tool names to canonical names.
+func canonicalTools(names []string) []string {
+	tools, _ := vocab.Gemini.CanonicalList(names)
+	return tools
+}
+
+// geminiTool maps a single canonical tool name to its Gemini CLI name.
+func geminiTool(tool string) string {
+	name, _ := vocab.Gemini.Native(tool)
+	return name
+}
+
+// canonicalTool maps a single Gemini CLI tool name to its canonical name.
+func canonicalTool(name string) string {
+	tool, _ := vocab.Gemini.Canonical(name)
+	return tool
+}
+
 // ReadFile reads a Gemini agent TOML file and returns canonical Agent.
 func (a *Adapter) ReadFile(path string) (*core.Agent, error) {
 	data, err := os.ReadFile(path)
@@ -135,28 +171,10 @@ func (a *Adapter) WriteFile(agent *core.Agent, path string) error {
 
 // mapGeminiModelToCanonical maps Gemini model names to canonical names.
 func mapGeminiModelToCanonical(geminiModel string) core.Model {
-	switch strings.ToLower(geminiModel) {
-	case "gemini-2.0-flash", "flash":
-		return core.ModelHaiku
-	case "gemini-2.0-pro", "pro":
-		return core.ModelSonnet
-	case "gemini-2.0-ultra", "ultra":
-		return core.ModelOpus
-	default:
-		return core.Model(geminiModel)
-	}
+	return models.Gemini.Canonical(geminiModel)
 }
 
 // mapCanonicalModelToGemini maps canonical model names to Gemini names.
 func mapCanonicalModelToGemini(model core.Model) string {
-	switch model {
-	case core.ModelHaiku:
-		return "gemini-2.0-flash"
-	case core.ModelSonnet:
-		return "gemini-2.0-pro"
-	case core.ModelOpus:
-		return "gemini-2.0-ultra"
-	default:
-		return string(model)
-	}
+	return models.Gemini.Write(model)
 }
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/agentplexus/assistantkit/agents/core"
+	"github.com/agentplexus/assistantkit/models"
+	"github.com/agentplexus/assistantkit/vocab"
 )
 
 const (
@@ -161,114 +163,63 @@ func (a *Adapter) FromCore(agent *core.Agent) *AgentConfig {
 	return kiroCfg
 }
 
+// Lossiness reports the canonical fields that the Kiro agent format drops or degrades.
+// Skills are written as steering file resources, which are not read back.
+func (a *Adapter) Lossiness(agent *core.Agent) []core.Loss {
+	losses := core.DroppedFields(AdapterName, agent,
+		core.FieldName, core.FieldDescription, core.FieldModel, core.FieldTools,
+		core.FieldAllowedTools, core.FieldSkills, core.FieldInstructions)
+	losses = append(losses, core.DegradedModel(AdapterName, agent, mapCanonicalModelToKiro, mapKiroModelToCanonical)...)
+	losses = append(losses, core.DegradedTools(AdapterName, agent, core.FieldTools, agent.Tools, kiroTool, canonicalTool)...)
+	losses = append(losses, core.DegradedTools(AdapterName, agent, core.FieldAllowedTools, agent.AllowedTools, kiroTool, canonicalTool)...)
+	losses = append(losses, core.UnsupportedTools(AdapterName, agent, core.FieldTools, agent.Tools, vocab.Kiro.Has)...)
+	losses = append(losses, core.UnsupportedTools(AdapterName, agent, core.FieldAllowedTools, agent.AllowedTools, vocab.Kiro.Has)...)
+	if len(agent.Skills) > 0 {
+		losses = append(losses, core.Loss{
+			Adapter: AdapterName,
+			Agent:   agent.Name,
+			Path:    core.FieldSkills,
+			Kind:    core.LossDegraded,
+			Detail:  "skills are written as steering resources and not read back",
+		})
+	}
+	return losses
+}
+
+// kiroTool maps a single canonical tool name to its Kiro name.
+func kiroTool(tool string) string {
+	name, _ := vocab.Kiro.Native(tool)
+	return name
+}
+
+// canonicalTool maps a single Kiro tool name to its canonical name.
+func canonicalTool(name string) string {
+	tool, _ := vocab.Kiro.Canonical(name)
+	return tool
+}
+
 // mapKiroModelToCanonical maps Kiro model names to canonical names.
 func mapKiroModelToCanonical(kiroModel string) core.Model {
-	switch kiroModel {
-	case "claude-sonnet-4", "claude-4-sonnet":
-		return core.ModelSonnet
-	case "claude-opus-4", "claude-4-opus":
-		return core.ModelOpus
-	case "claude-haiku", "claude-3-haiku":
-		return core.ModelHaiku
-	default:
-		// Return as Model type
-		return core.Model(kiroModel)
-	}
+	return models.Kiro.Canonical(kiroModel)
 }
 
 // mapCanonicalModelToKiro maps canonical model names to Kiro names.
 func mapCanonicalModelToKiro(model core.Model) string {
-	switch model {
-	case core.ModelSonnet:
-		return "claude-sonnet-4"
-	case core.ModelOpus:
-		return "claude-opus-4"
-	case core.ModelHaiku:
-		return "claude-haiku"
-	default:
-		return string(model)
-	}
+	return models.Kiro.Write(model)
 }
 
 // mapKiroToolsToCanonical maps Kiro tool names to canonical names.
+// Tools without a canonical name are kept unchanged.
 func mapKiroToolsToCanonical(kiroTools []string) []string {
-	toolMap := map[string]string{
-		// Core tools
-		"execute_bash": "Bash",
-		"fs_read":      "Read",
-		"fs_write":     "Write",
-		"grep":         "Grep",
-		"glob":         "Glob",
-		"web_search":   "WebSearch",
-		"web_fetch":    "WebFetch",
-		// Advanced tools
-		"code":         "Code",
-		"use_aws":      "AWS",
-		"use_subagent": "Task",
-		"introspect":   "Introspect",
-		"report_issue": "ReportIssue",
-		// Experimental tools
-		"knowledge": "Knowledge",
-		"thinking":  "Thinking",
-		"todo_list": "TodoList",
-		"delegate":  "Delegate",
-	}
-
-	var canonical []string
-	for _, tool := range kiroTools {
-		if mapped, ok := toolMap[tool]; ok {
-			canonical = append(canonical, mapped)
-		} else {
-			// Capitalize first letter for unknown tools
-			if len(tool) > 0 {
-				canonical = append(canonical, strings.ToUpper(tool[:1])+tool[1:])
-			}
-		}
-	}
-	return canonical
+	tools, _ := vocab.Kiro.CanonicalList(kiroTools)
+	return tools
 }
 
-// mapCanonicalToolsToKiro maps canonical tool names to Kiro names.
+// mapCanonicalToolsToKiro maps canonical tool names to Kiro names,
+// deduplicating tools that share a name (Write and Edit are both fs_write).
+// Tools Kiro does not have are kept unchanged.
 func mapCanonicalToolsToKiro(tools []string) []string {
-	toolMap := map[string]string{
-		// Core tools
-		"Bash":      "execute_bash",
-		"Read":      "fs_read",
-		"Write":     "fs_write",
-		"Edit":      "fs_write", // Edit maps to fs_write in Kiro
-		"Grep":      "grep",
-		"Glob":      "glob",
-		"WebSearch": "web_search",
-		"WebFetch":  "web_fetch",
-		// Advanced tools
-		"Code":        "code",
-		"AWS":         "use_aws",
-		"Task":        "use_subagent",
-		"Introspect":  "introspect",
-		"ReportIssue": "report_issue",
-		// Experimental tools
-		"Knowledge": "knowledge",
-		"Thinking":  "thinking",
-		"TodoList":  "todo_list",
-		"Delegate":  "delegate",
-	}
-
-	seen := make(map[string]bool)
-	var kiroTools []string
-	for _, tool := range tools {
-		var kiroTool string
-		if mapped, ok := toolMap[tool]; ok {
-			kiroTool = mapped
-		} else {
-			// Lowercase with underscore for unknown tools
-			kiroTool = strings.ToLower(tool)
-		}
-		// Deduplicate (e.g., Write and Edit both map to fs_write)
-		if !seen[kiroTool] {
-			seen[kiroTool] = true
-			kiroTools = append(kiroTools, kiroTool)
-		}
-	}
+	kiroTools, _ := vocab.Kiro.NativeList(tools)
 	return kiroTools
 }
 
@@ -267,3 +267,25 @@ func TestReverseToolMapping(t *testing.T) {
 		}
 	}
 }
+
+func TestToolMappingMCPAndUnsupported(t *testing.T) {
+	got := mapCanonicalToolsToKiro([]string{"Read", "mcp__github__create_issue", "NotebookEdit"})
+	expected := []string{"fs_read", "@github/create_issue", "NotebookEdit"}
+	for i, tool := range expected {
+		if i >= len(got) || got[i] != tool {
+			t.Errorf("Tool[%d] = %v, want %q", i, got, tool)
+		}
+	}
+
+	back := mapKiroToolsToCanonical(got)
+	if back[1] != "mcp__github__create_issue" {
+		t.Errorf("MCP tool read back as %q", back[1])
+	}
+
+	adapter := &Adapter{}
+	agent := &core.Agent{Name: "test", AllowedTools: []string{"Read", "NotebookEdit"}}
+	losses := adapter.Lossiness(agent)
+	if len(losses) != 1 || losses[0].Kind != core.LossUnsupported || losses[0].Path != core.FieldAllowedTools {
+		t.Errorf("Expected one unsupported allowedTools loss, got %v", losses)
+	}
+}
@@ -0,0 +1,211 @@
+package agents
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/agentplexus/assistantkit/agents/core"
+)
+
+var (
+	toolNames  = []string{"Read", "Write", "Edit", "Bash", "Grep", "Glob", "WebFetch", "Task"}
+	modelNames = []Model{"", ModelHaiku, ModelSonnet, ModelOpus, "gpt-4o", "custom-model"}
+	words      = []string{"alpha", "bravo", "charlie", "delta", "echo"}
+)
+
+// writeOnly lists adapters that generate output but cannot parse it back.
+var writeOnly = map[string]bool{"aws-agentcore": true}
+
+// randomAgent is a canonical agent generated for property-based tests.
+type randomAgent struct {
+	agent *Agent
+}
+
+// Generate implements quick.Generator.
+func (randomAgent) Generate(r *rand.Rand, size int) reflect.Value {
+	agent := NewAgent("agent-"+pick(r), "Works on "+pick(r)+" tasks")
+	agent.Model = modelNames[r.Intn(len(modelNames))]
+	agent.Tools = subset(r, toolNames)
+	agent.AllowedTools = subset(r, toolNames)
+	agent.Skills = subset(r, words)
+	agent.Dependencies = subset(r, words)
+	agent.Requires = subset(r, []string{"git", "go", "gh"})
+	if r.Intn(3) == 0 {
+		agent.Namespace = pick(r)
+	}
+	if r.Intn(3) == 0 {
+		agent.Icon = "lucide:" + pick(r)
+	}
+	if r.Intn(3) == 0 {
+		agent.Tasks = []core.Task{{ID: pick(r), Description: "Check " + pick(r)}}
+	}
+	agent.Instructions = "You are the " + pick(r) + " agent.\n\nFollow the " + pick(r) + " process."
+	return reflect.ValueOf(randomAgent{agent: agent})
+}
+
+func pick(r *rand.Rand) string {
+	return words[r.Intn(len(words))]
+}
+
+// subset returns a random subset of items, without duplicates, in random order.
+func subset(r *rand.Rand, items []string) []string {
+	var out []string
+	for _, i := range r.Perm(len(items))[:r.Intn(4)] {
+		out = append(out, items[i])
+	}
+	return out
+}
+
+// fieldValue returns the value of an agent field, normalized so that values
+// with the same meaning compare equal. Tool lists are compared as sets.
+func fieldValue(agent *Agent, field string) interface{} {
+	switch field {
+	case core.FieldName:
+		return agent.Name
+	case core.FieldNamespace:
+		return agent.Namespace
+	case core.FieldDescription:
+		return agent.Description
+	case core.FieldIcon:
+		return agent.Icon
+	case core.FieldModel:
+		return agent.Model
+	case core.FieldTools:
+		return set(agent.Tools)
+	case core.FieldAllowedTools:
+		return set(agent.AllowedTools)
+	case core.FieldSkills:
+		return list(agent.Skills)
+	case core.FieldDependencies:
+		return list(agent.Dependencies)
+	case core.FieldRequires:
+		return list(agent.Requires)
+	case core.FieldInstructions:
+		return strings.TrimSpace(agent.Instructions)
+	case core.FieldTasks:
+		if len(agent.Tasks) == 0 {
+			return nil
+		}
+		return agent.Tasks
+	}
+	panic("unknown field " + field)
+}
+
+func list(l []string) []string {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+func set(l []string) []string {
+	if len(l) == 0 {
+		return nil
+	}
+	s := append([]string(nil), l...)
+	sort.Strings(s)
+	return s
+}
+
+var agentFields = []string{
+	core.FieldName, core.FieldNamespace, core.FieldDescription, core.FieldIcon,
+	core.FieldModel, core.FieldTools, core.FieldAllowedTools, core.FieldSkills,
+	core.FieldDependencies, core.FieldRequires, core.FieldInstructions, core.FieldTasks,
+}
+
+// checkRoundTrip asserts that Parse(Marshal(agent)) preserves every field
+// that the adapter does not report as lost.
+func checkRoundTrip(adapter Adapter, agent *Agent) error {
+	lost := make(map[string]bool)
+	for _, loss := range core.Lossiness(adapter, agent) {
+		lost[loss.Path] = true
+	}
+
+	data, err := adapter.Marshal(agent)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	got, err := adapter.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parse: %w\n%s", err, data)
+	}
+
+	for _, field := range agentFields {
+		if lost[field] {
+			continue
+		}
+		w, g := fieldValue(agent, field), fieldValue(got, field)
+		if !reflect.DeepEqual(w, g) {
+			return fmt.Errorf("%s not reported as lost but changed: expected %v, got %v\n%s", field, w, g, data)
+		}
+	}
+	return nil
+}
+
+func TestRoundTripPreservesSupportedFields(t *testing.T) {
+	for _, name := range AdapterNames() {
+		t.Run(name, func(t *testing.T) {
+			adapter, _ := GetAdapter(name)
+			if _, ok := adapter.(core.LossReporter); !ok {
+				t.Fatalf("adapter %q does not implement LossReporter", name)
+			}
+			if writeOnly[name] {
+				t.Skip("adapter does not support parsing")
+			}
+
+			var failure error
+			property := func(ra randomAgent) bool {
+				failure = checkRoundTrip(adapter, ra.agent)
+				return failure == nil
+			}
+			qc := &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(1))}
+			if err := quick.Check(property, qc); err != nil {
+				t.Errorf("round trip failed: %v", failure)
+			}
+		})
+	}
+}
+
+func TestLossiness(t *testing.T) {
+	agent := NewAgent("reviewer", "Reviews code")
+	agent.Model = ModelSonnet
+	agent.Tools = []string{"Read", "Edit"}
+	agent.AllowedTools = []string{"Read"}
+	agent.Skills = []string{"review"}
+
+	tests := []struct {
+		adapter  string
+		expected []string
+	}{
+		{"claude", []string{"claude: agent reviewer: allowedTools dropped"}},
+		{"kiro", []string{
+			`kiro: agent reviewer: tools degraded: tool "Edit" is written as "fs_write" and read back as "Write"`,
+			"kiro: agent reviewer: skills degraded: skills are written as steering resources and not read back",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.adapter, func(t *testing.T) {
+			losses, err := Lossiness(agent, tt.adapter)
+			if err != nil {
+				t.Fatalf("Lossiness failed: %v", err)
+			}
+			var got []string
+			for _, loss := range losses {
+				got = append(got, loss.String())
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := Lossiness(agent, "unknown"); err == nil {
+		t.Error("expected error for unknown adapter")
+	}
+}
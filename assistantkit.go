@@ -7,8 +7,7 @@
 //   - MCP (Model Context Protocol) server configurations
 //   - Hooks (automation/lifecycle callbacks)
 //   - Settings (permissions, sandbox, general settings) - coming soon
-//   - Rules (team rules, coding guidelines) - coming soon
-//   - Memory (CLAUDE.md, .cursorrules, etc.) - coming soon
+//   - Context (CLAUDE.md, AGENTS.md, Cursor and Windsurf rules, etc.)
 //
 // # MCP Configuration
 //
@@ -52,6 +51,18 @@
 //	// Or convert between formats
 //	data, _ := hooks.Convert(jsonData, "claude", "cursor")
 //
+// # Tool Vocabulary
+//
+// The vocab subpackage maps canonical tool names (Claude Code's, with MCP
+// tools named mcp__<server>__<tool>) to each platform's names. Agent tool
+// lists and hook matchers are translated through it.
+//
+// # Model Mapping
+//
+// The models subpackage maps canonical agent models (haiku, sonnet, opus) to
+// each platform's model IDs. Deployment targets can override the mapping,
+// and models a platform does not know are flagged rather than passed through.
+//
 // # Related Projects
 //
 // Assistant Kit is part of the AgentPlexus family of Go modules:
@@ -99,13 +110,19 @@ func SupportedConfigTypes() []ConfigType {
 // SupportedTools returns a list of AI coding tools that Assistant Kit supports.
 func SupportedTools() []string {
 	return []string{
-		"claude",   // Claude Code / Claude Desktop
-		"cursor",   // Cursor IDE
-		"windsurf", // Windsurf (Codeium)
-		"vscode",   // VS Code / GitHub Copilot
-		"codex",    // OpenAI Codex CLI
-		"cline",    // Cline VS Code extension
-		"roo",      // Roo Code VS Code extension
-		"kiro",     // AWS Kiro CLI
+		"claude",    // Claude Code / Claude Desktop
+		"cursor",    // Cursor IDE
+		"windsurf",  // Windsurf (Codeium)
+		"vscode",    // VS Code / GitHub Copilot
+		"codex",     // OpenAI Codex CLI
+		"cline",     // Cline VS Code extension
+		"roo",       // Roo Code VS Code extension
+		"kiro",      // AWS Kiro CLI
+		"gemini",    // Google Gemini CLI
+		"zed",       // Zed editor
+		"continue",  // Continue IDE extension
+		"goose",     // Goose agent
+		"opencode",  // OpenCode
+		"jetbrains", // JetBrains AI Assistant
 	}
 }
@@ -76,7 +76,7 @@ type Bundle struct {
 	// Agents are the agent/subagent definitions.
 	Agents []*agentscore.Agent
 
-	// Context is the project context (CLAUDE.md, .cursorrules, etc.).
+	// Context is the project context (CLAUDE.md, .cursor/rules, AGENTS.md, etc.).
 	Context *contextcore.Context
 
 	// MCP is the MCP server configuration.
@@ -132,11 +132,12 @@ func (b *Bundle) AddMCPServer(name string, server MCPServer) {
 		Transport: mcpcore.TransportStdio,
 	}
 
-	// Also add to plugin for tools that read from plugin manifest
+	// Also add to plugin for tools that read from plugin manifest. Manifests
+	// are committed, so secret references are written as ${VAR} placeholders.
 	b.Plugin.AddMCPServer(name, pluginscore.MCPServer{
 		Command: server.Command,
 		Args:    server.Args,
-		Env:     server.Env,
+		Env:     mcpcore.RenderEnvPlaceholders(name, mcpcore.FieldEnv, server.Env),
 	})
 }
 
@@ -3,7 +3,10 @@ package bundle
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	mcpcore "github.com/agentplexus/assistantkit/mcp/core"
 )
 
 func TestNewBundle(t *testing.T) {
@@ -211,6 +214,64 @@ func TestGenerateKiro(t *testing.T) {
 	}
 }
 
+func TestGenerateGemini(t *testing.T) {
+	b := New("agentcall", "0.1.0", "Voice calling for AI assistants")
+	b.AddMCPServer("agentcall", MCPServer{Command: "./agentcall"})
+	b.MCP.Servers["docs"] = mcpcore.Server{
+		Transport:      mcpcore.TransportHTTP,
+		URL:            "https://example.com/mcp",
+		ToolTimeoutSec: 30,
+	}
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("gemini", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "gemini-extension.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"name": "agentcall"`, `"command": "./agentcall"`, `"httpUrl": "https://example.com/mcp"`, `"timeout": 30000`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in gemini-extension.json, got:\n%s", want, data)
+		}
+	}
+}
+
+func TestGenerateSecretReferences(t *testing.T) {
+	b := New("agentcall", "0.1.0", "Voice calling for AI assistants")
+	b.AddMCPServer("agentcall", MCPServer{
+		Command: "./agentcall",
+		Env: map[string]string{
+			"NGROK_AUTHTOKEN": "${keyring:ngrok/agentcall}",
+			"TWILIO_API_KEY":  "SKlive123",
+		},
+	})
+
+	tmpDir := t.TempDir()
+	if err := b.Generate("claude", tmpDir); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, ".claude-plugin", "plugin.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"NGROK_AUTHTOKEN": "${NGROK_AUTHTOKEN}"`) {
+		t.Errorf("expected secret reference rendered as placeholder, got:\n%s", data)
+	}
+	if strings.Contains(string(data), "keyring") {
+		t.Errorf("expected no canonical secret reference in plugin.json, got:\n%s", data)
+	}
+
+	warnings := strings.Join(b.Warnings("claude"), "\n")
+	if !strings.Contains(warnings, "TWILIO_API_KEY looks like a plaintext secret") {
+		t.Errorf("expected plaintext secret warning, got:\n%s", warnings)
+	}
+	if !strings.Contains(b.Warnings("gemini")[0], "gemini: ") {
+		t.Errorf("expected gemini warnings, got %v", b.Warnings("gemini"))
+	}
+}
+
 func TestToolConfig(t *testing.T) {
 	// Verify all supported tools have configs
 	for _, tool := range SupportedTools {
@@ -25,12 +25,21 @@ import (
 	_ "github.com/agentplexus/assistantkit/commands/codex"
 	_ "github.com/agentplexus/assistantkit/commands/gemini"
 	_ "github.com/agentplexus/assistantkit/context/claude"
+	_ "github.com/agentplexus/assistantkit/context/codex"
+	_ "github.com/agentplexus/assistantkit/context/cursor"
+	_ "github.com/agentplexus/assistantkit/context/gemini"
+	_ "github.com/agentplexus/assistantkit/context/kiro"
+	_ "github.com/agentplexus/assistantkit/context/vscode"
 	_ "github.com/agentplexus/assistantkit/hooks/claude"
+	_ "github.com/agentplexus/assistantkit/hooks/codex"
 	_ "github.com/agentplexus/assistantkit/hooks/cursor"
+	_ "github.com/agentplexus/assistantkit/hooks/gemini"
+	_ "github.com/agentplexus/assistantkit/hooks/vscode"
 	_ "github.com/agentplexus/assistantkit/hooks/windsurf"
 	_ "github.com/agentplexus/assistantkit/mcp/claude"
 	_ "github.com/agentplexus/assistantkit/mcp/codex"
 	_ "github.com/agentplexus/assistantkit/mcp/cursor"
+	_ "github.com/agentplexus/assistantkit/mcp/gemini"
 	_ "github.com/agentplexus/assistantkit/mcp/kiro"
 	_ "github.com/agentplexus/assistantkit/mcp/vscode"
 	_ "github.com/agentplexus/assistantkit/plugins/claude"
@@ -79,27 +88,35 @@ var DefaultToolConfigs = map[string]ToolConfig{
 		ContextFile: "CLAUDE.md",
 	},
 	"kiro": {
-		AgentsDir: ".kiro/agents",
-		MCPDir:    ".kiro/settings",
-		MCPFile:   "mcp.json",
+		AgentsDir:   ".kiro/agents",
+		MCPDir:      ".kiro/settings",
+		MCPFile:     "mcp.json",
+		ContextDir:  ".kiro/steering",
+		ContextFile: "product.md",
 	},
 	"gemini": {
 		PluginDir:   ".",
 		PluginFile:  "gemini-extension.json",
 		CommandsDir: "commands",
+		HooksDir:    "hooks",
+		HooksFile:   "hooks.json",
 		AgentsDir:   "agents",
+		ContextDir:  ".",
+		ContextFile: "GEMINI.md",
 	},
 	"cursor": {
-		HooksDir:    ".",
-		HooksFile:   ".cursorrules",
+		HooksDir:    ".cursor",
+		HooksFile:   "hooks.json",
 		MCPDir:      ".cursor",
 		MCPFile:     "mcp.json",
-		ContextDir:  ".",
-		ContextFile: ".cursorrules",
+		ContextDir:  ".cursor/rules",
+		ContextFile: "project.mdc",
 	},
 	"codex": {
 		SkillsDir:   "skills",
 		CommandsDir: "prompts",
+		HooksDir:    ".codex",
+		HooksFile:   "hooks.json",
 		AgentsDir:   "agents",
 		MCPDir:      ".codex",
 		MCPFile:     "mcp.json",
@@ -107,8 +124,12 @@ var DefaultToolConfigs = map[string]ToolConfig{
 		ContextFile: "AGENTS.md",
 	},
 	"vscode": {
-		MCPDir:  ".vscode",
-		MCPFile: "mcp.json",
+		HooksDir:    ".github/hooks",
+		HooksFile:   "hooks.json",
+		MCPDir:      ".vscode",
+		MCPFile:     "mcp.json",
+		ContextDir:  ".github",
+		ContextFile: "copilot-instructions.md",
 	},
 }
 
@@ -173,6 +194,25 @@ func (b *Bundle) GenerateAll(outputDir string) error {
 	return nil
 }
 
+// Warnings returns a description of each MCP server field that the tool's
+// generated files drop, degrade or expose as a plaintext secret.
+func (b *Bundle) Warnings(tool string) []string {
+	if b.MCP == nil || len(b.MCP.Servers) == 0 {
+		return nil
+	}
+	var losses []mcpcore.Loss
+	if adapter, ok := mcpcore.GetAdapter(tool); ok {
+		losses = mcpcore.Lossiness(adapter, b.MCP)
+	} else {
+		losses = mcpcore.SecretLosses(tool, b.MCP)
+	}
+	warnings := make([]string, 0, len(losses))
+	for _, loss := range losses {
+		warnings = append(warnings, loss.String())
+	}
+	return warnings
+}
+
 // generatePlugin generates the plugin manifest for a tool.
 func (b *Bundle) generatePlugin(tool, outputDir string, config ToolConfig) error {
 	if config.PluginDir == "" || config.PluginFile == "" {
@@ -211,6 +251,42 @@ func (b *Bundle) generatePlugin(tool, outputDir string, config ToolConfig) error
 		return &GenerateError{Tool: tool, Component: "plugin", Err: err}
 	}
 
+	// Gemini extensions declare MCP servers in the manifest, in the same
+	// form as settings.json, so write them with the MCP adapter
+	if tool == "gemini" {
+		return b.mergeMCP(tool, pluginPath)
+	}
+
+	return nil
+}
+
+// mergeMCP replaces the MCP servers in a generated manifest with the ones
+// written by the tool's MCP adapter, which keeps the fields the plugin
+// format has no room for.
+func (b *Bundle) mergeMCP(tool, path string) error {
+	if b.MCP == nil || len(b.MCP.Servers) == 0 {
+		return nil
+	}
+	adapter, ok := mcpcore.GetAdapter(tool)
+	if !ok {
+		return nil
+	}
+	merger, ok := adapter.(mcpcore.Merger)
+	if !ok {
+		return nil
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return &GenerateError{Tool: tool, Component: "mcp", Err: err}
+	}
+	data, err := merger.Merge(b.MCP, existing)
+	if err != nil {
+		return &GenerateError{Tool: tool, Component: "mcp", Err: err}
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return &GenerateError{Tool: tool, Component: "mcp", Err: err}
+	}
 	return nil
 }
 
@@ -393,7 +469,7 @@ func (b *Bundle) generateClaudePlugin(config ToolConfig, pluginPath string) erro
 			claudePlugin.MCPServers[name] = pluginsclaude.MCPServerConfig{
 				Command:  server.Command,
 				Args:     server.Args,
-				Env:      server.Env,
+				Env:      mcpcore.RenderEnvPlaceholders(name, mcpcore.FieldEnv, server.Env),
 				Cwd:      server.Cwd,
 				Disabled: !server.IsEnabled(),
 			}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/commands"
+	"github.com/agentplexus/assistantkit/hooks"
+	"github.com/agentplexus/assistantkit/mcp"
+	"github.com/agentplexus/assistantkit/merge"
+	"github.com/agentplexus/assistantkit/skills"
+	"github.com/spf13/cobra"
+)
+
+// Config types supported by the convert command.
+const (
+	configTypeMCP      = "mcp"
+	configTypeHooks    = "hooks"
+	configTypeCommands = "commands"
+	configTypeSkills   = "skills"
+)
+
+var (
+	convType   string
+	convFrom   string
+	convTo     string
+	convOutput string
+	convMerge  bool
+	convDryRun bool
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert [file]",
+	Short: "Convert a config file between AI assistant formats",
+	Long: `Convert an MCP, hooks, command or skill file from one tool's format to another.
+
+The config type and source tool are detected from the file path when possible:
+  - .mcp.json, ~/.claude.json          mcp / claude
+  - .cursor/mcp.json                   mcp / cursor
+  - .vscode/mcp.json                   mcp / vscode
+  - ~/.codex/config.toml               mcp / codex
+  - .kiro/settings/mcp.json            mcp / kiro
+  - .zed/settings.json                 mcp / zed
+  - .continue/mcpServers/*.yaml        mcp / continue
+  - ~/.config/goose/config.yaml        mcp / goose
+  - opencode.json                      mcp / opencode
+  - .claude/settings.json              hooks / claude
+  - .cursor/hooks.json                 hooks / cursor
+  - .windsurf/hooks.json               hooks / windsurf
+  - .gemini/settings.json              hooks / gemini (--type=mcp for its MCP servers)
+  - commands/*.md, commands/*.toml     commands / claude, gemini
+  - prompts/*.md                       commands / codex
+  - skills/<name>/SKILL.md             skills / claude
+  - .kiro/steering/*.md                skills / kiro
+
+Use --type and --from to override detection. Reading from stdin (no file
+argument or "-") requires both flags. Output is written to stdout unless
+--output is given. Fields that the target format drops or degrades are
+reported as warnings on stderr.
+
+With --merge, MCP and hooks configs are merged into the existing --output
+file instead of replacing it: only the MCP servers or hooks are updated,
+and all other settings, key order and TOML comments are kept. The diff is
+printed to stdout. Add --dry-run to print the diff without writing.
+
+Example:
+  assistantkit convert .cursor/mcp.json --to=vscode
+  assistantkit convert ~/.codex/config.toml --to=claude --output=.mcp.json
+  cat settings.json | assistantkit convert --type=hooks --from=claude --to=cursor
+  assistantkit convert .mcp.json --to=codex --output=~/.codex/config.toml --merge --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConvert,
+}
+
+func init() {
+	convertCmd.Flags().StringVar(&convType, "type", "", "Config type (mcp, hooks, commands, skills); detected from path if omitted")
+	convertCmd.Flags().StringVar(&convFrom, "from", "", "Source tool; detected from path if omitted")
+	convertCmd.Flags().StringVar(&convTo, "to", "", "Target tool (required)")
+	convertCmd.Flags().StringVarP(&convOutput, "output", "o", "", "Output file (default: stdout)")
+	convertCmd.Flags().BoolVar(&convMerge, "merge", false, "Merge into the existing output file, keeping unmanaged settings (mcp, hooks)")
+	convertCmd.Flags().BoolVar(&convDryRun, "dry-run", false, "With --merge, print the diff without writing")
+	_ = convertCmd.MarkFlagRequired("to")
+}
+
+func runConvert(cmd *cobra.Command, args []string) error {
+	path := ""
+	if len(args) == 1 && args[0] != "-" {
+		path = expandHome(args[0])
+	}
+
+	configType, from := convType, convFrom
+	if path != "" && (configType == "" || from == "") {
+		detectedType, detectedFrom, err := detectConfig(path)
+		if err != nil {
+			return err
+		}
+		if configType == "" {
+			configType = detectedType
+		}
+		if from == "" {
+			from = detectedFrom
+		}
+	}
+	if configType == "" || from == "" {
+		return fmt.Errorf("--type and --from are required when reading from stdin")
+	}
+	if convMerge && convOutput == "" {
+		return fmt.Errorf("--merge requires --output")
+	}
+	if convDryRun && !convMerge {
+		return fmt.Errorf("--dry-run requires --merge")
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if path == "" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("reading input: %w", err)
+	}
+
+	out, err := convertData(configType, data, from, convTo)
+	if err != nil {
+		return fmt.Errorf("converting %s from %s to %s: %w", configType, from, convTo, err)
+	}
+
+	warnings, err := conversionLosses(configType, data, from, convTo)
+	if err != nil {
+		return fmt.Errorf("checking lossiness: %w", err)
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", w)
+	}
+
+	if convOutput == "" {
+		_, err = cmd.OutOrStdout().Write(out)
+		return err
+	}
+
+	outPath := expandHome(convOutput)
+	if convMerge && convDryRun {
+		result, err := mergeOutput(configType, data, from, convTo, outPath, true)
+		if err != nil {
+			return fmt.Errorf("merging output: %w", err)
+		}
+		_, err = fmt.Fprint(cmd.OutOrStdout(), result.Diff)
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		return fmt.Errorf("creating output dir: %w", err)
+	}
+	if convMerge {
+		result, err := mergeOutput(configType, data, from, convTo, outPath, false)
+		if err != nil {
+			return fmt.Errorf("merging output: %w", err)
+		}
+		if !result.Changed {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Unchanged %s (%s -> %s): %s\n", configType, from, convTo, outPath)
+			return nil
+		}
+		fmt.Fprint(cmd.OutOrStdout(), result.Diff)
+		fmt.Fprintf(cmd.ErrOrStderr(), "Merged %s (%s -> %s): %s\n", configType, from, convTo, outPath)
+		return nil
+	}
+	if err := os.WriteFile(outPath, out, 0644); err != nil {
+		return fmt.Errorf("writing output: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Converted %s (%s -> %s): %s\n", configType, from, convTo, outPath)
+	return nil
+}
+
+// conversionLosses returns a description of each field the target format
+// drops or degrades.
+func conversionLosses(configType string, data []byte, from, to string) ([]string, error) {
+	var warnings []string
+	switch configType {
+	case configTypeMCP:
+		adapter, ok := mcp.GetAdapter(from)
+		if !ok {
+			return nil, fmt.Errorf("unknown mcp adapter: %s", from)
+		}
+		cfg, err := adapter.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		losses, err := mcp.Lossiness(cfg, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, loss := range losses {
+			warnings = append(warnings, loss.String())
+		}
+	case configTypeHooks:
+		adapter, ok := hooks.GetAdapter(from)
+		if !ok {
+			return nil, fmt.Errorf("unknown hooks adapter: %s", from)
+		}
+		cfg, err := adapter.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		losses, err := hooks.Lossiness(cfg, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, loss := range losses {
+			warnings = append(warnings, loss.String())
+		}
+	case configTypeCommands:
+		adapter, ok := commands.GetAdapter(from)
+		if !ok {
+			return nil, fmt.Errorf("unknown commands adapter: %s", from)
+		}
+		command, err := adapter.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		losses, err := commands.Lossiness(command, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, loss := range losses {
+			warnings = append(warnings, loss.String())
+		}
+	case configTypeSkills:
+		adapter, ok := skills.GetAdapter(from)
+		if !ok {
+			return nil, fmt.Errorf("unknown skills adapter: %s", from)
+		}
+		skill, err := adapter.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		losses, err := skills.Lossiness(skill, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, loss := range losses {
+			warnings = append(warnings, loss.String())
+		}
+	}
+	return warnings, nil
+}
+
+// mergeOutput parses data with the source adapter and merges it into the
+// file at path with the target adapter. Only MCP and hooks configs can be
+// merged.
+func mergeOutput(configType string, data []byte, from, to, path string, dryRun bool) (*merge.Result, error) {
+	switch configType {
+	case configTypeMCP:
+		adapter, ok := mcp.GetAdapter(from)
+		if !ok {
+			return nil, fmt.Errorf("unknown mcp adapter: %s", from)
+		}
+		cfg, err := adapter.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.MergeFile(cfg, to, path, dryRun)
+	case configTypeHooks:
+		adapter, ok := hooks.GetAdapter(from)
+		if !ok {
+			return nil, fmt.Errorf("unknown hooks adapter: %s", from)
+		}
+		cfg, err := adapter.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		return hooks.MergeFile(cfg, to, path, dryRun)
+	default:
+		return nil, fmt.Errorf("--merge is not supported for %s", configType)
+	}
+}
+
+// convertData dispatches to the registry for the given config type.
+func convertData(configType string, data []byte, from, to string) ([]byte, error) {
+	switch configType {
+	case configTypeMCP:
+		return mcp.Convert(data, from, to)
+	case configTypeHooks:
+		return hooks.Convert(data, from, to)
+	case configTypeCommands:
+		return commands.Convert(data, from, to)
+	case configTypeSkills:
+		return skills.Convert(data, from, to)
+	default:
+		return nil, fmt.Errorf("unknown config type: %s", configType)
+	}
+}
+
+// detectConfig infers the config type and source tool from a file path.
+func detectConfig(path string) (configType, tool string, err error) {
+	slashed := filepath.ToSlash(filepath.Clean(path))
+	base := strings.ToLower(filepath.Base(slashed))
+	ext := filepath.Ext(base)
+	dirs := strings.Split(strings.ToLower(filepath.Dir(slashed)), "/")
+
+	inDir := func(name string) bool {
+		for _, d := range dirs {
+			if d == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	// MCP configs with distinctive file names
+	case base == ".mcp.json", base == ".claude.json", base == "claude_desktop_config.json":
+		return configTypeMCP, "claude", nil
+	case base == "mcp_config.json":
+		return configTypeMCP, "windsurf", nil
+	case base == "cline_mcp_settings.json":
+		return configTypeMCP, "cline", nil
+	case base == "mcp_settings.json":
+		return configTypeMCP, "roo", nil
+	case base == "config.toml" && inDir(".codex"):
+		return configTypeMCP, "codex", nil
+	case base == "opencode.json", base == "opencode.jsonc":
+		return configTypeMCP, "opencode", nil
+	case base == "settings.json" && (inDir(".zed") || inDir("zed")):
+		return configTypeMCP, "zed", nil
+	case (ext == ".yaml" || ext == ".yml") && inDir(".continue"):
+		return configTypeMCP, "continue", nil
+	case base == "config.yaml" && inDir("goose"):
+		return configTypeMCP, "goose", nil
+
+	// MCP configs named mcp.json, identified by directory
+	case base == "mcp.json" && inDir(".cursor"):
+		return configTypeMCP, "cursor", nil
+	case base == "mcp.json" && inDir(".vscode"), base == "mcp.json" && inDir("user") && inDir("code"):
+		return configTypeMCP, "vscode", nil
+	case base == "mcp.json" && inDir(".roo"):
+		return configTypeMCP, "roo", nil
+	case base == "mcp.json" && inDir(".kiro"):
+		return configTypeMCP, "kiro", nil
+
+	// Hooks
+	case inDir(".claude") && (base == "settings.json" || base == "settings.local.json"),
+		base == "managed-settings.json":
+		return configTypeHooks, "claude", nil
+	case base == "hooks.json" && inDir(".cursor"):
+		return configTypeHooks, "cursor", nil
+	case base == "hooks.json" && (inDir(".windsurf") || inDir("windsurf")):
+		return configTypeHooks, "windsurf", nil
+	case base == "hooks.json" && inDir(".codex"):
+		return configTypeHooks, "codex", nil
+	case base == "settings.json" && inDir(".gemini"):
+		return configTypeHooks, "gemini", nil
+	case ext == ".json" && inDir(".kiro") && inDir("agents"):
+		return configTypeHooks, "kiro", nil
+	case ext == ".json" && inDir(".github") && inDir("hooks"):
+		return configTypeHooks, "vscode", nil
+
+	// Skills
+	case base == "skill.md" && inDir(".codex"):
+		return configTypeSkills, "codex", nil
+	case base == "skill.md":
+		return configTypeSkills, "claude", nil
+	case ext == ".md" && inDir(".kiro") && inDir("steering"):
+		return configTypeSkills, "kiro", nil
+
+	// Commands
+	case ext == ".md" && inDir("prompts"):
+		return configTypeCommands, "codex", nil
+	case ext == ".toml" && inDir("commands"):
+		return configTypeCommands, "gemini", nil
+	case ext == ".md" && inDir("commands"):
+		return configTypeCommands, "claude", nil
+	}
+
+	return "", "", fmt.Errorf("cannot detect config type and tool from path %q; use --type and --from", path)
+}
+
+// expandHome expands a leading "~" to the user's home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
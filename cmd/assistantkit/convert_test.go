@@ -0,0 +1,161 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectConfig(t *testing.T) {
+	tests := []struct {
+		path       string
+		configType string
+		tool       string
+	}{
+		{".mcp.json", configTypeMCP, "claude"},
+		{"/home/me/.claude.json", configTypeMCP, "claude"},
+		{".cursor/mcp.json", configTypeMCP, "cursor"},
+		{".vscode/mcp.json", configTypeMCP, "vscode"},
+		{"/home/me/.config/Code/User/mcp.json", configTypeMCP, "vscode"},
+		{"/home/me/.codex/config.toml", configTypeMCP, "codex"},
+		{"/home/me/.codeium/windsurf/mcp_config.json", configTypeMCP, "windsurf"},
+		{"globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json", configTypeMCP, "cline"},
+		{".roo/mcp.json", configTypeMCP, "roo"},
+		{".kiro/settings/mcp.json", configTypeMCP, "kiro"},
+		{".zed/settings.json", configTypeMCP, "zed"},
+		{"/home/me/.config/zed/settings.json", configTypeMCP, "zed"},
+		{".continue/mcpServers/github.yaml", configTypeMCP, "continue"},
+		{"/home/me/.continue/config.yaml", configTypeMCP, "continue"},
+		{"/home/me/.config/goose/config.yaml", configTypeMCP, "goose"},
+		{"opencode.json", configTypeMCP, "opencode"},
+		{"/home/me/.config/opencode/opencode.jsonc", configTypeMCP, "opencode"},
+		{".claude/settings.json", configTypeHooks, "claude"},
+		{".claude/settings.local.json", configTypeHooks, "claude"},
+		{".cursor/hooks.json", configTypeHooks, "cursor"},
+		{".windsurf/hooks.json", configTypeHooks, "windsurf"},
+		{"/home/me/.codeium/windsurf/hooks.json", configTypeHooks, "windsurf"},
+		{"/home/me/.codex/hooks.json", configTypeHooks, "codex"},
+		{".gemini/settings.json", configTypeHooks, "gemini"},
+		{".kiro/agents/reviewer.json", configTypeHooks, "kiro"},
+		{".github/hooks/hooks.json", configTypeHooks, "vscode"},
+		{".claude/commands/release.md", configTypeCommands, "claude"},
+		{".gemini/commands/release.toml", configTypeCommands, "gemini"},
+		{"/home/me/.codex/prompts/release.md", configTypeCommands, "codex"},
+		{".claude/skills/review/SKILL.md", configTypeSkills, "claude"},
+		{"/home/me/.codex/skills/review/SKILL.md", configTypeSkills, "codex"},
+		{".kiro/steering/review.md", configTypeSkills, "kiro"},
+	}
+
+	for _, tt := range tests {
+		configType, tool, err := detectConfig(tt.path)
+		if err != nil {
+			t.Errorf("detectConfig(%q) failed: %v", tt.path, err)
+			continue
+		}
+		if configType != tt.configType || tool != tt.tool {
+			t.Errorf("detectConfig(%q) = %s/%s, want %s/%s", tt.path, configType, tool, tt.configType, tt.tool)
+		}
+	}
+}
+
+func TestDetectConfigUnknown(t *testing.T) {
+	if _, _, err := detectConfig("notes/readme.txt"); err == nil {
+		t.Error("Expected error for unrecognized path")
+	}
+}
+
+func TestConvertData(t *testing.T) {
+	input := []byte(`{"mcpServers":{"github":{"command":"npx","args":["-y","@modelcontextprotocol/server-github"]}}}`)
+
+	out, err := convertData(configTypeMCP, input, "cursor", "vscode")
+	if err != nil {
+		t.Fatalf("convertData failed: %v", err)
+	}
+	if !strings.Contains(string(out), `"servers"`) {
+		t.Errorf("Expected VS Code servers key, got %s", out)
+	}
+
+	if _, err := convertData("unknown", input, "cursor", "vscode"); err == nil {
+		t.Error("Expected error for unknown config type")
+	}
+}
+
+func TestConversionLosses(t *testing.T) {
+	input := []byte(`{"mcpServers":{"fs":{"command":"npx","alwaysAllow":["read_file"]}}}`)
+
+	warnings, err := conversionLosses(configTypeMCP, input, "cline", "claude")
+	if err != nil {
+		t.Fatalf("conversionLosses failed: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != "claude: servers.fs.alwaysAllow dropped" {
+		t.Errorf("Expected alwaysAllow warning, got %v", warnings)
+	}
+
+	warnings, err = conversionLosses(configTypeMCP, input, "cline", "roo")
+	if err != nil {
+		t.Fatalf("conversionLosses failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings for roo, got %v", warnings)
+	}
+}
+
+func TestConversionLossesCommandsAndSkills(t *testing.T) {
+	command := []byte(`[command]
+name = "release"
+description = "Cut a release"
+
+[content]
+instructions = "Tag and publish the release."
+
+[[examples]]
+input = "/release v1.2.3"
+`)
+	warnings, err := conversionLosses(configTypeCommands, command, "gemini", "codex")
+	if err != nil {
+		t.Fatalf("conversionLosses failed: %v", err)
+	}
+	expected := []string{
+		"codex: command release: examples dropped",
+		"codex: command release: name dropped: the name is taken from the file name",
+	}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("Expected %v, got %v", expected, warnings)
+	}
+
+	skill := []byte("---\nname: review\ndescription: Reviews pull requests\ntriggers: [review, pr]\n---\n\nRead the diff.\n")
+	warnings, err = conversionLosses(configTypeSkills, skill, "claude", "codex")
+	if err != nil {
+		t.Fatalf("conversionLosses failed: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != "codex: skill review: triggers dropped" {
+		t.Errorf("Expected triggers warning, got %v", warnings)
+	}
+}
+
+func TestMergeOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	existing := "# Codex\nmodel = \"o3\"\n"
+	if err := os.WriteFile(path, []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+	input := []byte(`{"mcpServers":{"github":{"command":"npx"}}}`)
+
+	result, err := mergeOutput(configTypeMCP, input, "claude", "codex", path, false)
+	if err != nil {
+		t.Fatalf("mergeOutput failed: %v", err)
+	}
+	if !strings.Contains(result.Diff, "+[mcp_servers.github]") {
+		t.Errorf("Expected diff adding github server, got:\n%s", result.Diff)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), existing) {
+		t.Errorf("Expected existing settings to be kept, got:\n%s", data)
+	}
+
+	if _, err := mergeOutput(configTypeSkills, input, "claude", "kiro", path, true); err == nil {
+		t.Error("Expected error for unsupported config type")
+	}
+}
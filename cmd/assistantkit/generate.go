@@ -29,6 +29,7 @@ The specs directory should contain:
   - commands/: Command definitions (*.md or *.json)
   - skills/: Skill definitions (*.md or *.json)
   - agents/: Agent definitions (*.md with YAML frontmatter)
+  - teams/: Team definitions (*.json or *.yaml, optional)
   - deployments/: Deployment definitions (*.json)
 
 Each deployment target receives a complete plugin:
@@ -36,6 +37,12 @@ Each deployment target receives a complete plugin:
   - kiro/kiro-cli: POWER.md + mcp.json or agents/*.json
   - gemini/gemini-cli: gemini-extension.json, commands/, agents/
 
+If the deployment names a team, each target also receives orchestration for
+it: a slash command for Claude, an AGENTS.md section and prompts for Codex,
+a command for Gemini, and a steering file and orchestrator agent for Kiro.
+Set "diagram": true on a Claude target to embed a Mermaid diagram of the
+task graph in its slash command.
+
 Example:
   assistantkit generate
   assistantkit generate --specs=specs --target=local --output=.`,
@@ -216,6 +223,7 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		dir := result.GeneratedDirs[target]
 		fmt.Printf("  - %s: %s\n", target, dir)
 	}
+	printWarnings(result.Warnings)
 
 	fmt.Println("\nDone!")
 	return nil
@@ -264,6 +272,7 @@ func runGenerateDeployment(cmd *cobra.Command, args []string) error {
 		dir := result.GeneratedDirs[target]
 		fmt.Printf("  - %s: %s\n", target, dir)
 	}
+	printWarnings(result.Warnings)
 
 	fmt.Println("\nDone!")
 	return nil
@@ -356,6 +365,7 @@ func runGenerateAgents(cmd *cobra.Command, args []string) error {
 		dir := result.GeneratedDirs[target]
 		fmt.Printf("  - %s: %s\n", target, dir)
 	}
+	printWarnings(result.Warnings)
 
 	fmt.Println("\nDone!")
 	return nil
@@ -418,7 +428,19 @@ func runGenerateAll(cmd *cobra.Command, args []string) error {
 		dir := agentResult.GeneratedDirs[target]
 		fmt.Printf("   Generated %s: %s\n", target, dir)
 	}
+	printWarnings(agentResult.Warnings)
 
 	fmt.Println("\nDone!")
 	return nil
 }
+
+// printWarnings prints fields that generated formats drop or degrade.
+func printWarnings(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Println("\nWarnings:")
+	for _, w := range warnings {
+		fmt.Printf("  - %s\n", w)
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/assistantkit/hooks"
+	"github.com/agentplexus/assistantkit/hooks/hookstest"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hooksConfig   string
+	hooksFrom     string
+	hooksTool     string
+	hooksEvent    string
+	hooksCommand  string
+	hooksFile     string
+	hooksPrompt   string
+	hooksToolName string
+	hooksInput    string
+	hooksDir      string
+	hooksTimeout  time.Duration
+	hooksExpect   string
+	hooksFormat   string
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Work with hook configurations",
+	Long: `Work with hook configurations.
+
+Subcommands:
+  test    Replay a synthetic event against configured hooks`,
+}
+
+var hooksTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Replay a synthetic event against configured hooks",
+	Long: `Send a synthetic event to the hooks in a config file, the way a tool would,
+and report the decision.
+
+The event is a canonical hook event (before_command, before_file_write,
+before_mcp, ...) described by --command, --file, --prompt, --tool-name
+and --input. It is sent as the tool given by --tool would send it:
+  - the payload is the tool's own JSON on the hook's stdin
+  - hook entries are selected with the tool's matchers (Claude, Codex and
+    Gemini match tool names against regular expressions; Kiro matches tool
+    names and MCP servers; other tools run every hook for the event)
+  - each command hook runs in a sandbox directory with its timeout
+  - each response is read back as the tool would read it
+
+Hooks see ASSISTANTKIT_HOOK_TOOL and ASSISTANTKIT_HOOK_EVENT, so hook
+programs built with the hooks runtime need no detection. Prompt hooks
+need a model and are skipped.
+
+The config type is detected from the path as for convert; use --from for
+other paths, or to read a canonical hooks config. The tool defaults to
+the config's tool. With --expect, the command fails unless the decision
+is the given one (allow, deny, ask, modify or none).
+
+Example:
+  assistantkit hooks test --config=.claude/settings.json --event=before_command --command="rm -rf /"
+  assistantkit hooks test --config=hooks.json --from=canonical --tool=cursor --event=before_file_read --file=.env --expect=deny
+  assistantkit hooks test --config=.gemini/settings.json --event=before_mcp --tool-name=mcp__github__create_issue --input='{"title":"x"}'`,
+	RunE: runHooksTest,
+}
+
+func init() {
+	hooksCmd.AddCommand(hooksTestCmd)
+
+	hooksTestCmd.Flags().StringVar(&hooksConfig, "config", "", "Hooks config file (required)")
+	hooksTestCmd.Flags().StringVar(&hooksFrom, "from", "", "Tool format of the config (or \"canonical\"); detected from path if omitted")
+	hooksTestCmd.Flags().StringVar(&hooksTool, "tool", "", "Tool to simulate (default: the config's tool)")
+	hooksTestCmd.Flags().StringVar(&hooksEvent, "event", "", "Canonical event to send (required)")
+	hooksTestCmd.Flags().StringVar(&hooksCommand, "command", "", "Shell command of a command event")
+	hooksTestCmd.Flags().StringVar(&hooksFile, "file", "", "File path of a file event")
+	hooksTestCmd.Flags().StringVar(&hooksPrompt, "prompt", "", "User prompt of a prompt event")
+	hooksTestCmd.Flags().StringVar(&hooksToolName, "tool-name", "", "Tool name (default: the tool's name for the event)")
+	hooksTestCmd.Flags().StringVar(&hooksInput, "input", "", "Tool input as a JSON object")
+	hooksTestCmd.Flags().StringVar(&hooksDir, "dir", "", "Sandbox directory (default: a temporary directory)")
+	hooksTestCmd.Flags().DurationVar(&hooksTimeout, "timeout", hookstest.DefaultTimeout, "Timeout for hooks that do not set one")
+	hooksTestCmd.Flags().StringVar(&hooksExpect, "expect", "", "Fail unless the decision is this one (allow, deny, ask, modify, none)")
+	hooksTestCmd.Flags().StringVar(&hooksFormat, "format", "text", "Output format (text, json)")
+	_ = hooksTestCmd.MarkFlagRequired("config")
+	_ = hooksTestCmd.MarkFlagRequired("event")
+}
+
+func runHooksTest(cmd *cobra.Command, args []string) error {
+	cfg, from, err := readHooksConfig(expandHome(hooksConfig), hooksFrom)
+	if err != nil {
+		return err
+	}
+	tool := hooksTool
+	if tool == "" {
+		if from == "canonical" {
+			return fmt.Errorf("--tool is required for a canonical config")
+		}
+		tool = from
+	}
+
+	event := hooks.Event(hooksEvent)
+	if !isHookEvent(event) {
+		return fmt.Errorf("unknown event: %s", hooksEvent)
+	}
+	payload := &hooks.Payload{
+		Event:    event,
+		Command:  hooksCommand,
+		FilePath: hooksFile,
+		Prompt:   hooksPrompt,
+		ToolName: hooksToolName,
+	}
+	if hooksInput != "" {
+		if err := json.Unmarshal([]byte(hooksInput), &payload.ToolInput); err != nil {
+			return fmt.Errorf("parsing --input: %w", err)
+		}
+	}
+
+	opts := hookstest.Options{Dir: expandHome(hooksDir), Timeout: hooksTimeout}
+	report, err := hookstest.Simulate(cfg, tool, payload, opts)
+	if err != nil {
+		return fmt.Errorf("simulating %s: %w", tool, err)
+	}
+
+	switch hooksFormat {
+	case "json":
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	case "text":
+		writeHooksReport(cmd.OutOrStdout(), report)
+	default:
+		return fmt.Errorf("unknown format: %s", hooksFormat)
+	}
+
+	if hooksExpect != "" && hooksExpect != decisionName(report.Decision) {
+		return fmt.Errorf("expected decision %s, got %s", hooksExpect, decisionName(report.Decision))
+	}
+	return nil
+}
+
+// readHooksConfig reads a hooks config in the given tool's format, or in
+// the format detected from its path, and returns it with the format.
+func readHooksConfig(path, from string) (*hooks.Config, string, error) {
+	if from == "" {
+		configType, tool, err := detectConfig(path)
+		if err != nil {
+			return nil, "", err
+		}
+		if configType != configTypeHooks {
+			return nil, "", fmt.Errorf("%s is a %s config, not a hooks config", path, configType)
+		}
+		from = tool
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if from == "canonical" {
+		cfg := hooks.NewConfig()
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, "", fmt.Errorf("parsing %s: %w", path, err)
+		}
+		return cfg, from, nil
+	}
+	adapter, ok := hooks.GetAdapter(from)
+	if !ok {
+		return nil, "", fmt.Errorf("unknown hooks format: %s", from)
+	}
+	cfg, err := adapter.Parse(data)
+	if err != nil {
+		return nil, "", err
+	}
+	return cfg, from, nil
+}
+
+// isHookEvent reports whether event is a canonical hook event.
+func isHookEvent(event hooks.Event) bool {
+	for _, e := range hooks.AllEvents() {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// decisionName returns the action of a decision, or "none".
+func decisionName(d hooks.Decision) string {
+	if d.Action == "" {
+		return "none"
+	}
+	return string(d.Action)
+}
+
+// writeHooksReport writes the hooks that ran and the resulting decision.
+func writeHooksReport(w io.Writer, report *hookstest.Report) {
+	p := report.Payload
+	fmt.Fprintf(w, "%s %s (%s)", report.Tool, p.NativeEvent, p.Event)
+	if p.ToolName != "" {
+		fmt.Fprintf(w, " tool=%s", p.ToolName)
+	}
+	fmt.Fprintln(w)
+
+	if len(report.Results) == 0 {
+		fmt.Fprintln(w, "  no matching hooks")
+	}
+	for _, r := range report.Results {
+		name := r.Hook.Command
+		if r.Skipped {
+			fmt.Fprintf(w, "  - %s: skipped (%s hook)\n", r.Event, r.Hook.Type)
+			continue
+		}
+		if r.Matcher != "" {
+			fmt.Fprintf(w, "  - %s [%s]: %s\n", r.Event, r.Matcher, name)
+		} else {
+			fmt.Fprintf(w, "  - %s: %s\n", r.Event, name)
+		}
+		switch {
+		case r.Err != nil:
+			fmt.Fprintf(w, "      error: %v\n", r.Err)
+		case r.TimedOut:
+			fmt.Fprintf(w, "      timed out after %s\n", r.Duration.Round(time.Millisecond))
+		default:
+			fmt.Fprintf(w, "      exit %d in %s, decision: %s\n", r.ExitCode, r.Duration.Round(time.Millisecond), decisionName(r.Decision))
+		}
+		if out := strings.TrimSpace(r.Stdout); out != "" {
+			fmt.Fprintf(w, "      stdout: %s\n", out)
+		}
+		if r.Stderr != "" {
+			fmt.Fprintf(w, "      stderr: %s\n", r.Stderr)
+		}
+	}
+
+	fmt.Fprintf(w, "Decision: %s", decisionName(report.Decision))
+	if report.Decision.Reason != "" {
+		fmt.Fprintf(w, " (%s)", report.Decision.Reason)
+	}
+	fmt.Fprintln(w)
+}
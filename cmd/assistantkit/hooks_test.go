@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/hooks"
+	"github.com/agentplexus/assistantkit/hooks/hookstest"
+)
+
+func TestReadHooksConfig(t *testing.T) {
+	dir := t.TempDir()
+	settings := filepath.Join(dir, ".claude", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(settings), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"hooks":{"PreToolUse":[{"matcher":"Bash","hooks":[{"type":"command","command":"./guard.sh"}]}]}}`
+	if err := os.WriteFile(settings, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, from, err := readHooksConfig(settings, "")
+	if err != nil {
+		t.Fatalf("readHooksConfig failed: %v", err)
+	}
+	if from != "claude" {
+		t.Errorf("Expected claude, got %q", from)
+	}
+	if entries := cfg.GetHooks(hooks.BeforeCommand); len(entries) != 1 || entries[0].Hooks[0].Command != "./guard.sh" {
+		t.Errorf("Expected guard hook for before_command, got %+v", cfg.Hooks)
+	}
+
+	canonical := filepath.Join(dir, "hooks.json")
+	data = `{"hooks":{"before_command":[{"hooks":[{"type":"command","command":"./guard.sh"}]}]}}`
+	if err := os.WriteFile(canonical, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _, err = readHooksConfig(canonical, "canonical")
+	if err != nil {
+		t.Fatalf("readHooksConfig failed: %v", err)
+	}
+	if cfg.HookCount() != 1 {
+		t.Errorf("Expected 1 hook, got %d", cfg.HookCount())
+	}
+
+	if _, _, err := readHooksConfig(filepath.Join(dir, ".mcp.json"), ""); err == nil {
+		t.Error("Expected error for an MCP config")
+	}
+}
+
+func TestWriteHooksReport(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("hook scripts use sh")
+	}
+	cfg := hooks.NewConfig()
+	cfg.AddHookWithMatcher(hooks.BeforeCommand, "Bash", hooks.NewCommandHook(`echo "blocked" >&2; exit 2`))
+
+	report := hookstest.Run(t, cfg, "claude", &hooks.Payload{Event: hooks.BeforeCommand, Command: "rm -rf /"})
+	var buf bytes.Buffer
+	writeHooksReport(&buf, report)
+
+	out := buf.String()
+	for _, want := range []string{
+		"claude PreToolUse (before_command) tool=Bash",
+		"before_command [Bash]: echo",
+		"exit 2",
+		"stderr: blocked",
+		"Decision: deny (blocked)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if got := decisionName(hooks.Decision{}); got != "none" {
+		t.Errorf("Expected none, got %q", got)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/agentplexus/assistantkit/discovery"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inspProject string
+	inspTools   []string
+	inspKinds   []string
+	inspFormat  string
+	inspAll     bool
+)
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Show the assistant configuration each tool will see",
+	Long: `Inspect the MCP servers, hooks, agents, commands and skills installed for
+every supported tool, across the managed (enterprise), project and user
+scopes.
+
+Each tool's configuration is resolved with managed settings taking
+precedence over project settings, and project settings over user settings.
+When the same MCP server, agent, command or skill is defined in several
+scopes, the location it is shadowed in is shown. Hooks from all scopes
+apply, unless a managed config allows managed hooks only.
+
+Example:
+  assistantkit inspect
+  assistantkit inspect --tool=cursor --kind=mcp
+  assistantkit inspect --project=~/src/app --format=json`,
+	RunE: runInspect,
+}
+
+func init() {
+	inspectCmd.Flags().StringVar(&inspProject, "project", ".", "Project directory")
+	inspectCmd.Flags().StringSliceVar(&inspTools, "tool", nil, "Only inspect these tools (e.g., claude,cursor)")
+	inspectCmd.Flags().StringSliceVar(&inspKinds, "kind", nil, "Only inspect these kinds (mcp, hooks, agents, commands, skills)")
+	inspectCmd.Flags().StringVar(&inspFormat, "format", "text", "Output format (text, json)")
+	inspectCmd.Flags().BoolVar(&inspAll, "all", false, "Also list locations that were searched but not found")
+}
+
+func runInspect(cmd *cobra.Command, args []string) error {
+	opts := discovery.Options{ProjectRoot: expandHome(inspProject), Tools: inspTools}
+	for _, k := range inspKinds {
+		opts.Kinds = append(opts.Kinds, discovery.Kind(k))
+	}
+
+	report, err := discovery.Discover(opts)
+	if err != nil {
+		return fmt.Errorf("discovering configs: %w", err)
+	}
+
+	switch inspFormat {
+	case "json":
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case "text":
+		return writeInspectText(cmd.OutOrStdout(), report, inspAll)
+	default:
+		return fmt.Errorf("unknown format: %s", inspFormat)
+	}
+}
+
+// writeInspectText writes the resolved view of each tool as text.
+func writeInspectText(w io.Writer, report *discovery.Report, all bool) error {
+	home, _ := os.UserHomeDir()
+	display := func(path string) string {
+		if rel, err := filepath.Rel(report.ProjectRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+		if rel, err := filepath.Rel(home, path); home != "" && err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.Join("~", rel)
+		}
+		return path
+	}
+	where := func(loc discovery.Location, shadowed []discovery.Location) string {
+		s := fmt.Sprintf("%s\t%s", loc.Scope, display(loc.Path))
+		for _, sh := range shadowed {
+			s += fmt.Sprintf(" (shadows %s %s)", sh.Scope, display(sh.Path))
+		}
+		return s
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Project: %s\n", report.ProjectRoot)
+	if len(report.Tools) == 0 {
+		fmt.Fprintln(tw, "\nNo configuration found.")
+	}
+
+	for _, tool := range report.Tools {
+		fmt.Fprintf(tw, "\n%s\n", tool.Tool)
+		if len(tool.Servers) > 0 {
+			fmt.Fprintln(tw, "  MCP servers:")
+			for _, s := range tool.Servers {
+				fmt.Fprintf(tw, "    %s\t%s\t%s\n", s.Name, serverSummary(s), where(s.Location, s.Shadowed))
+			}
+		}
+		if tool.Hooks != nil {
+			fmt.Fprintf(tw, "  Hooks:\n")
+			var flags []string
+			if tool.Hooks.DisableAllHooks {
+				flags = append(flags, "all hooks disabled")
+			}
+			if tool.Hooks.AllowManagedHooksOnly {
+				flags = append(flags, "managed hooks only")
+			}
+			if len(flags) > 0 {
+				fmt.Fprintf(tw, "    (%s)\n", strings.Join(flags, ", "))
+			}
+			events := tool.Hooks.Events()
+			sort.Slice(events, func(i, j int) bool { return events[i] < events[j] })
+			for _, event := range events {
+				n := 0
+				for _, entry := range tool.Hooks.Hooks[event] {
+					n += len(entry.Hooks)
+				}
+				fmt.Fprintf(tw, "    %s\t%d hook(s)\n", event, n)
+			}
+			for _, loc := range tool.HookSources {
+				fmt.Fprintf(tw, "    from\t%s\n", where(loc, nil))
+			}
+		}
+		for _, group := range []struct {
+			title string
+			items []discovery.ResolvedItem
+		}{{"Agents", tool.Agents}, {"Commands", tool.Commands}, {"Skills", tool.Skills}} {
+			if len(group.items) == 0 {
+				continue
+			}
+			fmt.Fprintf(tw, "  %s:\n", group.title)
+			for _, item := range group.items {
+				fmt.Fprintf(tw, "    %s\t%s\t%s\n", item.Name, item.Description, where(item.Location, item.Shadowed))
+			}
+		}
+	}
+
+	var errs, searched []string
+	for _, src := range report.Sources {
+		switch {
+		case src.Error != "":
+			errs = append(errs, fmt.Sprintf("  %s %s %s: %s", src.Tool, src.Kind, display(src.Path), src.Error))
+		case all:
+			status := "missing"
+			if src.Found {
+				status = "found"
+			}
+			searched = append(searched, fmt.Sprintf("  %s\t%s\t%s\t%s\t%s", src.Tool, src.Kind, src.Scope, status, display(src.Path)))
+		}
+	}
+	if len(errs) > 0 {
+		fmt.Fprintf(tw, "\nErrors:\n%s\n", strings.Join(errs, "\n"))
+	}
+	if len(searched) > 0 {
+		fmt.Fprintf(tw, "\nSearched:\n%s\n", strings.Join(searched, "\n"))
+	}
+	return tw.Flush()
+}
+
+// serverSummary describes how an MCP server is started or reached.
+func serverSummary(s discovery.ResolvedServer) string {
+	server := s.Server
+	if server.URL != "" {
+		return fmt.Sprintf("%s %s", server.InferTransport(), server.URL)
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s", server.InferTransport(), server.Command, strings.Join(server.Args, " ")))
+}
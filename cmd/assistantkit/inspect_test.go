@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/discovery"
+)
+
+func TestWriteInspectText(t *testing.T) {
+	home, project := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+
+	files := map[string]string{
+		filepath.Join(project, ".mcp.json"):        `{"mcpServers": {"github": {"command": "npx", "args": ["-y", "server-github"]}}}`,
+		filepath.Join(home, ".claude.json"):        `{"mcpServers": {"github": {"command": "old"}}}`,
+		filepath.Join(project, ".cursor/mcp.json"): `{"mcpServers": {"docs": {"url": "https://example.com/mcp"}}}`,
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := discovery.Discover(discovery.Options{ProjectRoot: project, Kinds: []discovery.Kind{discovery.KindMCP}})
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := writeInspectText(&buf, report, false); err != nil {
+		t.Fatalf("writeInspectText failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"claude\n  MCP servers:\n    github  stdio npx -y server-github  project  .mcp.json (shadows user ~/.claude.json)\n",
+		"cursor\n  MCP servers:\n    docs  http https://example.com/mcp  project  .cursor/mcp.json\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Searched:") {
+		t.Errorf("Expected searched locations to be omitted, got:\n%s", out)
+	}
+}
@@ -4,6 +4,16 @@
 //
 //	assistantkit generate plugins [flags]
 //	assistantkit generate power [flags]
+//	assistantkit convert [file] --to=<tool> [flags]
+//	assistantkit validate [flags]
+//	assistantkit inspect [flags]
+//	assistantkit sync mcp [flags]
+//	assistantkit hooks test [flags]
+//	assistantkit mcp probe [flags]
+//	assistantkit mcp proxy [flags]
+//	assistantkit serve mcp [flags]
+//	assistantkit team run <team> [flags]
+//	assistantkit team graph <team> [flags]
 //
 // Generate plugins from canonical specs:
 //
@@ -12,6 +22,42 @@
 // Generate a single power:
 //
 //	assistantkit generate power --name=mypower --output=~/.kiro/powers/mypower
+//
+// Convert a config file between tool formats:
+//
+//	assistantkit convert .cursor/mcp.json --to=vscode
+//
+// Validate a specs directory:
+//
+//	assistantkit validate --specs=specs --format=sarif
+//
+// Show the MCP servers, hooks, agents, commands and skills each tool sees:
+//
+//	assistantkit inspect --tool=cursor
+//
+// Keep MCP servers in sync across tools:
+//
+//	assistantkit sync mcp --source=mcp.json --strategy=ours
+//
+// Replay a synthetic event against configured hooks:
+//
+//	assistantkit hooks test --config=.claude/settings.json --event=before_command --command="rm -rf /"
+//
+// Start configured MCP servers and check their tools:
+//
+//	assistantkit mcp probe --config=.mcp.json
+//
+// Serve configured MCP servers as one server that applies their tool filters:
+//
+//	assistantkit mcp proxy --config=mcp.json
+//
+// Serve commands, skills and project context as an MCP server:
+//
+//	assistantkit serve mcp --specs=specs
+//
+// Run a team's checks locally and report GO/NO-GO:
+//
+//	assistantkit team run release-team --workers=4
 package main
 
 import (
@@ -40,4 +86,12 @@ func main() {
 
 func init() {
 	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(convertCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(inspectCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(hooksCmd)
+	rootCmd.AddCommand(mcpCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(teamCmd)
 }
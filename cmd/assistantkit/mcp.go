@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/assistantkit/mcp"
+	"github.com/agentplexus/assistantkit/mcp/probe"
+	"github.com/agentplexus/assistantkit/mcp/proxy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mcpConfig         string
+	mcpFrom           string
+	mcpServers        []string
+	mcpStartupTimeout time.Duration
+	mcpToolTimeout    time.Duration
+	mcpFormat         string
+	mcpPrefix         string
+	mcpSeparator      string
+	mcpRename         []string
+	mcpTransport      string
+	mcpAddr           string
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Work with MCP servers",
+	Long: `Work with MCP servers.
+
+Subcommands:
+  probe   Start configured servers and check their tools
+  proxy   Serve configured servers as a single server, applying tool filters`,
+}
+
+var mcpProbeCmd = &cobra.Command{
+	Use:   "probe",
+	Short: "Start configured MCP servers and check their tools",
+	Long: `Talk to each configured MCP server the way an assistant would.
+
+Stdio servers are started with their command, args, env and cwd; HTTP and
+SSE servers are connected to at their URL with their headers. Secret
+references in env and headers are resolved from the environment and from
+files. For each server the probe:
+  - performs the initialize handshake and reports the server's name,
+    protocol version and capabilities
+  - lists its tools, prompts and resources, with the latency of each step
+  - checks that every tool named in enabledTools, disabledTools and
+    alwaysAllow exists on the server, suggesting the closest name
+
+startupTimeoutSec bounds the startup and handshake, and toolTimeoutSec
+each list request; --startup-timeout and --tool-timeout apply to servers
+that set neither. Disabled servers are skipped.
+
+The config is read in the format detected from its path, as for convert,
+or in the format given by --from; other files are read as a canonical MCP
+config. The command fails if any server fails or names a missing tool.
+
+Example:
+  assistantkit mcp probe --config=.mcp.json
+  assistantkit mcp probe --config=~/.codex/config.toml --server=github --format=json
+  assistantkit mcp probe --config=mcp.json --startup-timeout=30s`,
+	RunE: runMCPProbe,
+}
+
+var mcpProxyCmd = &cobra.Command{
+	Use:   "proxy",
+	Short: "Serve configured MCP servers as a single server, applying tool filters",
+	Long: `Start the configured MCP servers and serve them as a single MCP server.
+
+Some assistants (Claude, Cursor, Windsurf) ignore enabledTools,
+disabledTools and toolTimeoutSec. The proxy applies them for every
+assistant:
+  - tools not in a server's enabledTools (when set) or in its
+    disabledTools are hidden and cannot be called
+  - each tool call is bounded by the server's toolTimeoutSec, or
+    --tool-timeout, and returns an error result when it times out
+
+Tools and prompts are named <server>__<name>; with --prefix=collisions only
+names offered by more than one server are prefixed. --rename gives a tool
+or prompt a name of its own. Resources keep their URIs. Servers that fail
+to start are reported on stderr and left out. alwaysAllow is not applied:
+approving tool calls is up to the assistant.
+
+The config is read as for probe. The proxy speaks stdio by default; use
+--transport=http or --transport=sse to serve on --addr. The HTTP
+transports have no authentication and are for localhost only: requests
+from a non-loopback Origin, or for a Host other than --addr, are rejected.
+
+"assistantkit sync mcp --proxy" writes a proxy entry in place of the
+servers each tool would otherwise not filter.
+
+Example:
+  assistantkit mcp proxy --config=mcp.json
+  assistantkit mcp proxy --config=mcp.json --server=github --rename=github.search_code=search
+  assistantkit mcp proxy --config=mcp.json --prefix=collisions --transport=http`,
+	RunE: runMCPProxy,
+}
+
+func init() {
+	mcpCmd.AddCommand(mcpProbeCmd)
+
+	mcpProbeCmd.Flags().StringVar(&mcpConfig, "config", "mcp.json", "MCP config file")
+	mcpProbeCmd.Flags().StringVar(&mcpFrom, "from", "", "Tool format of the config (or \"canonical\"); detected from path if omitted")
+	mcpProbeCmd.Flags().StringSliceVar(&mcpServers, "server", nil, "Servers to probe (default: all)")
+	mcpProbeCmd.Flags().DurationVar(&mcpStartupTimeout, "startup-timeout", probe.DefaultStartupTimeout, "Startup timeout for servers without startupTimeoutSec")
+	mcpProbeCmd.Flags().DurationVar(&mcpToolTimeout, "tool-timeout", probe.DefaultToolTimeout, "List timeout for servers without toolTimeoutSec")
+	mcpProbeCmd.Flags().StringVar(&mcpFormat, "format", "text", "Output format (text, json)")
+
+	mcpCmd.AddCommand(mcpProxyCmd)
+
+	mcpProxyCmd.Flags().StringVar(&mcpConfig, "config", "mcp.json", "MCP config file")
+	mcpProxyCmd.Flags().StringVar(&mcpFrom, "from", "", "Tool format of the config (or \"canonical\"); detected from path if omitted")
+	mcpProxyCmd.Flags().StringSliceVar(&mcpServers, "server", nil, "Servers to proxy (default: all)")
+	mcpProxyCmd.Flags().StringVar(&mcpPrefix, "prefix", string(proxy.PrefixAlways), "Which names to prefix with their server (always, collisions)")
+	mcpProxyCmd.Flags().StringVar(&mcpSeparator, "separator", proxy.DefaultSeparator, "Separator between server and tool names")
+	mcpProxyCmd.Flags().StringArrayVar(&mcpRename, "rename", nil, "Rename a tool or prompt, as server.name=new_name (repeatable)")
+	mcpProxyCmd.Flags().DurationVar(&mcpStartupTimeout, "startup-timeout", probe.DefaultStartupTimeout, "Startup timeout for servers without startupTimeoutSec")
+	mcpProxyCmd.Flags().DurationVar(&mcpToolTimeout, "tool-timeout", probe.DefaultToolTimeout, "Tool call timeout for servers without toolTimeoutSec")
+	mcpProxyCmd.Flags().StringVar(&mcpTransport, "transport", "stdio", "Transport (stdio, http, sse)")
+	mcpProxyCmd.Flags().StringVar(&mcpAddr, "addr", "localhost:8080", "Listen address for the http and sse transports")
+}
+
+func runMCPProbe(cmd *cobra.Command, args []string) error {
+	cfg, err := readMCPConfig(expandHome(mcpConfig), mcpFrom)
+	if err != nil {
+		return err
+	}
+	if cfg, err = selectServers(cfg, mcpServers); err != nil {
+		return err
+	}
+
+	opts := probe.Options{StartupTimeout: mcpStartupTimeout, ToolTimeout: mcpToolTimeout}
+	reports := probe.All(context.Background(), cfg, opts)
+
+	switch mcpFormat {
+	case "json":
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(reports); err != nil {
+			return err
+		}
+	case "text":
+		writeProbeReports(cmd.OutOrStdout(), reports)
+	default:
+		return fmt.Errorf("unknown format: %s", mcpFormat)
+	}
+
+	failed := 0
+	for _, report := range reports {
+		if !report.OK() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d server(s) failed the probe", failed, len(reports))
+	}
+	return nil
+}
+
+func runMCPProxy(cmd *cobra.Command, args []string) error {
+	cfg, err := readMCPConfig(expandHome(mcpConfig), mcpFrom)
+	if err != nil {
+		return err
+	}
+	if cfg, err = selectServers(cfg, mcpServers); err != nil {
+		return err
+	}
+	rename, err := parseRenames(mcpRename)
+	if err != nil {
+		return err
+	}
+
+	opts := proxy.Options{
+		Prefix:         proxy.PrefixMode(mcpPrefix),
+		Separator:      mcpSeparator,
+		Rename:         rename,
+		StartupTimeout: mcpStartupTimeout,
+		ToolTimeout:    mcpToolTimeout,
+	}
+	p, err := proxy.Start(context.Background(), cfg, opts)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	// Stdout carries the protocol on stdio, so status goes to stderr.
+	stderr := cmd.ErrOrStderr()
+	names := make([]string, 0, len(p.Errors))
+	for name := range p.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(stderr, "warning: %s: %v\n", name, p.Errors[name])
+	}
+	for _, warning := range p.Warnings {
+		fmt.Fprintf(stderr, "warning: %s\n", warning)
+	}
+	fmt.Fprintf(stderr, "Proxying %d tool(s) from %d server(s) over %s\n", len(p.Tools()), len(p.Servers()), mcpTransport)
+
+	return serveProtocol(cmd, p.Server(), mcpTransport, mcpAddr)
+}
+
+// parseRenames parses --rename values of the form server.name=new_name.
+func parseRenames(values []string) (map[string]map[string]string, error) {
+	renames := make(map[string]map[string]string)
+	for _, value := range values {
+		from, to, ok := strings.Cut(value, "=")
+		server, name, ok2 := strings.Cut(from, ".")
+		if !ok || !ok2 || server == "" || name == "" || to == "" {
+			return nil, fmt.Errorf("invalid rename %q: expected server.name=new_name", value)
+		}
+		if renames[server] == nil {
+			renames[server] = make(map[string]string)
+		}
+		renames[server][name] = to
+	}
+	return renames, nil
+}
+
+// selectServers returns the named servers of cfg, or cfg if none are named.
+func selectServers(cfg *mcp.Config, names []string) (*mcp.Config, error) {
+	if len(names) == 0 {
+		return cfg, nil
+	}
+	selected := mcp.NewConfig()
+	for _, name := range names {
+		server, ok := cfg.GetServer(name)
+		if !ok {
+			return nil, fmt.Errorf("server %q is not in %s", name, mcpConfig)
+		}
+		selected.AddServer(name, server)
+	}
+	return selected, nil
+}
+
+// readMCPConfig reads an MCP config in the given tool's format, or in the
+// format detected from its path, or as a canonical config.
+func readMCPConfig(path, from string) (*mcp.Config, error) {
+	if from == "" {
+		if configType, tool, err := detectConfig(path); err == nil {
+			// Gemini CLI keeps MCP servers and hooks in the same settings.json.
+			if configType != configTypeMCP && !(configType == configTypeHooks && tool == "gemini") {
+				return nil, fmt.Errorf("%s is a %s config, not an MCP config", path, configType)
+			}
+			from = tool
+		}
+	}
+	if from == "canonical" {
+		from = ""
+	}
+	return readSyncSource(path, from)
+}
+
+// writeProbeReports writes one block per probed server.
+func writeProbeReports(w io.Writer, reports []*probe.Report) {
+	for _, r := range reports {
+		fmt.Fprintf(w, "%s (%s): ", r.Name, r.Transport)
+		switch {
+		case r.Skipped:
+			fmt.Fprintln(w, "skipped (disabled)")
+			continue
+		case r.Err != nil:
+			fmt.Fprintf(w, "FAILED: %v\n", r.Err)
+			continue
+		case r.OK():
+			fmt.Fprint(w, "ok")
+		default:
+			fmt.Fprint(w, "MISSING TOOLS")
+		}
+		fmt.Fprintf(w, " in %s\n", r.Latency.Initialize.Round(time.Millisecond))
+
+		server := r.Server.Name
+		if r.Server.Version != "" {
+			server += " " + r.Server.Version
+		}
+		fmt.Fprintf(w, "  server: %s, protocol %s\n", server, r.ProtocolVersion)
+		writeProbeList(w, "tools", r.Capabilities.Tools != nil, r.Tools, r.Latency.Tools)
+		writeProbeList(w, "prompts", r.Capabilities.Prompts != nil, r.Prompts, r.Latency.Prompts)
+		writeProbeList(w, "resources", r.Capabilities.Resources != nil, r.Resources, r.Latency.Resources)
+		for _, m := range r.Missing {
+			fmt.Fprintf(w, "  ! %s\n", m)
+		}
+		for _, warning := range r.Warnings {
+			fmt.Fprintf(w, "  warning: %s\n", warning)
+		}
+	}
+}
+
+func writeProbeList(w io.Writer, kind string, offered bool, names []string, latency time.Duration) {
+	if !offered {
+		fmt.Fprintf(w, "  %s: not offered\n", kind)
+		return
+	}
+	fmt.Fprintf(w, "  %s (%d) in %s", kind, len(names), latency.Round(time.Millisecond))
+	if len(names) > 0 {
+		fmt.Fprintf(w, ": %s", strings.Join(names, ", "))
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/assistantkit/mcp/probe"
+	"github.com/agentplexus/assistantkit/mcp/protocol"
+)
+
+func TestReadMCPConfig(t *testing.T) {
+	dir := t.TempDir()
+	claudePath := filepath.Join(dir, ".mcp.json")
+	if err := os.WriteFile(claudePath, []byte(`{"mcpServers":{"github":{"command":"gh-mcp"}}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	canonicalPath := filepath.Join(dir, "servers.json")
+	if err := os.WriteFile(canonicalPath, []byte(`{"servers":{"docs":{"url":"https://example.com/mcp"}}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := readMCPConfig(claudePath, "")
+	if err != nil {
+		t.Fatalf("readMCPConfig failed: %v", err)
+	}
+	if server, ok := cfg.GetServer("github"); !ok || server.Command != "gh-mcp" {
+		t.Errorf("Expected the claude server, got %+v", cfg.Servers)
+	}
+
+	cfg, err = readMCPConfig(canonicalPath, "")
+	if err != nil {
+		t.Fatalf("readMCPConfig failed: %v", err)
+	}
+	if _, ok := cfg.GetServer("docs"); !ok {
+		t.Errorf("Expected the canonical server, got %+v", cfg.Servers)
+	}
+
+	geminiPath := filepath.Join(dir, ".gemini", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(geminiPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(geminiPath, []byte(`{"hooks":{},"mcpServers":{"search":{"httpUrl":"https://example.com/mcp"}}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = readMCPConfig(geminiPath, "")
+	if err != nil {
+		t.Fatalf("readMCPConfig failed: %v", err)
+	}
+	if server, ok := cfg.GetServer("search"); !ok || server.Transport != "http" {
+		t.Errorf("Expected the gemini server, got %+v", cfg.Servers)
+	}
+
+	if _, err := readMCPConfig(filepath.Join(dir, ".claude", "settings.json"), ""); err == nil {
+		t.Error("Expected error for a hooks config")
+	}
+}
+
+func TestWriteProbeReports(t *testing.T) {
+	reports := []*probe.Report{
+		{
+			Name:            "github",
+			Transport:       "stdio",
+			Server:          protocol.Implementation{Name: "github-mcp", Version: "1.0.0"},
+			Capabilities:    protocol.ServerCapabilities{Tools: &protocol.ListCapability{}},
+			Latency:         probe.Latency{Initialize: 120 * time.Millisecond, Tools: 30 * time.Millisecond},
+			Tools:           []string{"create_issue", "list_issues"},
+			Missing:         []probe.Missing{{Field: "enabledTools", Tool: "list_isues", Suggestion: "list_issues"}},
+			ProtocolVersion: protocol.Version,
+		},
+		{Name: "docs", Transport: "http", Err: errors.New("connection refused")},
+		{Name: "old", Transport: "stdio", Skipped: true},
+	}
+	var buf bytes.Buffer
+	writeProbeReports(&buf, reports)
+	expected := `github (stdio): MISSING TOOLS in 120ms
+  server: github-mcp 1.0.0, protocol 2025-06-18
+  tools (2) in 30ms: create_issue, list_issues
+  prompts: not offered
+  resources: not offered
+  ! enabledTools: tool "list_isues" does not exist on the server (did you mean "list_issues"?)
+docs (http): FAILED: connection refused
+old (stdio): skipped (disabled)
+`
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestParseRenames(t *testing.T) {
+	renames, err := parseRenames([]string{"github.search_code=search", "docs.get=docs_get", "github.list=ls"})
+	if err != nil {
+		t.Fatalf("parseRenames failed: %v", err)
+	}
+	expected := map[string]map[string]string{
+		"github": {"search_code": "search", "list": "ls"},
+		"docs":   {"get": "docs_get"},
+	}
+	if !reflect.DeepEqual(renames, expected) {
+		t.Errorf("Expected %v, got %v", expected, renames)
+	}
+	for _, bad := range []string{"search=x", "github.search", "github.=x", "github.search="} {
+		if _, err := parseRenames([]string{bad}); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
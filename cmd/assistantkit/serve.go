@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/agentplexus/assistantkit/mcp/protocol"
+	"github.com/agentplexus/assistantkit/mcp/specserver"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveSpecs     string
+	serveContext   string
+	serveTransport string
+	serveAddr      string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve specs to AI assistants",
+	Long: `Serve canonical specs to AI assistants.
+
+Subcommands:
+  mcp    Serve commands, skills and project context as an MCP server`,
+}
+
+var serveMCPCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve commands, skills and project context as an MCP server",
+	Long: `Serve the specs directory as an MCP server, so that any MCP-capable
+assistant (including Cline, Roo and VS Code, which have no native commands
+or skills) can use the team's commands and skills.
+
+  - each command in commands/ is a prompt, with its arguments as prompt
+    arguments; required arguments and patterns are enforced, defaults
+    filled in and {{name}} placeholders replaced
+  - each skill in skills/ is a resource (skill://<name>), and each of its
+    references and assets too (skill://<name>/<path>)
+  - the project context is a resource (context://<name>) rendered as
+    Markdown
+
+The context is read from --context, or from CONTEXT.json in the specs
+directory or the current directory if present.
+
+The server speaks stdio by default; use --transport=http for streamable
+HTTP or --transport=sse for the legacy SSE transport, on --addr. The HTTP
+transports have no authentication and are for localhost only: requests
+from a non-loopback Origin, or for a Host other than --addr, are rejected.
+
+Example:
+  assistantkit serve mcp --specs=specs
+  assistantkit serve mcp --specs=specs --transport=http --addr=localhost:8080
+
+Register it with an assistant like any stdio server:
+  {"mcpServers": {"team": {"command": "assistantkit", "args": ["serve", "mcp", "--specs", "specs"]}}}`,
+	RunE: runServeMCP,
+}
+
+func init() {
+	serveCmd.AddCommand(serveMCPCmd)
+
+	serveMCPCmd.Flags().StringVar(&serveSpecs, "specs", "specs", "Specs directory")
+	serveMCPCmd.Flags().StringVar(&serveContext, "context", "", "Project context file (default: CONTEXT.json in the specs or current directory)")
+	serveMCPCmd.Flags().StringVar(&serveTransport, "transport", "stdio", "Transport (stdio, http, sse)")
+	serveMCPCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "Listen address for the http and sse transports")
+}
+
+func runServeMCP(cmd *cobra.Command, args []string) error {
+	specsDir := expandHome(serveSpecs)
+	if info, err := os.Stat(specsDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("specs directory not found: %s", specsDir)
+	}
+	contextPath := expandHome(serveContext)
+	if contextPath == "" {
+		contextPath = findContext(specsDir)
+	}
+	specs, err := specserver.Load(specsDir, contextPath)
+	if err != nil {
+		return err
+	}
+	server := specserver.New(specs)
+
+	// Stdout carries the protocol on stdio, so status goes to stderr.
+	fmt.Fprintf(cmd.ErrOrStderr(), "Serving %d command(s) and %d skill(s) from %s over %s\n",
+		len(specs.Commands), len(specs.Skills), specsDir, serveTransport)
+
+	return serveProtocol(cmd, server, serveTransport, serveAddr)
+}
+
+// serveProtocol serves an MCP server on stdin and stdout, or on addr over
+// streamable HTTP or SSE until interrupted. Over HTTP, the server rejects
+// requests for any host but addr and its loopback equivalents.
+func serveProtocol(cmd *cobra.Command, server *protocol.Server, transport, addr string) error {
+	switch transport {
+	case "stdio":
+		return server.ServeStdio(context.Background(), cmd.InOrStdin(), cmd.OutOrStdout())
+	case "http", "sse":
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		server.Addr = addr
+		var handler http.Handler = server
+		if transport == "sse" {
+			handler = server.SSEHandler()
+		}
+		srv := &http.Server{Addr: addr, Handler: handler}
+		go func() {
+			<-ctx.Done()
+			_ = srv.Close()
+		}()
+		fmt.Fprintf(cmd.ErrOrStderr(), "Listening on http://%s\n", addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown transport: %s", transport)
+	}
+}
+
+// findContext returns the first CONTEXT.json in the specs directory or the
+// current directory, or "" if there is none.
+func findContext(specsDir string) string {
+	for _, path := range []string{filepath.Join(specsDir, "CONTEXT.json"), "CONTEXT.json"} {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindContext(t *testing.T) {
+	dir := t.TempDir()
+	specs := filepath.Join(dir, "specs")
+	if err := os.MkdirAll(specs, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	if got := findContext(specs); got != "" {
+		t.Errorf("Expected no context, got %s", got)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "CONTEXT.json"), []byte(`{"name":"x"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := findContext(specs); got != "CONTEXT.json" {
+		t.Errorf("Expected CONTEXT.json in the current directory, got %s", got)
+	}
+	inSpecs := filepath.Join(specs, "CONTEXT.json")
+	if err := os.WriteFile(inSpecs, []byte(`{"name":"x"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := findContext(specs); got != inSpecs {
+		t.Errorf("Expected %s, got %s", inSpecs, got)
+	}
+}
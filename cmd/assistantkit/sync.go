@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/discovery"
+	"github.com/agentplexus/assistantkit/mcp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncSource   string
+	syncFrom     string
+	syncTools    []string
+	syncScope    string
+	syncStrategy string
+	syncLockfile string
+	syncProject  string
+	syncDryRun   bool
+	syncProxy    bool
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Keep tool configs in sync with a canonical config",
+	Long: `Push a canonical configuration to every selected tool and keep it in sync.
+
+Subcommands:
+  mcp    Sync MCP servers to Claude, Cursor, VS Code, Windsurf, Codex, Cline, Roo and Kiro`,
+}
+
+var syncMCPCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Sync a canonical MCP config to every selected tool",
+	Long: `Sync MCP servers from a canonical config to each selected tool's config file.
+
+Each server is compared three ways: the canonical config (ours), the state
+recorded in the lockfile at the last sync (base), and the tool's file on
+disk (theirs). Canonical changes are applied where the tool's file was not
+edited. Servers edited by hand since the last sync are conflicts, resolved
+with --strategy:
+  - ours:   overwrite with the canonical server
+  - theirs: keep the edited server
+  - prompt: ask for each conflict (default)
+
+With --proxy, servers whose enabledTools, disabledTools or
+toolTimeoutSec a tool ignores are written to that tool as a single
+"assistantkit-proxy" entry that runs "assistantkit mcp proxy" on the
+source, which must then be a canonical config. The source path is written
+relative to the project for the project scope and absolute for the user
+scope.
+
+Only the MCP section of each file is written; other settings are kept.
+Servers that were never synced and are not in the canonical config are
+left alone.
+
+The source is a canonical MCP config (JSON) unless --from names the tool
+whose format it is in. Targets are the tools' config files in the chosen
+scope (project or user); the source file itself is never a target.
+Without --tools, only config files that already exist are targets.
+
+Example:
+  assistantkit sync mcp --source=mcp.json
+  assistantkit sync mcp --source=.mcp.json --from=claude --tools=cursor,vscode --dry-run
+  assistantkit sync mcp --source=mcp.json --scope=user --strategy=ours
+  assistantkit sync mcp --source=mcp.json --tools=cursor --proxy`,
+	RunE: runSyncMCP,
+}
+
+func init() {
+	syncCmd.AddCommand(syncMCPCmd)
+
+	syncMCPCmd.Flags().StringVar(&syncSource, "source", "mcp.json", "Canonical MCP config file")
+	syncMCPCmd.Flags().StringVar(&syncFrom, "from", "", "Tool format of the source file (default: canonical JSON)")
+	syncMCPCmd.Flags().StringSliceVar(&syncTools, "tools", nil, "Tools to sync (default: all tools with a config in the scope)")
+	syncMCPCmd.Flags().StringVar(&syncScope, "scope", "project", "Scope of the target files (project, user)")
+	syncMCPCmd.Flags().StringVar(&syncStrategy, "strategy", "prompt", "Conflict strategy (ours, theirs, prompt)")
+	syncMCPCmd.Flags().StringVar(&syncLockfile, "lockfile", "", "Lockfile path (default: <project>/.assistantkit/mcp.lock.json)")
+	syncMCPCmd.Flags().StringVar(&syncProject, "project", ".", "Project directory")
+	syncMCPCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Print the changes and diffs without writing")
+	syncMCPCmd.Flags().BoolVar(&syncProxy, "proxy", false, "Proxy the servers whose tool filters a tool ignores")
+}
+
+func runSyncMCP(cmd *cobra.Command, args []string) error {
+	project := expandHome(syncProject)
+	source, err := readSyncSource(expandHome(syncSource), syncFrom)
+	if err != nil {
+		return fmt.Errorf("reading source: %w", err)
+	}
+
+	targets, err := syncTargets(project, discovery.Scope(syncScope), syncTools, expandHome(syncSource))
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		if len(syncTools) == 0 {
+			return fmt.Errorf("no %s-scope MCP configs found; name the tools to create with --tools", syncScope)
+		}
+		return fmt.Errorf("no %s config locations for tools %v", syncScope, syncTools)
+	}
+
+	lockPath := expandHome(syncLockfile)
+	if lockPath == "" {
+		lockPath = filepath.Join(project, ".assistantkit", "mcp.lock.json")
+	}
+	lock, err := mcp.ReadLockfile(lockPath)
+	if err != nil {
+		return err
+	}
+
+	opts := mcp.SyncOptions{
+		Strategy: mcp.SyncStrategy(syncStrategy),
+		Resolver: promptResolver(cmd.InOrStdin(), cmd.ErrOrStderr()),
+		DryRun:   syncDryRun,
+	}
+	if syncProxy {
+		if syncFrom != "" {
+			return fmt.Errorf("--proxy needs a canonical source, not a %s config", syncFrom)
+		}
+		if opts.Proxy, err = proxyConfigPath(project, discovery.Scope(syncScope), expandHome(syncSource)); err != nil {
+			return err
+		}
+	}
+	results, syncErr := mcp.Sync(source, targets, lock, opts)
+	writeSyncResults(cmd.OutOrStdout(), results, syncDryRun)
+	if syncErr != nil {
+		return fmt.Errorf("syncing: %w", syncErr)
+	}
+
+	if syncDryRun {
+		return nil
+	}
+	if err := lock.WriteFile(lockPath); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Synced %d target(s); lockfile: %s\n", len(results), lockPath)
+	return nil
+}
+
+// readSyncSource reads the canonical config, or a tool config if from is set.
+func readSyncSource(path, from string) (*mcp.Config, error) {
+	if from == "" {
+		return mcp.ReadFile(path)
+	}
+	adapter, ok := mcp.GetAdapter(from)
+	if !ok {
+		return nil, fmt.Errorf("unknown mcp adapter: %s", from)
+	}
+	return adapter.ReadFile(path)
+}
+
+// proxyConfigPath returns the source path for proxy entries: relative to the
+// project for the project scope, where the tools run in the project, and
+// absolute otherwise.
+func proxyConfigPath(project string, scope discovery.Scope, source string) (string, error) {
+	abs, err := filepath.Abs(source)
+	if err != nil {
+		return "", err
+	}
+	if scope != discovery.ScopeProject {
+		return abs, nil
+	}
+	root, err := filepath.Abs(project)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return abs, nil
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// syncTargets returns the first config location of each selected tool in
+// the scope, skipping the source file. With no tools selected, only
+// locations that exist are targets, so that no tool gets a new config.
+// Paths inside the project are kept relative to the project flag so that
+// the lockfile is portable.
+func syncTargets(project string, scope discovery.Scope, tools []string, source string) ([]mcp.SyncTarget, error) {
+	locations, err := discovery.Locations(discovery.Options{
+		ProjectRoot: project,
+		Tools:       tools,
+		Kinds:       []discovery.Kind{discovery.KindMCP},
+	})
+	if err != nil {
+		return nil, err
+	}
+	root, _ := filepath.Abs(project)
+	sourceAbs, _ := filepath.Abs(source)
+
+	var targets []mcp.SyncTarget
+	seen := make(map[string]bool)
+	for _, loc := range locations {
+		if loc.Scope != scope || seen[loc.Tool] || loc.Path == sourceAbs {
+			continue
+		}
+		if len(tools) == 0 {
+			if _, err := os.Stat(loc.Path); err != nil {
+				continue
+			}
+		}
+		seen[loc.Tool] = true
+		path := loc.Path
+		if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = filepath.Join(project, rel)
+		}
+		targets = append(targets, mcp.SyncTarget{Tool: loc.Tool, Path: path})
+	}
+	for _, tool := range tools {
+		if !seen[tool] {
+			return nil, fmt.Errorf("%s has no %s-scope MCP config location", tool, scope)
+		}
+	}
+	return targets, nil
+}
+
+// promptResolver asks on in for the resolution of each conflict.
+func promptResolver(in io.Reader, out io.Writer) func(mcp.Conflict) (mcp.SyncStrategy, error) {
+	reader := bufio.NewReader(in)
+	show := func(s *mcp.Server) string {
+		if s == nil {
+			return "(absent)"
+		}
+		data, _ := json.Marshal(s)
+		return string(data)
+	}
+	return func(c mcp.Conflict) (mcp.SyncStrategy, error) {
+		fmt.Fprintf(out, "Conflict in %s: server %q was edited since the last sync\n", c.Target.Path, c.Server)
+		fmt.Fprintf(out, "  base:   %s\n  ours:   %s\n  theirs: %s\n", show(c.Base), show(c.Ours), show(c.Theirs))
+		for {
+			fmt.Fprint(out, "Keep [o]urs, [t]heirs or [a]bort? ")
+			line, err := reader.ReadString('\n')
+			switch strings.ToLower(strings.TrimSpace(line)) {
+			case "o", "ours":
+				return mcp.StrategyOurs, nil
+			case "t", "theirs":
+				return mcp.StrategyTheirs, nil
+			case "a", "abort":
+				return "", fmt.Errorf("sync aborted")
+			}
+			if err != nil {
+				return "", fmt.Errorf("reading answer: %w", err)
+			}
+		}
+	}
+}
+
+// writeSyncResults prints the changes of each target, and the diffs for a dry run.
+func writeSyncResults(w io.Writer, results []*mcp.SyncResult, diffs bool) {
+	symbols := map[string]string{"add": "+", "update": "~", "remove": "-", "keep": "="}
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\n", r.Target.Tool, r.Target.Path)
+		if len(r.Changes) == 0 {
+			fmt.Fprintln(w, "  up to date")
+		}
+		for _, c := range r.Changes {
+			line := fmt.Sprintf("  %s %s", symbols[string(c.Kind)], c.Server)
+			if c.Conflict {
+				line += " (conflict)"
+			}
+			fmt.Fprintln(w, line)
+		}
+		if diffs && r.Merge != nil && r.Merge.Diff != "" {
+			fmt.Fprint(w, r.Merge.Diff)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/discovery"
+	"github.com/agentplexus/assistantkit/mcp"
+)
+
+func TestSyncTargets(t *testing.T) {
+	home, project := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+
+	targets, err := syncTargets(project, discovery.ScopeProject, []string{"claude", "cursor"}, filepath.Join(project, ".mcp.json"))
+	if err == nil {
+		t.Errorf("Expected error when the only claude location is the source, got %v", targets)
+	}
+
+	targets, err = syncTargets(project, discovery.ScopeProject, []string{"claude", "cursor"}, "mcp.json")
+	if err != nil {
+		t.Fatalf("syncTargets failed: %v", err)
+	}
+	expected := []mcp.SyncTarget{
+		{Tool: "claude", Path: filepath.Join(project, ".mcp.json")},
+		{Tool: "cursor", Path: filepath.Join(project, ".cursor", "mcp.json")},
+	}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("Expected %v, got %v", expected, targets)
+	}
+
+	if _, err := syncTargets(project, discovery.ScopeProject, []string{"codex"}, "mcp.json"); err == nil {
+		t.Error("Expected error for tool without a project-scope location")
+	}
+}
+
+func TestSyncTargetsExisting(t *testing.T) {
+	home, project := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+
+	targets, err := syncTargets(project, discovery.ScopeProject, nil, "mcp.json")
+	if err != nil {
+		t.Fatalf("syncTargets failed: %v", err)
+	}
+	if len(targets) != 0 {
+		t.Errorf("Expected no targets in an empty project, got %v", targets)
+	}
+
+	if err := os.MkdirAll(filepath.Join(project, ".cursor"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, ".cursor", "mcp.json"), []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	targets, err = syncTargets(project, discovery.ScopeProject, nil, "mcp.json")
+	if err != nil {
+		t.Fatalf("syncTargets failed: %v", err)
+	}
+	expected := []mcp.SyncTarget{{Tool: "cursor", Path: filepath.Join(project, ".cursor", "mcp.json")}}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("Expected %v, got %v", expected, targets)
+	}
+}
+
+func TestPromptResolver(t *testing.T) {
+	var out bytes.Buffer
+	resolve := promptResolver(strings.NewReader("maybe\nt\na\n"), &out)
+	conflict := mcp.Conflict{Target: mcp.SyncTarget{Path: ".mcp.json"}, Server: "github"}
+
+	if got, err := resolve(conflict); err != nil || got != mcp.StrategyTheirs {
+		t.Errorf("Expected theirs, got %q, %v", got, err)
+	}
+	if strings.Count(out.String(), "Keep [o]urs") != 2 {
+		t.Errorf("Expected the question to be repeated after an invalid answer, got:\n%s", out.String())
+	}
+	if _, err := resolve(conflict); err == nil {
+		t.Error("Expected error when aborting")
+	}
+	if _, err := resolve(conflict); err == nil {
+		t.Error("Expected error at end of input")
+	}
+}
+
+func TestWriteSyncResults(t *testing.T) {
+	results := []*mcp.SyncResult{
+		{Target: mcp.SyncTarget{Tool: "cursor", Path: ".cursor/mcp.json"}, Changes: []mcp.ServerChange{
+			{Server: "docs", Kind: "add"},
+			{Server: "github", Kind: "keep", Conflict: true},
+		}},
+		{Target: mcp.SyncTarget{Tool: "claude", Path: ".mcp.json"}},
+	}
+	var buf bytes.Buffer
+	writeSyncResults(&buf, results, false)
+	expected := "cursor\t.cursor/mcp.json\n  + docs\n  = github (conflict)\nclaude\t.mcp.json\n  up to date\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestProxyConfigPath(t *testing.T) {
+	project := t.TempDir()
+	source := filepath.Join(project, "specs", "mcp.json")
+
+	if got, err := proxyConfigPath(project, discovery.ScopeProject, source); err != nil || got != "specs/mcp.json" {
+		t.Errorf("Expected a project-relative path, got %q, %v", got, err)
+	}
+	if got, err := proxyConfigPath(project, discovery.ScopeUser, source); err != nil || got != source {
+		t.Errorf("Expected %s, got %q, %v", source, got, err)
+	}
+	outside := filepath.Join(t.TempDir(), "mcp.json")
+	if got, err := proxyConfigPath(project, discovery.ScopeProject, outside); err != nil || got != outside {
+		t.Errorf("Expected %s for a source outside the project, got %q, %v", outside, got, err)
+	}
+}
+
+func TestSyncProxyCanonicalSourceNamedLikeToolFile(t *testing.T) {
+	project := t.TempDir()
+	source := mcp.NewConfig()
+	source.AddServer("search", mcp.Server{Command: "search-server", DisabledTools: []string{"delete"}})
+	data, err := json.Marshal(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A canonical config named like Claude's project file.
+	sourcePath := filepath.Join(project, ".mcp.json")
+	if err := os.WriteFile(sourcePath, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cursorPath := filepath.Join(project, ".cursor", "mcp.json")
+	if err := os.MkdirAll(filepath.Dir(cursorPath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	targets := []mcp.SyncTarget{{Tool: "cursor", Path: cursorPath}}
+	opts := mcp.SyncOptions{Strategy: mcp.StrategyOurs, Proxy: ".mcp.json"}
+	lock, err := mcp.ReadLockfile(filepath.Join(project, "mcp.lock.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mcp.Sync(source, targets, lock, opts); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	cursor, _ := mcp.GetAdapter("cursor")
+	cfg, err := cursor.ReadFile(cursorPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := cfg.Servers["assistantkit-proxy"]
+	if !ok {
+		t.Fatalf("Expected proxy entry, got %v", cfg.Servers)
+	}
+
+	// Read the source the way "assistantkit mcp proxy" does with the entry's args.
+	var config, from string
+	var servers []string
+	for i := 0; i+1 < len(entry.Args); i++ {
+		switch entry.Args[i] {
+		case "--config":
+			config = entry.Args[i+1]
+		case "--from":
+			from = entry.Args[i+1]
+		case "--server":
+			servers = append(servers, entry.Args[i+1])
+		}
+	}
+	proxied, err := readMCPConfig(filepath.Join(project, config), from)
+	if err != nil {
+		t.Fatalf("readMCPConfig failed: %v", err)
+	}
+	if proxied, err = selectServers(proxied, servers); err != nil {
+		t.Fatalf("selectServers failed: %v", err)
+	}
+	if server, ok := proxied.Servers["search"]; !ok || server.Command != "search-server" {
+		t.Errorf("Expected the canonical search server, got %v", proxied.Servers)
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+	"github.com/agentplexus/assistantkit/teams/report"
+	"github.com/agentplexus/assistantkit/teams/runner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	teamSpecs   string
+	teamDir     string
+	teamWorkers int
+	teamTimeout time.Duration
+	teamVersion string
+	teamFormat  string
+	teamOutput  string
+	teamInputs  []string
+
+	graphFormat string
+	graphOutput string
+)
+
+var teamCmd = &cobra.Command{
+	Use:   "team",
+	Short: "Work with team definitions",
+	Long: `Work with team definitions.
+
+Subcommands:
+  run     Execute a team's subtasks locally and report GO/NO-GO
+  graph   Export a team's task graph and critical path`,
+}
+
+var teamRunCmd = &cobra.Command{
+	Use:   "run <team>",
+	Short: "Execute a team's subtasks locally and report GO/NO-GO",
+	Long: `Execute the subtasks of a team definition without an LLM in the loop.
+
+The team is a team file, or the name of a team in the teams/ directory of
+--specs. Tasks run in dependency order: each parallel group starts when
+the previous one has finished, and its tasks run concurrently, at most
+--workers at a time. Each task runs its subtasks in order:
+  - command runs through the system shell and must exit with status 0;
+    with pattern, its output must also not match the pattern
+  - pattern without command must not match any line of the files
+    selected by the files glob ("**" matches any number of directories)
+  - file must exist
+
+A failing subtask is NO-GO when it is required and WARN otherwise. Each
+command is bounded by the subtask's timeout, or --timeout. Tasks whose
+dependencies are NO-GO are skipped. Commands run in --dir and see
+ASSISTANTKIT_TEAM, ASSISTANTKIT_TASK and ASSISTANTKIT_VERSION.
+
+Tasks follow their failure policies: a task whose when condition does not
+hold is skipped, retry reruns a failing task after its backoff,
+continue_on_error reports failures as WARN so dependents still run, and
+an on_failure fallback runs only if its task fails. Conditions check
+upstream task statuses, shell commands, or inputs set with --input.
+
+The text format reports each task as it finishes. The other formats
+write a report once the team has finished:
+  json      JSON with a stable, versioned schema
+  markdown  GO/NO-GO release report
+  junit     JUnit XML for CI test dashboards
+  table     terminal table, coloured when writing to a terminal unless
+            NO_COLOR is set
+
+The command fails if the team's overall status is NO-GO.
+
+Example:
+  assistantkit team run release-team
+  assistantkit team run specs/teams/release-team.yaml --workers=2 --format=json
+  assistantkit team run release-team --format=junit -o team-report.xml
+  assistantkit team run release-team --version=v1.2.0 --timeout=5m
+  assistantkit team run release-team --input deps_changed=true`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTeamRun,
+}
+
+var teamGraphCmd = &cobra.Command{
+	Use:   "graph <team>",
+	Short: "Export a team's task graph and critical path",
+	Long: `Export the task graph of a team definition.
+
+The team is a team file, or the name of a team in the teams/ directory of
+--specs. Formats:
+  mermaid  Mermaid flowchart, for Markdown files and GitHub
+  dot      Graphviz DOT, for rendering with dot -Tsvg
+  path     the critical path as text
+
+Each task is a node coloured by agent and listing its required and
+optional subtasks; condition and fallback edges are dashed. The critical
+path is the chain of tasks with the longest estimated duration, using
+subtask timeouts as estimates and counting every retry and its backoff;
+its edges are highlighted in the diagrams.
+
+Example:
+  assistantkit team graph release-team
+  assistantkit team graph release-team --format=dot | dot -Tsvg -o team.svg
+  assistantkit team graph release-team --format=path`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTeamGraph,
+}
+
+func init() {
+	teamCmd.AddCommand(teamRunCmd)
+	teamCmd.AddCommand(teamGraphCmd)
+
+	teamRunCmd.Flags().StringVar(&teamSpecs, "specs", "specs", "Specs directory to look up team names in")
+	teamRunCmd.Flags().StringVar(&teamDir, "dir", ".", "Directory to run commands and checks in")
+	teamRunCmd.Flags().IntVar(&teamWorkers, "workers", 0, "Maximum number of tasks to run at a time (default: number of CPUs)")
+	teamRunCmd.Flags().DurationVar(&teamTimeout, "timeout", runner.DefaultTimeout, "Timeout for subtasks without a timeout")
+	teamRunCmd.Flags().StringVar(&teamVersion, "version", "", "Target version (default: the team's version)")
+	teamRunCmd.Flags().StringVar(&teamFormat, "format", "text", "Output format (text, json, markdown, junit, table)")
+	teamRunCmd.Flags().StringVarP(&teamOutput, "output", "o", "", "Output file (default: stdout)")
+	teamRunCmd.Flags().StringArrayVar(&teamInputs, "input", nil, "Input for task conditions, as key=value (repeatable)")
+
+	teamGraphCmd.Flags().StringVar(&teamSpecs, "specs", "specs", "Specs directory to look up team names in")
+	teamGraphCmd.Flags().StringVar(&graphFormat, "format", "mermaid", "Output format (mermaid, dot, path)")
+	teamGraphCmd.Flags().StringVarP(&graphOutput, "output", "o", "", "Output file (default: stdout)")
+}
+
+func runTeamRun(cmd *cobra.Command, args []string) error {
+	switch teamFormat {
+	case "text", "json", "markdown", "junit", "table":
+	default:
+		return fmt.Errorf("unknown format: %s", teamFormat)
+	}
+	inputs, err := parseInputs(teamInputs)
+	if err != nil {
+		return err
+	}
+	team, err := findTeam(args[0], teamSpecs)
+	if err != nil {
+		return err
+	}
+
+	// Failed checks are reported in the output, not as usage errors
+	cmd.SilenceUsage = true
+
+	out := cmd.OutOrStdout()
+	if teamOutput != "" {
+		f, err := os.Create(teamOutput)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	opts := runner.Options{
+		Dir:     teamDir,
+		Workers: teamWorkers,
+		Timeout: teamTimeout,
+		Version: teamVersion,
+		Inputs:  inputs,
+	}
+	if teamFormat == "text" {
+		// Report each task as it finishes; the summary follows.
+		opts.Progress = func(result core.TaskResult) {
+			writeTaskResult(out, result)
+		}
+	}
+	result, err := runner.Run(context.Background(), team, opts)
+	if err != nil {
+		return err
+	}
+
+	if teamFormat == "text" {
+		writeTeamSummary(out, result)
+	} else {
+		color := teamFormat == "table" && isTerminal(out) && os.Getenv("NO_COLOR") == ""
+		if err := report.Write(out, result, report.Format(teamFormat), report.Options{Color: color}); err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
+	}
+
+	if result.Status.IsBlocking() {
+		return fmt.Errorf("team %s is %s", result.Name, result.Status)
+	}
+	return nil
+}
+
+// parseInputs parses key=value inputs.
+func parseInputs(args []string) (map[string]string, error) {
+	inputs := make(map[string]string)
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid input %q: expected key=value", arg)
+		}
+		inputs[key] = value
+	}
+	return inputs, nil
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func runTeamGraph(cmd *cobra.Command, args []string) error {
+	team, err := findTeam(args[0], teamSpecs)
+	if err != nil {
+		return err
+	}
+
+	var out string
+	cfg := core.GraphConfig{HighlightCriticalPath: true}
+	switch graphFormat {
+	case "mermaid":
+		out = team.GenerateMermaid(cfg)
+	case "dot":
+		out = team.GenerateDOT(cfg)
+	case "path":
+		path, err := team.CriticalPath()
+		if err != nil {
+			return err
+		}
+		out = formatCriticalPath(team, path)
+	default:
+		return fmt.Errorf("unknown format: %s", graphFormat)
+	}
+
+	if graphOutput != "" {
+		return os.WriteFile(graphOutput, []byte(out), 0644)
+	}
+	_, err = io.WriteString(cmd.OutOrStdout(), out)
+	return err
+}
+
+// formatCriticalPath lists the tasks of a critical path with their
+// maximum durations, retries included.
+func formatCriticalPath(team *core.Team, path *core.CriticalPath) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Critical path: %s (%s)\n", strings.Join(path.Tasks, " -> "), path.Duration)
+	width := 0
+	for _, name := range path.Tasks {
+		width = max(width, len(name))
+	}
+	for _, name := range path.Tasks {
+		task := team.GetTask(name)
+		fmt.Fprintf(&b, "  %-*s  %-8s  %s\n", width, name, task.MaxDuration(), task.Agent)
+	}
+	return b.String()
+}
+
+// findTeam reads the team file at ref, or the team named ref in the teams/
+// directory of specs.
+func findTeam(ref, specs string) (*core.Team, error) {
+	if info, err := os.Stat(ref); err == nil && !info.IsDir() {
+		return core.ReadTeamFile(ref)
+	}
+	dir := filepath.Join(specs, "teams")
+	teams, err := core.ReadTeamDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("team %q is not a file and %s cannot be read: %w", ref, dir, err)
+	}
+	for _, team := range teams {
+		if team.Name == ref {
+			return team, nil
+		}
+	}
+	return nil, fmt.Errorf("team %q not found in %s", ref, dir)
+}
+
+// writeTaskResult writes one line per task and one per subtask, with the
+// message and output of failed subtasks.
+func writeTaskResult(w io.Writer, result core.TaskResult) {
+	fmt.Fprintf(w, "%s %-5s %s (%s) in %s", result.Status.Emoji(), result.Status, result.Name, result.Agent,
+		result.Duration.Round(time.Millisecond))
+	if result.Attempts > 1 {
+		fmt.Fprintf(w, " after %d attempts", result.Attempts)
+	}
+	fmt.Fprintln(w)
+	for _, st := range result.Subtasks {
+		fmt.Fprintf(w, "  %s %-5s %s", st.Status.Emoji(), st.Status, st.Name)
+		if st.Message != "" {
+			fmt.Fprintf(w, ": %s", st.Message)
+		}
+		fmt.Fprintln(w)
+		if st.Status == core.StatusNoGo || st.Status == core.StatusWarn {
+			for _, line := range strings.Split(st.Output, "\n") {
+				if line != "" {
+					fmt.Fprintf(w, "      %s\n", line)
+				}
+			}
+		}
+	}
+}
+
+// writeTeamSummary writes the overall status and the count of tasks by
+// status.
+func writeTeamSummary(w io.Writer, result *core.TeamResult) {
+	name := result.Name
+	if result.Version != "" {
+		name += " " + result.Version
+	}
+	fmt.Fprintf(w, "\n%s %s: %s (%s) in %s\n", result.Status.Emoji(), name, result.Status, report.Summarize(result),
+		result.Duration.Round(time.Millisecond))
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+const testTeam = `name: release
+process: sequential
+version: v1.0.0
+tasks:
+  - name: build
+    agent: qa
+    subtasks:
+      - name: compile
+        command: echo compiled
+        required: true
+  - name: publish
+    agent: release
+    depends_on: [build]
+    subtasks:
+      - name: notes
+        file: NOTES.md
+        required: true
+`
+
+func TestFindTeam(t *testing.T) {
+	dir := t.TempDir()
+	teamsDir := filepath.Join(dir, "specs", "teams")
+	if err := os.MkdirAll(teamsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(teamsDir, "release-team.yaml")
+	if err := os.WriteFile(path, []byte(testTeam), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	team, err := findTeam(path, "")
+	if err != nil {
+		t.Fatalf("findTeam by path failed: %v", err)
+	}
+	if team.Name != "release" {
+		t.Errorf("Expected release, got %q", team.Name)
+	}
+
+	team, err = findTeam("release", filepath.Join(dir, "specs"))
+	if err != nil {
+		t.Fatalf("findTeam by name failed: %v", err)
+	}
+	if len(team.Tasks) != 2 {
+		t.Errorf("Expected 2 tasks, got %d", len(team.Tasks))
+	}
+
+	if _, err := findTeam("missing", filepath.Join(dir, "specs")); err == nil {
+		t.Error("Expected error for an unknown team")
+	}
+}
+
+func TestRunTeamRun(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("subtask commands use sh")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "release-team.yaml")
+	if err := os.WriteFile(path, []byte(testTeam), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	teamDir, teamFormat, teamWorkers, teamVersion = dir, "text", 1, ""
+	defer func() { teamDir, teamFormat, teamOutput = ".", "text", "" }()
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	err := runTeamRun(cmd, []string{path})
+	if err == nil || !strings.Contains(err.Error(), "NO-GO") {
+		t.Errorf("Expected NO-GO error for missing notes, got %v", err)
+	}
+	for _, want := range []string{"GO    build (qa)", "NO-GO notes: NOTES.md does not exist", "release v1.0.0: NO-GO (1 GO, 1 NO-GO)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, out.String())
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "NOTES.md"), []byte("notes\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := runTeamRun(cmd, []string{path}); err != nil {
+		t.Errorf("Expected GO, got %v:\n%s", err, out.String())
+	}
+
+	teamFormat, teamOutput = "junit", filepath.Join(dir, "report.xml")
+	if err := runTeamRun(cmd, []string{path}); err != nil {
+		t.Fatalf("runTeamRun failed: %v", err)
+	}
+	data, err := os.ReadFile(teamOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `<testsuites name="release v1.0.0" tests="2" failures="0"`) {
+		t.Errorf("Expected JUnit report:\n%s", data)
+	}
+}
+
+func TestRunTeamGraph(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "release-team.yaml")
+	team := strings.Replace(testTeam, "        command: echo compiled\n", "        command: echo compiled\n        timeout: 90\n", 1)
+	if err := os.WriteFile(path, []byte(team), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { graphFormat = "mermaid" }()
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"mermaid", "task1 --> task2\n"},
+		{"dot", `task1 -> task2 [color="#dc2626", penwidth=3];`},
+		{"path", "Critical path: build -> publish (1m30s)\n  build    1m30s     qa\n  publish  0s        release\n"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetOut(&out)
+		graphFormat = tt.format
+		if err := runTeamGraph(cmd, []string{path}); err != nil {
+			t.Fatalf("runTeamGraph(%s) failed: %v", tt.format, err)
+		}
+		if !strings.Contains(out.String(), tt.want) {
+			t.Errorf("Expected %s output to contain %q:\n%s", tt.format, tt.want, out.String())
+		}
+	}
+
+	graphFormat = "svg"
+	if err := runTeamGraph(&cobra.Command{}, []string{path}); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+func TestParseInputs(t *testing.T) {
+	inputs, err := parseInputs([]string{"deps_changed=true", "target=a=b", "empty="})
+	if err != nil {
+		t.Fatalf("parseInputs failed: %v", err)
+	}
+	if inputs["deps_changed"] != "true" || inputs["target"] != "a=b" || inputs["empty"] != "" || len(inputs) != 3 {
+		t.Errorf("unexpected inputs: %v", inputs)
+	}
+	if _, err := parseInputs([]string{"deps_changed"}); err == nil {
+		t.Error("expected error for input without =")
+	}
+}
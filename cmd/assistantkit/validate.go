@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentplexus/assistantkit/lint"
+	"github.com/spf13/cobra"
+)
+
+var (
+	valSpecsDir string
+	valFormat   string
+	valOutput   string
+	valStrict   bool
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a specs directory and report all problems",
+	Long: `Validate every file in a specs directory and report all problems found.
+
+The specs directory is the same one used by 'generate':
+  - plugin.json: Plugin metadata, MCP servers and hooks file reference
+  - agents/: Agent definitions (*.md with YAML frontmatter)
+  - commands/: Command definitions (*.md or *.json)
+  - skills/: Skill definitions (*.md or <name>/skill.json)
+  - teams/: Team definitions (*.json or *.yaml)
+  - deployments/: Deployment definitions (*.json)
+
+Each diagnostic includes the file, line, field, severity and rule. Output
+formats are text (default), json and sarif. The command exits non-zero if
+any errors are found, or any warnings with --strict.
+
+Example:
+  assistantkit validate
+  assistantkit validate --specs=specs --format=sarif --output=assistantkit.sarif`,
+	RunE: runValidate,
+}
+
+func init() {
+	validateCmd.Flags().StringVar(&valSpecsDir, "specs", "specs", "Path to unified specs directory")
+	validateCmd.Flags().StringVar(&valFormat, "format", "text", "Output format (text, json, sarif)")
+	validateCmd.Flags().StringVarP(&valOutput, "output", "o", "", "Output file (default: stdout)")
+	validateCmd.Flags().BoolVar(&valStrict, "strict", false, "Fail on warnings as well as errors")
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	diags, err := lint.Specs(valSpecsDir)
+	if err != nil {
+		return fmt.Errorf("reading specs dir: %w", err)
+	}
+
+	// Problems are reported through diagnostics, not usage errors
+	cmd.SilenceUsage = true
+
+	out := cmd.OutOrStdout()
+	if valOutput != "" {
+		f, err := os.Create(valOutput)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := lint.Write(out, diags, lint.Format(valFormat)); err != nil {
+		return fmt.Errorf("writing diagnostics: %w", err)
+	}
+
+	errCount := diags.Count(lint.SeverityError)
+	warnCount := diags.Count(lint.SeverityWarning)
+	if errCount > 0 || (valStrict && warnCount > 0) {
+		return fmt.Errorf("validation failed: %d error(s), %d warning(s)", errCount, warnCount)
+	}
+	return nil
+}
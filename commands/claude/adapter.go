@@ -125,6 +125,26 @@ func (a *Adapter) Marshal(cmd *core.Command) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// Lossiness reports the canonical fields that the Claude command format drops or degrades.
+// The body holds the generated title and sections, which are read back as
+// instructions.
+func (a *Adapter) Lossiness(cmd *core.Command) []core.Loss {
+	losses := core.DroppedFields(a.Name(), cmd,
+		core.FieldName, core.FieldDescription, core.FieldArguments,
+		core.FieldInstructions, core.FieldProcess, core.FieldDependencies)
+	losses = append(losses, core.NameFromFile(a.Name(), cmd)...)
+	if len(cmd.Arguments) > 0 {
+		losses = append(losses, core.Degraded(a.Name(), cmd, core.FieldArguments, "arguments are written as usage and arguments sections and not read back"))
+	}
+	if len(cmd.Process) > 0 {
+		losses = append(losses, core.Degraded(a.Name(), cmd, core.FieldProcess, "steps are written as a process section and not read back"))
+	}
+	if len(cmd.Dependencies) > 0 {
+		losses = append(losses, core.Degraded(a.Name(), cmd, core.FieldDependencies, "dependencies are written as a dependencies section and not read back"))
+	}
+	return append(losses, core.Degraded(a.Name(), cmd, core.FieldInstructions, "the generated title and sections are read back as instructions"))
+}
+
 // ReadFile reads a Claude command Markdown file and returns canonical Command.
 func (a *Adapter) ReadFile(path string) (*core.Command, error) {
 	data, err := os.ReadFile(path)
@@ -116,6 +116,30 @@ func (a *Adapter) Marshal(cmd *core.Command) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// Lossiness reports the canonical fields that the Codex prompt format drops or degrades.
+func (a *Adapter) Lossiness(cmd *core.Command) []core.Loss {
+	losses := core.DroppedFields(a.Name(), cmd,
+		core.FieldName, core.FieldDescription, core.FieldArguments,
+		core.FieldInstructions, core.FieldProcess, core.FieldDependencies)
+	losses = append(losses, core.NameFromFile(a.Name(), cmd)...)
+	if len(cmd.Arguments) > 0 {
+		losses = append(losses, core.Degraded(a.Name(), cmd, core.FieldArguments, "arguments are written as an argument-hint; only names, hints and whether they are required are read back"))
+	}
+	if len(cmd.Process) > 0 {
+		losses = append(losses, core.Degraded(a.Name(), cmd, core.FieldProcess, "steps are written into the prompt and not read back"))
+	}
+	if len(cmd.Dependencies) > 0 {
+		losses = append(losses, core.Degraded(a.Name(), cmd, core.FieldDependencies, "dependencies are written into the prompt and not read back"))
+	}
+	switch {
+	case len(cmd.Arguments) > 0 || len(cmd.Process) > 0 || len(cmd.Dependencies) > 0:
+		losses = append(losses, core.Degraded(a.Name(), cmd, core.FieldInstructions, "the generated sections are read back as instructions"))
+	case cmd.Instructions == "" && cmd.Description != "":
+		losses = append(losses, core.Degraded(a.Name(), cmd, core.FieldInstructions, "the description is written as the prompt and read back as instructions"))
+	}
+	return losses
+}
+
 // ReadFile reads a Codex prompt Markdown file and returns canonical Command.
 func (a *Adapter) ReadFile(path string) (*core.Command, error) {
 	data, err := os.ReadFile(path)
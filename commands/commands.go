@@ -51,6 +51,7 @@ type (
 	Argument = core.Argument
 	Example  = core.Example
 	Adapter  = core.Adapter
+	Loss     = core.Loss
 )
 
 // Re-export core functions
@@ -65,6 +66,12 @@ var (
 	WriteCommandsToDir = core.WriteCommandsToDir
 )
 
+// Lossiness returns the fields of cmd that the named adapter's format drops
+// or degrades. Example: Lossiness(cmd, "codex")
+func Lossiness(cmd *Command, to string) ([]Loss, error) {
+	return core.DefaultRegistry.Lossiness(cmd, to)
+}
+
 // Re-export error types
 type (
 	ParseError   = core.ParseError
@@ -0,0 +1,144 @@
+package core
+
+import "fmt"
+
+// LossKind describes how a field is affected when written to a target format.
+type LossKind string
+
+const (
+	// LossDropped means the field is not written at all.
+	LossDropped LossKind = "dropped"
+
+	// LossDegraded means the field is written but reads back with a different value.
+	LossDegraded LossKind = "degraded"
+)
+
+// Canonical field names used in Loss paths. They match the JSON names of
+// the Command fields.
+const (
+	FieldName         = "name"
+	FieldDescription  = "description"
+	FieldArguments    = "arguments"
+	FieldInstructions = "instructions"
+	FieldProcess      = "process"
+	FieldDependencies = "dependencies"
+	FieldExamples     = "examples"
+)
+
+// Loss describes a canonical field that a target format cannot represent exactly.
+type Loss struct {
+	// Adapter is the name of the target adapter.
+	Adapter string `json:"adapter"`
+
+	// Command is the name of the command.
+	Command string `json:"command"`
+
+	// Path locates the field in the canonical command (e.g., "examples").
+	Path string `json:"path"`
+
+	// Kind is whether the field is dropped or degraded.
+	Kind LossKind `json:"kind"`
+
+	// Detail explains the loss.
+	Detail string `json:"detail,omitempty"`
+}
+
+// String returns a one-line description of the loss.
+func (l Loss) String() string {
+	s := fmt.Sprintf("%s: command %s: %s %s", l.Adapter, l.Command, l.Path, l.Kind)
+	if l.Detail != "" {
+		s += ": " + l.Detail
+	}
+	return s
+}
+
+// LossReporter is implemented by adapters that can report which fields of a
+// canonical command they drop or degrade when marshaling.
+type LossReporter interface {
+	// Lossiness returns the losses incurred by marshaling cmd with this adapter.
+	Lossiness(cmd *Command) []Loss
+}
+
+// Lossiness returns the losses incurred by marshaling cmd with the given
+// adapter. It returns nil if the adapter does not implement LossReporter.
+func Lossiness(adapter Adapter, cmd *Command) []Loss {
+	if r, ok := adapter.(LossReporter); ok {
+		return r.Lossiness(cmd)
+	}
+	return nil
+}
+
+// Lossiness returns the losses incurred by marshaling cmd with the named adapter.
+func (r *Registry) Lossiness(cmd *Command, to string) ([]Loss, error) {
+	adapter, ok := r.GetAdapter(to)
+	if !ok {
+		return nil, fmt.Errorf("unknown adapter: %s", to)
+	}
+	return Lossiness(adapter, cmd), nil
+}
+
+// SetFields returns the names of the fields that are set on the command.
+func SetFields(cmd *Command) []string {
+	var fields []string
+	add := func(set bool, name string) {
+		if set {
+			fields = append(fields, name)
+		}
+	}
+	add(cmd.Name != "", FieldName)
+	add(cmd.Description != "", FieldDescription)
+	add(len(cmd.Arguments) > 0, FieldArguments)
+	add(cmd.Instructions != "", FieldInstructions)
+	add(len(cmd.Process) > 0, FieldProcess)
+	add(len(cmd.Dependencies) > 0, FieldDependencies)
+	add(len(cmd.Examples) > 0, FieldExamples)
+	return fields
+}
+
+// DroppedFields returns a LossDropped entry for every field set on cmd
+// that is not in supported.
+func DroppedFields(adapter string, cmd *Command, supported ...string) []Loss {
+	keep := make(map[string]bool, len(supported))
+	for _, f := range supported {
+		keep[f] = true
+	}
+
+	var losses []Loss
+	for _, field := range SetFields(cmd) {
+		if !keep[field] {
+			losses = append(losses, Loss{
+				Adapter: adapter,
+				Command: cmd.Name,
+				Path:    field,
+				Kind:    LossDropped,
+			})
+		}
+	}
+	return losses
+}
+
+// Degraded returns a LossDegraded entry for the given field.
+func Degraded(adapter string, cmd *Command, field, detail string) Loss {
+	return Loss{
+		Adapter: adapter,
+		Command: cmd.Name,
+		Path:    field,
+		Kind:    LossDegraded,
+		Detail:  detail,
+	}
+}
+
+// NameFromFile returns a LossDropped entry for the command name in formats
+// that take it from the file name rather than the file content.
+func NameFromFile(adapter string, cmd *Command) []Loss {
+	if cmd.Name == "" {
+		return nil
+	}
+	return []Loss{{
+		Adapter: adapter,
+		Command: cmd.Name,
+		Path:    FieldName,
+		Kind:    LossDropped,
+		Detail:  "the name is taken from the file name",
+	}}
+}
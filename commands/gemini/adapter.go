@@ -2,6 +2,7 @@
 package gemini
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -149,6 +150,19 @@ func (a *Adapter) Marshal(cmd *core.Command) ([]byte, error) {
 	return data, nil
 }
 
+// Lossiness reports the canonical fields that the Gemini command format drops or degrades.
+func (a *Adapter) Lossiness(cmd *core.Command) []core.Loss {
+	losses := core.DroppedFields(a.Name(), cmd,
+		core.FieldName, core.FieldDescription, core.FieldArguments,
+		core.FieldInstructions, core.FieldProcess, core.FieldExamples)
+	for _, arg := range cmd.Arguments {
+		if arg.Pattern != "" {
+			losses = append(losses, core.Degraded(a.Name(), cmd, core.FieldArguments, fmt.Sprintf("argument %q pattern is not written", arg.Name)))
+		}
+	}
+	return losses
+}
+
 // ReadFile reads a Gemini command TOML file and returns canonical Command.
 func (a *Adapter) ReadFile(path string) (*core.Command, error) {
 	data, err := os.ReadFile(path)
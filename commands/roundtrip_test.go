@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/agentplexus/assistantkit/commands/core"
+)
+
+var words = []string{"alpha", "bravo", "charlie", "delta", "echo"}
+
+// randomCommand is a canonical command generated for property-based tests.
+type randomCommand struct {
+	cmd *Command
+}
+
+// Generate implements quick.Generator.
+func (randomCommand) Generate(r *rand.Rand, size int) reflect.Value {
+	cmd := NewCommand("cmd-"+pick(r), "Runs the "+pick(r)+" workflow")
+	for i := r.Intn(3); i > 0; i-- {
+		arg := Argument{Name: pick(r), Type: "string", Required: r.Intn(2) == 0}
+		if r.Intn(2) == 0 {
+			arg.Description = "The " + pick(r) + " value"
+		}
+		if r.Intn(3) == 0 {
+			arg.Pattern = "^[a-z]+$"
+		}
+		cmd.AddArgument(arg)
+	}
+	if r.Intn(4) > 0 {
+		cmd.Instructions = "Run the " + pick(r) + " checks.\n\nReport the " + pick(r) + " results."
+	}
+	cmd.Process = subset(r, words)
+	cmd.Dependencies = subset(r, []string{"git", "go", "gh"})
+	if r.Intn(3) == 0 {
+		cmd.AddExample("Run for "+pick(r), "/"+cmd.Name+" "+pick(r), "")
+	}
+	return reflect.ValueOf(randomCommand{cmd: cmd})
+}
+
+func pick(r *rand.Rand) string {
+	return words[r.Intn(len(words))]
+}
+
+// subset returns a random subset of items, without duplicates, in random order.
+func subset(r *rand.Rand, items []string) []string {
+	var out []string
+	for _, i := range r.Perm(len(items))[:r.Intn(4)] {
+		out = append(out, items[i])
+	}
+	return out
+}
+
+// fieldValue returns the value of a command field, normalized so that
+// values with the same meaning compare equal.
+func fieldValue(cmd *Command, field string) interface{} {
+	switch field {
+	case core.FieldName:
+		return cmd.Name
+	case core.FieldDescription:
+		return cmd.Description
+	case core.FieldArguments:
+		if len(cmd.Arguments) == 0 {
+			return nil
+		}
+		return cmd.Arguments
+	case core.FieldInstructions:
+		return strings.TrimSpace(cmd.Instructions)
+	case core.FieldProcess:
+		return list(cmd.Process)
+	case core.FieldDependencies:
+		return list(cmd.Dependencies)
+	case core.FieldExamples:
+		if len(cmd.Examples) == 0 {
+			return nil
+		}
+		return cmd.Examples
+	}
+	panic("unknown field " + field)
+}
+
+func list(l []string) []string {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+var commandFields = []string{
+	core.FieldName, core.FieldDescription, core.FieldArguments, core.FieldInstructions,
+	core.FieldProcess, core.FieldDependencies, core.FieldExamples,
+}
+
+// checkRoundTrip asserts that Parse(Marshal(cmd)) preserves every field
+// that the adapter does not report as lost.
+func checkRoundTrip(adapter Adapter, cmd *Command) error {
+	lost := make(map[string]bool)
+	for _, loss := range core.Lossiness(adapter, cmd) {
+		lost[loss.Path] = true
+	}
+
+	data, err := adapter.Marshal(cmd)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	got, err := adapter.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parse: %w\n%s", err, data)
+	}
+
+	for _, field := range commandFields {
+		if lost[field] {
+			continue
+		}
+		w, g := fieldValue(cmd, field), fieldValue(got, field)
+		if !reflect.DeepEqual(w, g) {
+			return fmt.Errorf("%s not reported as lost but changed: expected %v, got %v\n%s", field, w, g, data)
+		}
+	}
+	return nil
+}
+
+func TestRoundTripPreservesSupportedFields(t *testing.T) {
+	for _, name := range AdapterNames() {
+		t.Run(name, func(t *testing.T) {
+			adapter, _ := GetAdapter(name)
+			if _, ok := adapter.(core.LossReporter); !ok {
+				t.Fatalf("adapter %q does not implement LossReporter", name)
+			}
+
+			var failure error
+			property := func(rc randomCommand) bool {
+				failure = checkRoundTrip(adapter, rc.cmd)
+				return failure == nil
+			}
+			qc := &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(1))}
+			if err := quick.Check(property, qc); err != nil {
+				t.Errorf("round trip failed: %v", failure)
+			}
+		})
+	}
+}
+
+func TestLossiness(t *testing.T) {
+	cmd := NewCommand("release", "Cut a release")
+	cmd.AddRequiredArgument("version", "Semantic version", "v1.2.3")
+	cmd.Instructions = "Tag and publish the release."
+	cmd.AddDependency("git")
+	cmd.AddExample("Release 1.2.3", "/release v1.2.3", "")
+
+	tests := []struct {
+		adapter  string
+		expected []string
+	}{
+		{"gemini", []string{"gemini: command release: dependencies dropped"}},
+		{"codex", []string{
+			"codex: command release: examples dropped",
+			"codex: command release: name dropped: the name is taken from the file name",
+			"codex: command release: arguments degraded: arguments are written as an argument-hint; only names, hints and whether they are required are read back",
+			"codex: command release: dependencies degraded: dependencies are written into the prompt and not read back",
+			"codex: command release: instructions degraded: the generated sections are read back as instructions",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.adapter, func(t *testing.T) {
+			losses, err := Lossiness(cmd, tt.adapter)
+			if err != nil {
+				t.Fatalf("Lossiness failed: %v", err)
+			}
+			var got []string
+			for _, loss := range losses {
+				got = append(got, loss.String())
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := Lossiness(cmd, "unknown"); err == nil {
+		t.Error("expected error for unknown adapter")
+	}
+}
@@ -0,0 +1,32 @@
+// Package codex provides a converter for generating AGENTS.md files
+// from the canonical project context format.
+package codex
+
+import (
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+const (
+	// ConverterName is the identifier for this converter.
+	ConverterName = "codex"
+
+	// OutputFile is the default output file name.
+	OutputFile = "AGENTS.md"
+)
+
+// Converter implements core.Converter for OpenAI Codex CLI AGENTS.md files.
+type Converter struct {
+	core.MarkdownConverter
+}
+
+// NewConverter creates a new Codex converter.
+func NewConverter() *Converter {
+	return &Converter{
+		MarkdownConverter: core.NewMarkdownConverter(ConverterName, OutputFile),
+	}
+}
+
+// init registers the converter with the default registry.
+func init() {
+	core.RegisterConverter(NewConverter())
+}
@@ -1,5 +1,5 @@
 // Package context provides a tool-agnostic system for managing project context
-// that can be converted to various AI assistant formats (CLAUDE.md, .cursorrules, etc.).
+// that can be converted to various AI assistant formats (CLAUDE.md, Cursor rules, AGENTS.md, etc.).
 //
 // The context package uses a JSON-based canonical format (CONTEXT.json) that can be
 // validated against a JSON Schema and converted to tool-specific formats.
@@ -21,23 +21,38 @@
 //
 // Then convert to tool-specific formats:
 //
-//	import (
-//	    "github.com/agentplexus/assistantkit/context"
-//	    _ "github.com/agentplexus/assistantkit/context/claude" // Register converter
-//	)
-//
 //	ctx, _ := context.ReadFile("CONTEXT.json")
 //	context.WriteFile(ctx, "claude", "CLAUDE.md")
 //
+//	// Or write every format in one pass
+//	context.GenerateAll(ctx, ".")
+//
+// Scoped guidance goes in "rules", each with a name, content and optional
+// description, globs and alwaysApply. Formats with rule files write one file
+// per rule; the others add a Rules section.
+//
 // # Supported Formats
 //
 //   - claude: CLAUDE.md for Claude Code
-//   - (future) cursor: .cursorrules for Cursor IDE
-//   - (future) copilot: .github/copilot-instructions.md for GitHub Copilot
+//   - codex: AGENTS.md for OpenAI Codex CLI
+//   - gemini: GEMINI.md for Gemini CLI
+//   - cursor: .cursor/rules/*.mdc for Cursor IDE
+//   - windsurf: .windsurf/rules/*.md for Windsurf
+//   - vscode: .github/copilot-instructions.md and .github/instructions/*.instructions.md for GitHub Copilot
+//   - kiro: .kiro/steering/*.md for Kiro
 package context
 
 import (
 	"github.com/agentplexus/assistantkit/context/core"
+
+	// Import converters to register them
+	_ "github.com/agentplexus/assistantkit/context/claude"
+	_ "github.com/agentplexus/assistantkit/context/codex"
+	_ "github.com/agentplexus/assistantkit/context/cursor"
+	_ "github.com/agentplexus/assistantkit/context/gemini"
+	_ "github.com/agentplexus/assistantkit/context/kiro"
+	_ "github.com/agentplexus/assistantkit/context/vscode"
+	_ "github.com/agentplexus/assistantkit/context/windsurf"
 )
 
 // Re-export core types for convenience.
@@ -72,9 +87,18 @@ type (
 	// Related represents a related project or resource.
 	Related = core.Related
 
+	// Rule is guidance scoped to part of the project.
+	Rule = core.Rule
+
+	// Activation describes when a tool loads a rule.
+	Activation = core.Activation
+
 	// Converter is the interface for format converters.
 	Converter = core.Converter
 
+	// MultiFileConverter is implemented by converters that emit several files.
+	MultiFileConverter = core.MultiFileConverter
+
 	// ParseError represents a parsing error.
 	ParseError = core.ParseError
 
@@ -83,6 +107,17 @@ type (
 
 	// ConversionError represents a conversion error.
 	ConversionError = core.ConversionError
+
+	// RuleError represents an invalid rule.
+	RuleError = core.RuleError
+)
+
+// Rule activations.
+const (
+	ActivationAlways = core.ActivationAlways
+	ActivationGlob   = core.ActivationGlob
+	ActivationAgent  = core.ActivationAgent
+	ActivationManual = core.ActivationManual
 )
 
 // Re-export core errors.
@@ -90,6 +125,10 @@ var (
 	ErrEmptyContext      = core.ErrEmptyContext
 	ErrMissingName       = core.ErrMissingName
 	ErrUnsupportedFormat = core.ErrUnsupportedFormat
+	ErrInvalidRuleName   = core.ErrInvalidRuleName
+	ErrEmptyRule         = core.ErrEmptyRule
+	ErrDuplicateRule     = core.ErrDuplicateRule
+	ErrReservedRuleName  = core.ErrReservedRuleName
 )
 
 // NewContext creates a new empty Context with the given name.
@@ -117,7 +156,9 @@ func WriteFile(ctx *Context, format, path string) error {
 	return core.DefaultRegistry.WriteFile(ctx, format, path)
 }
 
-// GenerateAll generates all supported formats in the given directory.
+// GenerateAll generates all supported formats in the given directory in one
+// pass: CLAUDE.md, AGENTS.md, GEMINI.md, Cursor and Windsurf rules, Copilot
+// instructions and Kiro steering files.
 func GenerateAll(ctx *Context, dir string) error {
 	return core.DefaultRegistry.GenerateAll(ctx, dir)
 }
@@ -0,0 +1,81 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testContext() *Context {
+	ctx := NewContext("demo")
+	ctx.Description = "A demo project"
+	ctx.Language = "go"
+	ctx.SetCommand("test", "go test ./...")
+	ctx.AddConvention("Wrap errors with context")
+	ctx.AddRule(Rule{Name: "testing", Globs: []string{"**/*_test.go"}, Content: "Use table-driven tests."})
+	ctx.AddRule(Rule{Name: "release", Description: "When preparing a release", Content: "Update CHANGELOG.md."})
+	return ctx
+}
+
+func TestGenerateAll(t *testing.T) {
+	dir := t.TempDir()
+	if err := GenerateAll(testContext(), dir); err != nil {
+		t.Fatalf("GenerateAll failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"CLAUDE.md":                                    "Use table-driven tests.",
+		"AGENTS.md":                                    "Use table-driven tests.",
+		"GEMINI.md":                                    "Use table-driven tests.",
+		".cursor/rules/project.mdc":                    "alwaysApply: true",
+		".cursor/rules/testing.mdc":                    "globs: **/*_test.go",
+		".cursor/rules/release.mdc":                    "description: When preparing a release",
+		".windsurf/rules/project.md":                   "trigger: always_on",
+		".windsurf/rules/testing.md":                   "trigger: glob",
+		".windsurf/rules/release.md":                   "trigger: model_decision",
+		".github/copilot-instructions.md":              "go test ./...",
+		".github/instructions/testing.instructions.md": `applyTo: "**/*_test.go"`,
+		".github/instructions/release.instructions.md": "Update CHANGELOG.md.",
+		".kiro/steering/product.md":                    "A demo project",
+		".kiro/steering/tech.md":                       "Wrap errors with context",
+		".kiro/steering/testing.md":                    "inclusion: fileMatch",
+		".kiro/steering/release.md":                    "inclusion: manual",
+	}
+	for file, content := range expected {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			t.Errorf("expected %s to be generated: %v", file, err)
+			continue
+		}
+		if !strings.Contains(string(data), content) {
+			t.Errorf("expected %s to contain %q, got:\n%s", file, content, data)
+		}
+	}
+
+	// Rules with their own files are not repeated in the main file.
+	data, _ := os.ReadFile(filepath.Join(dir, ".cursor", "rules", "project.mdc"))
+	if strings.Contains(string(data), "table-driven") {
+		t.Error("expected project.mdc to leave rules to their own files")
+	}
+}
+
+func TestGenerateAllInvalidRule(t *testing.T) {
+	ctx := testContext()
+	ctx.AddRule(Rule{Name: "../escape", Content: "x"})
+
+	err := GenerateAll(ctx, t.TempDir())
+	if err == nil {
+		t.Fatal("expected error for invalid rule name")
+	}
+	if !strings.Contains(err.Error(), ErrInvalidRuleName.Error()) {
+		t.Errorf("expected invalid rule name error, got %v", err)
+	}
+}
+
+func TestConverterNames(t *testing.T) {
+	got := strings.Join(ConverterNames(), ",")
+	if got != "claude,codex,cursor,gemini,kiro,vscode,windsurf" {
+		t.Errorf("unexpected converters: %s", got)
+	}
+}
@@ -1,10 +1,12 @@
 // Package core provides the canonical types for project context that can be
-// converted to various AI assistant formats (CLAUDE.md, .cursorrules, etc.).
+// converted to various AI assistant formats (CLAUDE.md, AGENTS.md, Cursor rules, etc.).
 package core
 
 import (
 	"encoding/json"
 	"os"
+	"regexp"
+	"strings"
 )
 
 // Context represents the canonical project context that can be
@@ -37,6 +39,9 @@ type Context struct {
 	// Conventions lists coding conventions and patterns.
 	Conventions []string `json:"conventions,omitempty"`
 
+	// Rules lists guidance scoped to parts of the project.
+	Rules []Rule `json:"rules,omitempty"`
+
 	// Dependencies describes key dependencies.
 	Dependencies *Dependencies `json:"dependencies,omitempty"`
 
@@ -97,6 +102,73 @@ func (p *Package) IsPublic() bool {
 	return *p.Public
 }
 
+// Rule is guidance scoped to part of the project, such as conventions for
+// tests or generated code. Formats with rule files (Cursor, Windsurf, Kiro,
+// Copilot) write one file per rule; the others add a Rules section.
+type Rule struct {
+	// Name identifies the rule and is used as its file name (e.g., "testing").
+	Name string `json:"name"`
+
+	// Description says when the rule applies. Agents use it to decide
+	// whether to load a rule that is neither always applied nor scoped by globs.
+	Description string `json:"description,omitempty"`
+
+	// Globs limits the rule to matching files (e.g., "**/*_test.go").
+	Globs []string `json:"globs,omitempty"`
+
+	// AlwaysApply includes the rule in every request.
+	AlwaysApply bool `json:"alwaysApply,omitempty"`
+
+	// Content is the guidance in Markdown.
+	Content string `json:"content"`
+}
+
+// Activation describes when a tool loads a rule.
+type Activation string
+
+const (
+	// ActivationAlways loads the rule in every request.
+	ActivationAlways Activation = "always"
+
+	// ActivationGlob loads the rule when a matching file is in context.
+	ActivationGlob Activation = "glob"
+
+	// ActivationAgent lets the agent load the rule based on its description.
+	ActivationAgent Activation = "agent"
+
+	// ActivationManual loads the rule only when the user references it.
+	ActivationManual Activation = "manual"
+)
+
+// Activation returns when the rule is loaded: always if AlwaysApply is set,
+// else by glob if Globs are set, else by the agent if it has a description.
+func (r *Rule) Activation() Activation {
+	switch {
+	case r.AlwaysApply:
+		return ActivationAlways
+	case len(r.Globs) > 0:
+		return ActivationGlob
+	case r.Description != "":
+		return ActivationAgent
+	default:
+		return ActivationManual
+	}
+}
+
+// Validate checks that the rule has content and a name usable as a file name.
+func (r *Rule) Validate() error {
+	if !ruleNamePattern.MatchString(r.Name) {
+		return &RuleError{Name: r.Name, Err: ErrInvalidRuleName}
+	}
+	if strings.TrimSpace(r.Content) == "" {
+		return &RuleError{Name: r.Name, Err: ErrEmptyRule}
+	}
+	return nil
+}
+
+// ruleNamePattern matches rule names that are safe as file names.
+var ruleNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
 // Dependencies describes the project's dependencies.
 type Dependencies struct {
 	// Runtime lists runtime dependencies.
@@ -226,6 +298,27 @@ func (c *Context) AddConvention(convention string) {
 	c.Conventions = append(c.Conventions, convention)
 }
 
+// AddRule adds a rule to the context.
+func (c *Context) AddRule(rule Rule) {
+	c.Rules = append(c.Rules, rule)
+}
+
+// ValidateRules checks every rule and that rule names are unique.
+func (c *Context) ValidateRules() error {
+	seen := make(map[string]bool, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		if seen[rule.Name] {
+			return &RuleError{Name: rule.Name, Err: ErrDuplicateRule}
+		}
+		seen[rule.Name] = true
+	}
+	return nil
+}
+
 // AddNote adds a note to the context.
 func (c *Context) AddNote(content string) {
 	c.Notes = append(c.Notes, Note{Content: content})
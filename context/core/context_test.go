@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -307,3 +308,44 @@ func TestWriteFileContents(t *testing.T) {
 		t.Errorf("expected name 'test', got '%s'", parsed.Name)
 	}
 }
+
+func TestRuleActivation(t *testing.T) {
+	tests := []struct {
+		rule     Rule
+		expected Activation
+	}{
+		{Rule{AlwaysApply: true, Globs: []string{"*.go"}}, ActivationAlways},
+		{Rule{Globs: []string{"*.go"}, Description: "Go files"}, ActivationGlob},
+		{Rule{Description: "When releasing"}, ActivationAgent},
+		{Rule{}, ActivationManual},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.Activation(); got != tt.expected {
+			t.Errorf("expected activation %s for %+v, got %s", tt.expected, tt.rule, got)
+		}
+	}
+}
+
+func TestContextValidateRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []Rule
+		err   error
+	}{
+		{"valid", []Rule{{Name: "go-style", Content: "x"}, {Name: "v1.2_notes", Content: "y"}}, nil},
+		{"path name", []Rule{{Name: "../x", Content: "x"}}, ErrInvalidRuleName},
+		{"empty name", []Rule{{Content: "x"}}, ErrInvalidRuleName},
+		{"empty content", []Rule{{Name: "x", Content: "  \n"}}, ErrEmptyRule},
+		{"duplicate", []Rule{{Name: "x", Content: "a"}, {Name: "x", Content: "b"}}, ErrDuplicateRule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewContext("test")
+			ctx.Rules = tt.rules
+			err := ctx.ValidateRules()
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,9 @@ package core
 import (
 	"io/fs"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
 // DefaultFileMode is the default permission mode for generated files.
@@ -25,6 +28,17 @@ type Converter interface {
 	WriteFile(ctx *Context, path string) error
 }
 
+// MultiFileConverter is implemented by converters that emit more than one
+// file, such as one Cursor rule file per rule. WriteFile writes the main
+// file to the given path and the other files relative to its directory.
+type MultiFileConverter interface {
+	Converter
+
+	// ConvertFiles returns the generated files keyed by path relative to the
+	// directory of the main file, which is keyed by its base name.
+	ConvertFiles(ctx *Context) (map[string][]byte, error)
+}
+
 // ConverterRegistry holds registered converters for different tools.
 type ConverterRegistry struct {
 	converters map[string]Converter
@@ -48,12 +62,13 @@ func (r *ConverterRegistry) Get(name string) (Converter, bool) {
 	return converter, ok
 }
 
-// Names returns the names of all registered converters.
+// Names returns the names of all registered converters, sorted.
 func (r *ConverterRegistry) Names() []string {
 	names := make([]string, 0, len(r.converters))
 	for name := range r.converters {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
@@ -75,14 +90,14 @@ func (r *ConverterRegistry) WriteFile(ctx *Context, format, path string) error {
 	return converter.WriteFile(ctx, path)
 }
 
-// GenerateAll generates all supported formats in the given directory.
+// GenerateAll generates all supported formats in the given directory in
+// one pass, creating directories such as .cursor/rules as needed.
 func (r *ConverterRegistry) GenerateAll(ctx *Context, dir string) error {
-	for _, converter := range r.converters {
-		var path string
-		if dir != "" {
-			path = dir + "/" + converter.OutputFileName()
-		} else {
-			path = converter.OutputFileName()
+	for _, name := range r.Names() {
+		converter := r.converters[name]
+		path := filepath.Join(dir, converter.OutputFileName())
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return &WriteError{Format: name, Path: path, Err: err}
 		}
 		if err := converter.WriteFile(ctx, path); err != nil {
 			return err
@@ -130,6 +145,63 @@ func (c *BaseConverter) OutputFileName() string {
 	return c.outputFile
 }
 
+// CheckContext returns a ConversionError if ctx is nil, has no name or has
+// an invalid rule.
+func CheckContext(format string, ctx *Context) error {
+	if ctx == nil {
+		return &ConversionError{Format: format, Err: ErrEmptyContext}
+	}
+	if ctx.Name == "" {
+		return &ConversionError{Format: format, Err: ErrMissingName}
+	}
+	if err := ctx.ValidateRules(); err != nil {
+		return &ConversionError{Format: format, Err: err}
+	}
+	return nil
+}
+
+// CheckRuleNames returns an error if a rule of ctx has one of the reserved
+// names, those of the files a converter generates besides the rule files.
+// Names are compared case-insensitively, as file systems may do.
+func CheckRuleNames(format string, ctx *Context, reserved ...string) error {
+	for _, rule := range ctx.Rules {
+		for _, name := range reserved {
+			if strings.EqualFold(rule.Name, name) {
+				return &ConversionError{Format: format, Err: &RuleError{Name: rule.Name, Err: ErrReservedRuleName}}
+			}
+		}
+	}
+	return nil
+}
+
+// WriteFilesWithData writes the files of a MultiFileConverter: the file keyed
+// by the base name of OutputFileName goes to path, and the others go to
+// their keys relative to the directory of path.
+func (c *BaseConverter) WriteFilesWithData(files map[string][]byte, path string) error {
+	main := filepath.Base(c.outputFile)
+	dir := filepath.Dir(path)
+
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		target := filepath.Join(dir, filepath.FromSlash(key))
+		if key == main {
+			target = path
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return &WriteError{Format: c.name, Path: target, Err: err}
+		}
+		if err := c.WriteFileWithData(files[key], target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // WriteFileWithData writes data to a file with proper error wrapping using DefaultFileMode.
 func (c *BaseConverter) WriteFileWithData(data []byte, path string) error {
 	return c.WriteFileWithDataAndMode(data, path, DefaultFileMode)
@@ -142,3 +214,31 @@ func (c *BaseConverter) WriteFileWithDataAndMode(data []byte, path string, mode
 	}
 	return nil
 }
+
+// MarkdownConverter converts the context to a single Markdown file with
+// every section, as read by CLAUDE.md, AGENTS.md and GEMINI.md.
+type MarkdownConverter struct {
+	BaseConverter
+}
+
+// NewMarkdownConverter creates a Markdown converter.
+func NewMarkdownConverter(name, outputFile string) MarkdownConverter {
+	return MarkdownConverter{BaseConverter: NewBaseConverter(name, outputFile)}
+}
+
+// Convert converts the context to Markdown.
+func (c *MarkdownConverter) Convert(ctx *Context) ([]byte, error) {
+	if err := CheckContext(c.name, ctx); err != nil {
+		return nil, err
+	}
+	return []byte(Markdown(ctx) + Footer), nil
+}
+
+// WriteFile writes the converted context to a file.
+func (c *MarkdownConverter) WriteFile(ctx *Context, path string) error {
+	data, err := c.Convert(ctx)
+	if err != nil {
+		return err
+	}
+	return c.WriteFileWithData(data, path)
+}
@@ -178,6 +178,23 @@ func TestConverterRegistryGenerateAll(t *testing.T) {
 		}
 	})
 
+	t.Run("nested output", func(t *testing.T) {
+		registry := NewConverterRegistry()
+		registry.Register(&mockConverter{
+			name:       "nested",
+			outputFile: ".tool/rules/project.md",
+			content:    []byte("# Nested"),
+		})
+
+		tmpDir := t.TempDir()
+		if err := registry.GenerateAll(NewContext("test-project"), tmpDir); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, ".tool", "rules", "project.md")); err != nil {
+			t.Errorf("expected nested file to exist: %v", err)
+		}
+	})
+
 	t.Run("with empty dir", func(t *testing.T) {
 		registry := NewConverterRegistry()
 		registry.Register(&mockConverter{
@@ -311,4 +328,51 @@ func TestBaseConverter(t *testing.T) {
 			t.Logf("note: file permissions %v (expected 0600, may differ due to umask)", info.Mode().Perm())
 		}
 	})
+	t.Run("WriteFilesWithData", func(t *testing.T) {
+		bc := NewBaseConverter("test", "TEST.md")
+		tmpDir := t.TempDir()
+		path := filepath.Join(tmpDir, "rules", "main.md")
+
+		files := map[string][]byte{
+			"main.md":       []byte("# Main"),
+			"other.md":      []byte("# Other"),
+			"sub/nested.md": []byte("# Nested"),
+		}
+		if err := bc.WriteFilesWithData(files, path); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := map[string]string{
+			path: "# Main",
+			filepath.Join(tmpDir, "rules", "other.md"):         "# Other",
+			filepath.Join(tmpDir, "rules", "sub", "nested.md"): "# Nested",
+		}
+		for file, content := range expected {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Errorf("failed to read %s: %v", file, err)
+				continue
+			}
+			if string(data) != content {
+				t.Errorf("expected %q in %s, got %q", content, file, data)
+			}
+		}
+	})
+}
+
+func TestCheckContext(t *testing.T) {
+	if err := CheckContext("test", nil); err == nil {
+		t.Error("expected error for nil context")
+	}
+	if err := CheckContext("test", &Context{}); err == nil {
+		t.Error("expected error for context without name")
+	}
+
+	ctx := NewContext("test")
+	ctx.AddRule(Rule{Name: "bad name", Content: "x"})
+	err := CheckContext("test", ctx)
+	var ruleErr *RuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Name != "bad name" {
+		t.Errorf("expected RuleError for 'bad name', got %v", err)
+	}
 }
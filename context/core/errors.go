@@ -15,6 +15,19 @@ var (
 
 	// ErrUnsupportedFormat is returned when a format is not supported.
 	ErrUnsupportedFormat = errors.New("unsupported output format")
+
+	// ErrInvalidRuleName is returned when a rule name cannot be used as a file name.
+	ErrInvalidRuleName = errors.New("rule name must be letters, digits, '.', '_' or '-'")
+
+	// ErrEmptyRule is returned when a rule has no content.
+	ErrEmptyRule = errors.New("rule content is required")
+
+	// ErrDuplicateRule is returned when two rules have the same name.
+	ErrDuplicateRule = errors.New("duplicate rule name")
+
+	// ErrReservedRuleName is returned when a rule would be written to the
+	// same file as a file the converter generates from the context.
+	ErrReservedRuleName = errors.New("rule name is reserved for a generated file")
 )
 
 // ParseError represents an error parsing a context file.
@@ -65,3 +78,17 @@ func (e *ConversionError) Error() string {
 func (e *ConversionError) Unwrap() error {
 	return e.Err
 }
+
+// RuleError represents an invalid rule.
+type RuleError struct {
+	Name string
+	Err  error
+}
+
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("invalid rule %q: %v", e.Name, e.Err)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,332 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Section names a part of the context rendered by Markdown.
+type Section string
+
+const (
+	SectionOverview     Section = "overview"
+	SectionArchitecture Section = "architecture"
+	SectionPackages     Section = "packages"
+	SectionCommands     Section = "commands"
+	SectionConventions  Section = "conventions"
+	SectionRules        Section = "rules"
+	SectionDependencies Section = "dependencies"
+	SectionTesting      Section = "testing"
+	SectionFiles        Section = "files"
+	SectionNotes        Section = "notes"
+	SectionRelated      Section = "related"
+)
+
+// AllSections returns the sections in the order single-file formats such as
+// CLAUDE.md render them.
+func AllSections() []Section {
+	return []Section{
+		SectionOverview, SectionArchitecture, SectionPackages,
+		SectionCommands, SectionConventions, SectionRules,
+		SectionDependencies, SectionTesting, SectionFiles,
+		SectionNotes, SectionRelated,
+	}
+}
+
+// ProjectSections returns all sections except rules, for formats that
+// write each rule to its own file.
+func ProjectSections() []Section {
+	var sections []Section
+	for _, s := range AllSections() {
+		if s != SectionRules {
+			sections = append(sections, s)
+		}
+	}
+	return sections
+}
+
+// Footer is appended to generated files to mark them as generated.
+const Footer = "---\n*Generated from CONTEXT.json*\n"
+
+// commandOrder lists common commands first for readability.
+var commandOrder = []string{"build", "test", "lint", "format", "run"}
+
+// Markdown renders the given sections of the context as Markdown, in the
+// order given. With no sections, all sections are rendered. The overview
+// is the only section with a top-level heading; the others use "##".
+func Markdown(ctx *Context, sections ...Section) string {
+	if len(sections) == 0 {
+		sections = AllSections()
+	}
+
+	var b strings.Builder
+	for _, section := range sections {
+		switch section {
+		case SectionOverview:
+			writeOverview(&b, ctx)
+		case SectionArchitecture:
+			writeArchitecture(&b, ctx.Architecture)
+		case SectionPackages:
+			writePackages(&b, ctx.Packages)
+		case SectionCommands:
+			writeCommands(&b, ctx.Commands)
+		case SectionConventions:
+			writeConventions(&b, ctx.Conventions)
+		case SectionRules:
+			writeRules(&b, ctx.Rules)
+		case SectionDependencies:
+			writeDependencies(&b, ctx.Dependencies)
+		case SectionTesting:
+			writeTesting(&b, ctx.Testing)
+		case SectionFiles:
+			writeFiles(&b, ctx.Files)
+		case SectionNotes:
+			writeNotes(&b, ctx.Notes)
+		case SectionRelated:
+			writeRelated(&b, ctx.Related)
+		}
+	}
+	return b.String()
+}
+
+func writeOverview(b *strings.Builder, ctx *Context) {
+	fmt.Fprintf(b, "# %s\n\n", ctx.Name)
+
+	if ctx.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", ctx.Description)
+	}
+
+	switch {
+	case ctx.Version != "" && ctx.Language != "":
+		fmt.Fprintf(b, "**Version:** %s | **Language:** %s\n\n", ctx.Version, ctx.Language)
+	case ctx.Version != "":
+		fmt.Fprintf(b, "**Version:** %s\n\n", ctx.Version)
+	case ctx.Language != "":
+		fmt.Fprintf(b, "**Language:** %s\n\n", ctx.Language)
+	}
+}
+
+func writeArchitecture(b *strings.Builder, arch *Architecture) {
+	if arch == nil {
+		return
+	}
+	b.WriteString("## Architecture\n\n")
+	if arch.Pattern != "" {
+		fmt.Fprintf(b, "**Pattern:** %s\n\n", arch.Pattern)
+	}
+	if arch.Summary != "" {
+		fmt.Fprintf(b, "%s\n\n", arch.Summary)
+	}
+	for _, diagram := range arch.Diagrams {
+		if diagram.Title != "" {
+			fmt.Fprintf(b, "### %s\n\n", diagram.Title)
+		}
+		if diagram.Type == "mermaid" {
+			b.WriteString("```mermaid\n")
+		} else {
+			b.WriteString("```\n")
+		}
+		b.WriteString(diagram.Content)
+		b.WriteString("\n```\n\n")
+	}
+}
+
+func writePackages(b *strings.Builder, packages []Package) {
+	if len(packages) == 0 {
+		return
+	}
+	b.WriteString("## Packages\n\n")
+	b.WriteString("| Package | Purpose |\n")
+	b.WriteString("|---------|----------|\n")
+	for _, pkg := range packages {
+		fmt.Fprintf(b, "| `%s` | %s |\n", pkg.Path, pkg.Purpose)
+	}
+	b.WriteString("\n")
+}
+
+func writeCommands(b *strings.Builder, commands map[string]string) {
+	if len(commands) == 0 {
+		return
+	}
+	b.WriteString("## Commands\n\n")
+	b.WriteString("```bash\n")
+	for _, key := range CommandNames(commands) {
+		fmt.Fprintf(b, "# %s\n%s\n\n", key, commands[key])
+	}
+	b.WriteString("```\n\n")
+}
+
+// CommandNames returns the command names with common commands (build, test,
+// lint, format, run) first and the rest sorted.
+func CommandNames(commands map[string]string) []string {
+	var names []string
+	written := make(map[string]bool)
+	for _, key := range commandOrder {
+		if _, ok := commands[key]; ok {
+			names = append(names, key)
+			written[key] = true
+		}
+	}
+	var rest []string
+	for key := range commands {
+		if !written[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+func writeConventions(b *strings.Builder, conventions []string) {
+	if len(conventions) == 0 {
+		return
+	}
+	b.WriteString("## Conventions\n\n")
+	for _, conv := range conventions {
+		fmt.Fprintf(b, "- %s\n", conv)
+	}
+	b.WriteString("\n")
+}
+
+func writeRules(b *strings.Builder, rules []Rule) {
+	if len(rules) == 0 {
+		return
+	}
+	b.WriteString("## Rules\n\n")
+	for _, rule := range rules {
+		fmt.Fprintf(b, "### %s\n\n", rule.Name)
+		switch {
+		case len(rule.Globs) > 0:
+			fmt.Fprintf(b, "*Applies to `%s`.*\n\n", strings.Join(rule.Globs, "`, `"))
+		case rule.Description != "":
+			fmt.Fprintf(b, "*%s*\n\n", rule.Description)
+		}
+		fmt.Fprintf(b, "%s\n\n", strings.TrimSpace(rule.Content))
+	}
+}
+
+func writeDependencies(b *strings.Builder, deps *Dependencies) {
+	if deps == nil || (len(deps.Runtime) == 0 && len(deps.Development) == 0) {
+		return
+	}
+	b.WriteString("## Dependencies\n\n")
+	writeDependencyList(b, "Runtime", deps.Runtime)
+	writeDependencyList(b, "Development", deps.Development)
+}
+
+func writeDependencyList(b *strings.Builder, title string, deps []Dependency) {
+	if len(deps) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "### %s\n\n", title)
+	for _, dep := range deps {
+		if dep.Purpose != "" {
+			fmt.Fprintf(b, "- **%s** - %s\n", dep.Name, dep.Purpose)
+		} else {
+			fmt.Fprintf(b, "- %s\n", dep.Name)
+		}
+	}
+	b.WriteString("\n")
+}
+
+func writeTesting(b *strings.Builder, testing *Testing) {
+	if testing == nil {
+		return
+	}
+	b.WriteString("## Testing\n\n")
+	if testing.Framework != "" {
+		fmt.Fprintf(b, "**Framework:** %s\n\n", testing.Framework)
+	}
+	if testing.Coverage != "" {
+		fmt.Fprintf(b, "**Coverage:** %s\n\n", testing.Coverage)
+	}
+	if len(testing.Patterns) > 0 {
+		b.WriteString("**Patterns:**\n")
+		for _, pattern := range testing.Patterns {
+			fmt.Fprintf(b, "- %s\n", pattern)
+		}
+		b.WriteString("\n")
+	}
+}
+
+func writeFiles(b *strings.Builder, files *Files) {
+	if files == nil || (len(files.EntryPoints) == 0 && len(files.Config) == 0) {
+		return
+	}
+	b.WriteString("## Key Files\n\n")
+	writeFileList(b, "Entry Points", files.EntryPoints)
+	writeFileList(b, "Configuration", files.Config)
+}
+
+func writeFileList(b *strings.Builder, title string, files []string) {
+	if len(files) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "**%s:**\n", title)
+	for _, f := range files {
+		fmt.Fprintf(b, "- `%s`\n", f)
+	}
+	b.WriteString("\n")
+}
+
+func writeNotes(b *strings.Builder, notes []Note) {
+	if len(notes) == 0 {
+		return
+	}
+	b.WriteString("## Notes\n\n")
+	for _, note := range notes {
+		prefix := ""
+		switch note.GetSeverity() {
+		case "warning":
+			prefix = "**Warning:** "
+		case "critical":
+			prefix = "**CRITICAL:** "
+		}
+		if note.Title != "" {
+			fmt.Fprintf(b, "### %s\n\n%s%s\n\n", note.Title, prefix, note.Content)
+		} else {
+			fmt.Fprintf(b, "- %s%s\n", prefix, note.Content)
+		}
+	}
+	b.WriteString("\n")
+}
+
+func writeRelated(b *strings.Builder, related []Related) {
+	if len(related) == 0 {
+		return
+	}
+	b.WriteString("## Related\n\n")
+	for _, rel := range related {
+		if rel.URL != "" {
+			fmt.Fprintf(b, "- [%s](%s)", rel.Name, rel.URL)
+		} else {
+			fmt.Fprintf(b, "- %s", rel.Name)
+		}
+		if rel.Description != "" {
+			fmt.Fprintf(b, " - %s", rel.Description)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// YAMLValue returns s as a YAML scalar for front matter, quoting it only
+// when it would otherwise not read back as the same string.
+func YAMLValue(s string) string {
+	if s == "" || strings.ContainsAny(s, ":#\"'\n{}[],&*!|>%@`") ||
+		strings.TrimSpace(s) != s || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "?") {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// FrontMatter returns a YAML front matter block for the given lines.
+func FrontMatter(lines ...string) string {
+	return "---\n" + strings.Join(lines, "\n") + "\n---\n\n"
+}
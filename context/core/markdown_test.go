@@ -0,0 +1,52 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownSections(t *testing.T) {
+	ctx := NewContext("demo")
+	ctx.Description = "A demo project"
+	ctx.SetCommand("test", "go test ./...")
+	ctx.AddRule(Rule{Name: "go", Globs: []string{"*.go"}, Content: "Run gofmt."})
+
+	all := Markdown(ctx)
+	if !strings.Contains(all, "### go") || !strings.Contains(all, "*Applies to `*.go`.*") {
+		t.Errorf("expected rules section, got:\n%s", all)
+	}
+
+	commands := Markdown(ctx, SectionCommands)
+	if strings.Contains(commands, "# demo") || !strings.Contains(commands, "go test ./...") {
+		t.Errorf("expected only the commands section, got:\n%s", commands)
+	}
+
+	if got := Markdown(ctx, SectionArchitecture); got != "" {
+		t.Errorf("expected empty output for missing section, got %q", got)
+	}
+}
+
+func TestCommandNames(t *testing.T) {
+	commands := map[string]string{"zeta": "z", "test": "t", "alpha": "a", "build": "b"}
+	expected := []string{"build", "test", "alpha", "zeta"}
+	if got := CommandNames(commands); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestYAMLValue(t *testing.T) {
+	tests := map[string]string{
+		"plain words":    "plain words",
+		"key: value":     `"key: value"`,
+		"**/*.go":        `"**/*.go"`,
+		"true":           `"true"`,
+		"":               `""`,
+		`say "hi" # now`: `"say \"hi\" # now"`,
+	}
+	for in, expected := range tests {
+		if got := YAMLValue(in); got != expected {
+			t.Errorf("YAMLValue(%q) = %s, expected %s", in, got, expected)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Package cursor provides a converter for generating Cursor project rules
+// (.cursor/rules/*.mdc) from the canonical project context format.
+//
+// The project context is written to an always-applied project.mdc rule, and
+// each context rule to its own .mdc file with description, globs and
+// alwaysApply front matter.
+package cursor
+
+import (
+	"strings"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+const (
+	// ConverterName is the identifier for this converter.
+	ConverterName = "cursor"
+
+	// OutputFile is the default output file name.
+	OutputFile = ".cursor/rules/project.mdc"
+
+	// RuleExt is the file extension of Cursor rules.
+	RuleExt = ".mdc"
+
+	// ProjectRule is the name of the rule holding the project context.
+	// Context rules cannot use it.
+	ProjectRule = "project"
+)
+
+// Converter implements core.MultiFileConverter for Cursor rules.
+type Converter struct {
+	core.BaseConverter
+}
+
+// NewConverter creates a new Cursor converter.
+func NewConverter() *Converter {
+	return &Converter{
+		BaseConverter: core.NewBaseConverter(ConverterName, OutputFile),
+	}
+}
+
+// Convert converts the context to the always-applied project rule.
+func (c *Converter) Convert(ctx *core.Context) ([]byte, error) {
+	if err := core.CheckContext(ConverterName, ctx); err != nil {
+		return nil, err
+	}
+	return []byte(frontMatter(ctx.Description, nil, true) + core.Markdown(ctx, core.ProjectSections()...) + core.Footer), nil
+}
+
+// ConvertFiles converts the context to the project rule and one rule file
+// per context rule.
+func (c *Converter) ConvertFiles(ctx *core.Context) (map[string][]byte, error) {
+	project, err := c.Convert(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.CheckRuleNames(ConverterName, ctx, ProjectRule); err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{ProjectRule + RuleExt: project}
+	for _, rule := range ctx.Rules {
+		files[rule.Name+RuleExt] = []byte(frontMatter(rule.Description, rule.Globs, rule.AlwaysApply) +
+			strings.TrimSpace(rule.Content) + "\n")
+	}
+	return files, nil
+}
+
+// WriteFile writes the project rule to path and the other rules beside it.
+func (c *Converter) WriteFile(ctx *core.Context, path string) error {
+	files, err := c.ConvertFiles(ctx)
+	if err != nil {
+		return err
+	}
+	return c.WriteFilesWithData(files, path)
+}
+
+// frontMatter returns Cursor rule front matter. Cursor reads globs as a
+// comma-separated list and writes all three keys even when empty.
+func frontMatter(description string, globs []string, alwaysApply bool) string {
+	desc := ""
+	if description != "" {
+		desc = core.YAMLValue(description)
+	}
+	apply := "false"
+	if alwaysApply {
+		apply = "true"
+	}
+	return core.FrontMatter(
+		"description: "+desc,
+		"globs: "+strings.Join(globs, ","),
+		"alwaysApply: "+apply,
+	)
+}
+
+// init registers the converter with the default registry.
+func init() {
+	core.RegisterConverter(NewConverter())
+}
@@ -0,0 +1,53 @@
+package cursor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+func TestConverterConvertFiles(t *testing.T) {
+	ctx := core.NewContext("demo")
+	ctx.Description = "Demo: a project"
+	ctx.AddRule(core.Rule{Name: "go", Globs: []string{"**/*.go", "go.mod"}, Content: "Run gofmt.\n"})
+	ctx.AddRule(core.Rule{Name: "style", AlwaysApply: true, Content: "Be concise."})
+
+	files, err := NewConverter().ConvertFiles(ctx)
+	if err != nil {
+		t.Fatalf("ConvertFiles failed: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %d", len(files))
+	}
+
+	project := string(files["project.mdc"])
+	if !strings.HasPrefix(project, "---\ndescription: \"Demo: a project\"\nglobs: \nalwaysApply: true\n---\n\n# demo") {
+		t.Errorf("unexpected project rule:\n%s", project)
+	}
+
+	expected := "---\ndescription: \nglobs: **/*.go,go.mod\nalwaysApply: false\n---\n\nRun gofmt.\n"
+	if got := string(files["go.mdc"]); got != expected {
+		t.Errorf("expected go rule:\n%s\ngot:\n%s", expected, got)
+	}
+	if !strings.Contains(string(files["style.mdc"]), "alwaysApply: true") {
+		t.Error("expected style rule to be always applied")
+	}
+}
+
+func TestConverterConvertMissingName(t *testing.T) {
+	if _, err := NewConverter().Convert(&core.Context{}); err == nil {
+		t.Error("expected error for context without name")
+	}
+}
+
+func TestConverterConvertFilesReservedRuleName(t *testing.T) {
+	ctx := core.NewContext("demo")
+	ctx.AddRule(core.Rule{Name: "Project", Content: "Would replace the project rule."})
+
+	_, err := NewConverter().ConvertFiles(ctx)
+	if !errors.Is(err, core.ErrReservedRuleName) {
+		t.Errorf("expected ErrReservedRuleName, got %v", err)
+	}
+}
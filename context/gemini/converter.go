@@ -0,0 +1,32 @@
+// Package gemini provides a converter for generating GEMINI.md files
+// from the canonical project context format.
+package gemini
+
+import (
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+const (
+	// ConverterName is the identifier for this converter.
+	ConverterName = "gemini"
+
+	// OutputFile is the default output file name.
+	OutputFile = "GEMINI.md"
+)
+
+// Converter implements core.Converter for Gemini CLI GEMINI.md files.
+type Converter struct {
+	core.MarkdownConverter
+}
+
+// NewConverter creates a new Gemini converter.
+func NewConverter() *Converter {
+	return &Converter{
+		MarkdownConverter: core.NewMarkdownConverter(ConverterName, OutputFile),
+	}
+}
+
+// init registers the converter with the default registry.
+func init() {
+	core.RegisterConverter(NewConverter())
+}
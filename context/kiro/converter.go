@@ -0,0 +1,133 @@
+// Package kiro provides a converter for generating Kiro steering files
+// (.kiro/steering/*.md) from the canonical project context format.
+//
+// The project context is split into Kiro's foundational steering files:
+// product.md (overview and notes), tech.md (commands, dependencies, testing
+// and conventions) and structure.md (architecture, packages and key files).
+// Each context rule is written to its own steering file with an inclusion
+// mode matching its activation.
+package kiro
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+const (
+	// ConverterName is the identifier for this converter.
+	ConverterName = "kiro"
+
+	// OutputFile is the default output file name.
+	OutputFile = ".kiro/steering/product.md"
+)
+
+// Kiro steering inclusion modes.
+const (
+	InclusionAlways    = "always"
+	InclusionFileMatch = "fileMatch"
+	InclusionManual    = "manual"
+)
+
+// steeringFiles lists the foundational steering files with their titles and sections.
+var steeringFiles = []struct {
+	name     string
+	title    string
+	sections []core.Section
+}{
+	{"product.md", "", []core.Section{core.SectionOverview, core.SectionNotes, core.SectionRelated}},
+	{"tech.md", "Tech Stack", []core.Section{core.SectionCommands, core.SectionDependencies, core.SectionTesting, core.SectionConventions}},
+	{"structure.md", "Project Structure", []core.Section{core.SectionArchitecture, core.SectionPackages, core.SectionFiles}},
+}
+
+// Converter implements core.MultiFileConverter for Kiro steering files.
+type Converter struct {
+	core.BaseConverter
+}
+
+// NewConverter creates a new Kiro converter.
+func NewConverter() *Converter {
+	return &Converter{
+		BaseConverter: core.NewBaseConverter(ConverterName, OutputFile),
+	}
+}
+
+// Convert converts the context to the product steering file.
+func (c *Converter) Convert(ctx *core.Context) ([]byte, error) {
+	files, err := c.ConvertFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return files["product.md"], nil
+}
+
+// ConvertFiles converts the context to the foundational steering files and
+// one steering file per context rule. Steering files without content are
+// omitted.
+func (c *Converter) ConvertFiles(ctx *core.Context) (map[string][]byte, error) {
+	if err := core.CheckContext(ConverterName, ctx); err != nil {
+		return nil, err
+	}
+	reserved := make([]string, len(steeringFiles))
+	for i, f := range steeringFiles {
+		reserved[i] = strings.TrimSuffix(f.name, ".md")
+	}
+	if err := core.CheckRuleNames(ConverterName, ctx, reserved...); err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	always := core.FrontMatter("inclusion: " + InclusionAlways)
+	for _, f := range steeringFiles {
+		body := core.Markdown(ctx, f.sections...)
+		if body == "" {
+			continue
+		}
+		if f.title != "" {
+			body = "# " + f.title + "\n\n" + body
+		}
+		files[f.name] = []byte(always + body + core.Footer)
+	}
+
+	for _, rule := range ctx.Rules {
+		var lines []string
+		switch rule.Activation() {
+		case core.ActivationAlways:
+			lines = append(lines, "inclusion: "+InclusionAlways)
+		case core.ActivationGlob:
+			lines = append(lines, "inclusion: "+InclusionFileMatch, "fileMatchPattern: "+fileMatchPattern(rule.Globs))
+		default:
+			lines = append(lines, "inclusion: "+InclusionManual)
+		}
+		files[rule.Name+".md"] = []byte(core.FrontMatter(lines...) + strings.TrimSpace(rule.Content) + "\n")
+	}
+	return files, nil
+}
+
+// WriteFile writes the product steering file to path and the other
+// steering files beside it.
+func (c *Converter) WriteFile(ctx *core.Context, path string) error {
+	files, err := c.ConvertFiles(ctx)
+	if err != nil {
+		return err
+	}
+	return c.WriteFilesWithData(files, path)
+}
+
+// fileMatchPattern returns a single glob as a string and several as a list.
+func fileMatchPattern(globs []string) string {
+	if len(globs) == 1 {
+		return strconv.Quote(globs[0])
+	}
+	quoted := make([]string, len(globs))
+	for i, g := range globs {
+		quoted[i] = strconv.Quote(g)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// init registers the converter with the default registry.
+func init() {
+	core.RegisterConverter(NewConverter())
+}
@@ -0,0 +1,57 @@
+package kiro
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+func TestConverterConvertFiles(t *testing.T) {
+	ctx := core.NewContext("demo")
+	ctx.Description = "A demo project"
+	ctx.SetCommand("build", "go build ./...")
+	ctx.AddRule(core.Rule{Name: "api", Globs: []string{"api/**/*.go"}, Content: "Version every endpoint."})
+	ctx.AddRule(core.Rule{Name: "db", Globs: []string{"*.sql", "migrations/**"}, Content: "Never drop columns."})
+	ctx.AddRule(core.Rule{Name: "release", Description: "Releasing", Content: "Tag from main."})
+
+	files, err := NewConverter().ConvertFiles(ctx)
+	if err != nil {
+		t.Fatalf("ConvertFiles failed: %v", err)
+	}
+
+	if _, ok := files["structure.md"]; ok {
+		t.Error("expected structure.md to be omitted without architecture, packages or files")
+	}
+	if !strings.HasPrefix(string(files["product.md"]), "---\ninclusion: always\n---\n\n# demo") {
+		t.Errorf("unexpected product.md:\n%s", files["product.md"])
+	}
+	tech := string(files["tech.md"])
+	if !strings.Contains(tech, "# Tech Stack") || !strings.Contains(tech, "go build ./...") {
+		t.Errorf("unexpected tech.md:\n%s", tech)
+	}
+
+	tests := map[string]string{
+		"api.md":     "inclusion: fileMatch\nfileMatchPattern: \"api/**/*.go\"\n",
+		"db.md":      "fileMatchPattern: [\"*.sql\", \"migrations/**\"]\n",
+		"release.md": "inclusion: manual\n",
+	}
+	for name, want := range tests {
+		if !strings.Contains(string(files[name]), want) {
+			t.Errorf("expected %s to contain %q, got:\n%s", name, want, files[name])
+		}
+	}
+}
+
+func TestConverterConvertFilesReservedRuleName(t *testing.T) {
+	for _, name := range []string{"product", "tech", "structure"} {
+		ctx := core.NewContext("demo")
+		ctx.AddRule(core.Rule{Name: name, Content: "Would replace a steering file."})
+
+		_, err := NewConverter().ConvertFiles(ctx)
+		if !errors.Is(err, core.ErrReservedRuleName) {
+			t.Errorf("%s: expected ErrReservedRuleName, got %v", name, err)
+		}
+	}
+}
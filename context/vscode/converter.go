@@ -0,0 +1,94 @@
+// Package vscode provides a converter for generating GitHub Copilot custom
+// instructions from the canonical project context format, as read by VS Code
+// and the Copilot coding agent.
+//
+// The project context is written to .github/copilot-instructions.md, and
+// each context rule to .github/instructions/<name>.instructions.md with an
+// applyTo front matter glob.
+package vscode
+
+import (
+	"strings"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+const (
+	// ConverterName is the identifier for this converter.
+	ConverterName = "vscode"
+
+	// OutputFile is the default output file name.
+	OutputFile = ".github/copilot-instructions.md"
+
+	// InstructionsDir is the directory of path-specific instructions,
+	// relative to the directory of OutputFile.
+	InstructionsDir = "instructions"
+
+	// InstructionsExt is the file extension of path-specific instructions.
+	InstructionsExt = ".instructions.md"
+)
+
+// Converter implements core.MultiFileConverter for Copilot instructions.
+type Converter struct {
+	core.BaseConverter
+}
+
+// NewConverter creates a new Copilot instructions converter.
+func NewConverter() *Converter {
+	return &Converter{
+		BaseConverter: core.NewBaseConverter(ConverterName, OutputFile),
+	}
+}
+
+// Convert converts the context to repository-wide custom instructions.
+func (c *Converter) Convert(ctx *core.Context) ([]byte, error) {
+	if err := core.CheckContext(ConverterName, ctx); err != nil {
+		return nil, err
+	}
+	return []byte(core.Markdown(ctx, core.ProjectSections()...) + core.Footer), nil
+}
+
+// ConvertFiles converts the context to repository-wide instructions and one
+// path-specific instructions file per context rule. Always-applied rules
+// apply to "**"; rules without globs are only attached manually.
+func (c *Converter) ConvertFiles(ctx *core.Context) (map[string][]byte, error) {
+	main, err := c.Convert(ctx)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{"copilot-instructions.md": main}
+	for _, rule := range ctx.Rules {
+		var lines []string
+		if rule.Description != "" {
+			lines = append(lines, "description: "+core.YAMLValue(rule.Description))
+		}
+		switch rule.Activation() {
+		case core.ActivationAlways:
+			lines = append(lines, `applyTo: "**"`)
+		case core.ActivationGlob:
+			lines = append(lines, "applyTo: "+core.YAMLValue(strings.Join(rule.Globs, ",")))
+		}
+
+		content := strings.TrimSpace(rule.Content) + "\n"
+		if len(lines) > 0 {
+			content = core.FrontMatter(lines...) + content
+		}
+		files[InstructionsDir+"/"+rule.Name+InstructionsExt] = []byte(content)
+	}
+	return files, nil
+}
+
+// WriteFile writes the repository-wide instructions to path and the
+// path-specific instructions to the instructions directory beside it.
+func (c *Converter) WriteFile(ctx *core.Context, path string) error {
+	files, err := c.ConvertFiles(ctx)
+	if err != nil {
+		return err
+	}
+	return c.WriteFilesWithData(files, path)
+}
+
+// init registers the converter with the default registry.
+func init() {
+	core.RegisterConverter(NewConverter())
+}
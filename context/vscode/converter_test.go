@@ -0,0 +1,37 @@
+package vscode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+func TestConverterConvertFiles(t *testing.T) {
+	ctx := core.NewContext("demo")
+	ctx.AddRule(core.Rule{Name: "tests", Description: "Testing", Globs: []string{"**/*_test.go"}, Content: "Use t.Run."})
+	ctx.AddRule(core.Rule{Name: "always", AlwaysApply: true, Content: "Be concise."})
+	ctx.AddRule(core.Rule{Name: "manual", Content: "Only on request."})
+
+	files, err := NewConverter().ConvertFiles(ctx)
+	if err != nil {
+		t.Fatalf("ConvertFiles failed: %v", err)
+	}
+
+	main := string(files["copilot-instructions.md"])
+	if !strings.HasPrefix(main, "# demo") {
+		t.Errorf("expected instructions without front matter, got:\n%s", main)
+	}
+
+	tests := map[string]string{
+		"tests":  "---\ndescription: Testing\napplyTo: \"**/*_test.go\"\n---\n\nUse t.Run.\n",
+		"always": "---\napplyTo: \"**\"\n---\n\nBe concise.\n",
+		"manual": "Only on request.\n",
+	}
+	for name, expected := range tests {
+		got := string(files[InstructionsDir+"/"+name+InstructionsExt])
+		if got != expected {
+			t.Errorf("expected %s:\n%s\ngot:\n%s", name, expected, got)
+		}
+	}
+}
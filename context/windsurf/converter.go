@@ -0,0 +1,100 @@
+// Package windsurf provides a converter for generating Windsurf workspace
+// rules (.windsurf/rules/*.md) from the canonical project context format.
+//
+// The project context is written to an always-on project.md rule, and each
+// context rule to its own file with a trigger matching its activation.
+package windsurf
+
+import (
+	"strings"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+const (
+	// ConverterName is the identifier for this converter.
+	ConverterName = "windsurf"
+
+	// OutputFile is the default output file name.
+	OutputFile = ".windsurf/rules/project.md"
+
+	// ProjectRule is the name of the rule holding the project context.
+	// Context rules cannot use it.
+	ProjectRule = "project"
+)
+
+// Windsurf rule triggers.
+const (
+	TriggerAlwaysOn      = "always_on"
+	TriggerGlob          = "glob"
+	TriggerModelDecision = "model_decision"
+	TriggerManual        = "manual"
+)
+
+// triggers maps rule activations to Windsurf triggers.
+var triggers = map[core.Activation]string{
+	core.ActivationAlways: TriggerAlwaysOn,
+	core.ActivationGlob:   TriggerGlob,
+	core.ActivationAgent:  TriggerModelDecision,
+	core.ActivationManual: TriggerManual,
+}
+
+// Converter implements core.MultiFileConverter for Windsurf rules.
+type Converter struct {
+	core.BaseConverter
+}
+
+// NewConverter creates a new Windsurf converter.
+func NewConverter() *Converter {
+	return &Converter{
+		BaseConverter: core.NewBaseConverter(ConverterName, OutputFile),
+	}
+}
+
+// Convert converts the context to the always-on project rule.
+func (c *Converter) Convert(ctx *core.Context) ([]byte, error) {
+	if err := core.CheckContext(ConverterName, ctx); err != nil {
+		return nil, err
+	}
+	header := core.FrontMatter("trigger: " + TriggerAlwaysOn)
+	return []byte(header + core.Markdown(ctx, core.ProjectSections()...) + core.Footer), nil
+}
+
+// ConvertFiles converts the context to the project rule and one rule file
+// per context rule.
+func (c *Converter) ConvertFiles(ctx *core.Context) (map[string][]byte, error) {
+	project, err := c.Convert(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.CheckRuleNames(ConverterName, ctx, ProjectRule); err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{ProjectRule + ".md": project}
+	for _, rule := range ctx.Rules {
+		trigger := triggers[rule.Activation()]
+		lines := []string{"trigger: " + trigger}
+		switch trigger {
+		case TriggerGlob:
+			lines = append(lines, "globs: "+strings.Join(rule.Globs, ","))
+		case TriggerModelDecision:
+			lines = append(lines, "description: "+core.YAMLValue(rule.Description))
+		}
+		files[rule.Name+".md"] = []byte(core.FrontMatter(lines...) + strings.TrimSpace(rule.Content) + "\n")
+	}
+	return files, nil
+}
+
+// WriteFile writes the project rule to path and the other rules beside it.
+func (c *Converter) WriteFile(ctx *core.Context, path string) error {
+	files, err := c.ConvertFiles(ctx)
+	if err != nil {
+		return err
+	}
+	return c.WriteFilesWithData(files, path)
+}
+
+// init registers the converter with the default registry.
+func init() {
+	core.RegisterConverter(NewConverter())
+}
@@ -0,0 +1,48 @@
+package windsurf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/context/core"
+)
+
+func TestConverterConvertFiles(t *testing.T) {
+	ctx := core.NewContext("demo")
+	ctx.AddRule(core.Rule{Name: "always", AlwaysApply: true, Content: "Be concise."})
+	ctx.AddRule(core.Rule{Name: "go", Globs: []string{"**/*.go"}, Content: "Run gofmt."})
+	ctx.AddRule(core.Rule{Name: "release", Description: "Releasing", Content: "Tag from main."})
+	ctx.AddRule(core.Rule{Name: "manual", Content: "Only on request."})
+
+	files, err := NewConverter().ConvertFiles(ctx)
+	if err != nil {
+		t.Fatalf("ConvertFiles failed: %v", err)
+	}
+
+	if !strings.HasPrefix(string(files["project.md"]), "---\ntrigger: always_on\n---\n\n# demo") {
+		t.Errorf("unexpected project rule:\n%s", files["project.md"])
+	}
+
+	tests := map[string]string{
+		"always.md":  "trigger: always_on\n",
+		"go.md":      "trigger: glob\nglobs: **/*.go\n",
+		"release.md": "trigger: model_decision\ndescription: Releasing\n",
+		"manual.md":  "trigger: manual\n",
+	}
+	for name, expected := range tests {
+		if !strings.Contains(string(files[name]), expected) {
+			t.Errorf("expected %s to contain %q, got:\n%s", name, expected, files[name])
+		}
+	}
+}
+
+func TestConverterConvertFilesReservedRuleName(t *testing.T) {
+	ctx := core.NewContext("demo")
+	ctx.AddRule(core.Rule{Name: "Project", Content: "Would replace the project rule."})
+
+	_, err := NewConverter().ConvertFiles(ctx)
+	if !errors.Is(err, core.ErrReservedRuleName) {
+		t.Errorf("expected ErrReservedRuleName, got %v", err)
+	}
+}
@@ -0,0 +1,386 @@
+// Package discovery finds the AI assistant configuration installed on a
+// machine and resolves what each tool will actually see.
+//
+// Tools read their configuration from several scopes:
+//   - managed: enterprise policy files (e.g., /etc/claude-code/managed-mcp.json)
+//   - project: files in the repository (e.g., .mcp.json, .cursor/mcp.json)
+//   - user: files in the home directory (e.g., ~/.claude.json, ~/.cursor/mcp.json)
+//
+// Discover walks every scope for every registered MCP, hooks, agents,
+// commands and skills adapter, loads the files it finds into canonical
+// types, and resolves them into one view per tool. When the same MCP
+// server, agent, command or skill is defined in several scopes, the
+// definition from the higher-precedence scope wins: managed over project
+// over user.
+//
+// Example:
+//
+//	report, err := discovery.Discover(discovery.Options{ProjectRoot: "."})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, s := range report.Tool("cursor").Servers {
+//	    fmt.Println(s.Name, s.Location.Scope)
+//	}
+package discovery
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/agents"
+	"github.com/agentplexus/assistantkit/commands"
+	"github.com/agentplexus/assistantkit/hooks"
+	"github.com/agentplexus/assistantkit/mcp"
+	"github.com/agentplexus/assistantkit/skills"
+)
+
+// Scope is the level at which a tool reads a configuration file.
+type Scope string
+
+const (
+	// ScopeManaged is enterprise-managed configuration.
+	ScopeManaged Scope = "managed"
+
+	// ScopeProject is configuration checked into the project.
+	ScopeProject Scope = "project"
+
+	// ScopeUser is configuration in the user's home directory.
+	ScopeUser Scope = "user"
+)
+
+// Scopes returns all scopes in precedence order, highest first.
+func Scopes() []Scope {
+	return []Scope{ScopeManaged, ScopeProject, ScopeUser}
+}
+
+// Precedence returns the rank of the scope; higher ranks win.
+func (s Scope) Precedence() int {
+	switch s {
+	case ScopeManaged:
+		return 3
+	case ScopeProject:
+		return 2
+	case ScopeUser:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Kind is the type of configuration at a location.
+type Kind string
+
+const (
+	KindMCP      Kind = "mcp"
+	KindHooks    Kind = "hooks"
+	KindAgents   Kind = "agents"
+	KindCommands Kind = "commands"
+	KindSkills   Kind = "skills"
+)
+
+// Kinds returns all configuration kinds.
+func Kinds() []Kind {
+	return []Kind{KindMCP, KindHooks, KindAgents, KindCommands, KindSkills}
+}
+
+// toolDirs maps tools to the directory that holds their agents, commands
+// and skills, relative to the project root or home directory.
+var toolDirs = map[string]string{
+	"claude": ".claude",
+	"codex":  ".codex",
+	"gemini": ".gemini",
+	"kiro":   ".kiro",
+}
+
+// Location is a place where a tool reads configuration. MCP and hooks
+// locations are files; agents, commands and skills locations are directories.
+type Location struct {
+	Kind  Kind   `json:"kind"`
+	Tool  string `json:"tool"`
+	Scope Scope  `json:"scope"`
+	Path  string `json:"path"`
+}
+
+// Options configures discovery.
+type Options struct {
+	// ProjectRoot is the project directory. Defaults to the current directory.
+	ProjectRoot string
+
+	// Tools limits discovery to the named tools. Empty means all tools.
+	Tools []string
+
+	// Kinds limits discovery to the given kinds. Empty means all kinds.
+	Kinds []Kind
+}
+
+// Source is the configuration loaded from one location.
+type Source struct {
+	Location
+
+	// Found is true if the location exists.
+	Found bool `json:"found"`
+
+	// Error describes why the location could not be loaded.
+	Error string `json:"error,omitempty"`
+
+	MCP      *mcp.Config         `json:"mcp,omitempty"`
+	Hooks    *hooks.Config       `json:"hooks,omitempty"`
+	Agents   []*agents.Agent     `json:"agents,omitempty"`
+	Commands []*commands.Command `json:"commands,omitempty"`
+	Skills   []*skills.Skill     `json:"skills,omitempty"`
+}
+
+// Report is the result of discovery.
+type Report struct {
+	// ProjectRoot is the absolute path of the project directory.
+	ProjectRoot string `json:"projectRoot"`
+
+	// Sources holds every location searched, in precedence order.
+	Sources []*Source `json:"sources"`
+
+	// Tools holds the resolved view of each tool with configuration, sorted by name.
+	Tools []*ToolView `json:"tools"`
+}
+
+// Tool returns the resolved view of the named tool, or an empty view if
+// no configuration was found for it.
+func (r *Report) Tool(name string) *ToolView {
+	for _, t := range r.Tools {
+		if t.Tool == name {
+			return t
+		}
+	}
+	return &ToolView{Tool: name}
+}
+
+// Discover searches all scopes for the configuration of every registered
+// adapter and resolves the results per tool.
+func Discover(opts Options) (*Report, error) {
+	locations, err := Locations(opts)
+	if err != nil {
+		return nil, err
+	}
+	root, err := projectRoot(opts)
+	if err != nil {
+		return nil, err
+	}
+	report := Scan(locations)
+	report.ProjectRoot = root
+	return report, nil
+}
+
+// Locations returns the locations searched for the given options, in
+// precedence order. MCP and hooks locations come from each adapter's
+// DefaultPaths: relative paths are project scope, paths in the home
+// directory are user scope and other absolute paths are managed scope.
+func Locations(opts Options) ([]Location, error) {
+	root, err := projectRoot(opts)
+	if err != nil {
+		return nil, err
+	}
+	home, _ := os.UserHomeDir()
+
+	var locations []Location
+	add := func(kind Kind, tool string, paths ...string) {
+		if !included(opts, kind, tool) {
+			return
+		}
+		for _, p := range paths {
+			loc := Location{Kind: kind, Tool: tool, Path: p}
+			switch {
+			case !filepath.IsAbs(p):
+				loc.Scope, loc.Path = ScopeProject, filepath.Join(root, p)
+			case home != "" && within(home, p):
+				loc.Scope = ScopeUser
+			default:
+				loc.Scope = ScopeManaged
+			}
+			locations = append(locations, loc)
+		}
+	}
+	dirs := func(kind Kind, tool, dir string) {
+		toolDir, ok := toolDirs[tool]
+		if !ok {
+			return
+		}
+		paths := []string{filepath.Join(toolDir, dir)}
+		if home != "" {
+			paths = append(paths, filepath.Join(home, toolDir, dir))
+		}
+		add(kind, tool, paths...)
+	}
+
+	for _, name := range sorted(mcp.AdapterNames()) {
+		adapter, _ := mcp.GetAdapter(name)
+		add(KindMCP, name, adapter.DefaultPaths()...)
+	}
+	for _, name := range sorted(hooks.AdapterNames()) {
+		adapter, _ := hooks.GetAdapter(name)
+		add(KindHooks, name, adapter.DefaultPaths()...)
+	}
+	for _, name := range agents.AdapterNames() {
+		adapter, _ := agents.GetAdapter(name)
+		dirs(KindAgents, name, adapter.DefaultDir())
+	}
+	for _, name := range commands.AdapterNames() {
+		adapter, _ := commands.GetAdapter(name)
+		dirs(KindCommands, name, adapter.DefaultDir())
+	}
+	for _, name := range skills.AdapterNames() {
+		adapter, _ := skills.GetAdapter(name)
+		dirs(KindSkills, name, adapter.DefaultDir())
+	}
+
+	sort.SliceStable(locations, func(i, j int) bool {
+		return locations[i].Scope.Precedence() > locations[j].Scope.Precedence()
+	})
+	return locations, nil
+}
+
+// Scan loads the configuration at each location and resolves the results
+// per tool. Locations must be in precedence order, highest first.
+func Scan(locations []Location) *Report {
+	report := &Report{}
+	for _, loc := range locations {
+		report.Sources = append(report.Sources, load(loc))
+	}
+	report.Tools = resolve(report.Sources)
+	return report
+}
+
+// load reads the configuration at a location.
+func load(loc Location) *Source {
+	src := &Source{Location: loc}
+	if _, err := os.Stat(loc.Path); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			src.Found = true
+			src.Error = err.Error()
+		}
+		return src
+	}
+	src.Found = true
+
+	var err error
+	switch loc.Kind {
+	case KindMCP:
+		adapter, _ := mcp.GetAdapter(loc.Tool)
+		src.MCP, err = adapter.ReadFile(loc.Path)
+	case KindHooks:
+		adapter, _ := hooks.GetAdapter(loc.Tool)
+		src.Hooks, err = adapter.ReadFile(loc.Path)
+	case KindAgents:
+		adapter, _ := agents.GetAdapter(loc.Tool)
+		err = readDir(loc.Path, adapter.FileExtension(), func(path string) error {
+			agent, err := adapter.ReadFile(path)
+			if err == nil {
+				src.Agents = append(src.Agents, agent)
+			}
+			return err
+		})
+	case KindCommands:
+		adapter, _ := commands.GetAdapter(loc.Tool)
+		err = readDir(loc.Path, adapter.FileExtension(), func(path string) error {
+			cmd, err := adapter.ReadFile(path)
+			if err == nil {
+				src.Commands = append(src.Commands, cmd)
+			}
+			return err
+		})
+	case KindSkills:
+		adapter, _ := skills.GetAdapter(loc.Tool)
+		err = readSkillDir(loc.Path, adapter.SkillFileName(), func(path string) error {
+			skill, err := adapter.ReadFile(path)
+			if err == nil {
+				src.Skills = append(src.Skills, skill)
+			}
+			return err
+		})
+	}
+	if err != nil {
+		src.Error = err.Error()
+	}
+	return src
+}
+
+// readDir calls read for each file in dir with the given extension, in
+// name order. It returns the first error.
+func readDir(dir, ext string, read func(path string) error) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var first error
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ext {
+			continue
+		}
+		if err := read(filepath.Join(dir, e.Name())); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// readSkillDir calls read for each skill in dir. A skill file name
+// starting with "." is a suffix of files in dir (e.g., Kiro steering
+// files); otherwise each skill is a subdirectory holding that file.
+func readSkillDir(dir, fileName string, read func(path string) error) error {
+	if strings.HasPrefix(fileName, ".") {
+		return readDir(dir, fileName, read)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var first error
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name(), fileName)
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := read(path); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+func projectRoot(opts Options) (string, error) {
+	root := opts.ProjectRoot
+	if root == "" {
+		root = "."
+	}
+	return filepath.Abs(root)
+}
+
+// included reports whether the options select the kind and tool.
+func included(opts Options, kind Kind, tool string) bool {
+	kindOK, toolOK := len(opts.Kinds) == 0, len(opts.Tools) == 0
+	for _, k := range opts.Kinds {
+		kindOK = kindOK || k == kind
+	}
+	for _, t := range opts.Tools {
+		toolOK = toolOK || t == tool
+	}
+	return kindOK && toolOK
+}
+
+// within reports whether path is inside dir.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func sorted(names []string) []string {
+	names = append([]string(nil), names...)
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,150 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func agentFile(name, description string) string {
+	return "---\nname: " + name + "\ndescription: " + description + "\n---\n\nYou are " + name + ".\n"
+}
+
+// setup creates a home directory and a project with Claude and Cursor
+// configuration in both scopes.
+func setup(t *testing.T) (home, project string) {
+	home, project = t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+
+	writeFile(t, filepath.Join(project, ".mcp.json"),
+		`{"mcpServers": {"github": {"command": "npx"}, "shared": {"command": "project"}}}`)
+	writeFile(t, filepath.Join(project, ".cursor", "mcp.json"),
+		`{"mcpServers": {"local": {"command": "local"}}}`)
+	writeFile(t, filepath.Join(project, ".claude", "settings.json"),
+		`{"permissions": {}, "hooks": {"Stop": [{"hooks": [{"type": "command", "command": "./project.sh"}]}]}}`)
+	writeFile(t, filepath.Join(project, ".claude", "agents", "reviewer.md"), agentFile("reviewer", "Project reviewer"))
+
+	writeFile(t, filepath.Join(home, ".claude.json"),
+		`{"numStartups": 3, "mcpServers": {"shared": {"command": "user"}, "personal": {"url": "https://example.com/mcp"}}}`)
+	writeFile(t, filepath.Join(home, ".cursor", "mcp.json"),
+		`{"mcpServers": {"global": {"command": "global"}}}`)
+	writeFile(t, filepath.Join(home, ".claude", "settings.json"),
+		`{"hooks": {"Stop": [{"hooks": [{"type": "command", "command": "./user.sh"}]}]}}`)
+	writeFile(t, filepath.Join(home, ".claude", "agents", "reviewer.md"), agentFile("reviewer", "User reviewer"))
+	writeFile(t, filepath.Join(home, ".claude", "agents", "helper.md"), agentFile("helper", "Helps"))
+	return home, project
+}
+
+func TestDiscover(t *testing.T) {
+	home, project := setup(t)
+
+	report, err := Discover(Options{ProjectRoot: project})
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	claude := report.Tool("claude")
+	var servers []string
+	for _, s := range claude.Servers {
+		servers = append(servers, s.Name+"@"+string(s.Location.Scope))
+	}
+	expected := []string{"github@project", "personal@user", "shared@project"}
+	if !reflect.DeepEqual(servers, expected) {
+		t.Errorf("Expected claude servers %v, got %v", expected, servers)
+	}
+	shared := claude.Servers[2]
+	if shared.Server.Command != "project" {
+		t.Errorf("Expected project definition of shared server, got %q", shared.Server.Command)
+	}
+	if len(shared.Shadowed) != 1 || shared.Shadowed[0].Path != filepath.Join(home, ".claude.json") {
+		t.Errorf("Expected shared server to shadow ~/.claude.json, got %v", shared.Shadowed)
+	}
+
+	var cursorServers []string
+	for _, s := range report.Tool("cursor").Servers {
+		cursorServers = append(cursorServers, s.Name)
+	}
+	if !reflect.DeepEqual(cursorServers, []string{"global", "local"}) {
+		t.Errorf("Expected cursor servers [global local], got %v", cursorServers)
+	}
+
+	if len(claude.Agents) != 2 || claude.Agents[1].Name != "reviewer" ||
+		claude.Agents[1].Description != "Project reviewer" || len(claude.Agents[1].Shadowed) != 1 {
+		t.Errorf("Expected project reviewer to shadow user reviewer, got %+v", claude.Agents)
+	}
+
+	if claude.Hooks == nil || claude.Hooks.HookCount() != 2 {
+		t.Fatalf("Expected 2 claude hooks from both scopes, got %+v", claude.Hooks)
+	}
+	if len(claude.HookSources) != 2 || claude.HookSources[0].Scope != ScopeProject {
+		t.Errorf("Expected project hooks first, got %v", claude.HookSources)
+	}
+
+	if got := report.Tool("unknown"); got.Tool != "unknown" || len(got.Servers) != 0 {
+		t.Errorf("Expected empty view for unknown tool, got %+v", got)
+	}
+}
+
+func TestLocations(t *testing.T) {
+	home, project := setup(t)
+
+	locations, err := Locations(Options{ProjectRoot: project, Tools: []string{"cursor"}, Kinds: []Kind{KindMCP}})
+	if err != nil {
+		t.Fatalf("Locations failed: %v", err)
+	}
+	expected := []Location{
+		{Kind: KindMCP, Tool: "cursor", Scope: ScopeProject, Path: filepath.Join(project, ".cursor", "mcp.json")},
+		{Kind: KindMCP, Tool: "cursor", Scope: ScopeUser, Path: filepath.Join(home, ".cursor", "mcp.json")},
+	}
+	if !reflect.DeepEqual(locations, expected) {
+		t.Errorf("Expected %v, got %v", expected, locations)
+	}
+
+	locations, err = Locations(Options{ProjectRoot: project, Tools: []string{"claude"}, Kinds: []Kind{KindHooks}})
+	if err != nil {
+		t.Fatalf("Locations failed: %v", err)
+	}
+	if len(locations) == 0 || locations[0].Scope != ScopeManaged {
+		t.Errorf("Expected managed claude settings first, got %v", locations)
+	}
+}
+
+func TestScanManagedHooksOnly(t *testing.T) {
+	dir := t.TempDir()
+	managed := filepath.Join(dir, "managed-settings.json")
+	user := filepath.Join(dir, "settings.json")
+	broken := filepath.Join(dir, "broken.json")
+	writeFile(t, managed, `{"allowManagedHooksOnly": true, "hooks": {"Stop": [{"hooks": [{"type": "command", "command": "./audit.sh"}]}]}}`)
+	writeFile(t, user, `{"hooks": {"Stop": [{"hooks": [{"type": "command", "command": "./user.sh"}]}]}}`)
+	writeFile(t, broken, `{"mcpServers": `)
+
+	report := Scan([]Location{
+		{Kind: KindHooks, Tool: "claude", Scope: ScopeManaged, Path: managed},
+		{Kind: KindMCP, Tool: "claude", Scope: ScopeProject, Path: broken},
+		{Kind: KindHooks, Tool: "claude", Scope: ScopeUser, Path: user},
+		{Kind: KindMCP, Tool: "claude", Scope: ScopeUser, Path: filepath.Join(dir, "missing.json")},
+	})
+
+	claude := report.Tool("claude")
+	if claude.Hooks.HookCount() != 1 || len(claude.HookSources) != 1 || claude.HookSources[0].Scope != ScopeManaged {
+		t.Errorf("Expected only managed hooks, got %+v from %v", claude.Hooks, claude.HookSources)
+	}
+	if src := report.Sources[1]; !src.Found || !strings.Contains(src.Error, "failed to parse") {
+		t.Errorf("Expected parse error for broken config, got %+v", src)
+	}
+	if report.Sources[3].Found {
+		t.Error("Expected missing location not to be found")
+	}
+}
@@ -0,0 +1,160 @@
+package discovery
+
+import (
+	"sort"
+
+	"github.com/agentplexus/assistantkit/hooks"
+	"github.com/agentplexus/assistantkit/mcp"
+)
+
+// ToolView is the configuration a tool will see once all scopes are
+// resolved.
+type ToolView struct {
+	Tool string `json:"tool"`
+
+	// Servers are the effective MCP servers, sorted by name.
+	Servers []ResolvedServer `json:"servers,omitempty"`
+
+	// Hooks are the effective hooks. Hooks from all scopes apply, in
+	// precedence order, unless a managed config allows managed hooks only.
+	Hooks *hooks.Config `json:"hooks,omitempty"`
+
+	// HookSources are the locations the effective hooks came from.
+	HookSources []Location `json:"hookSources,omitempty"`
+
+	// Agents, Commands and Skills are the effective definitions, sorted by name.
+	Agents   []ResolvedItem `json:"agents,omitempty"`
+	Commands []ResolvedItem `json:"commands,omitempty"`
+	Skills   []ResolvedItem `json:"skills,omitempty"`
+}
+
+// ResolvedServer is an MCP server with the location that defines it.
+type ResolvedServer struct {
+	Name   string     `json:"name"`
+	Server mcp.Server `json:"server"`
+
+	// Location is the highest-precedence location that defines the server.
+	Location Location `json:"location"`
+
+	// Shadowed lists lower-precedence locations whose definition is ignored.
+	Shadowed []Location `json:"shadowed,omitempty"`
+}
+
+// ResolvedItem is a named agent, command or skill with the location that
+// defines it.
+type ResolvedItem struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Location is the highest-precedence location that defines the item.
+	Location Location `json:"location"`
+
+	// Shadowed lists lower-precedence locations whose definition is ignored.
+	Shadowed []Location `json:"shadowed,omitempty"`
+}
+
+// resolve builds a view per tool from sources in precedence order.
+func resolve(sources []*Source) []*ToolView {
+	views := make(map[string]*ToolView)
+	servers := make(map[string]map[string]*ResolvedServer)
+	items := make(map[string]map[Kind]map[string]*ResolvedItem)
+	managedOnly := make(map[string]bool)
+
+	view := func(tool string) *ToolView {
+		if views[tool] == nil {
+			views[tool] = &ToolView{Tool: tool}
+			servers[tool] = make(map[string]*ResolvedServer)
+			items[tool] = make(map[Kind]map[string]*ResolvedItem)
+		}
+		return views[tool]
+	}
+	addItem := func(loc Location, name, description string) {
+		byName := items[loc.Tool][loc.Kind]
+		if byName == nil {
+			byName = make(map[string]*ResolvedItem)
+			items[loc.Tool][loc.Kind] = byName
+		}
+		if existing, ok := byName[name]; ok {
+			existing.Shadowed = append(existing.Shadowed, loc)
+			return
+		}
+		byName[name] = &ResolvedItem{Name: name, Description: description, Location: loc}
+	}
+
+	for _, src := range sources {
+		if !src.Found || src.Error != "" {
+			continue
+		}
+		v := view(src.Tool)
+		switch src.Kind {
+		case KindMCP:
+			for name, server := range src.MCP.Servers {
+				if existing, ok := servers[src.Tool][name]; ok {
+					existing.Shadowed = append(existing.Shadowed, src.Location)
+					continue
+				}
+				servers[src.Tool][name] = &ResolvedServer{Name: name, Server: server, Location: src.Location}
+			}
+		case KindHooks:
+			if managedOnly[src.Tool] && src.Scope != ScopeManaged {
+				continue
+			}
+			if src.Scope == ScopeManaged && src.Hooks.AllowManagedHooksOnly {
+				managedOnly[src.Tool] = true
+			}
+			mergeHooks(v, src)
+		case KindAgents:
+			for _, a := range src.Agents {
+				addItem(src.Location, a.Name, a.Description)
+			}
+		case KindCommands:
+			for _, c := range src.Commands {
+				addItem(src.Location, c.Name, c.Description)
+			}
+		case KindSkills:
+			for _, s := range src.Skills {
+				addItem(src.Location, s.Name, s.Description)
+			}
+		}
+	}
+
+	var result []*ToolView
+	for tool, v := range views {
+		for _, s := range servers[tool] {
+			v.Servers = append(v.Servers, *s)
+		}
+		sort.Slice(v.Servers, func(i, j int) bool { return v.Servers[i].Name < v.Servers[j].Name })
+		v.Agents = sortedItems(items[tool][KindAgents])
+		v.Commands = sortedItems(items[tool][KindCommands])
+		v.Skills = sortedItems(items[tool][KindSkills])
+		result = append(result, v)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Tool < result[j].Tool })
+	return result
+}
+
+// mergeHooks adds the hooks of src to the view.
+func mergeHooks(v *ToolView, src *Source) {
+	if v.Hooks == nil {
+		v.Hooks = hooks.NewConfig()
+	}
+	cfg := src.Hooks
+	if cfg.Version > v.Hooks.Version {
+		v.Hooks.Version = cfg.Version
+	}
+	v.Hooks.DisableAllHooks = v.Hooks.DisableAllHooks || cfg.DisableAllHooks
+	v.Hooks.AllowManagedHooksOnly = v.Hooks.AllowManagedHooksOnly || cfg.AllowManagedHooksOnly
+	for _, event := range cfg.Events() {
+		v.Hooks.Hooks[event] = append(v.Hooks.Hooks[event], cfg.Hooks[event]...)
+	}
+	v.HookSources = append(v.HookSources, src.Location)
+}
+
+func sortedItems(byName map[string]*ResolvedItem) []ResolvedItem {
+	var list []ResolvedItem
+	for _, item := range byName {
+		list = append(list, *item)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
@@ -21,10 +21,12 @@ import (
 
 	"github.com/agentplexus/assistantkit/agents"
 	"github.com/agentplexus/assistantkit/commands"
+	"github.com/agentplexus/assistantkit/models"
 	"github.com/agentplexus/assistantkit/plugins"
 	powercore "github.com/agentplexus/assistantkit/powers/core"
 	"github.com/agentplexus/assistantkit/powers/kiro"
 	"github.com/agentplexus/assistantkit/skills"
+	"github.com/agentplexus/assistantkit/teams"
 )
 
 // Result contains the results of plugin generation.
@@ -565,6 +567,9 @@ type DeploymentResult struct {
 
 	// GeneratedDirs maps target names to their output directories.
 	GeneratedDirs map[string]string
+
+	// Warnings lists agent fields that a target's format drops or degrades.
+	Warnings []string
 }
 
 // Deployment generates platform-specific output from multi-agent-spec definitions.
@@ -608,12 +613,15 @@ func Deployment(specsDir string, deploymentFile string) (*DeploymentResult, erro
 			outputDir = filepath.Join(specsDir, "..", outputDir)
 		}
 
-		if err := generateDeploymentTarget(target, agts, outputDir); err != nil {
+		resolved, modelWarnings := resolveModels(target, agts)
+		if err := generateDeploymentTarget(target, resolved, outputDir); err != nil {
 			return nil, fmt.Errorf("generating target %s: %w", target.Name, err)
 		}
 
 		result.TargetsGenerated = append(result.TargetsGenerated, target.Name)
 		result.GeneratedDirs[target.Name] = outputDir
+		result.Warnings = append(result.Warnings, agentWarnings(target.Name, deploymentAgentAdapter(target.Platform), agts)...)
+		result.Warnings = append(result.Warnings, modelWarnings...)
 	}
 
 	return result, nil
@@ -670,6 +678,16 @@ type DeploymentTarget struct {
 	Priority string          `json:"priority,omitempty"`
 	Output   string          `json:"output"`
 	Config   json.RawMessage `json:"config,omitempty"`
+
+	// Models overrides the platform's default model mapping. Keys are
+	// canonical models (haiku, sonnet, opus) or other model names used by
+	// agents; values are the platform's model IDs.
+	Models map[string]string `json:"models,omitempty"`
+
+	// Diagram embeds a Mermaid diagram of the team's task graph, with the
+	// critical path highlighted, in the orchestration instructions. Only
+	// Claude Code's orchestration command includes it.
+	Diagram bool `json:"diagram,omitempty"`
 }
 
 // DeploymentSpec represents a deployment definition.
@@ -773,6 +791,9 @@ type AgentsResult struct {
 
 	// GeneratedDirs maps target names to their output directories.
 	GeneratedDirs map[string]string
+
+	// Warnings lists agent fields that a target's format drops or degrades.
+	Warnings []string
 }
 
 // Agents generates platform-specific agents from a specs directory with simplified options.
@@ -817,12 +838,15 @@ func Agents(specsDir, target, outputDir string) (*AgentsResult, error) {
 			targetOutputDir = filepath.Join(outputDir, targetOutputDir)
 		}
 
-		if err := generateDeploymentTarget(tgt, agts, targetOutputDir); err != nil {
+		resolved, modelWarnings := resolveModels(tgt, agts)
+		if err := generateDeploymentTarget(tgt, resolved, targetOutputDir); err != nil {
 			return nil, fmt.Errorf("generating target %s: %w", tgt.Name, err)
 		}
 
 		result.TargetsGenerated = append(result.TargetsGenerated, tgt.Name)
 		result.GeneratedDirs[tgt.Name] = targetOutputDir
+		result.Warnings = append(result.Warnings, agentWarnings(tgt.Name, deploymentAgentAdapter(tgt.Platform), agts)...)
+		result.Warnings = append(result.Warnings, modelWarnings...)
 	}
 
 	return result, nil
@@ -847,6 +871,9 @@ type GenerateResult struct {
 
 	// GeneratedDirs maps target names to their output directories.
 	GeneratedDirs map[string]string
+
+	// Warnings lists agent fields that a target's format drops or degrades.
+	Warnings []string
 }
 
 // Generate generates platform-specific plugins from a unified specs directory.
@@ -857,12 +884,15 @@ type GenerateResult struct {
 //   - commands (from specs/commands/*.md)
 //   - skills (from specs/skills/*.md)
 //   - plugin manifest (from specs/plugin.json)
+//   - orchestration for the deployment's team (from specs/teams/), rendered
+//     for the target's platform
 //
 // The specsDir should contain:
 //   - plugin.json: Plugin metadata
 //   - commands/: Command definitions (*.md or *.json)
 //   - skills/: Skill definitions (*.md or *.json)
 //   - agents/: Agent definitions (*.md with YAML frontmatter)
+//   - teams/: Team definitions (*.json or *.yaml, optional)
 //   - deployments/: Deployment definitions (*.json)
 //
 // The target parameter specifies which deployment file to use (looks for {target}.json).
@@ -921,6 +951,19 @@ func Generate(specsDir, target, outputDir string) (*GenerateResult, error) {
 	}
 	result.TeamName = deployment.Team
 
+	// Load the deployment's team for orchestration
+	team, err := loadTeam(filepath.Join(specsDir, "teams"), deployment.Team)
+	if err != nil {
+		return nil, fmt.Errorf("loading team: %w", err)
+	}
+	if deployment.Team != "" && team == nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("team %s not found in teams/, skipping orchestration", deployment.Team))
+	}
+	orchestration := teams.OrchestrationConfig{AgentSpecsPath: agentSpecsPath(specsDir, outputDir)}
+	if team != nil {
+		orchestration.Version = team.Version
+	}
+
 	// Generate each target
 	for _, tgt := range deployment.Targets {
 		// Resolve output path relative to outputDir
@@ -929,17 +972,79 @@ func Generate(specsDir, target, outputDir string) (*GenerateResult, error) {
 			targetOutputDir = filepath.Join(outputDir, targetOutputDir)
 		}
 
-		if err := generatePlatformPlugin(tgt.Platform, targetOutputDir, plugin, cmds, skls, agts); err != nil {
+		resolved, modelWarnings := resolveModels(tgt, agts)
+		if err := generatePlatformPlugin(tgt.Platform, targetOutputDir, plugin, cmds, skls, resolved); err != nil {
 			return nil, fmt.Errorf("generating target %s: %w", tgt.Name, err)
 		}
+		if team != nil {
+			cfg := orchestration
+			cfg.IncludeDiagram = tgt.Diagram
+			warning, err := generateOrchestration(tgt, team, cfg, targetOutputDir)
+			if err != nil {
+				return nil, fmt.Errorf("generating orchestration for target %s: %w", tgt.Name, err)
+			}
+			if warning != "" {
+				result.Warnings = append(result.Warnings, warning)
+			}
+		}
 
 		result.TargetsGenerated = append(result.TargetsGenerated, tgt.Name)
 		result.GeneratedDirs[tgt.Name] = targetOutputDir
+		result.Warnings = append(result.Warnings, agentWarnings(tgt.Name, pluginAgentAdapter(tgt.Platform), agts)...)
+		result.Warnings = append(result.Warnings, modelWarnings...)
 	}
 
 	return result, nil
 }
 
+// loadTeam returns the team with the given name from dir, or nil if name is
+// empty, dir does not exist or no team has that name.
+func loadTeam(dir, name string) (*teams.Team, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	tms, err := teams.ReadTeamDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range tms {
+		if team.Name == name {
+			return team, nil
+		}
+	}
+	return nil, nil
+}
+
+// agentSpecsPath returns the path of the agent specs relative to the output
+// base directory, where orchestration prompts tell agents to read their
+// instructions from.
+func agentSpecsPath(specsDir, outputDir string) string {
+	rel, err := filepath.Rel(outputDir, filepath.Join(specsDir, "agents"))
+	if err != nil {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// generateOrchestration writes the orchestration artifacts for team with
+// the renderer of the target's platform. It returns a warning if the
+// platform has no renderer.
+func generateOrchestration(target DeploymentTarget, team *teams.Team, cfg teams.OrchestrationConfig, outputDir string) (string, error) {
+	renderer, ok := teams.GetRenderer(agentAdapterName(target.Platform))
+	if !ok {
+		return fmt.Sprintf("%s: no team orchestration for platform %s", target.Name, target.Platform), nil
+	}
+	artifacts, err := renderer.Render(team, cfg)
+	if err != nil {
+		return "", err
+	}
+	return "", teams.WriteArtifacts(outputDir, artifacts)
+}
+
 // generatePlatformPlugin generates a complete plugin for a specific platform.
 // It combines agents, commands, skills, and plugin manifest into a platform-specific format.
 func generatePlatformPlugin(
@@ -975,17 +1080,7 @@ func generateDeploymentTargetAgentsOnly(platform string, agts []*agents.Agent, o
 		return nil
 	}
 
-	// Map platform names to adapter names
-	adapterName := platform
-	switch platform {
-	case "claude-code":
-		adapterName = "claude"
-	case "kiro-cli":
-		adapterName = "kiro"
-	case "gemini-cli":
-		adapterName = "gemini"
-	}
-
+	adapterName := agentAdapterName(platform)
 	adapter, ok := agents.GetAdapter(adapterName)
 	if !ok {
 		return fmt.Errorf("%s adapter not found", adapterName)
@@ -1001,3 +1096,87 @@ func generateDeploymentTargetAgentsOnly(platform string, agts []*agents.Agent, o
 
 	return nil
 }
+
+// agentAdapterName maps a platform name to its agents adapter name.
+func agentAdapterName(platform string) string {
+	switch platform {
+	case "claude-code":
+		return "claude"
+	case "kiro-cli":
+		return "kiro"
+	case "gemini-cli":
+		return "gemini"
+	default:
+		return platform
+	}
+}
+
+// pluginAgentAdapter returns the agents adapter that generatePlatformPlugin
+// uses to write agents for platform, or "" if agents are not written with
+// an adapter.
+func pluginAgentAdapter(platform string) string {
+	switch platform {
+	case "claude", "claude-code":
+		return "claude"
+	case "kiro", "kiro-cli", "gemini", "gemini-cli":
+		return ""
+	default:
+		return agentAdapterName(platform)
+	}
+}
+
+// deploymentAgentAdapter returns the agents adapter that
+// generateDeploymentTarget uses for platform, or "" if the platform is skipped.
+func deploymentAgentAdapter(platform string) string {
+	switch platform {
+	case "claude-code", "kiro-cli", "gemini-cli":
+		return agentAdapterName(platform)
+	default:
+		return ""
+	}
+}
+
+// resolveModels returns copies of the agents with their models resolved to
+// the target platform's model IDs, applying the target's overrides, and a
+// warning for each model the platform does not know. Unknown models are
+// written unchanged.
+func resolveModels(target DeploymentTarget, agts []*agents.Agent) ([]*agents.Agent, []string) {
+	platform := agentAdapterName(target.Platform)
+	var warnings []string
+	resolved := make([]*agents.Agent, len(agts))
+	for i, agt := range agts {
+		resolved[i] = agt
+		if agt.Model == "" {
+			continue
+		}
+		id, err := models.Resolve(platform, agt.Model, target.Models)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: agent %s: %v", target.Name, agt.Name, err))
+		}
+		if id != string(agt.Model) {
+			cp := *agt
+			cp.Model = agents.Model(id)
+			resolved[i] = &cp
+		}
+	}
+	return resolved, warnings
+}
+
+// agentWarnings returns a warning for each agent field that the named agents
+// adapter drops or degrades, prefixed with the target name.
+func agentWarnings(target, adapterName string, agts []*agents.Agent) []string {
+	if adapterName == "" {
+		return nil
+	}
+	var warnings []string
+	for _, agt := range agts {
+		losses, err := agents.Lossiness(agt, adapterName)
+		if err != nil {
+			return nil
+		}
+		for _, loss := range losses {
+			warnings = append(warnings, target+": "+loss.String())
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,67 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSpecs writes files (relative path -> content) under a temp specs dir.
+func writeSpecs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGenerateOrchestrationDiagram(t *testing.T) {
+	specs := writeSpecs(t, map[string]string{
+		"plugin.json":        `{"name": "demo", "version": "1.0.0", "description": "Demo plugin"}`,
+		"agents/reviewer.md": "---\nname: reviewer\ndescription: Reviews code\n---\n\nYou review code.\n",
+		"teams/demo.json": `{
+  "name": "demo",
+  "process": "sequential",
+  "agents": ["reviewer"],
+  "tasks": [
+    {"name": "lint", "agent": "reviewer"},
+    {"name": "review", "agent": "reviewer", "depends_on": ["lint"]}
+  ]
+}`,
+		"deployments/local.json": `{"team": "demo", "targets": [
+  {"name": "plain", "platform": "claude-code", "output": "plain"},
+  {"name": "diagram", "platform": "claude-code", "output": "diagram", "diagram": true}
+]}`,
+	})
+	out := t.TempDir()
+
+	if _, err := Generate(specs, "local", out); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	plain, err := os.ReadFile(filepath.Join(out, "plain", "commands", "demo.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(plain), "```mermaid") {
+		t.Errorf("Expected no diagram without diagram set:\n%s", plain)
+	}
+
+	diagram, err := os.ReadFile(filepath.Join(out, "diagram", "commands", "demo.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## Task Graph\n\n```mermaid\nflowchart TD\n", "task1 --> task2", "linkStyle 0 stroke:"} {
+		if !strings.Contains(string(diagram), want) {
+			t.Errorf("Expected orchestration to contain %q:\n%s", want, diagram)
+		}
+	}
+}
@@ -2,11 +2,14 @@ package claude
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 
 	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/merge"
+	"github.com/agentplexus/assistantkit/vocab"
 )
 
 const (
@@ -71,6 +74,7 @@ func (a *Adapter) SupportedEvents() []core.Event {
 		core.BeforeFileWrite, core.AfterFileWrite,
 		core.BeforeCommand, core.AfterCommand,
 		core.BeforeMCP, core.AfterMCP,
+		core.BeforeTool, core.AfterTool,
 		core.BeforePrompt,
 		core.OnStop, core.OnSessionStart, core.OnSessionEnd,
 		core.OnPermission, core.OnNotification,
@@ -207,6 +211,47 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	return claudeCfg
 }
 
+// Lossiness reports the canonical fields that the Claude format drops or degrades.
+// Matchers on tool events select the canonical event when the config is read
+// back, so a matcher that names a different tool is reported as degraded, and
+// a matcher naming a tool Claude does not have is reported as unsupported.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, a.SupportedEvents(), cfg,
+		core.FieldDisableAllHooks, core.FieldAllowManagedHooksOnly,
+		core.FieldMatcher, core.FieldPrompt, core.FieldTimeout)
+
+	for _, event := range a.SupportedEvents() {
+		claudeEvent, _ := a.canonicalToClaudeEvent(event)
+		if claudeEvent != PreToolUse && claudeEvent != PostToolUse {
+			continue
+		}
+		for i, entry := range cfg.Hooks[event] {
+			if entry.Matcher == "" {
+				continue
+			}
+			if got := a.claudeToCanonicalEvent(claudeEvent, entry.Matcher); got != event {
+				losses = append(losses, core.Loss{
+					Adapter: AdapterName,
+					Path:    core.EntryPath(event, i) + "." + core.FieldMatcher,
+					Kind:    core.LossDegraded,
+					Detail:  fmt.Sprintf("matcher %q is read back as event %s", entry.Matcher, got),
+				})
+			}
+		}
+	}
+	return append(losses, core.TranslatedMatchers(AdapterName, a.SupportedEvents(), cfg, vocab.Claude)...)
+}
+
+// Merge writes cfg into an existing Claude settings file, replacing only
+// the hooks, disableAllHooks and allowManagedHooksOnly keys and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "hooks", "disableAllHooks", "allowManagedHooksOnly")
+}
+
 // claudeToCanonicalEvent converts a Claude event to canonical event.
 func (a *Adapter) claudeToCanonicalEvent(claudeEvent ClaudeEvent, matcher string) core.Event {
 	// Check direct mapping first
@@ -234,11 +279,6 @@ func (a *Adapter) claudeToCanonicalEvent(claudeEvent ClaudeEvent, matcher string
 
 // canonicalToClaudeEvent converts a canonical event to Claude event and matcher.
 func (a *Adapter) canonicalToClaudeEvent(event core.Event) (ClaudeEvent, string) {
-	// Check if this event is supported by Claude
-	if !event.GetToolSupport().Claude {
-		return "", ""
-	}
-
 	// Get matcher if applicable
 	matcher := canonicalEventToMatcher[event]
 
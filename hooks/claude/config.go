@@ -86,6 +86,8 @@ var eventMapping = map[core.Event]ClaudeEvent{
 	core.AfterCommand:    PostToolUse, // with matcher "Bash"
 	core.BeforeMCP:       PreToolUse,  // with MCP tool matcher
 	core.AfterMCP:        PostToolUse, // with MCP tool matcher
+	core.BeforeTool:      PreToolUse,  // without matcher
+	core.AfterTool:       PostToolUse, // without matcher
 	core.BeforePrompt:    UserPromptSubmit,
 	core.OnStop:          Stop,
 	core.OnSessionStart:  SessionStart,
@@ -111,6 +113,9 @@ var reverseEventMapping = map[ClaudeEvent]core.Event{
 
 // matcherToCanonicalEvent maps matchers to canonical events for Pre/PostToolUse.
 var matcherToCanonicalEventBefore = map[string]core.Event{
+	"":           core.BeforeTool,
+	"*":          core.BeforeTool,
+	"mcp__.*":    core.BeforeMCP,
 	"Read":       core.BeforeFileRead,
 	"Write":      core.BeforeFileWrite,
 	"Edit":       core.BeforeFileWrite,
@@ -119,6 +124,9 @@ var matcherToCanonicalEventBefore = map[string]core.Event{
 }
 
 var matcherToCanonicalEventAfter = map[string]core.Event{
+	"":           core.AfterTool,
+	"*":          core.AfterTool,
+	"mcp__.*":    core.AfterMCP,
 	"Read":       core.AfterFileRead,
 	"Write":      core.AfterFileWrite,
 	"Edit":       core.AfterFileWrite,
@@ -134,4 +142,6 @@ var canonicalEventToMatcher = map[core.Event]string{
 	core.AfterFileWrite:  "Write|Edit",
 	core.BeforeCommand:   "Bash",
 	core.AfterCommand:    "Bash",
+	core.BeforeMCP:       "mcp__.*",
+	core.AfterMCP:        "mcp__.*",
 }
@@ -0,0 +1,259 @@
+package claude
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+// Payload is the JSON Claude Code writes to a hook's stdin.
+type Payload struct {
+	SessionID      string         `json:"session_id"`
+	TranscriptPath string         `json:"transcript_path,omitempty"`
+	Cwd            string         `json:"cwd,omitempty"`
+	HookEventName  ClaudeEvent    `json:"hook_event_name"`
+	ToolName       string         `json:"tool_name,omitempty"`
+	ToolInput      map[string]any `json:"tool_input,omitempty"`
+	ToolResponse   any            `json:"tool_response,omitempty"`
+	Prompt         string         `json:"prompt,omitempty"`
+}
+
+// Output is the JSON a Claude Code hook writes to stdout.
+type Output struct {
+	Continue           *bool               `json:"continue,omitempty"`
+	StopReason         string              `json:"stopReason,omitempty"`
+	Decision           string              `json:"decision,omitempty"`
+	Reason             string              `json:"reason,omitempty"`
+	HookSpecificOutput *HookSpecificOutput `json:"hookSpecificOutput,omitempty"`
+}
+
+// HookSpecificOutput holds the event-specific part of a hook's output.
+type HookSpecificOutput struct {
+	HookEventName ClaudeEvent `json:"hookEventName"`
+
+	// PreToolUse
+	PermissionDecision       string         `json:"permissionDecision,omitempty"`
+	PermissionDecisionReason string         `json:"permissionDecisionReason,omitempty"`
+	UpdatedInput             map[string]any `json:"updatedInput,omitempty"`
+
+	// PermissionRequest
+	Decision *PermissionDecision `json:"decision,omitempty"`
+}
+
+// PermissionDecision answers a PermissionRequest hook.
+type PermissionDecision struct {
+	Behavior     string         `json:"behavior"`
+	Message      string         `json:"message,omitempty"`
+	UpdatedInput map[string]any `json:"updatedInput,omitempty"`
+}
+
+// mcpToolPrefix prefixes the names of MCP tools.
+const mcpToolPrefix = "mcp__"
+
+// Detect reports whether a payload was sent by Claude Code. Gemini CLI
+// shares some event names but also sends a timestamp.
+func (a *Adapter) Detect(fields map[string]json.RawMessage) bool {
+	if core.HasField(fields, "timestamp") {
+		return false
+	}
+	event := ClaudeEvent(core.StringField(fields, "hook_event_name"))
+	_, ok := reverseEventMapping[event]
+	return ok || event == PreToolUse || event == PostToolUse
+}
+
+// DecodePayload converts a Claude Code hook payload to canonical form.
+func (a *Adapter) DecodePayload(data []byte, nativeEvent string) (*core.Payload, error) {
+	var in Payload
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	if nativeEvent != "" {
+		in.HookEventName = ClaudeEvent(nativeEvent)
+	}
+
+	p := &core.Payload{
+		Adapter:     AdapterName,
+		NativeEvent: string(in.HookEventName),
+		SessionID:   in.SessionID,
+		Cwd:         in.Cwd,
+		ToolName:    in.ToolName,
+		ToolInput:   in.ToolInput,
+		ToolOutput:  in.ToolResponse,
+		Prompt:      in.Prompt,
+		Raw:         data,
+	}
+	switch in.HookEventName {
+	case PreToolUse:
+		p.Event = toolEvent(in.ToolName)
+	case PostToolUse:
+		p.Event = toolEvent(in.ToolName).AfterEvent()
+	default:
+		event, ok := reverseEventMapping[in.HookEventName]
+		if !ok {
+			return nil, &core.ParseError{Format: AdapterName, Err: core.ErrUnsupportedEvent}
+		}
+		p.Event = event
+	}
+	p.Command = p.InputString("command")
+	p.FilePath = p.InputString("file_path", "notebook_path")
+	return p, nil
+}
+
+// EncodeDecision serializes a decision as Claude Code hook output. Tool
+// calls and permission requests take the decision from hookSpecificOutput;
+// prompts, tool results and stops are blocked with decision "block"; and
+// other events are blocked by stopping the session.
+func (a *Adapter) EncodeDecision(p *core.Payload, d core.Decision) (*core.Response, error) {
+	event := ClaudeEvent(p.NativeEvent)
+	unsupported := &core.DecisionError{Format: AdapterName, Event: p.NativeEvent, Action: d.Action}
+
+	switch event {
+	case PreToolUse:
+		if d.Action == "" {
+			return &core.Response{}, nil
+		}
+		out := &HookSpecificOutput{
+			HookEventName:            event,
+			PermissionDecision:       string(d.Action),
+			PermissionDecisionReason: d.Reason,
+		}
+		if d.Action == core.ActionModify {
+			out.PermissionDecision = string(core.ActionAllow)
+			out.UpdatedInput = d.Input
+		}
+		return core.JSONResponse(Output{HookSpecificOutput: out})
+
+	case PermissionRequest:
+		var decision *PermissionDecision
+		switch d.Action {
+		case "", core.ActionAsk:
+			return &core.Response{}, nil // the permission dialog is shown
+		case core.ActionAllow, core.ActionModify:
+			decision = &PermissionDecision{Behavior: string(core.ActionAllow), UpdatedInput: d.Input}
+		case core.ActionDeny:
+			decision = &PermissionDecision{Behavior: string(core.ActionDeny), Message: d.Reason}
+		}
+		return core.JSONResponse(Output{HookSpecificOutput: &HookSpecificOutput{HookEventName: event, Decision: decision}})
+	}
+
+	switch d.Action {
+	case "", core.ActionAllow:
+		return &core.Response{}, nil
+	case core.ActionModify:
+		return nil, unsupported
+	}
+	switch event {
+	case PostToolUse, UserPromptSubmit, Stop, SubagentStop:
+		return core.JSONResponse(Output{Decision: "block", Reason: d.Reason})
+	}
+	stop := false
+	return core.JSONResponse(Output{Continue: &stop, StopReason: d.Reason})
+}
+
+// toolEvent returns the canonical before event for a call of the named tool.
+func toolEvent(name string) core.Event {
+	if event, ok := matcherToCanonicalEventBefore[name]; ok {
+		return event
+	}
+	switch {
+	case name == "MultiEdit" || name == "NotebookEdit":
+		return core.BeforeFileWrite
+	case strings.HasPrefix(name, mcpToolPrefix):
+		return core.BeforeMCP
+	}
+	return core.BeforeTool
+}
+
+// toolNames maps canonical tool events to the tool named in simulated payloads.
+var toolNames = map[core.Event]string{
+	core.BeforeFileRead:  "Read",
+	core.AfterFileRead:   "Read",
+	core.BeforeFileWrite: "Write",
+	core.AfterFileWrite:  "Write",
+	core.BeforeCommand:   "Bash",
+	core.AfterCommand:    "Bash",
+	core.BeforeMCP:       "mcp__test__tool",
+	core.AfterMCP:        "mcp__test__tool",
+}
+
+// EncodePayload renders a canonical payload as Claude Code's hook stdin.
+func (a *Adapter) EncodePayload(p *core.Payload) ([]byte, string, error) {
+	event := ClaudeEvent(p.NativeEvent)
+	if event == "" {
+		if event, _ = a.canonicalToClaudeEvent(p.Event); event == "" {
+			return nil, "", &core.ConversionError{To: AdapterName, Event: p.Event, Err: core.ErrUnsupportedEvent}
+		}
+	}
+	out := Payload{
+		SessionID:     p.SessionID,
+		Cwd:           p.Cwd,
+		HookEventName: event,
+		Prompt:        p.Prompt,
+	}
+	switch event {
+	case PreToolUse, PostToolUse, PermissionRequest:
+		out.ToolName = p.ToolName
+		if out.ToolName == "" {
+			out.ToolName = toolNames[p.Event]
+		}
+		out.ToolInput = p.ToolInputWith("command", "file_path")
+		out.ToolResponse = p.ToolOutput
+	}
+	data, err := json.Marshal(out)
+	return data, string(event), err
+}
+
+// Matches reports whether Claude Code runs an entry registered for event
+// when it sends p. Tool events match the entry's matcher as a regular
+// expression against the tool name.
+func (a *Adapter) Matches(event core.Event, entry core.HookEntry, p *core.Payload) bool {
+	native, matcher := a.canonicalToClaudeEvent(event)
+	if native == "" || string(native) != p.NativeEvent {
+		return false
+	}
+	if entry.Matcher != "" {
+		matcher = entry.Matcher
+	}
+	switch native {
+	case PreToolUse, PostToolUse, PermissionRequest:
+		return core.MatchToolRegexp(matcher, p.ToolName)
+	}
+	return true
+}
+
+// DecodeDecision reads a Claude Code hook's response. Exit code 2 blocks
+// with the reason on stderr; other non-zero exit codes are hook errors
+// that Claude ignores.
+func (a *Adapter) DecodeDecision(p *core.Payload, resp *core.Response) core.Decision {
+	if resp.ExitCode == core.ExitBlock {
+		return core.Decision{Action: core.ActionDeny, Reason: resp.Stderr}
+	}
+	var out Output
+	if resp.ExitCode != 0 || !core.DecodeJSON(resp, &out) {
+		return core.Decision{}
+	}
+	if h := out.HookSpecificOutput; h != nil {
+		if pd := h.Decision; pd != nil {
+			d := core.Decision{Action: core.Action(pd.Behavior), Reason: pd.Message}
+			if d.Action == core.ActionAllow && pd.UpdatedInput != nil {
+				d.Action, d.Input = core.ActionModify, pd.UpdatedInput
+			}
+			return d
+		}
+		if h.PermissionDecision != "" {
+			d := core.Decision{Action: core.Action(h.PermissionDecision), Reason: h.PermissionDecisionReason}
+			if d.Action == core.ActionAllow && h.UpdatedInput != nil {
+				d.Action, d.Input = core.ActionModify, h.UpdatedInput
+			}
+			return d
+		}
+	}
+	if out.Decision == "block" {
+		return core.Decision{Action: core.ActionDeny, Reason: out.Reason}
+	}
+	if out.Continue != nil && !*out.Continue {
+		return core.Decision{Action: core.ActionDeny, Reason: out.StopReason}
+	}
+	return core.Decision{}
+}
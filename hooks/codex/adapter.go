@@ -0,0 +1,260 @@
+package codex
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/merge"
+	"github.com/agentplexus/assistantkit/vocab"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "codex"
+
+	// ConfigFileName is the hooks config file name.
+	ConfigFileName = "hooks.json"
+
+	// ProjectConfigDir is the project config directory.
+	ProjectConfigDir = ".codex"
+)
+
+// Adapter implements core.Adapter for Codex hooks.
+type Adapter struct{}
+
+// NewAdapter creates a new Codex hooks adapter.
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Name returns the adapter name.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns the default config file paths for Codex hooks.
+func (a *Adapter) DefaultPaths() []string {
+	paths := []string{
+		filepath.Join(ProjectConfigDir, ConfigFileName),
+	}
+
+	// User config
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ProjectConfigDir, ConfigFileName))
+	}
+
+	return paths
+}
+
+// SupportedEvents returns the events supported by Codex.
+func (a *Adapter) SupportedEvents() []core.Event {
+	return []core.Event{
+		core.BeforeFileWrite, core.AfterFileWrite,
+		core.BeforeCommand, core.AfterCommand,
+		core.BeforeMCP, core.AfterMCP,
+		core.BeforeTool, core.AfterTool,
+		core.BeforePrompt,
+		core.OnStop, core.OnSessionStart,
+	}
+}
+
+// Parse parses Codex hooks config data into the canonical format.
+func (a *Adapter) Parse(data []byte) (*core.Config, error) {
+	var codexCfg Config
+	if err := json.Unmarshal(data, &codexCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return a.ToCore(&codexCfg), nil
+}
+
+// Marshal converts canonical config to Codex format.
+func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	codexCfg := a.FromCore(cfg)
+	return json.MarshalIndent(codexCfg, "", "  ")
+}
+
+// ReadFile reads a Codex hooks config file.
+func (a *Adapter) ReadFile(path string) (*core.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Path: path, Err: err}
+	}
+	cfg, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteFile writes canonical config to a Codex format file.
+func (a *Adapter) WriteFile(cfg *core.Config, path string) error {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	return nil
+}
+
+// ToCore converts Codex hooks config to canonical format.
+func (a *Adapter) ToCore(codexCfg *Config) *core.Config {
+	cfg := core.NewConfig()
+
+	for codexEvent, entries := range codexCfg.Hooks {
+		for _, entry := range entries {
+			canonicalEvent := a.codexToCanonicalEvent(codexEvent, entry.Matcher)
+			if canonicalEvent == "" {
+				continue
+			}
+
+			var coreHooks []core.Hook
+			for _, h := range entry.Hooks {
+				coreHooks = append(coreHooks, core.Hook{
+					Type:    core.HookTypeCommand,
+					Command: h.Command,
+					Timeout: h.Timeout,
+				})
+			}
+
+			cfg.Hooks[canonicalEvent] = append(cfg.Hooks[canonicalEvent], core.HookEntry{
+				Matcher: core.CanonicalMatcher(vocab.Codex, canonicalEvent, entry.Matcher),
+				Hooks:   coreHooks,
+			})
+		}
+	}
+
+	return cfg
+}
+
+// FromCore converts canonical config to Codex format. Prompt hooks are
+// skipped since Codex only runs commands.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	codexCfg := NewConfig()
+
+	for _, event := range core.AllEvents() {
+		codexEvent, matcher := a.canonicalToCodexEvent(event)
+		if codexEvent == "" {
+			continue // Event not supported by Codex
+		}
+
+		for _, entry := range cfg.Hooks[event] {
+			m := core.NativeMatcher(vocab.Codex, event, entry.Matcher)
+			if m == "" {
+				m = matcher
+			}
+
+			var codexHooks []Hook
+			for _, h := range entry.Hooks {
+				if h.Command == "" {
+					continue
+				}
+				codexHooks = append(codexHooks, Hook{
+					Type:    "command",
+					Command: h.Command,
+					Timeout: h.Timeout,
+				})
+			}
+			if len(codexHooks) == 0 {
+				continue
+			}
+
+			codexCfg.Hooks[codexEvent] = append(codexCfg.Hooks[codexEvent], HookEntry{
+				Matcher: m,
+				Hooks:   codexHooks,
+			})
+		}
+	}
+
+	return codexCfg
+}
+
+// Lossiness reports the canonical fields that the Codex format drops or degrades.
+// As with Claude, a matcher that names a tool Codex does not know is read
+// back as an MCP event.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, a.SupportedEvents(), cfg,
+		core.FieldMatcher, core.FieldTimeout)
+	losses = append(losses, core.MovedEntries(AdapterName, cfg, a.readBack)...)
+	return append(losses, core.TranslatedMatchers(AdapterName, a.SupportedEvents(), cfg, vocab.Codex)...)
+}
+
+// Merge writes cfg into an existing Codex hooks file, replacing only
+// the hooks key and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "hooks")
+}
+
+// readBack returns the canonical event an entry is read back as.
+func (a *Adapter) readBack(event core.Event, entry core.HookEntry) core.Event {
+	codexEvent, matcher := a.canonicalToCodexEvent(event)
+	if codexEvent == "" {
+		return ""
+	}
+	if entry.Matcher != "" {
+		matcher = core.NativeMatcher(vocab.Codex, event, entry.Matcher)
+	}
+	return a.codexToCanonicalEvent(codexEvent, matcher)
+}
+
+// codexToCanonicalEvent converts a Codex event to canonical event.
+func (a *Adapter) codexToCanonicalEvent(codexEvent CodexEvent, matcher string) core.Event {
+	if event, ok := reverseEventMapping[codexEvent]; ok {
+		return event
+	}
+
+	switch codexEvent {
+	case PreToolUse:
+		if event, ok := matcherToCanonicalEventBefore[matcher]; ok {
+			return event
+		}
+		// Default to BeforeMCP for unknown matchers (likely MCP tools)
+		return core.BeforeMCP
+	case PostToolUse:
+		if event, ok := matcherToCanonicalEventAfter[matcher]; ok {
+			return event
+		}
+		return core.AfterMCP
+	}
+
+	return ""
+}
+
+// canonicalToCodexEvent converts a canonical event to Codex event and matcher.
+func (a *Adapter) canonicalToCodexEvent(event core.Event) (CodexEvent, string) {
+	if codexEvent, ok := eventMapping[event]; ok {
+		return codexEvent, canonicalEventToMatcher[event]
+	}
+	return "", ""
+}
+
+// ReadProjectConfig reads the project-level .codex/hooks.json hooks.
+func ReadProjectConfig() (*core.Config, error) {
+	adapter := NewAdapter()
+	return adapter.ReadFile(filepath.Join(ProjectConfigDir, ConfigFileName))
+}
+
+// ReadUserConfig reads the user-level ~/.codex/hooks.json hooks.
+func ReadUserConfig() (*core.Config, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	adapter := NewAdapter()
+	return adapter.ReadFile(filepath.Join(home, ProjectConfigDir, ConfigFileName))
+}
+
+// init registers the adapter with the default registry.
+func init() {
+	core.Register(NewAdapter())
+}
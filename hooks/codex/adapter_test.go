@@ -0,0 +1,128 @@
+package codex
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+func TestAdapterName(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter.Name() != "codex" {
+		t.Errorf("Expected name 'codex', got %q", adapter.Name())
+	}
+}
+
+func TestAdapterDefaultPaths(t *testing.T) {
+	paths := NewAdapter().DefaultPaths()
+	if len(paths) == 0 || paths[0] != filepath.Join(ProjectConfigDir, ConfigFileName) {
+		t.Errorf("Expected project hooks.json first, got %v", paths)
+	}
+}
+
+func TestAdapterParse(t *testing.T) {
+	data := `{
+		"hooks": {
+			"PreToolUse": [
+				{"matcher": "Bash", "hooks": [{"type": "command", "command": "./guard.sh", "timeout": 10}]},
+				{"matcher": "apply_patch", "hooks": [{"type": "command", "command": "./lint.sh"}]},
+				{"hooks": [{"type": "command", "command": "./any.sh"}]}
+			],
+			"UserPromptSubmit": [{"hooks": [{"type": "command", "command": "./prompt.sh"}]}],
+			"Stop": [{"hooks": [{"type": "command", "command": "./stop.sh"}]}]
+		}
+	}`
+
+	cfg, err := NewAdapter().Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		event   core.Event
+		command string
+	}{
+		{core.BeforeCommand, "./guard.sh"},
+		{core.BeforeFileWrite, "./lint.sh"},
+		{core.BeforeTool, "./any.sh"},
+		{core.BeforePrompt, "./prompt.sh"},
+		{core.OnStop, "./stop.sh"},
+	}
+	for _, tt := range tests {
+		entries := cfg.GetHooks(tt.event)
+		if len(entries) != 1 || entries[0].Hooks[0].Command != tt.command {
+			t.Errorf("Expected %s to run %s, got %+v", tt.event, tt.command, entries)
+		}
+	}
+	if timeout := cfg.GetHooks(core.BeforeCommand)[0].Hooks[0].Timeout; timeout != 10 {
+		t.Errorf("Expected timeout 10, got %d", timeout)
+	}
+
+	if _, err := NewAdapter().Parse([]byte(`{invalid`)); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestAdapterFromCore(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("./guard.sh"))
+	cfg.AddHook(core.AfterTool, core.NewCommandHook("./log.sh"))
+	cfg.AddHook(core.OnSessionStart, core.NewCommandHook("./start.sh"))
+
+	codexCfg := NewAdapter().FromCore(cfg)
+	if pre := codexCfg.Hooks[PreToolUse]; len(pre) != 1 || pre[0].Matcher != "Bash" {
+		t.Errorf("Unexpected PreToolUse entries: %+v", pre)
+	}
+	if post := codexCfg.Hooks[PostToolUse]; len(post) != 1 || post[0].Matcher != "" {
+		t.Errorf("Unexpected PostToolUse entries: %+v", post)
+	}
+	if start := codexCfg.Hooks[SessionStart]; len(start) != 1 || start[0].Hooks[0].Type != "command" {
+		t.Errorf("Unexpected SessionStart entries: %+v", start)
+	}
+}
+
+func TestAdapterLossiness(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddHook(core.BeforeFileRead, core.NewCommandHook("./read.sh"))
+	cfg.AddHook(core.OnSessionEnd, core.NewCommandHook("./end.sh"))
+	cfg.AddHook(core.BeforeCommand, core.NewPromptHook("Safe?"))
+
+	var got []string
+	for _, loss := range NewAdapter().Lossiness(cfg) {
+		got = append(got, loss.String())
+	}
+	expected := []string{
+		"codex: hooks.before_command[0].hooks[0] dropped: prompt hooks are not supported",
+		"codex: hooks.before_file_read dropped: event is not supported",
+		"codex: hooks.on_session_end dropped: event is not supported",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected losses:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestAdapterReadWriteFile(t *testing.T) {
+	adapter := NewAdapter()
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+
+	cfg := core.NewConfig()
+	cfg.AddHook(core.OnStop, core.NewCommandHook("./stop.sh"))
+	if err := adapter.WriteFile(cfg, path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	got, err := adapter.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if got.HookCount() != 1 {
+		t.Errorf("Expected 1 hook, got %d", got.HookCount())
+	}
+
+	if _, err := adapter.ReadFile(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not-exist error, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+// Package codex provides an adapter for OpenAI Codex CLI hooks configuration.
+//
+// Codex hooks are configured in hooks.json files that use the same layout
+// as Claude's hooks section:
+//   - Project: .codex/hooks.json
+//   - User: ~/.codex/hooks.json
+//
+// Codex hook events:
+//   - SessionStart: At session start
+//   - UserPromptSubmit: When user submits a prompt
+//   - PreToolUse: Before tool execution (can block)
+//   - PostToolUse: After tool execution
+//   - Stop: When the agent finishes a turn
+//
+// Tool events are filtered by a matcher against Codex tool names
+// (e.g., "Bash", "apply_patch"). Only command hooks are supported.
+package codex
+
+import "github.com/agentplexus/assistantkit/hooks/core"
+
+// CodexEvent represents Codex hook event names.
+type CodexEvent string
+
+const (
+	SessionStart     CodexEvent = "SessionStart"
+	UserPromptSubmit CodexEvent = "UserPromptSubmit"
+	PreToolUse       CodexEvent = "PreToolUse"
+	PostToolUse      CodexEvent = "PostToolUse"
+	Stop             CodexEvent = "Stop"
+)
+
+// Config represents a Codex hooks.json file.
+type Config struct {
+	Hooks map[CodexEvent][]HookEntry `json:"hooks,omitempty"`
+}
+
+// HookEntry represents a Codex hook entry with matcher and hooks.
+type HookEntry struct {
+	// Matcher filters which tools trigger this hook.
+	// Examples: "Bash", "apply_patch", "mcp__.*"
+	Matcher string `json:"matcher,omitempty"`
+
+	// Hooks is the list of hooks to execute.
+	Hooks []Hook `json:"hooks"`
+}
+
+// Hook represents a single Codex hook definition.
+type Hook struct {
+	// Type is always "command".
+	Type string `json:"type"`
+
+	// Command is the shell command to execute.
+	Command string `json:"command"`
+
+	// Timeout in seconds for hook execution.
+	Timeout int `json:"timeout,omitempty"`
+
+	// StatusMessage is shown while the hook runs.
+	StatusMessage string `json:"statusMessage,omitempty"`
+}
+
+// NewConfig creates a new empty Codex hooks config.
+func NewConfig() *Config {
+	return &Config{
+		Hooks: make(map[CodexEvent][]HookEntry),
+	}
+}
+
+// eventMapping maps canonical events to Codex events.
+var eventMapping = map[core.Event]CodexEvent{
+	core.BeforeFileWrite: PreToolUse, // with matcher "apply_patch"
+	core.AfterFileWrite:  PostToolUse,
+	core.BeforeCommand:   PreToolUse, // with matcher "Bash"
+	core.AfterCommand:    PostToolUse,
+	core.BeforeMCP:       PreToolUse, // with matcher "mcp__.*"
+	core.AfterMCP:        PostToolUse,
+	core.BeforeTool:      PreToolUse, // without matcher
+	core.AfterTool:       PostToolUse,
+	core.BeforePrompt:    UserPromptSubmit,
+	core.OnStop:          Stop,
+	core.OnSessionStart:  SessionStart,
+}
+
+// reverseEventMapping maps Codex events back to canonical events.
+// Note: PreToolUse/PostToolUse need matcher context to determine exact canonical event.
+var reverseEventMapping = map[CodexEvent]core.Event{
+	UserPromptSubmit: core.BeforePrompt,
+	Stop:             core.OnStop,
+	SessionStart:     core.OnSessionStart,
+}
+
+// canonicalEventToMatcher maps canonical tool events to Codex matchers.
+var canonicalEventToMatcher = map[core.Event]string{
+	core.BeforeFileWrite: "apply_patch",
+	core.AfterFileWrite:  "apply_patch",
+	core.BeforeCommand:   "Bash",
+	core.AfterCommand:    "Bash",
+	core.BeforeMCP:       "mcp__.*",
+	core.AfterMCP:        "mcp__.*",
+}
+
+// matcherToCanonicalEventBefore maps matchers to canonical events for PreToolUse.
+var matcherToCanonicalEventBefore = map[string]core.Event{
+	"":            core.BeforeTool,
+	"*":           core.BeforeTool,
+	"apply_patch": core.BeforeFileWrite,
+	"Bash":        core.BeforeCommand,
+}
+
+// matcherToCanonicalEventAfter maps matchers to canonical events for PostToolUse.
+var matcherToCanonicalEventAfter = map[string]core.Event{
+	"":            core.AfterTool,
+	"*":           core.AfterTool,
+	"apply_patch": core.AfterFileWrite,
+	"Bash":        core.AfterCommand,
+}
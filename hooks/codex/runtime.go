@@ -0,0 +1,194 @@
+package codex
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/vocab"
+)
+
+// Payload is the JSON Codex writes to a hook's stdin. It has the same
+// shape as Claude Code's.
+type Payload struct {
+	SessionID      string         `json:"session_id"`
+	TranscriptPath string         `json:"transcript_path,omitempty"`
+	Cwd            string         `json:"cwd,omitempty"`
+	HookEventName  CodexEvent     `json:"hook_event_name"`
+	ToolName       string         `json:"tool_name,omitempty"`
+	ToolInput      map[string]any `json:"tool_input,omitempty"`
+	ToolResponse   any            `json:"tool_response,omitempty"`
+	Prompt         string         `json:"prompt,omitempty"`
+}
+
+// Output is the JSON a Codex hook writes to stdout.
+type Output struct {
+	Continue           *bool               `json:"continue,omitempty"`
+	StopReason         string              `json:"stopReason,omitempty"`
+	Decision           string              `json:"decision,omitempty"`
+	Reason             string              `json:"reason,omitempty"`
+	HookSpecificOutput *HookSpecificOutput `json:"hookSpecificOutput,omitempty"`
+}
+
+// HookSpecificOutput holds the PreToolUse part of a hook's output.
+type HookSpecificOutput struct {
+	HookEventName            CodexEvent `json:"hookEventName"`
+	PermissionDecision       string     `json:"permissionDecision,omitempty"`
+	PermissionDecisionReason string     `json:"permissionDecisionReason,omitempty"`
+}
+
+// Detect always returns false: Codex payloads cannot be told apart from
+// Claude Code's, so they are decoded by the claude adapter unless the tool
+// is named explicitly. The two formats encode decisions the same way.
+func (a *Adapter) Detect(fields map[string]json.RawMessage) bool {
+	return false
+}
+
+// DecodePayload converts a Codex hook payload to canonical form.
+func (a *Adapter) DecodePayload(data []byte, nativeEvent string) (*core.Payload, error) {
+	var in Payload
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	if nativeEvent != "" {
+		in.HookEventName = CodexEvent(nativeEvent)
+	}
+
+	p := &core.Payload{
+		Adapter:     AdapterName,
+		NativeEvent: string(in.HookEventName),
+		SessionID:   in.SessionID,
+		Cwd:         in.Cwd,
+		ToolName:    in.ToolName,
+		ToolInput:   in.ToolInput,
+		ToolOutput:  in.ToolResponse,
+		Prompt:      in.Prompt,
+		Raw:         data,
+	}
+	switch in.HookEventName {
+	case PreToolUse:
+		p.Event = toolEvent(in.ToolName)
+	case PostToolUse:
+		p.Event = toolEvent(in.ToolName).AfterEvent()
+	default:
+		event, ok := reverseEventMapping[in.HookEventName]
+		if !ok {
+			return nil, &core.ParseError{Format: AdapterName, Err: core.ErrUnsupportedEvent}
+		}
+		p.Event = event
+	}
+	p.Command = p.InputString("command")
+	return p, nil
+}
+
+// EncodeDecision serializes a decision as Codex hook output. Codex has no
+// permission prompt for hooks to answer, so ask is sent as deny and allow
+// needs no output.
+func (a *Adapter) EncodeDecision(p *core.Payload, d core.Decision) (*core.Response, error) {
+	event := CodexEvent(p.NativeEvent)
+	switch d.Action {
+	case "", core.ActionAllow:
+		return &core.Response{}, nil
+	case core.ActionModify:
+		return nil, &core.DecisionError{Format: AdapterName, Event: p.NativeEvent, Action: d.Action}
+	}
+
+	switch event {
+	case PreToolUse:
+		return core.JSONResponse(Output{HookSpecificOutput: &HookSpecificOutput{
+			HookEventName:            event,
+			PermissionDecision:       string(core.ActionDeny),
+			PermissionDecisionReason: d.Reason,
+		}})
+	case PostToolUse, UserPromptSubmit, Stop:
+		return core.JSONResponse(Output{Decision: "block", Reason: d.Reason})
+	}
+	stop := false
+	return core.JSONResponse(Output{Continue: &stop, StopReason: d.Reason})
+}
+
+// toolEvent returns the canonical before event for a call of the named tool.
+func toolEvent(name string) core.Event {
+	if event, ok := matcherToCanonicalEventBefore[name]; ok {
+		return event
+	}
+	if strings.HasPrefix(name, "mcp__") {
+		return core.BeforeMCP
+	}
+	return core.BeforeTool
+}
+
+// toolNames maps canonical tool events to the tool named in simulated payloads.
+var toolNames = map[core.Event]string{
+	core.BeforeFileWrite: "apply_patch",
+	core.AfterFileWrite:  "apply_patch",
+	core.BeforeCommand:   "Bash",
+	core.AfterCommand:    "Bash",
+	core.BeforeMCP:       "mcp__test__tool",
+	core.AfterMCP:        "mcp__test__tool",
+}
+
+// EncodePayload renders a canonical payload as Codex's hook stdin.
+func (a *Adapter) EncodePayload(p *core.Payload) ([]byte, string, error) {
+	event := CodexEvent(p.NativeEvent)
+	if event == "" {
+		if event, _ = a.canonicalToCodexEvent(p.Event); event == "" {
+			return nil, "", &core.ConversionError{To: AdapterName, Event: p.Event, Err: core.ErrUnsupportedEvent}
+		}
+	}
+	out := Payload{
+		SessionID:     p.SessionID,
+		Cwd:           p.Cwd,
+		HookEventName: event,
+		Prompt:        p.Prompt,
+	}
+	if event == PreToolUse || event == PostToolUse {
+		out.ToolName = p.ToolName
+		if out.ToolName == "" {
+			out.ToolName = toolNames[p.Event]
+		}
+		out.ToolInput = p.ToolInputWith("command", "")
+		out.ToolResponse = p.ToolOutput
+	}
+	data, err := json.Marshal(out)
+	return data, string(event), err
+}
+
+// Matches reports whether Codex runs an entry registered for event when it
+// sends p. Tool events match the entry's matcher as a regular expression
+// against the tool name.
+func (a *Adapter) Matches(event core.Event, entry core.HookEntry, p *core.Payload) bool {
+	native, matcher := a.canonicalToCodexEvent(event)
+	if native == "" || string(native) != p.NativeEvent {
+		return false
+	}
+	if entry.Matcher != "" {
+		matcher = core.NativeMatcher(vocab.Codex, event, entry.Matcher)
+	}
+	if native == PreToolUse || native == PostToolUse {
+		return core.MatchToolRegexp(matcher, p.ToolName)
+	}
+	return true
+}
+
+// DecodeDecision reads a Codex hook's response. Exit code 2 blocks with the
+// reason on stderr.
+func (a *Adapter) DecodeDecision(p *core.Payload, resp *core.Response) core.Decision {
+	if resp.ExitCode == core.ExitBlock {
+		return core.Decision{Action: core.ActionDeny, Reason: resp.Stderr}
+	}
+	var out Output
+	if resp.ExitCode != 0 || !core.DecodeJSON(resp, &out) {
+		return core.Decision{}
+	}
+	if h := out.HookSpecificOutput; h != nil && h.PermissionDecision != "" {
+		return core.Decision{Action: core.Action(h.PermissionDecision), Reason: h.PermissionDecisionReason}
+	}
+	if out.Decision == "block" {
+		return core.Decision{Action: core.ActionDeny, Reason: out.Reason}
+	}
+	if out.Continue != nil && !*out.Continue {
+		return core.Decision{Action: core.ActionDeny, Reason: out.StopReason}
+	}
+	return core.Decision{}
+}
@@ -57,6 +57,45 @@ func (r *AdapterRegistry) Names() []string {
 	return names
 }
 
+// Supports reports whether the named adapter supports the event.
+func (r *AdapterRegistry) Supports(tool string, event Event) bool {
+	adapter, ok := r.Get(tool)
+	if !ok {
+		return false
+	}
+	for _, e := range adapter.SupportedEvents() {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ToolSupport returns which registered adapters support the event.
+func (r *AdapterRegistry) ToolSupport(event Event) ToolSupport {
+	support := make(ToolSupport, len(r.adapters))
+	for name := range r.adapters {
+		support[name] = r.Supports(name, event)
+	}
+	return support
+}
+
+// Filter returns a copy of cfg with only the events the named adapter
+// supports. Unknown adapters support no events.
+func (r *AdapterRegistry) Filter(cfg *Config, tool string) *Config {
+	filtered := NewConfig()
+	filtered.Version = cfg.Version
+	filtered.DisableAllHooks = cfg.DisableAllHooks
+	filtered.AllowManagedHooksOnly = cfg.AllowManagedHooksOnly
+
+	for event, entries := range cfg.Hooks {
+		if r.Supports(tool, event) {
+			filtered.Hooks[event] = entries
+		}
+	}
+	return filtered
+}
+
 // Convert converts a config from one format to another.
 func (r *AdapterRegistry) Convert(data []byte, from, to string) ([]byte, error) {
 	fromAdapter, ok := r.Get(from)
@@ -74,7 +113,7 @@ func (r *AdapterRegistry) Convert(data []byte, from, to string) ([]byte, error)
 	}
 
 	// Filter to only events supported by the target tool
-	filtered := cfg.FilterByTool(to)
+	filtered := r.Filter(cfg, to)
 
 	return toAdapter.Marshal(filtered)
 }
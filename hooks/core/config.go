@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/fs"
 	"os"
+	"sort"
 )
 
 // DefaultFileMode is the default permission mode for configuration files.
@@ -123,29 +124,10 @@ func (c *Config) Merge(other *Config) {
 	}
 }
 
-// FilterByTool returns a new config with only hooks supported by the specified tool.
+// FilterByTool returns a new config with only hooks supported by the
+// specified tool in the default registry.
 func (c *Config) FilterByTool(tool string) *Config {
-	filtered := NewConfig()
-	filtered.Version = c.Version
-	filtered.DisableAllHooks = c.DisableAllHooks
-	filtered.AllowManagedHooksOnly = c.AllowManagedHooksOnly
-
-	for event, entries := range c.Hooks {
-		support := event.GetToolSupport()
-		var supported bool
-		switch tool {
-		case "claude":
-			supported = support.Claude
-		case "cursor":
-			supported = support.Cursor
-		case "windsurf":
-			supported = support.Windsurf
-		}
-		if supported {
-			filtered.Hooks[event] = entries
-		}
-	}
-	return filtered
+	return DefaultRegistry.Filter(c, tool)
 }
 
 // Validate checks if the configuration is valid.
@@ -167,6 +149,30 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ValidateAll checks every hook and returns all validation errors,
+// ordered by event.
+func (c *Config) ValidateAll() []error {
+	events := c.Events()
+	sort.Slice(events, func(i, j int) bool { return events[i] < events[j] })
+
+	var errs []error
+	for _, event := range events {
+		for i, entry := range c.Hooks[event] {
+			for j, hook := range entry.Hooks {
+				if err := hook.Validate(); err != nil {
+					errs = append(errs, &HookValidationError{
+						Event:      event,
+						EntryIndex: i,
+						HookIndex:  j,
+						Err:        err,
+					})
+				}
+			}
+		}
+	}
+	return errs
+}
+
 // MarshalJSON implements json.Marshaler.
 func (c *Config) MarshalJSON() ([]byte, error) {
 	type Alias Config
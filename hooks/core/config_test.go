@@ -126,22 +126,27 @@ func TestConfigMerge(t *testing.T) {
 }
 
 func TestConfigFilterByTool(t *testing.T) {
+	registry := NewAdapterRegistry()
+	registry.Register(&mockAdapter{name: "claude", events: []Event{BeforeCommand, OnSessionStart}})
+	registry.Register(&mockAdapter{name: "cursor", events: []Event{BeforeCommand, AfterResponse}})
+	registry.Register(&mockAdapter{name: "windsurf", events: []Event{BeforeCommand}})
+
 	cfg := NewConfig()
 	cfg.AddHook(BeforeCommand, NewCommandHook("echo cmd"))      // All tools
 	cfg.AddHook(OnSessionStart, NewCommandHook("echo session")) // Claude only
 	cfg.AddHook(AfterResponse, NewCommandHook("echo response")) // Cursor only
 
-	claudeCfg := cfg.FilterByTool("claude")
+	claudeCfg := registry.Filter(cfg, "claude")
 	if len(claudeCfg.Hooks) != 2 {
 		t.Errorf("Claude config should have 2 events, got %d", len(claudeCfg.Hooks))
 	}
 
-	cursorCfg := cfg.FilterByTool("cursor")
+	cursorCfg := registry.Filter(cfg, "cursor")
 	if len(cursorCfg.Hooks) != 2 {
 		t.Errorf("Cursor config should have 2 events, got %d", len(cursorCfg.Hooks))
 	}
 
-	windsurfCfg := cfg.FilterByTool("windsurf")
+	windsurfCfg := registry.Filter(cfg, "windsurf")
 	if len(windsurfCfg.Hooks) != 1 {
 		t.Errorf("Windsurf config should have 1 event, got %d", len(windsurfCfg.Hooks))
 	}
@@ -198,6 +203,26 @@ func TestConfigValidate(t *testing.T) {
 	}
 }
 
+func TestConfigValidateAll(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddHook(BeforeCommand, NewCommandHook("echo test"))
+	if errs := cfg.ValidateAll(); len(errs) != 0 {
+		t.Errorf("Valid config should not return errors: %v", errs)
+	}
+
+	cfg.Hooks[BeforeCommand] = append(cfg.Hooks[BeforeCommand], HookEntry{Hooks: []Hook{{}}})
+	cfg.Hooks[AfterFileWrite] = []HookEntry{
+		{Hooks: []Hook{{Command: "echo", Prompt: "check"}}},
+	}
+	errs := cfg.ValidateAll()
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %v", len(errs), errs)
+	}
+	if hve, ok := errs[0].(*HookValidationError); !ok || hve.Event != AfterFileWrite {
+		t.Errorf("Expected first error for %q, got %v", AfterFileWrite, errs[0])
+	}
+}
+
 func TestConfigWriteReadFile(t *testing.T) {
 	// Create temp file
 	tmpFile, err := os.CreateTemp("", "config-test-*.json")
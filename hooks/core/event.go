@@ -2,6 +2,8 @@
 // that can be converted to/from various AI assistant formats.
 package core
 
+import "sort"
+
 // Event represents the canonical hook event types.
 // Different tools use different naming conventions, but these map to common concepts.
 type Event string
@@ -21,6 +23,10 @@ const (
 	BeforeMCP Event = "before_mcp"
 	AfterMCP  Event = "after_mcp"
 
+	// Any tool call, for tools whose hooks are not filtered by tool name
+	BeforeTool Event = "before_tool"
+	AfterTool  Event = "after_tool"
+
 	// Prompt/Input operations
 	BeforePrompt Event = "before_prompt"
 
@@ -54,7 +60,7 @@ func (e Event) String() string {
 // IsBeforeEvent returns true if this is a "before" event that can block actions.
 func (e Event) IsBeforeEvent() bool {
 	switch e {
-	case BeforeFileRead, BeforeFileWrite, BeforeCommand, BeforeMCP,
+	case BeforeFileRead, BeforeFileWrite, BeforeCommand, BeforeMCP, BeforeTool,
 		BeforePrompt, BeforeCompact, BeforeTabRead:
 		return true
 	default:
@@ -65,7 +71,7 @@ func (e Event) IsBeforeEvent() bool {
 // IsAfterEvent returns true if this is an "after" event for observation.
 func (e Event) IsAfterEvent() bool {
 	switch e {
-	case AfterFileRead, AfterFileWrite, AfterCommand, AfterMCP,
+	case AfterFileRead, AfterFileWrite, AfterCommand, AfterMCP, AfterTool,
 		AfterResponse, AfterThought, AfterTabEdit:
 		return true
 	default:
@@ -78,6 +84,38 @@ func (e Event) CanBlock() bool {
 	return e.IsBeforeEvent() || e == OnPermission
 }
 
+// IsToolEvent returns true if hooks for this event run around tool calls,
+// so that entry matchers select tools by name.
+func (e Event) IsToolEvent() bool {
+	switch e {
+	case BeforeFileRead, AfterFileRead, BeforeFileWrite, AfterFileWrite,
+		BeforeCommand, AfterCommand, BeforeMCP, AfterMCP, BeforeTool, AfterTool,
+		OnPermission:
+		return true
+	default:
+		return false
+	}
+}
+
+// AfterEvent returns the after event paired with a before tool event, such
+// as AfterCommand for BeforeCommand. Other events are returned unchanged.
+func (e Event) AfterEvent() Event {
+	switch e {
+	case BeforeFileRead:
+		return AfterFileRead
+	case BeforeFileWrite:
+		return AfterFileWrite
+	case BeforeCommand:
+		return AfterCommand
+	case BeforeMCP:
+		return AfterMCP
+	case BeforeTool:
+		return AfterTool
+	default:
+		return e
+	}
+}
+
 // AllEvents returns all defined canonical events.
 func AllEvents() []Event {
 	return []Event{
@@ -85,6 +123,7 @@ func AllEvents() []Event {
 		BeforeFileWrite, AfterFileWrite,
 		BeforeCommand, AfterCommand,
 		BeforeMCP, AfterMCP,
+		BeforeTool, AfterTool,
 		BeforePrompt,
 		OnStop, OnSessionStart, OnSessionEnd,
 		AfterResponse, AfterThought,
@@ -94,57 +133,31 @@ func AllEvents() []Event {
 	}
 }
 
-// ToolSupport indicates which tools support which events.
-type ToolSupport struct {
-	Claude   bool
-	Cursor   bool
-	Windsurf bool
+// ToolSupport maps tool names to whether they support an event. It is
+// derived from the SupportedEvents of the registered adapters, so adding an
+// adapter is enough to add a tool.
+type ToolSupport map[string]bool
+
+// Supports reports whether the named tool supports the event.
+func (s ToolSupport) Supports(tool string) bool {
+	return s[tool]
 }
 
-// GetToolSupport returns which tools support the given event.
-func (e Event) GetToolSupport() ToolSupport {
-	switch e {
-	case BeforeFileRead:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
-	case AfterFileRead:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: true}
-	case BeforeFileWrite:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: true}
-	case AfterFileWrite:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
-	case BeforeCommand:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
-	case AfterCommand:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
-	case BeforeMCP:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
-	case AfterMCP:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
-	case BeforePrompt:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: true}
-	case OnStop:
-		return ToolSupport{Claude: true, Cursor: true, Windsurf: false}
-	case OnSessionStart:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
-	case OnSessionEnd:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
-	case AfterResponse:
-		return ToolSupport{Claude: false, Cursor: true, Windsurf: false}
-	case AfterThought:
-		return ToolSupport{Claude: false, Cursor: true, Windsurf: false}
-	case OnPermission:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
-	case OnNotification:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
-	case BeforeCompact:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
-	case OnSubagentStop:
-		return ToolSupport{Claude: true, Cursor: false, Windsurf: false}
-	case BeforeTabRead:
-		return ToolSupport{Claude: false, Cursor: true, Windsurf: false}
-	case AfterTabEdit:
-		return ToolSupport{Claude: false, Cursor: true, Windsurf: false}
-	default:
-		return ToolSupport{}
+// Tools returns the names of the tools that support the event, sorted.
+func (s ToolSupport) Tools() []string {
+	var tools []string
+	for tool, ok := range s {
+		if ok {
+			tools = append(tools, tool)
+		}
 	}
+	sort.Strings(tools)
+	return tools
+}
+
+// GetToolSupport returns which tools in the default registry support the event.
+// Only registered adapters are listed, so the result is empty unless the
+// adapter packages are imported (the hooks package imports all of them).
+func (e Event) GetToolSupport() ToolSupport {
+	return DefaultRegistry.ToolSupport(e)
 }
@@ -1,6 +1,9 @@
 package core
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestEventString(t *testing.T) {
 	event := BeforeCommand
@@ -73,36 +76,39 @@ func TestEventCanBlock(t *testing.T) {
 }
 
 func TestEventGetToolSupport(t *testing.T) {
+	registry := NewAdapterRegistry()
+	registry.Register(&mockAdapter{name: "alpha", events: []Event{BeforeCommand, OnStop}})
+	registry.Register(&mockAdapter{name: "beta", events: []Event{BeforeCommand}})
+
 	tests := []struct {
-		event    Event
-		claude   bool
-		cursor   bool
-		windsurf bool
+		event Event
+		tools []string
 	}{
-		{BeforeCommand, true, true, true},
-		{AfterCommand, true, true, true},
-		{BeforeMCP, true, true, true},
-		{OnSessionStart, true, false, false},
-		{OnSessionEnd, true, false, false},
-		{AfterResponse, false, true, false},
-		{AfterThought, false, true, false},
-		{OnPermission, true, false, false},
+		{BeforeCommand, []string{"alpha", "beta"}},
+		{OnStop, []string{"alpha"}},
+		{AfterResponse, nil},
 	}
 
 	for _, tt := range tests {
 		t.Run(string(tt.event), func(t *testing.T) {
-			support := tt.event.GetToolSupport()
-			if support.Claude != tt.claude {
-				t.Errorf("Claude support: expected %v, got %v", tt.claude, support.Claude)
+			support := registry.ToolSupport(tt.event)
+			if len(support) != 2 {
+				t.Errorf("Expected an entry per registered adapter, got %v", support)
 			}
-			if support.Cursor != tt.cursor {
-				t.Errorf("Cursor support: expected %v, got %v", tt.cursor, support.Cursor)
+			if got := support.Tools(); !reflect.DeepEqual(got, tt.tools) {
+				t.Errorf("Expected tools %v, got %v", tt.tools, got)
 			}
-			if support.Windsurf != tt.windsurf {
-				t.Errorf("Windsurf support: expected %v, got %v", tt.windsurf, support.Windsurf)
+			for _, tool := range tt.tools {
+				if !support.Supports(tool) || !registry.Supports(tool, tt.event) {
+					t.Errorf("Expected %s to support %s", tool, tt.event)
+				}
 			}
 		})
 	}
+
+	if registry.Supports("unknown", BeforeCommand) {
+		t.Error("Unknown tool should not support any event")
+	}
 }
 
 func TestAllEvents(t *testing.T) {
@@ -128,103 +134,12 @@ func TestAllEvents(t *testing.T) {
 	}
 }
 
-func TestEventGetToolSupportComprehensive(t *testing.T) {
-	// Test all events to ensure GetToolSupport doesn't panic and returns valid data
-	allEvents := AllEvents()
-	for _, event := range allEvents {
-		support := event.GetToolSupport()
-		// At least one tool should support each event
-		if !support.Claude && !support.Cursor && !support.Windsurf {
-			t.Errorf("Event %q is not supported by any tool", event)
-		}
-	}
-}
-
-func TestEventGetToolSupportFileEvents(t *testing.T) {
-	// Test file events with their expected support
-	tests := []struct {
-		event    Event
-		claude   bool
-		cursor   bool
-		windsurf bool
-	}{
-		{BeforeFileRead, true, true, true},
-		{AfterFileRead, true, false, true},   // Cursor doesn't support AfterFileRead
-		{BeforeFileWrite, true, false, true}, // Cursor doesn't support BeforeFileWrite
-		{AfterFileWrite, true, true, true},
-	}
-	for _, tt := range tests {
-		support := tt.event.GetToolSupport()
-		if support.Claude != tt.claude {
-			t.Errorf("Event %q Claude support: got %v, want %v", tt.event, support.Claude, tt.claude)
-		}
-		if support.Cursor != tt.cursor {
-			t.Errorf("Event %q Cursor support: got %v, want %v", tt.event, support.Cursor, tt.cursor)
-		}
-		if support.Windsurf != tt.windsurf {
-			t.Errorf("Event %q Windsurf support: got %v, want %v", tt.event, support.Windsurf, tt.windsurf)
-		}
-	}
-}
-
-func TestEventGetToolSupportMCPEvents(t *testing.T) {
-	// MCP events should be supported by all three tools
-	mcpEvents := []Event{BeforeMCP, AfterMCP}
-	for _, event := range mcpEvents {
-		support := event.GetToolSupport()
-		if !support.Claude || !support.Cursor || !support.Windsurf {
-			t.Errorf("Event %q should be supported by all tools", event)
-		}
-	}
-}
-
-func TestEventGetToolSupportClaudeOnly(t *testing.T) {
-	// Claude-only events
-	claudeOnlyEvents := []Event{
-		OnSessionStart, OnSessionEnd,
-		OnPermission, OnNotification,
-		BeforeCompact, OnSubagentStop,
-	}
-	for _, event := range claudeOnlyEvents {
-		support := event.GetToolSupport()
-		if !support.Claude {
-			t.Errorf("Event %q should be supported by Claude", event)
-		}
-		if support.Cursor {
-			t.Errorf("Event %q should NOT be supported by Cursor", event)
-		}
-		if support.Windsurf {
-			t.Errorf("Event %q should NOT be supported by Windsurf", event)
-		}
-	}
-}
-
-func TestEventGetToolSupportCursorOnly(t *testing.T) {
-	// Cursor-only events
-	cursorOnlyEvents := []Event{
-		AfterResponse, AfterThought,
-		BeforeTabRead, AfterTabEdit,
-	}
-	for _, event := range cursorOnlyEvents {
-		support := event.GetToolSupport()
-		if support.Claude {
-			t.Errorf("Event %q should NOT be supported by Claude", event)
-		}
-		if !support.Cursor {
-			t.Errorf("Event %q should be supported by Cursor", event)
-		}
-		if support.Windsurf {
-			t.Errorf("Event %q should NOT be supported by Windsurf", event)
-		}
-	}
-}
-
 func TestEventGetToolSupportUnknownEvent(t *testing.T) {
-	// Unknown event should return empty support (default case)
+	// Unknown event should not be supported by any registered tool
 	unknownEvent := Event("unknown_event")
 	support := unknownEvent.GetToolSupport()
-	if support.Claude || support.Cursor || support.Windsurf {
-		t.Error("Unknown event should not be supported by any tool")
+	if tools := support.Tools(); len(tools) != 0 {
+		t.Errorf("Unknown event should not be supported by any tool, got %v", tools)
 	}
 }
 
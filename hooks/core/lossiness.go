@@ -0,0 +1,176 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LossKind describes how a field is affected when written to a target format.
+type LossKind string
+
+const (
+	// LossDropped means the field is not written at all.
+	LossDropped LossKind = "dropped"
+
+	// LossDegraded means the field is written but reads back with a different value.
+	LossDegraded LossKind = "degraded"
+
+	// LossUnsupported means the field names something the target does not
+	// have, such as a tool, and is written unchanged.
+	LossUnsupported LossKind = "unsupported"
+)
+
+// Canonical field names used in Loss paths. They match the JSON names of
+// the Config, HookEntry and Hook fields.
+const (
+	FieldVersion               = "version"
+	FieldDisableAllHooks       = "disableAllHooks"
+	FieldAllowManagedHooksOnly = "allowManagedHooksOnly"
+	FieldMatcher               = "matcher"
+	FieldPrompt                = "prompt"
+	FieldTimeout               = "timeout"
+	FieldShowOutput            = "showOutput"
+	FieldWorkingDir            = "workingDir"
+)
+
+// Loss describes a canonical field that a target format cannot represent exactly.
+type Loss struct {
+	// Adapter is the name of the target adapter.
+	Adapter string `json:"adapter"`
+
+	// Path locates the field in the canonical config
+	// (e.g., "hooks.before_command[0].hooks[1].timeout").
+	Path string `json:"path"`
+
+	// Kind is whether the field is dropped, degraded or unsupported.
+	Kind LossKind `json:"kind"`
+
+	// Detail explains the loss.
+	Detail string `json:"detail,omitempty"`
+}
+
+// String returns a one-line description of the loss.
+func (l Loss) String() string {
+	s := fmt.Sprintf("%s: %s %s", l.Adapter, l.Path, l.Kind)
+	if l.Detail != "" {
+		s += ": " + l.Detail
+	}
+	return s
+}
+
+// LossReporter is implemented by adapters that can report which fields of a
+// canonical config they drop or degrade when marshaling.
+type LossReporter interface {
+	// Lossiness returns the losses incurred by marshaling cfg with this adapter.
+	Lossiness(cfg *Config) []Loss
+}
+
+// Lossiness returns the losses incurred by marshaling cfg with the given
+// adapter. It returns nil if the adapter does not implement LossReporter.
+func Lossiness(adapter Adapter, cfg *Config) []Loss {
+	if r, ok := adapter.(LossReporter); ok {
+		return r.Lossiness(cfg)
+	}
+	return nil
+}
+
+// Lossiness returns the losses incurred by marshaling cfg with the named adapter.
+func (r *AdapterRegistry) Lossiness(cfg *Config, to string) ([]Loss, error) {
+	adapter, ok := r.Get(to)
+	if !ok {
+		return nil, &ConversionError{To: to, Err: ErrUnsupportedEvent}
+	}
+	return Lossiness(adapter, cfg), nil
+}
+
+// EntryPath returns the Loss path of a hook entry.
+func EntryPath(event Event, entry int) string {
+	return fmt.Sprintf("hooks.%s[%d]", event, entry)
+}
+
+// HookPath returns the Loss path of a hook within an entry.
+func HookPath(event Event, entry, hook int) string {
+	return fmt.Sprintf("%s.hooks[%d]", EntryPath(event, entry), hook)
+}
+
+// DroppedFields returns the losses for a format that supports only the given
+// events and fields. Hooks for unsupported events are reported per event,
+// prompt hooks are reported per hook unless FieldPrompt is supported, and
+// every other unsupported field is reported where it is set. Events are
+// reported in name order.
+func DroppedFields(adapter string, events []Event, cfg *Config, supported ...string) []Loss {
+	keep := make(map[string]bool, len(supported))
+	for _, f := range supported {
+		keep[f] = true
+	}
+	eventSet := make(map[Event]bool, len(events))
+	for _, e := range events {
+		eventSet[e] = true
+	}
+
+	var losses []Loss
+	drop := func(set bool, path, detail string) {
+		if set {
+			losses = append(losses, Loss{Adapter: adapter, Path: path, Kind: LossDropped, Detail: detail})
+		}
+	}
+
+	drop(cfg.Version > 0 && !keep[FieldVersion], FieldVersion, "")
+	drop(cfg.DisableAllHooks && !keep[FieldDisableAllHooks], FieldDisableAllHooks, "")
+	drop(cfg.AllowManagedHooksOnly && !keep[FieldAllowManagedHooksOnly], FieldAllowManagedHooksOnly, "")
+
+	for _, event := range sortedEvents(cfg) {
+		entries := cfg.Hooks[event]
+		if !eventSet[event] {
+			drop(len(entries) > 0, "hooks."+string(event), "event is not supported")
+			continue
+		}
+		for i, entry := range entries {
+			drop(entry.Matcher != "" && !keep[FieldMatcher], EntryPath(event, i)+"."+FieldMatcher, "")
+			for j, h := range entry.Hooks {
+				path := HookPath(event, i, j)
+				if h.Command == "" && !keep[FieldPrompt] {
+					drop(true, path, "prompt hooks are not supported")
+					continue
+				}
+				drop(h.Timeout > 0 && !keep[FieldTimeout], path+"."+FieldTimeout, "")
+				drop(h.ShowOutput && !keep[FieldShowOutput], path+"."+FieldShowOutput, "")
+				drop(h.WorkingDir != "" && !keep[FieldWorkingDir], path+"."+FieldWorkingDir, "")
+			}
+		}
+	}
+	return losses
+}
+
+// sortedEvents returns the events configured in cfg sorted by name.
+func sortedEvents(cfg *Config) []Event {
+	events := cfg.Events()
+	sort.Slice(events, func(i, j int) bool { return events[i] < events[j] })
+	return events
+}
+
+// MovedEntries returns the degraded losses for the entries of cfg that a
+// format reads back under a different event. readBack returns the event an
+// entry is read back as, or "" if the format does not support the event.
+// A moved entry with a matcher is reported at its matcher, since the matcher
+// selects the event; one without a matcher is reported at the entry itself.
+func MovedEntries(adapter string, cfg *Config, readBack func(event Event, entry HookEntry) Event) []Loss {
+	var losses []Loss
+	for _, event := range sortedEvents(cfg) {
+		for i, entry := range cfg.Hooks[event] {
+			got := readBack(event, entry)
+			if got == "" || got == event {
+				continue
+			}
+			loss := Loss{Adapter: adapter, Path: EntryPath(event, i), Kind: LossDegraded}
+			if entry.Matcher != "" {
+				loss.Path += "." + FieldMatcher
+				loss.Detail = fmt.Sprintf("matcher %q is read back as event %s", entry.Matcher, got)
+			} else {
+				loss.Detail = fmt.Sprintf("entry is read back as event %s", got)
+			}
+			losses = append(losses, loss)
+		}
+	}
+	return losses
+}
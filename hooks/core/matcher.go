@@ -0,0 +1,71 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/agentplexus/assistantkit/vocab"
+)
+
+// NativeMatcher translates the canonical matcher of an entry for event to
+// the platform's tool names. Only tool events match tool names; matchers
+// of other events are returned unchanged.
+func NativeMatcher(p *vocab.Platform, event Event, matcher string) string {
+	if !event.IsToolEvent() {
+		return matcher
+	}
+	native, _ := p.NativeMatcher(matcher)
+	return native
+}
+
+// CanonicalMatcher translates a platform matcher of an entry for event to
+// canonical tool names, like NativeMatcher in reverse.
+func CanonicalMatcher(p *vocab.Platform, event Event, matcher string) string {
+	if !event.IsToolEvent() {
+		return matcher
+	}
+	canonical, _ := p.CanonicalMatcher(matcher)
+	return canonical
+}
+
+// TranslatedMatchers returns the losses for the tool matchers of cfg that
+// do not survive translation to the platform's tool names: tools the
+// platform does not have are unsupported, and matchers that read back
+// differently, such as "Write|Edit" for a platform with one write tool,
+// are degraded. Entries for events outside events are skipped.
+func TranslatedMatchers(adapter string, events []Event, cfg *Config, p *vocab.Platform) []Loss {
+	eventSet := make(map[Event]bool, len(events))
+	for _, e := range events {
+		eventSet[e] = true
+	}
+
+	var losses []Loss
+	for _, event := range sortedEvents(cfg) {
+		if !eventSet[event] || !event.IsToolEvent() {
+			continue
+		}
+		for i, entry := range cfg.Hooks[event] {
+			if entry.Matcher == "" {
+				continue
+			}
+			path := EntryPath(event, i) + "." + FieldMatcher
+			native, unknown := p.NativeMatcher(entry.Matcher)
+			for _, tool := range unknown {
+				losses = append(losses, Loss{
+					Adapter: adapter,
+					Path:    path,
+					Kind:    LossUnsupported,
+					Detail:  fmt.Sprintf("tool %q does not exist on %s", tool, adapter),
+				})
+			}
+			if back, _ := p.CanonicalMatcher(native); back != entry.Matcher {
+				losses = append(losses, Loss{
+					Adapter: adapter,
+					Path:    path,
+					Kind:    LossDegraded,
+					Detail:  fmt.Sprintf("matcher %q is written as %q and read back as %q", entry.Matcher, native, back),
+				})
+			}
+		}
+	}
+	return losses
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/agentplexus/assistantkit/vocab"
+)
+
+func TestEventIsToolEvent(t *testing.T) {
+	for _, event := range []Event{BeforeCommand, AfterFileWrite, BeforeMCP, AfterTool, OnPermission} {
+		if !event.IsToolEvent() {
+			t.Errorf("Expected %q to be a tool event", event)
+		}
+	}
+	for _, event := range []Event{BeforePrompt, OnSessionStart, BeforeCompact, AfterTabEdit} {
+		if event.IsToolEvent() {
+			t.Errorf("Expected %q to not be a tool event", event)
+		}
+	}
+}
+
+func TestMatcherTranslation(t *testing.T) {
+	if m := NativeMatcher(vocab.Gemini, BeforeTool, "Write|Edit"); m != "write_file|replace" {
+		t.Errorf("Expected write_file|replace, got %q", m)
+	}
+	if m := CanonicalMatcher(vocab.Kiro, BeforeMCP, "@git"); m != "mcp__git__.*" {
+		t.Errorf("Expected mcp__git__.*, got %q", m)
+	}
+	// Session start matchers name sources, not tools.
+	if m := NativeMatcher(vocab.Gemini, OnSessionStart, "Read"); m != "Read" {
+		t.Errorf("Expected Read to be kept, got %q", m)
+	}
+}
+
+func TestTranslatedMatchers(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddHookWithMatcher(BeforeTool, "Bash", NewCommandHook("./ok.sh"))
+	cfg.AddHookWithMatcher(BeforeTool, "NotebookEdit", NewCommandHook("./notebook.sh"))
+	cfg.AddHookWithMatcher(BeforeFileWrite, "Write|Edit", NewCommandHook("./lint.sh"))
+	cfg.AddHookWithMatcher(OnSessionStart, "startup", NewCommandHook("./start.sh"))
+	cfg.AddHookWithMatcher(AfterThought, "NotebookEdit", NewCommandHook("./thought.sh"))
+
+	events := []Event{BeforeTool, BeforeFileWrite, OnSessionStart}
+	expected := []string{
+		`kiro: hooks.before_file_write[0].matcher degraded: matcher "Write|Edit" is written as "fs_write" and read back as "Write"`,
+		`kiro: hooks.before_tool[1].matcher unsupported: tool "NotebookEdit" does not exist on kiro`,
+	}
+	losses := TranslatedMatchers("kiro", events, cfg, vocab.Kiro)
+	if len(losses) != len(expected) {
+		t.Fatalf("Expected %d losses, got %v", len(expected), losses)
+	}
+	for i, loss := range losses {
+		if loss.String() != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], loss)
+		}
+	}
+
+	if losses := TranslatedMatchers("claude", events, cfg, vocab.Claude); len(losses) != 0 {
+		t.Errorf("Expected no losses for claude, got %v", losses)
+	}
+}
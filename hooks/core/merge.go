@@ -0,0 +1,52 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/agentplexus/assistantkit/merge"
+)
+
+// ErrMergeNotSupported is returned when an adapter cannot merge into an existing file.
+var ErrMergeNotSupported = errors.New("adapter does not support merging into existing files")
+
+// Merger is implemented by adapters that can write a config into an existing
+// file without clobbering settings they do not manage, such as the
+// permissions and model in Claude's settings.json.
+type Merger interface {
+	// Merge returns existing with the adapter's hooks settings replaced by cfg.
+	// Existing may be empty, in which case the result is the marshaled cfg.
+	Merge(cfg *Config, existing []byte) ([]byte, error)
+}
+
+// MergeFile merges cfg into the file at path using the adapter, creating the
+// file if it does not exist. Only the adapter's hooks settings are changed.
+// If dryRun is true, the file is not written; the result still holds the diff.
+func MergeFile(adapter Adapter, cfg *Config, path string, dryRun bool) (*merge.Result, error) {
+	m, ok := adapter.(Merger)
+	if !ok {
+		return nil, &WriteError{Format: adapter.Name(), Path: path, Err: ErrMergeNotSupported}
+	}
+	result, err := merge.File(path, DefaultFileMode, dryRun, func(existing []byte) ([]byte, error) {
+		data, err := m.Merge(cfg, existing)
+		if err != nil {
+			return nil, &ParseError{Format: adapter.Name(), Path: path, Err: err}
+		}
+		return data, nil
+	})
+	if err != nil {
+		if _, ok := err.(*ParseError); ok {
+			return nil, err
+		}
+		return nil, &WriteError{Format: adapter.Name(), Path: path, Err: err}
+	}
+	return result, nil
+}
+
+// MergeFile merges cfg into the file at path using the named adapter.
+func (r *AdapterRegistry) MergeFile(cfg *Config, to, path string, dryRun bool) (*merge.Result, error) {
+	adapter, ok := r.Get(to)
+	if !ok {
+		return nil, &ConversionError{To: to, Err: ErrUnsupportedEvent}
+	}
+	return MergeFile(adapter, cfg, path, dryRun)
+}
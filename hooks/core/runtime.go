@@ -0,0 +1,233 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Runtime errors.
+var (
+	// ErrUnknownPayload is returned when no registered adapter recognizes a hook payload.
+	ErrUnknownPayload = errors.New("hook payload not recognized")
+
+	// ErrRuntimeNotSupported is returned when an adapter cannot decode hook payloads.
+	ErrRuntimeNotSupported = errors.New("adapter does not support hook payloads")
+
+	// ErrUnsupportedDecision is returned when a tool cannot express a decision for an event.
+	ErrUnsupportedDecision = errors.New("decision not supported for this event")
+)
+
+// ExitBlock is the exit code that tools read as "block the action" when a
+// hook reports its decision through the exit code rather than stdout JSON.
+const ExitBlock = 2
+
+// Payload is the canonical form of the JSON a tool writes to a hook's stdin.
+// Tool-specific fields that have no canonical equivalent remain in Raw.
+type Payload struct {
+	// Adapter is the name of the tool that ran the hook (e.g., "claude").
+	Adapter string `json:"adapter"`
+
+	// Event is the canonical event. For tool events it is refined by the
+	// tool being called, so a Claude PreToolUse for Bash is BeforeCommand.
+	Event Event `json:"event"`
+
+	// NativeEvent is the tool's own event name (e.g., "PreToolUse").
+	NativeEvent string `json:"native_event"`
+
+	// SessionID identifies the session or conversation, if the tool sends one.
+	SessionID string `json:"session_id,omitempty"`
+
+	// Cwd is the working directory of the session.
+	Cwd string `json:"cwd,omitempty"`
+
+	// ToolName is the tool's own name for the tool being called (e.g., "Bash").
+	ToolName string `json:"tool_name,omitempty"`
+
+	// ToolInput holds the arguments of the tool call.
+	ToolInput map[string]any `json:"tool_input,omitempty"`
+
+	// ToolOutput holds the result of the tool call for after events.
+	ToolOutput any `json:"tool_output,omitempty"`
+
+	// Command is the shell command for command events.
+	Command string `json:"command,omitempty"`
+
+	// FilePath is the file being read or written for file events.
+	FilePath string `json:"file_path,omitempty"`
+
+	// Prompt is the user's prompt for prompt events.
+	Prompt string `json:"prompt,omitempty"`
+
+	// Raw is the payload as the tool sent it.
+	Raw json.RawMessage `json:"-"`
+}
+
+// InputString returns the first of the named tool input fields that holds a
+// non-empty string.
+func (p *Payload) InputString(keys ...string) string {
+	for _, k := range keys {
+		if s, ok := p.ToolInput[k].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// Action is the outcome a hook handler asks for.
+type Action string
+
+const (
+	// ActionAllow approves the action, skipping any permission prompt.
+	ActionAllow Action = "allow"
+
+	// ActionDeny blocks the action. For stop events it asks the agent to keep
+	// going, with the reason as its next instruction.
+	ActionDeny Action = "deny"
+
+	// ActionAsk asks the user to confirm the action. Tools that cannot prompt
+	// treat it as ActionDeny, so a handler that asks never silently allows.
+	ActionAsk Action = "ask"
+
+	// ActionModify approves the action with the tool input replaced.
+	ActionModify Action = "modify"
+)
+
+// Decision is a hook handler's canonical answer to a payload. The zero
+// Decision makes no decision and leaves the tool's normal behavior in place.
+type Decision struct {
+	// Action is the requested outcome.
+	Action Action `json:"action,omitempty"`
+
+	// Reason explains the decision to the user and the agent.
+	Reason string `json:"reason,omitempty"`
+
+	// Input replaces the tool input for ActionModify.
+	Input map[string]any `json:"input,omitempty"`
+}
+
+// Response is a decision serialized for a tool: what the hook process
+// writes to stdout and stderr and the code it exits with.
+type Response struct {
+	ExitCode int    `json:"exit_code"`
+	Stdout   []byte `json:"stdout,omitempty"`
+	Stderr   string `json:"stderr,omitempty"`
+}
+
+// JSONResponse returns a response that writes v as JSON to stdout and exits 0.
+func JSONResponse(v any) (*Response, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{Stdout: append(data, '\n')}, nil
+}
+
+// BlockResponse returns a response that blocks the action with ExitBlock,
+// writing the reason to stderr.
+func BlockResponse(reason string) *Response {
+	return &Response{ExitCode: ExitBlock, Stderr: reason}
+}
+
+// DecisionError is returned when a tool cannot express a decision for an event.
+type DecisionError struct {
+	Format string
+	Event  string
+	Action Action
+}
+
+func (e *DecisionError) Error() string {
+	return fmt.Sprintf("%s cannot %s for event %q", e.Format, e.Action, e.Event)
+}
+
+func (e *DecisionError) Unwrap() error {
+	return ErrUnsupportedDecision
+}
+
+// Runtime is implemented by adapters that can decode the payload their tool
+// writes to a hook's stdin and encode a decision the way the tool reads it.
+type Runtime interface {
+	// Detect reports whether a payload, decoded as a JSON object, was sent
+	// by this adapter's tool.
+	Detect(fields map[string]json.RawMessage) bool
+
+	// DecodePayload converts a payload to canonical form. The native event
+	// is taken from nativeEvent if set, otherwise from the payload itself.
+	DecodePayload(data []byte, nativeEvent string) (*Payload, error)
+
+	// EncodeDecision serializes a decision for a payload decoded by this adapter.
+	EncodeDecision(p *Payload, d Decision) (*Response, error)
+}
+
+// runtime returns the named adapter as a Runtime.
+func (r *AdapterRegistry) runtime(name string) (Runtime, error) {
+	adapter, ok := r.Get(name)
+	if !ok {
+		return nil, &ParseError{Format: name, Err: ErrUnknownPayload}
+	}
+	rt, ok := adapter.(Runtime)
+	if !ok {
+		return nil, &ParseError{Format: name, Err: ErrRuntimeNotSupported}
+	}
+	return rt, nil
+}
+
+// Detect returns the name of the first adapter, in name order, that
+// recognizes the payload.
+func (r *AdapterRegistry) Detect(data []byte) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", &ParseError{Format: "hook payload", Err: err}
+	}
+	names := r.Names()
+	sort.Strings(names)
+	for _, name := range names {
+		if rt, ok := r.adapters[name].(Runtime); ok && rt.Detect(fields) {
+			return name, nil
+		}
+	}
+	return "", &ParseError{Format: "hook payload", Err: ErrUnknownPayload}
+}
+
+// DecodePayload converts a payload from the named tool to canonical form.
+// If tool is empty, the tool is detected from the payload.
+func (r *AdapterRegistry) DecodePayload(tool string, data []byte, nativeEvent string) (*Payload, error) {
+	if tool == "" {
+		detected, err := r.Detect(data)
+		if err != nil {
+			return nil, err
+		}
+		tool = detected
+	}
+	rt, err := r.runtime(tool)
+	if err != nil {
+		return nil, err
+	}
+	return rt.DecodePayload(data, nativeEvent)
+}
+
+// EncodeDecision serializes a decision for the tool that sent the payload.
+func (r *AdapterRegistry) EncodeDecision(p *Payload, d Decision) (*Response, error) {
+	rt, err := r.runtime(p.Adapter)
+	if err != nil {
+		return nil, err
+	}
+	return rt.EncodeDecision(p, d)
+}
+
+// HasField reports whether a decoded payload has the named field set to
+// something other than null.
+func HasField(fields map[string]json.RawMessage, name string) bool {
+	v, ok := fields[name]
+	return ok && string(v) != "null"
+}
+
+// StringField returns the named field of a decoded payload if it is a string.
+func StringField(fields map[string]json.RawMessage, name string) string {
+	var s string
+	if v, ok := fields[name]; ok {
+		_ = json.Unmarshal(v, &s)
+	}
+	return s
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestEventAfterEvent(t *testing.T) {
+	tests := map[Event]Event{
+		BeforeFileRead:  AfterFileRead,
+		BeforeFileWrite: AfterFileWrite,
+		BeforeCommand:   AfterCommand,
+		BeforeMCP:       AfterMCP,
+		BeforeTool:      AfterTool,
+		BeforePrompt:    BeforePrompt,
+		OnStop:          OnStop,
+	}
+	for event, expected := range tests {
+		if got := event.AfterEvent(); got != expected {
+			t.Errorf("Expected %q.AfterEvent() to be %q, got %q", event, expected, got)
+		}
+	}
+}
+
+func TestPayloadInputString(t *testing.T) {
+	p := &Payload{ToolInput: map[string]any{"path": "", "file_path": "a.go", "n": 1}}
+	if got := p.InputString("path", "file_path"); got != "a.go" {
+		t.Errorf("Expected 'a.go', got %q", got)
+	}
+	if got := p.InputString("n", "missing"); got != "" {
+		t.Errorf("Expected empty string, got %q", got)
+	}
+}
+
+func TestDecisionError(t *testing.T) {
+	err := &DecisionError{Format: "cursor", Event: "afterFileEdit", Action: ActionDeny}
+	if err.Error() != `cursor cannot deny for event "afterFileEdit"` {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+	if !errors.Is(err, ErrUnsupportedDecision) {
+		t.Error("Expected DecisionError to wrap ErrUnsupportedDecision")
+	}
+}
+
+func TestRegistryRuntimeErrors(t *testing.T) {
+	r := NewAdapterRegistry()
+	r.Register(&mockAdapter{name: "plain"})
+
+	if _, err := r.DecodePayload("missing", []byte(`{}`), ""); !errors.Is(err, ErrUnknownPayload) {
+		t.Errorf("Expected ErrUnknownPayload, got %v", err)
+	}
+	if _, err := r.DecodePayload("plain", []byte(`{}`), ""); !errors.Is(err, ErrRuntimeNotSupported) {
+		t.Errorf("Expected ErrRuntimeNotSupported, got %v", err)
+	}
+	if _, err := r.Detect([]byte(`not json`)); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestJSONResponse(t *testing.T) {
+	resp, err := JSONResponse(map[string]string{"decision": "block"})
+	if err != nil {
+		t.Fatalf("JSONResponse failed: %v", err)
+	}
+	if resp.ExitCode != 0 || string(resp.Stdout) != "{\"decision\":\"block\"}\n" {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+
+	fields := map[string]json.RawMessage{"a": json.RawMessage(`"x"`), "b": json.RawMessage(`null`)}
+	if !HasField(fields, "a") || HasField(fields, "b") || StringField(fields, "a") != "x" {
+		t.Error("Unexpected field helpers result")
+	}
+}
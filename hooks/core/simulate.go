@@ -0,0 +1,105 @@
+package core
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// Simulator is implemented by adapters that can stand in for their tool
+// when testing hooks: they render a canonical payload as the tool's stdin,
+// select hook entries the way the tool does and read a hook's response
+// back as a decision.
+type Simulator interface {
+	Runtime
+
+	// EncodePayload renders a canonical payload as the JSON the tool writes
+	// to a hook's stdin and returns it with the tool's event name. Fields
+	// not set in p, such as the tool name of a BeforeCommand, get the
+	// tool's defaults.
+	EncodePayload(p *Payload) (data []byte, nativeEvent string, err error)
+
+	// Matches reports whether the tool runs an entry registered for event
+	// when it sends p. The payload must have been decoded by the adapter.
+	Matches(event Event, entry HookEntry, p *Payload) bool
+
+	// DecodeDecision reads a hook's response the way the tool does. Output
+	// the tool would ignore yields the zero Decision.
+	DecodeDecision(p *Payload, resp *Response) Decision
+}
+
+// Simulator returns the named adapter as a Simulator.
+func (r *AdapterRegistry) Simulator(name string) (Simulator, error) {
+	adapter, ok := r.Get(name)
+	if !ok {
+		return nil, &ConversionError{To: name, Err: ErrUnsupportedEvent}
+	}
+	sim, ok := adapter.(Simulator)
+	if !ok {
+		return nil, &ConversionError{To: name, Err: ErrRuntimeNotSupported}
+	}
+	return sim, nil
+}
+
+// ToolInputWith returns the payload's tool input with Command and FilePath
+// added under the given keys when set and not already present. An empty
+// key skips the field.
+func (p *Payload) ToolInputWith(commandKey, pathKey string) map[string]any {
+	input := make(map[string]any, len(p.ToolInput)+2)
+	for k, v := range p.ToolInput {
+		input[k] = v
+	}
+	add := func(key, value string) {
+		if _, ok := input[key]; key != "" && value != "" && !ok {
+			input[key] = value
+		}
+	}
+	add(commandKey, p.Command)
+	add(pathKey, p.FilePath)
+	if len(input) == 0 {
+		return nil
+	}
+	return input
+}
+
+// MatchToolRegexp reports whether a regular expression matcher, as used by
+// Claude, Codex and Gemini, selects the named tool. An empty matcher or "*"
+// selects every tool; otherwise the expression must match the whole name.
+// An invalid expression only matches a tool with exactly that name.
+func MatchToolRegexp(matcher, tool string) bool {
+	if matcher == "" || matcher == "*" {
+		return true
+	}
+	re, err := regexp.Compile("^(?:" + matcher + ")$")
+	if err != nil {
+		return matcher == tool
+	}
+	return re.MatchString(tool)
+}
+
+// actionRank orders actions by precedence when several hooks decide.
+var actionRank = map[Action]int{
+	"":           0,
+	ActionAllow:  1,
+	ActionModify: 2,
+	ActionAsk:    3,
+	ActionDeny:   4,
+}
+
+// CombineDecisions returns the decision that takes effect when several hooks
+// run for one event: deny over ask over modify over allow. Among decisions
+// with the same action, the first wins.
+func CombineDecisions(decisions ...Decision) Decision {
+	var combined Decision
+	for _, d := range decisions {
+		if actionRank[d.Action] > actionRank[combined.Action] {
+			combined = d
+		}
+	}
+	return combined
+}
+
+// DecodeJSON decodes a hook's stdout into v. It reports false when there is
+// no output or the output is not JSON, which tools treat as plain text.
+func DecodeJSON(resp *Response, v any) bool {
+	return len(resp.Stdout) > 0 && json.Unmarshal(resp.Stdout, v) == nil
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMatchToolRegexp(t *testing.T) {
+	tests := []struct {
+		matcher string
+		tool    string
+		want    bool
+	}{
+		{"", "Bash", true},
+		{"*", "Bash", true},
+		{"Bash", "Bash", true},
+		{"Bash", "BashOutput", false},
+		{"Write|Edit", "Edit", true},
+		{"mcp__github__.*", "mcp__github__create_issue", true},
+		{"mcp__github__.*", "mcp__slack__post", false},
+		{"(", "(", true},
+		{"(", "Bash", false},
+	}
+	for _, tt := range tests {
+		if got := MatchToolRegexp(tt.matcher, tt.tool); got != tt.want {
+			t.Errorf("MatchToolRegexp(%q, %q) = %v, expected %v", tt.matcher, tt.tool, got, tt.want)
+		}
+	}
+}
+
+func TestCombineDecisions(t *testing.T) {
+	allow := Decision{Action: ActionAllow}
+	ask := Decision{Action: ActionAsk, Reason: "ask"}
+	deny1 := Decision{Action: ActionDeny, Reason: "first"}
+	deny2 := Decision{Action: ActionDeny, Reason: "second"}
+
+	if got := CombineDecisions(); got.Action != "" {
+		t.Errorf("Expected no decision, got %+v", got)
+	}
+	if got := CombineDecisions(allow, ask, Decision{}); got.Action != ActionAsk {
+		t.Errorf("Expected ask, got %+v", got)
+	}
+	if got := CombineDecisions(allow, deny1, ask, deny2); got.Reason != "first" {
+		t.Errorf("Expected first deny, got %+v", got)
+	}
+}
+
+func TestPayloadToolInputWith(t *testing.T) {
+	p := &Payload{Command: "ls", FilePath: "a.go", ToolInput: map[string]any{"file_path": "b.go"}}
+	input := p.ToolInputWith("command", "file_path")
+	if input["command"] != "ls" || input["file_path"] != "b.go" {
+		t.Errorf("Expected command added and file_path kept, got %v", input)
+	}
+	if _, ok := p.ToolInput["command"]; ok {
+		t.Error("Expected payload tool input to be unchanged")
+	}
+	if input := (&Payload{}).ToolInputWith("command", ""); input != nil {
+		t.Errorf("Expected nil input, got %v", input)
+	}
+}
+
+func TestRegistrySimulator(t *testing.T) {
+	r := NewAdapterRegistry()
+	r.Register(&mockAdapter{name: "mock"})
+
+	if _, err := r.Simulator("unknown"); !errors.Is(err, ErrUnsupportedEvent) {
+		t.Errorf("Expected ErrUnsupportedEvent, got %v", err)
+	}
+	if _, err := r.Simulator("mock"); !errors.Is(err, ErrRuntimeNotSupported) {
+		t.Errorf("Expected ErrRuntimeNotSupported, got %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"runtime"
 
 	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/merge"
 )
 
 const (
@@ -167,6 +168,22 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	return cursorCfg
 }
 
+// Lossiness reports the canonical fields that the Cursor format drops or degrades.
+// Cursor hooks are plain commands without matchers, prompts or timeouts.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	return core.DroppedFields(AdapterName, a.SupportedEvents(), cfg, core.FieldVersion)
+}
+
+// Merge writes cfg into an existing Cursor settings file, replacing only
+// the version and hooks keys and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "version", "hooks")
+}
+
 // ProjectConfigPath returns the project hooks config path.
 func ProjectConfigPath() string {
 	return filepath.Join(ProjectConfigDir, ConfigFileName)
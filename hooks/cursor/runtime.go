@@ -0,0 +1,207 @@
+package cursor
+
+import (
+	"encoding/json"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+// Payload is the JSON Cursor writes to a hook's stdin. Which fields are set
+// depends on the event.
+type Payload struct {
+	ConversationID string          `json:"conversation_id"`
+	GenerationID   string          `json:"generation_id,omitempty"`
+	HookEventName  CursorEvent     `json:"hook_event_name"`
+	WorkspaceRoots []string        `json:"workspace_roots,omitempty"`
+	Command        string          `json:"command,omitempty"`
+	Cwd            string          `json:"cwd,omitempty"`
+	Output         string          `json:"output,omitempty"`
+	ToolName       string          `json:"tool_name,omitempty"`
+	ToolInput      json.RawMessage `json:"tool_input,omitempty"`
+	ResultJSON     json.RawMessage `json:"result_json,omitempty"`
+	FilePath       string          `json:"file_path,omitempty"`
+	Content        string          `json:"content,omitempty"`
+	Edits          []any           `json:"edits,omitempty"`
+	Prompt         string          `json:"prompt,omitempty"`
+	Text           string          `json:"text,omitempty"`
+	Status         string          `json:"status,omitempty"`
+}
+
+// Output is the JSON a Cursor hook writes to stdout.
+type Output struct {
+	Permission      string `json:"permission,omitempty"`
+	Continue        *bool  `json:"continue,omitempty"`
+	UserMessage     string `json:"user_message,omitempty"`
+	AgentMessage    string `json:"agent_message,omitempty"`
+	FollowupMessage string `json:"followup_message,omitempty"`
+}
+
+// Detect reports whether a payload was sent by Cursor.
+func (a *Adapter) Detect(fields map[string]json.RawMessage) bool {
+	_, ok := reverseEventMapping[CursorEvent(core.StringField(fields, "hook_event_name"))]
+	return ok && core.HasField(fields, "conversation_id")
+}
+
+// DecodePayload converts a Cursor hook payload to canonical form.
+func (a *Adapter) DecodePayload(data []byte, nativeEvent string) (*core.Payload, error) {
+	var in Payload
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	if nativeEvent != "" {
+		in.HookEventName = CursorEvent(nativeEvent)
+	}
+	event, ok := reverseEventMapping[in.HookEventName]
+	if !ok {
+		return nil, &core.ParseError{Format: AdapterName, Err: core.ErrUnsupportedEvent}
+	}
+
+	p := &core.Payload{
+		Adapter:     AdapterName,
+		Event:       event,
+		NativeEvent: string(in.HookEventName),
+		SessionID:   in.ConversationID,
+		Cwd:         in.Cwd,
+		ToolName:    in.ToolName,
+		Command:     in.Command,
+		FilePath:    in.FilePath,
+		Prompt:      in.Prompt,
+		Raw:         data,
+	}
+	if p.Cwd == "" && len(in.WorkspaceRoots) > 0 {
+		p.Cwd = in.WorkspaceRoots[0]
+	}
+	p.ToolInput = toolInput(in.ToolInput)
+	switch {
+	case in.Output != "":
+		p.ToolOutput = in.Output
+	case len(in.ResultJSON) > 0:
+		p.ToolOutput = in.ResultJSON
+	case in.Edits != nil:
+		p.ToolOutput = in.Edits
+	}
+	return p, nil
+}
+
+// EncodeDecision serializes a decision as Cursor hook output. Shell and MCP
+// calls take allow, deny or ask; file reads take allow or deny; prompts
+// are continued or not; and a denied stop is sent back to the agent as a
+// follow-up message.
+func (a *Adapter) EncodeDecision(p *core.Payload, d core.Decision) (*core.Response, error) {
+	event := CursorEvent(p.NativeEvent)
+	if d.Action == "" {
+		return &core.Response{}, nil
+	}
+	unsupported := &core.DecisionError{Format: AdapterName, Event: p.NativeEvent, Action: d.Action}
+	if d.Action == core.ActionModify {
+		return nil, unsupported
+	}
+
+	switch event {
+	case BeforeShellExecution, BeforeMCPExecution:
+		return core.JSONResponse(Output{Permission: string(d.Action), UserMessage: d.Reason, AgentMessage: d.Reason})
+	case BeforeReadFile, BeforeTabFileRead:
+		permission := core.ActionAllow
+		if d.Action != core.ActionAllow {
+			permission = core.ActionDeny
+		}
+		return core.JSONResponse(Output{Permission: string(permission), UserMessage: d.Reason, AgentMessage: d.Reason})
+	case BeforeSubmitPrompt:
+		cont := d.Action == core.ActionAllow
+		return core.JSONResponse(Output{Continue: &cont, UserMessage: d.Reason})
+	case Stop:
+		if d.Action == core.ActionAllow {
+			return &core.Response{}, nil
+		}
+		return core.JSONResponse(Output{FollowupMessage: d.Reason})
+	}
+
+	if d.Action == core.ActionAllow {
+		return &core.Response{}, nil
+	}
+	return nil, unsupported
+}
+
+// toolInput decodes MCP tool input, which Cursor sends either as an object
+// or as a JSON-encoded string.
+func toolInput(raw json.RawMessage) map[string]any {
+	if len(raw) == 0 {
+		return nil
+	}
+	var input map[string]any
+	if json.Unmarshal(raw, &input) == nil {
+		return input
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil && json.Unmarshal([]byte(s), &input) == nil {
+		return input
+	}
+	return nil
+}
+
+// EncodePayload renders a canonical payload as Cursor's hook stdin.
+func (a *Adapter) EncodePayload(p *core.Payload) ([]byte, string, error) {
+	event := CursorEvent(p.NativeEvent)
+	if event == "" {
+		if event = eventMapping[p.Event]; event == "" {
+			return nil, "", &core.ConversionError{To: AdapterName, Event: p.Event, Err: core.ErrUnsupportedEvent}
+		}
+	}
+	out := Payload{
+		ConversationID: p.SessionID,
+		HookEventName:  event,
+		Command:        p.Command,
+		Cwd:            p.Cwd,
+		ToolName:       p.ToolName,
+		FilePath:       p.FilePath,
+		Prompt:         p.Prompt,
+	}
+	if p.Cwd != "" {
+		out.WorkspaceRoots = []string{p.Cwd}
+	}
+	if p.ToolInput != nil {
+		input, err := json.Marshal(p.ToolInput)
+		if err != nil {
+			return nil, "", err
+		}
+		out.ToolInput = input
+	}
+	if s, ok := p.ToolOutput.(string); ok {
+		out.Output = s
+	}
+	data, err := json.Marshal(out)
+	return data, string(event), err
+}
+
+// Matches reports whether Cursor runs an entry registered for event when it
+// sends p. Cursor hooks have no matcher, so every entry for the event runs.
+func (a *Adapter) Matches(event core.Event, entry core.HookEntry, p *core.Payload) bool {
+	native, ok := eventMapping[event]
+	return ok && string(native) == p.NativeEvent
+}
+
+// DecodeDecision reads a Cursor hook's response. Exit code 2 denies.
+func (a *Adapter) DecodeDecision(p *core.Payload, resp *core.Response) core.Decision {
+	if resp.ExitCode == core.ExitBlock {
+		return core.Decision{Action: core.ActionDeny, Reason: resp.Stderr}
+	}
+	var out Output
+	if resp.ExitCode != 0 || !core.DecodeJSON(resp, &out) {
+		return core.Decision{}
+	}
+	reason := out.AgentMessage
+	if reason == "" {
+		reason = out.UserMessage
+	}
+	switch {
+	case out.Permission != "":
+		return core.Decision{Action: core.Action(out.Permission), Reason: reason}
+	case out.Continue != nil && !*out.Continue:
+		return core.Decision{Action: core.ActionDeny, Reason: reason}
+	case out.Continue != nil:
+		return core.Decision{Action: core.ActionAllow}
+	case out.FollowupMessage != "":
+		return core.Decision{Action: core.ActionDeny, Reason: out.FollowupMessage}
+	}
+	return core.Decision{}
+}
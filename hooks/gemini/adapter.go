@@ -0,0 +1,282 @@
+package gemini
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/merge"
+	"github.com/agentplexus/assistantkit/vocab"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "gemini"
+
+	// SettingsFileName is the settings file name containing hooks.
+	SettingsFileName = "settings.json"
+
+	// ProjectConfigDir is the project config directory.
+	ProjectConfigDir = ".gemini"
+)
+
+// Adapter implements core.Adapter for Gemini CLI hooks.
+type Adapter struct{}
+
+// NewAdapter creates a new Gemini hooks adapter.
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Name returns the adapter name.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns the default config file paths for Gemini hooks.
+func (a *Adapter) DefaultPaths() []string {
+	paths := []string{
+		filepath.Join(ProjectConfigDir, SettingsFileName),
+	}
+
+	// User config
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ProjectConfigDir, SettingsFileName))
+	}
+
+	// System config
+	switch runtime.GOOS {
+	case "darwin":
+		paths = append(paths, filepath.Join("/Library/Application Support/GeminiCli", SettingsFileName))
+	case "linux":
+		paths = append(paths, filepath.Join("/etc/gemini-cli", SettingsFileName))
+	case "windows":
+		paths = append(paths, filepath.Join("C:\\ProgramData\\gemini-cli", SettingsFileName))
+	}
+
+	return paths
+}
+
+// SupportedEvents returns the events supported by Gemini CLI.
+func (a *Adapter) SupportedEvents() []core.Event {
+	return []core.Event{
+		core.BeforeFileRead, core.AfterFileRead,
+		core.BeforeFileWrite, core.AfterFileWrite,
+		core.BeforeCommand, core.AfterCommand,
+		core.BeforeMCP, core.AfterMCP,
+		core.BeforeTool, core.AfterTool,
+		core.BeforePrompt,
+		core.OnStop, core.OnSessionStart, core.OnSessionEnd,
+		core.OnNotification, core.BeforeCompact,
+	}
+}
+
+// Parse parses Gemini hooks config data into the canonical format.
+func (a *Adapter) Parse(data []byte) (*core.Config, error) {
+	var geminiCfg Config
+	if err := json.Unmarshal(data, &geminiCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return a.ToCore(&geminiCfg), nil
+}
+
+// Marshal converts canonical config to Gemini format.
+func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	geminiCfg := a.FromCore(cfg)
+	return json.MarshalIndent(geminiCfg, "", "  ")
+}
+
+// ReadFile reads a Gemini hooks config file.
+func (a *Adapter) ReadFile(path string) (*core.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Path: path, Err: err}
+	}
+	cfg, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteFile writes canonical config to a Gemini format file.
+func (a *Adapter) WriteFile(cfg *core.Config, path string) error {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	return nil
+}
+
+// ToCore converts Gemini hooks config to canonical format.
+func (a *Adapter) ToCore(geminiCfg *Config) *core.Config {
+	cfg := core.NewConfig()
+
+	for geminiEvent, entries := range geminiCfg.Hooks {
+		for _, entry := range entries {
+			canonicalEvent := a.geminiToCanonicalEvent(geminiEvent, entry.Matcher)
+			if canonicalEvent == "" {
+				continue
+			}
+
+			var coreHooks []core.Hook
+			for _, h := range entry.Hooks {
+				coreHooks = append(coreHooks, core.Hook{
+					Type:    core.HookTypeCommand,
+					Command: h.Command,
+					Timeout: secondsFromMillis(h.Timeout),
+				})
+			}
+
+			cfg.Hooks[canonicalEvent] = append(cfg.Hooks[canonicalEvent], core.HookEntry{
+				Matcher: core.CanonicalMatcher(vocab.Gemini, canonicalEvent, entry.Matcher),
+				Hooks:   coreHooks,
+			})
+		}
+	}
+
+	return cfg
+}
+
+// FromCore converts canonical config to Gemini format. Prompt hooks are
+// skipped since Gemini CLI only runs commands.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	geminiCfg := NewConfig()
+
+	for _, event := range core.AllEvents() {
+		geminiEvent, matcher := a.canonicalToGeminiEvent(event)
+		if geminiEvent == "" {
+			continue // Event not supported by Gemini
+		}
+
+		for _, entry := range cfg.Hooks[event] {
+			m := core.NativeMatcher(vocab.Gemini, event, entry.Matcher)
+			if m == "" {
+				m = matcher
+			}
+
+			var geminiHooks []Hook
+			for _, h := range entry.Hooks {
+				if h.Command == "" {
+					continue
+				}
+				geminiHooks = append(geminiHooks, Hook{
+					Type:    "command",
+					Command: h.Command,
+					Timeout: h.Timeout * 1000,
+				})
+			}
+			if len(geminiHooks) == 0 {
+				continue
+			}
+
+			geminiCfg.Hooks[geminiEvent] = append(geminiCfg.Hooks[geminiEvent], HookEntry{
+				Matcher: m,
+				Hooks:   geminiHooks,
+			})
+		}
+	}
+
+	return geminiCfg
+}
+
+// Lossiness reports the canonical fields that the Gemini format drops or degrades.
+// As with Claude, a matcher that names a tool Gemini does not know is read
+// back as an MCP event.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, a.SupportedEvents(), cfg,
+		core.FieldMatcher, core.FieldTimeout)
+	losses = append(losses, core.MovedEntries(AdapterName, cfg, a.readBack)...)
+	return append(losses, core.TranslatedMatchers(AdapterName, a.SupportedEvents(), cfg, vocab.Gemini)...)
+}
+
+// Merge writes cfg into an existing Gemini settings file, replacing only
+// the hooks key and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "hooks")
+}
+
+// readBack returns the canonical event an entry is read back as.
+func (a *Adapter) readBack(event core.Event, entry core.HookEntry) core.Event {
+	geminiEvent, matcher := a.canonicalToGeminiEvent(event)
+	if geminiEvent == "" {
+		return ""
+	}
+	if entry.Matcher != "" {
+		matcher = core.NativeMatcher(vocab.Gemini, event, entry.Matcher)
+	}
+	return a.geminiToCanonicalEvent(geminiEvent, matcher)
+}
+
+// geminiToCanonicalEvent converts a Gemini event to canonical event.
+func (a *Adapter) geminiToCanonicalEvent(geminiEvent GeminiEvent, matcher string) core.Event {
+	if event, ok := reverseEventMapping[geminiEvent]; ok {
+		return event
+	}
+
+	switch geminiEvent {
+	case BeforeTool:
+		if event, ok := matcherToCanonicalEventBefore[matcher]; ok {
+			return event
+		}
+		// Default to BeforeMCP for unknown matchers (likely MCP tools)
+		return core.BeforeMCP
+	case AfterTool:
+		if event, ok := matcherToCanonicalEventAfter[matcher]; ok {
+			return event
+		}
+		return core.AfterMCP
+	}
+
+	return ""
+}
+
+// canonicalToGeminiEvent converts a canonical event to Gemini event and matcher.
+func (a *Adapter) canonicalToGeminiEvent(event core.Event) (GeminiEvent, string) {
+	if geminiEvent, ok := eventMapping[event]; ok {
+		return geminiEvent, canonicalEventToMatcher[event]
+	}
+	return "", ""
+}
+
+// secondsFromMillis converts a Gemini timeout to whole seconds, rounding up
+// so that a short timeout is not read back as no timeout.
+func secondsFromMillis(ms int) int {
+	if ms <= 0 {
+		return 0
+	}
+	return (ms + 999) / 1000
+}
+
+// ReadProjectConfig reads the project-level .gemini/settings.json hooks.
+func ReadProjectConfig() (*core.Config, error) {
+	adapter := NewAdapter()
+	return adapter.ReadFile(filepath.Join(ProjectConfigDir, SettingsFileName))
+}
+
+// ReadUserConfig reads the user-level ~/.gemini/settings.json hooks.
+func ReadUserConfig() (*core.Config, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	adapter := NewAdapter()
+	return adapter.ReadFile(filepath.Join(home, ProjectConfigDir, SettingsFileName))
+}
+
+// init registers the adapter with the default registry.
+func init() {
+	core.Register(NewAdapter())
+}
@@ -0,0 +1,158 @@
+package gemini
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+func TestAdapterName(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter.Name() != "gemini" {
+		t.Errorf("Expected name 'gemini', got %q", adapter.Name())
+	}
+}
+
+func TestAdapterDefaultPaths(t *testing.T) {
+	paths := NewAdapter().DefaultPaths()
+	if len(paths) == 0 || paths[0] != filepath.Join(ProjectConfigDir, SettingsFileName) {
+		t.Errorf("Expected project settings first, got %v", paths)
+	}
+}
+
+func TestAdapterParse(t *testing.T) {
+	data := `{
+		"theme": "dark",
+		"hooks": {
+			"BeforeTool": [
+				{"matcher": "run_shell_command", "hooks": [{"name": "guard", "type": "command", "command": "./guard.sh", "timeout": 1500}]},
+				{"matcher": "write_file|replace", "hooks": [{"type": "command", "command": "./lint.sh"}]},
+				{"matcher": "mcp__github__search", "hooks": [{"type": "command", "command": "./mcp.sh"}]},
+				{"hooks": [{"type": "command", "command": "./any.sh"}]}
+			],
+			"BeforeAgent": [{"hooks": [{"type": "command", "command": "./prompt.sh"}]}],
+			"PreCompress": [{"hooks": [{"type": "command", "command": "./compact.sh"}]}]
+		}
+	}`
+
+	cfg, err := NewAdapter().Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		event   core.Event
+		command string
+	}{
+		{core.BeforeCommand, "./guard.sh"},
+		{core.BeforeFileWrite, "./lint.sh"},
+		{core.BeforeMCP, "./mcp.sh"},
+		{core.BeforeTool, "./any.sh"},
+		{core.BeforePrompt, "./prompt.sh"},
+		{core.BeforeCompact, "./compact.sh"},
+	}
+	for _, tt := range tests {
+		entries := cfg.GetHooks(tt.event)
+		if len(entries) != 1 || entries[0].Hooks[0].Command != tt.command {
+			t.Errorf("Expected %s to run %s, got %+v", tt.event, tt.command, entries)
+		}
+	}
+
+	// Matchers are read back with canonical tool names.
+	if m := cfg.GetHooks(core.BeforeFileWrite)[0].Matcher; m != "Write|Edit" {
+		t.Errorf("Expected matcher Write|Edit, got %q", m)
+	}
+
+	// Timeouts in milliseconds are rounded up to seconds.
+	if timeout := cfg.GetHooks(core.BeforeCommand)[0].Hooks[0].Timeout; timeout != 2 {
+		t.Errorf("Expected timeout 2, got %d", timeout)
+	}
+}
+
+func TestAdapterParseInvalid(t *testing.T) {
+	if _, err := NewAdapter().Parse([]byte(`{invalid`)); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestAdapterFromCore(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("./guard.sh").WithTimeout(30))
+	cfg.AddHook(core.BeforeMCP, core.NewCommandHook("./mcp.sh"))
+	cfg.AddHook(core.OnStop, core.NewCommandHook("./stop.sh"))
+	cfg.AddHook(core.OnStop, core.NewPromptHook("Is the task done?"))
+
+	geminiCfg := NewAdapter().FromCore(cfg)
+
+	before := geminiCfg.Hooks[BeforeTool]
+	if len(before) != 2 || before[0].Matcher != "run_shell_command" || before[1].Matcher != "mcp__.*" {
+		t.Fatalf("Unexpected BeforeTool entries: %+v", before)
+	}
+	if before[0].Hooks[0].Timeout != 30000 {
+		t.Errorf("Expected timeout 30000ms, got %d", before[0].Hooks[0].Timeout)
+	}
+	if hooks := geminiCfg.Hooks[AfterAgent]; len(hooks) != 1 || hooks[0].Hooks[0].Command != "./stop.sh" {
+		t.Errorf("Expected only the command hook under AfterAgent, got %+v", hooks)
+	}
+}
+
+func TestAdapterLossiness(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddHook(core.OnPermission, core.NewCommandHook("./perm.sh"))
+	cfg.AddHookWithMatcher(core.BeforeCommand, "Bash", core.NewCommandHook("./bash.sh"))
+	cfg.AddHookWithMatcher(core.BeforeTool, "Task", core.NewCommandHook("./task.sh"))
+	cfg.AddHook(core.OnStop, core.NewPromptHook("Done?"))
+
+	var got []string
+	for _, loss := range NewAdapter().Lossiness(cfg) {
+		got = append(got, loss.String())
+	}
+	expected := []string{
+		"gemini: hooks.on_permission dropped: event is not supported",
+		"gemini: hooks.on_stop[0].hooks[0] dropped: prompt hooks are not supported",
+		`gemini: hooks.before_tool[0].matcher degraded: matcher "Task" is read back as event before_mcp`,
+		`gemini: hooks.before_tool[0].matcher unsupported: tool "Task" does not exist on gemini`,
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected losses:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestAdapterMergeKeepsSettings(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddHook(core.OnSessionStart, core.NewCommandHook("./start.sh"))
+
+	data, err := NewAdapter().Merge(cfg, []byte(`{"theme": "dark", "hooks": {"SessionEnd": []}}`))
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"theme": "dark"`) || strings.Contains(string(data), "SessionEnd") {
+		t.Errorf("Expected theme kept and hooks replaced, got %s", data)
+	}
+}
+
+func TestAdapterReadWriteFile(t *testing.T) {
+	adapter := NewAdapter()
+	path := filepath.Join(t.TempDir(), SettingsFileName)
+
+	cfg := core.NewConfig()
+	cfg.AddHook(core.OnSessionEnd, core.NewCommandHook("./end.sh"))
+	if err := adapter.WriteFile(cfg, path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	got, err := adapter.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if got.HookCount() != 1 {
+		t.Errorf("Expected 1 hook, got %d", got.HookCount())
+	}
+
+	if _, err := adapter.ReadFile(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not-exist error, got %v", err)
+	}
+}
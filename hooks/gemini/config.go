@@ -0,0 +1,145 @@
+// Package gemini provides an adapter for Gemini CLI hooks configuration.
+//
+// Gemini CLI hooks are configured in the "hooks" section of settings.json files:
+//   - Project: .gemini/settings.json
+//   - User: ~/.gemini/settings.json
+//   - System: /etc/gemini-cli/settings.json (Linux)
+//
+// Gemini CLI hook events:
+//   - BeforeTool: Before tool execution (can block)
+//   - AfterTool: After tool execution
+//   - BeforeAgent: After the user submits a prompt, before planning
+//   - AfterAgent: When the agent loop ends
+//   - SessionStart: At session start
+//   - SessionEnd: At session end
+//   - PreCompress: Before chat history is compressed
+//   - Notification: When notifications are sent
+//
+// Tool events are filtered by a regular expression matched against Gemini
+// tool names (e.g., "run_shell_command", "write_file|replace"). Timeouts are
+// in milliseconds.
+package gemini
+
+import "github.com/agentplexus/assistantkit/hooks/core"
+
+// GeminiEvent represents Gemini CLI hook event names.
+type GeminiEvent string
+
+const (
+	BeforeTool   GeminiEvent = "BeforeTool"
+	AfterTool    GeminiEvent = "AfterTool"
+	BeforeAgent  GeminiEvent = "BeforeAgent"
+	AfterAgent   GeminiEvent = "AfterAgent"
+	SessionStart GeminiEvent = "SessionStart"
+	SessionEnd   GeminiEvent = "SessionEnd"
+	PreCompress  GeminiEvent = "PreCompress"
+	Notification GeminiEvent = "Notification"
+)
+
+// Config represents the hooks section of Gemini CLI's settings.json.
+type Config struct {
+	Hooks map[GeminiEvent][]HookEntry `json:"hooks,omitempty"`
+}
+
+// HookEntry represents a Gemini hook entry with matcher and hooks.
+type HookEntry struct {
+	// Matcher is a regular expression matched against tool names.
+	Matcher string `json:"matcher,omitempty"`
+
+	// Hooks is the list of hooks to execute.
+	Hooks []Hook `json:"hooks"`
+}
+
+// Hook represents a single Gemini hook definition.
+type Hook struct {
+	// Name identifies the hook in logs and the /hooks command.
+	Name string `json:"name,omitempty"`
+
+	// Type is always "command".
+	Type string `json:"type"`
+
+	// Command is the shell command to execute.
+	Command string `json:"command"`
+
+	// Description explains what the hook does.
+	Description string `json:"description,omitempty"`
+
+	// Timeout in milliseconds for hook execution.
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// NewConfig creates a new empty Gemini hooks config.
+func NewConfig() *Config {
+	return &Config{
+		Hooks: make(map[GeminiEvent][]HookEntry),
+	}
+}
+
+// eventMapping maps canonical events to Gemini events.
+var eventMapping = map[core.Event]GeminiEvent{
+	core.BeforeFileRead:  BeforeTool, // with matcher "read_file|read_many_files"
+	core.AfterFileRead:   AfterTool,
+	core.BeforeFileWrite: BeforeTool, // with matcher "write_file|replace"
+	core.AfterFileWrite:  AfterTool,
+	core.BeforeCommand:   BeforeTool, // with matcher "run_shell_command"
+	core.AfterCommand:    AfterTool,
+	core.BeforeMCP:       BeforeTool, // with matcher "mcp__.*"
+	core.AfterMCP:        AfterTool,
+	core.BeforeTool:      BeforeTool, // without matcher
+	core.AfterTool:       AfterTool,
+	core.BeforePrompt:    BeforeAgent,
+	core.OnStop:          AfterAgent,
+	core.OnSessionStart:  SessionStart,
+	core.OnSessionEnd:    SessionEnd,
+	core.OnNotification:  Notification,
+	core.BeforeCompact:   PreCompress,
+}
+
+// reverseEventMapping maps Gemini events back to canonical events.
+// Note: BeforeTool/AfterTool need matcher context to determine exact canonical event.
+var reverseEventMapping = map[GeminiEvent]core.Event{
+	BeforeAgent:  core.BeforePrompt,
+	AfterAgent:   core.OnStop,
+	SessionStart: core.OnSessionStart,
+	SessionEnd:   core.OnSessionEnd,
+	Notification: core.OnNotification,
+	PreCompress:  core.BeforeCompact,
+}
+
+// canonicalEventToMatcher maps canonical tool events to Gemini matchers.
+var canonicalEventToMatcher = map[core.Event]string{
+	core.BeforeFileRead:  "read_file|read_many_files",
+	core.AfterFileRead:   "read_file|read_many_files",
+	core.BeforeFileWrite: "write_file|replace",
+	core.AfterFileWrite:  "write_file|replace",
+	core.BeforeCommand:   "run_shell_command",
+	core.AfterCommand:    "run_shell_command",
+	core.BeforeMCP:       "mcp__.*",
+	core.AfterMCP:        "mcp__.*",
+}
+
+// matcherToCanonicalEventBefore maps matchers to canonical events for BeforeTool.
+var matcherToCanonicalEventBefore = map[string]core.Event{
+	"":                          core.BeforeTool,
+	"*":                         core.BeforeTool,
+	"read_file":                 core.BeforeFileRead,
+	"read_many_files":           core.BeforeFileRead,
+	"read_file|read_many_files": core.BeforeFileRead,
+	"write_file":                core.BeforeFileWrite,
+	"replace":                   core.BeforeFileWrite,
+	"write_file|replace":        core.BeforeFileWrite,
+	"run_shell_command":         core.BeforeCommand,
+}
+
+// matcherToCanonicalEventAfter maps matchers to canonical events for AfterTool.
+var matcherToCanonicalEventAfter = map[string]core.Event{
+	"":                          core.AfterTool,
+	"*":                         core.AfterTool,
+	"read_file":                 core.AfterFileRead,
+	"read_many_files":           core.AfterFileRead,
+	"read_file|read_many_files": core.AfterFileRead,
+	"write_file":                core.AfterFileWrite,
+	"replace":                   core.AfterFileWrite,
+	"write_file|replace":        core.AfterFileWrite,
+	"run_shell_command":         core.AfterCommand,
+}
@@ -0,0 +1,222 @@
+package gemini
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/vocab"
+)
+
+// Payload is the JSON Gemini CLI writes to a hook's stdin.
+type Payload struct {
+	SessionID      string         `json:"session_id"`
+	TranscriptPath string         `json:"transcript_path,omitempty"`
+	Cwd            string         `json:"cwd,omitempty"`
+	HookEventName  GeminiEvent    `json:"hook_event_name"`
+	Timestamp      string         `json:"timestamp,omitempty"`
+	ToolName       string         `json:"tool_name,omitempty"`
+	ToolInput      map[string]any `json:"tool_input,omitempty"`
+	ToolResponse   any            `json:"tool_response,omitempty"`
+	Prompt         string         `json:"prompt,omitempty"`
+}
+
+// Output is the JSON a Gemini CLI hook writes to stdout.
+type Output struct {
+	Continue           *bool               `json:"continue,omitempty"`
+	StopReason         string              `json:"stopReason,omitempty"`
+	Decision           string              `json:"decision,omitempty"`
+	Reason             string              `json:"reason,omitempty"`
+	HookSpecificOutput *HookSpecificOutput `json:"hookSpecificOutput,omitempty"`
+}
+
+// HookSpecificOutput holds the BeforeTool part of a hook's output.
+type HookSpecificOutput struct {
+	HookEventName GeminiEvent    `json:"hookEventName"`
+	ToolInput     map[string]any `json:"tool_input,omitempty"`
+}
+
+// Detect reports whether a payload was sent by Gemini CLI. Gemini always
+// sends a timestamp, which tells its SessionStart, SessionEnd and
+// Notification payloads apart from Claude Code's.
+func (a *Adapter) Detect(fields map[string]json.RawMessage) bool {
+	if !core.HasField(fields, "timestamp") {
+		return false
+	}
+	_, ok := eventNames[GeminiEvent(core.StringField(fields, "hook_event_name"))]
+	return ok
+}
+
+// DecodePayload converts a Gemini CLI hook payload to canonical form.
+func (a *Adapter) DecodePayload(data []byte, nativeEvent string) (*core.Payload, error) {
+	var in Payload
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	if nativeEvent != "" {
+		in.HookEventName = GeminiEvent(nativeEvent)
+	}
+
+	p := &core.Payload{
+		Adapter:     AdapterName,
+		NativeEvent: string(in.HookEventName),
+		SessionID:   in.SessionID,
+		Cwd:         in.Cwd,
+		ToolName:    in.ToolName,
+		ToolInput:   in.ToolInput,
+		ToolOutput:  in.ToolResponse,
+		Prompt:      in.Prompt,
+		Raw:         data,
+	}
+	switch in.HookEventName {
+	case BeforeTool:
+		p.Event = toolEvent(in.ToolName)
+	case AfterTool:
+		p.Event = toolEvent(in.ToolName).AfterEvent()
+	default:
+		event, ok := reverseEventMapping[in.HookEventName]
+		if !ok {
+			return nil, &core.ParseError{Format: AdapterName, Err: core.ErrUnsupportedEvent}
+		}
+		p.Event = event
+	}
+	p.Command = p.InputString("command")
+	p.FilePath = p.InputString("file_path", "absolute_path")
+	return p, nil
+}
+
+// EncodeDecision serializes a decision as Gemini CLI hook output. Tool
+// calls take allow, deny or ask, and modify rewrites the tool input; agent
+// events and tool results are denied with a reason for the agent; and
+// other events are blocked by stopping the session.
+func (a *Adapter) EncodeDecision(p *core.Payload, d core.Decision) (*core.Response, error) {
+	event := GeminiEvent(p.NativeEvent)
+	if d.Action == "" {
+		return &core.Response{}, nil
+	}
+
+	switch event {
+	case BeforeTool:
+		out := Output{Decision: string(d.Action), Reason: d.Reason}
+		if d.Action == core.ActionModify {
+			out.Decision = string(core.ActionAllow)
+			out.HookSpecificOutput = &HookSpecificOutput{HookEventName: event, ToolInput: d.Input}
+		}
+		return core.JSONResponse(out)
+	}
+
+	switch d.Action {
+	case core.ActionAllow:
+		return &core.Response{}, nil
+	case core.ActionModify:
+		return nil, &core.DecisionError{Format: AdapterName, Event: p.NativeEvent, Action: d.Action}
+	}
+	switch event {
+	case AfterTool, BeforeAgent, AfterAgent:
+		return core.JSONResponse(Output{Decision: string(core.ActionDeny), Reason: d.Reason})
+	}
+	stop := false
+	return core.JSONResponse(Output{Continue: &stop, StopReason: d.Reason})
+}
+
+// eventNames holds every Gemini CLI hook event.
+var eventNames = map[GeminiEvent]bool{
+	BeforeTool: true, AfterTool: true, BeforeAgent: true, AfterAgent: true,
+	SessionStart: true, SessionEnd: true, PreCompress: true, Notification: true,
+}
+
+// toolEvent returns the canonical before event for a call of the named tool.
+func toolEvent(name string) core.Event {
+	if event, ok := matcherToCanonicalEventBefore[name]; ok {
+		return event
+	}
+	if strings.HasPrefix(name, "mcp__") {
+		return core.BeforeMCP
+	}
+	return core.BeforeTool
+}
+
+// toolNames maps canonical tool events to the tool named in simulated payloads.
+var toolNames = map[core.Event]string{
+	core.BeforeFileRead:  "read_file",
+	core.AfterFileRead:   "read_file",
+	core.BeforeFileWrite: "write_file",
+	core.AfterFileWrite:  "write_file",
+	core.BeforeCommand:   "run_shell_command",
+	core.AfterCommand:    "run_shell_command",
+	core.BeforeMCP:       "mcp__test__tool",
+	core.AfterMCP:        "mcp__test__tool",
+}
+
+// EncodePayload renders a canonical payload as Gemini CLI's hook stdin.
+func (a *Adapter) EncodePayload(p *core.Payload) ([]byte, string, error) {
+	event := GeminiEvent(p.NativeEvent)
+	if event == "" {
+		if event, _ = a.canonicalToGeminiEvent(p.Event); event == "" {
+			return nil, "", &core.ConversionError{To: AdapterName, Event: p.Event, Err: core.ErrUnsupportedEvent}
+		}
+	}
+	out := Payload{
+		SessionID:     p.SessionID,
+		Cwd:           p.Cwd,
+		HookEventName: event,
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+		Prompt:        p.Prompt,
+	}
+	if event == BeforeTool || event == AfterTool {
+		out.ToolName = p.ToolName
+		if out.ToolName == "" {
+			out.ToolName = toolNames[p.Event]
+		}
+		out.ToolInput = p.ToolInputWith("command", "file_path")
+		out.ToolResponse = p.ToolOutput
+	}
+	data, err := json.Marshal(out)
+	return data, string(event), err
+}
+
+// Matches reports whether Gemini CLI runs an entry registered for event
+// when it sends p. Tool events match the entry's matcher as a regular
+// expression against the tool name.
+func (a *Adapter) Matches(event core.Event, entry core.HookEntry, p *core.Payload) bool {
+	native, matcher := a.canonicalToGeminiEvent(event)
+	if native == "" || string(native) != p.NativeEvent {
+		return false
+	}
+	if entry.Matcher != "" {
+		matcher = core.NativeMatcher(vocab.Gemini, event, entry.Matcher)
+	}
+	if native == BeforeTool || native == AfterTool {
+		return core.MatchToolRegexp(matcher, p.ToolName)
+	}
+	return true
+}
+
+// DecodeDecision reads a Gemini CLI hook's response. Exit code 2 blocks
+// with the reason on stderr.
+func (a *Adapter) DecodeDecision(p *core.Payload, resp *core.Response) core.Decision {
+	if resp.ExitCode == core.ExitBlock {
+		return core.Decision{Action: core.ActionDeny, Reason: resp.Stderr}
+	}
+	var out Output
+	if resp.ExitCode != 0 || !core.DecodeJSON(resp, &out) {
+		return core.Decision{}
+	}
+	switch out.Decision {
+	case "deny", "block":
+		return core.Decision{Action: core.ActionDeny, Reason: out.Reason}
+	case "ask":
+		return core.Decision{Action: core.ActionAsk, Reason: out.Reason}
+	case "allow", "approve":
+		d := core.Decision{Action: core.ActionAllow, Reason: out.Reason}
+		if h := out.HookSpecificOutput; h != nil && h.ToolInput != nil {
+			d.Action, d.Input = core.ActionModify, h.ToolInput
+		}
+		return d
+	}
+	if out.Continue != nil && !*out.Continue {
+		return core.Decision{Action: core.ActionDeny, Reason: out.StopReason}
+	}
+	return core.Decision{}
+}
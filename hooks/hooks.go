@@ -8,11 +8,21 @@
 //   - Claude Code (.claude/settings.json)
 //   - Cursor IDE (.cursor/hooks.json)
 //   - Windsurf / Codeium (.windsurf/hooks.json)
+//   - OpenAI Codex CLI (.codex/hooks.json)
+//   - Gemini CLI (.gemini/settings.json)
+//   - Kiro CLI (.kiro/agents/<agent>.json)
+//   - VS Code / GitHub Copilot (.github/hooks/hooks.json)
 //
 // The package provides:
 //   - A canonical Config type that represents hook configuration
 //   - Adapters for reading/writing tool-specific formats
 //   - Conversion between different tool formats
+//   - Lossiness reports for fields a target format cannot represent
+//   - Merge-aware writes that update only the managed section of existing files
+//   - Canonical hook payloads and decisions for writing hook programs
+//     (see the runtime subpackage)
+//   - Simulation of tool events against a config for testing hooks
+//     (see the hookstest subpackage)
 //
 // Example usage:
 //
@@ -31,10 +41,15 @@ package hooks
 
 import (
 	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/merge"
 
 	// Import adapters to register them
 	_ "github.com/agentplexus/assistantkit/hooks/claude"
+	_ "github.com/agentplexus/assistantkit/hooks/codex"
 	_ "github.com/agentplexus/assistantkit/hooks/cursor"
+	_ "github.com/agentplexus/assistantkit/hooks/gemini"
+	_ "github.com/agentplexus/assistantkit/hooks/kiro"
+	_ "github.com/agentplexus/assistantkit/hooks/vscode"
 	_ "github.com/agentplexus/assistantkit/hooks/windsurf"
 )
 
@@ -57,6 +72,35 @@ type (
 
 	// Adapter is the interface for tool-specific adapters.
 	Adapter = core.Adapter
+
+	// ToolSupport maps tool names to whether they support an event.
+	ToolSupport = core.ToolSupport
+
+	// Loss describes a field that a target format drops or degrades.
+	Loss = core.Loss
+
+	// MergeResult describes the outcome of merging into an existing file.
+	MergeResult = merge.Result
+
+	// Payload is the canonical form of a tool's hook stdin payload.
+	Payload = core.Payload
+
+	// Decision is a hook handler's canonical answer to a payload.
+	Decision = core.Decision
+
+	// Action is the outcome a decision asks for.
+	Action = core.Action
+
+	// Response is a decision serialized for a tool.
+	Response = core.Response
+)
+
+// Decision actions
+const (
+	ActionAllow  = core.ActionAllow
+	ActionDeny   = core.ActionDeny
+	ActionAsk    = core.ActionAsk
+	ActionModify = core.ActionModify
 )
 
 // Hook type constants
@@ -85,6 +129,12 @@ const (
 	AfterMCP  = core.AfterMCP
 )
 
+// Event constants - Any tool
+const (
+	BeforeTool = core.BeforeTool
+	AfterTool  = core.AfterTool
+)
+
 // Event constants - Prompt/Lifecycle
 const (
 	BeforePrompt   = core.BeforePrompt
@@ -98,8 +148,8 @@ const (
 	AfterResponse  = core.AfterResponse  // Cursor
 	AfterThought   = core.AfterThought   // Cursor
 	OnPermission   = core.OnPermission   // Claude
-	OnNotification = core.OnNotification // Claude
-	BeforeCompact  = core.BeforeCompact  // Claude
+	OnNotification = core.OnNotification // Claude, Gemini
+	BeforeCompact  = core.BeforeCompact  // Claude, Gemini
 	OnSubagentStop = core.OnSubagentStop // Claude
 	BeforeTabRead  = core.BeforeTabRead  // Cursor
 	AfterTabEdit   = core.AfterTabEdit   // Cursor
@@ -121,7 +171,7 @@ func NewPromptHook(prompt string) Hook {
 }
 
 // GetAdapter returns an adapter by name from the default registry.
-// Supported names: "claude", "cursor", "windsurf"
+// Supported names: "claude", "codex", "cursor", "gemini", "kiro", "vscode", "windsurf"
 func GetAdapter(name string) (Adapter, bool) {
 	return core.GetAdapter(name)
 }
@@ -132,6 +182,20 @@ func Convert(data []byte, from, to string) ([]byte, error) {
 	return core.Convert(data, from, to)
 }
 
+// Lossiness returns the fields of cfg that the named tool's format drops or
+// degrades. Example: Lossiness(cfg, "cursor")
+func Lossiness(cfg *Config, to string) ([]Loss, error) {
+	return core.DefaultRegistry.Lossiness(cfg, to)
+}
+
+// MergeFile writes cfg to path in the named tool's format, replacing only
+// the hooks in an existing file and keeping all other settings. If dryRun
+// is true, the file is not written and the result only reports the diff.
+// Example: MergeFile(cfg, "claude", ".claude/settings.json", false)
+func MergeFile(cfg *Config, to, path string, dryRun bool) (*MergeResult, error) {
+	return core.DefaultRegistry.MergeFile(cfg, to, path, dryRun)
+}
+
 // AdapterNames returns the names of all registered adapters.
 func AdapterNames() []string {
 	return core.DefaultRegistry.Names()
@@ -141,7 +205,11 @@ func AdapterNames() []string {
 func SupportedTools() []string {
 	return []string{
 		"claude",   // Claude Code
+		"codex",    // OpenAI Codex CLI
 		"cursor",   // Cursor IDE
+		"gemini",   // Gemini CLI
+		"kiro",     // Kiro CLI
+		"vscode",   // VS Code (GitHub Copilot)
 		"windsurf", // Windsurf (Codeium)
 	}
 }
@@ -1,11 +1,12 @@
 package hooks
 
 import (
+	"reflect"
 	"testing"
 )
 
 func TestGetAdapter(t *testing.T) {
-	adapters := []string{"claude", "cursor", "windsurf"}
+	adapters := []string{"claude", "codex", "cursor", "gemini", "kiro", "vscode", "windsurf"}
 
 	for _, name := range adapters {
 		t.Run(name, func(t *testing.T) {
@@ -30,7 +31,7 @@ func TestAdapterNames(t *testing.T) {
 
 func TestSupportedTools(t *testing.T) {
 	tools := SupportedTools()
-	expected := []string{"claude", "cursor", "windsurf"}
+	expected := []string{"claude", "codex", "cursor", "gemini", "kiro", "vscode", "windsurf"}
 
 	if len(tools) != len(expected) {
 		t.Errorf("Expected %d tools, got %d", len(expected), len(tools))
@@ -169,22 +170,82 @@ func TestEventCanBlock(t *testing.T) {
 }
 
 func TestEventToolSupport(t *testing.T) {
-	// BeforeCommand should be supported by all three
-	support := BeforeCommand.GetToolSupport()
-	if !support.Claude || !support.Cursor || !support.Windsurf {
-		t.Error("BeforeCommand should be supported by Claude, Cursor, and Windsurf")
+	tests := []struct {
+		event    Event
+		expected []string
+	}{
+		{BeforeCommand, []string{"claude", "codex", "cursor", "gemini", "kiro", "vscode", "windsurf"}},
+		{BeforeTool, []string{"claude", "codex", "gemini", "kiro", "vscode"}},
+		{OnSessionStart, []string{"claude", "codex", "gemini", "kiro", "vscode"}},
+		{BeforeCompact, []string{"claude", "gemini"}},
+		{AfterResponse, []string{"cursor"}},
 	}
 
-	// OnSessionStart is Claude-only
-	support = OnSessionStart.GetToolSupport()
-	if !support.Claude || support.Cursor || support.Windsurf {
-		t.Error("OnSessionStart should only be supported by Claude")
+	for _, tt := range tests {
+		support := tt.event.GetToolSupport()
+		if got := support.Tools(); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("Expected %s to be supported by %v, got %v", tt.event, tt.expected, got)
+		}
+	}
+
+	// Every registered adapter appears, so unsupported tools are explicit.
+	support := AfterResponse.GetToolSupport()
+	if supported, ok := support["claude"]; !ok || supported {
+		t.Error("Expected AfterResponse to be listed as unsupported by Claude")
+	}
+}
+
+func TestEventToolSupportByTool(t *testing.T) {
+	tests := []struct {
+		event    Event
+		claude   bool
+		cursor   bool
+		windsurf bool
+	}{
+		// File events: Cursor has no AfterFileRead or BeforeFileWrite hook.
+		{BeforeFileRead, true, true, true},
+		{AfterFileRead, true, false, true},
+		{BeforeFileWrite, true, false, true},
+		{AfterFileWrite, true, true, true},
+		{BeforeCommand, true, true, true},
+		{AfterCommand, true, true, true},
+		{BeforeMCP, true, true, true},
+		{AfterMCP, true, true, true},
+		// Claude-only events, among Claude, Cursor and Windsurf.
+		{OnSessionStart, true, false, false},
+		{OnSessionEnd, true, false, false},
+		{OnPermission, true, false, false},
+		{OnNotification, true, false, false},
+		{BeforeCompact, true, false, false},
+		{OnSubagentStop, true, false, false},
+		// Cursor-only events.
+		{AfterResponse, false, true, false},
+		{AfterThought, false, true, false},
+		{BeforeTabRead, false, true, false},
+		{AfterTabEdit, false, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.event), func(t *testing.T) {
+			support := tt.event.GetToolSupport()
+			if got := support.Supports("claude"); got != tt.claude {
+				t.Errorf("Claude support: expected %v, got %v", tt.claude, got)
+			}
+			if got := support.Supports("cursor"); got != tt.cursor {
+				t.Errorf("Cursor support: expected %v, got %v", tt.cursor, got)
+			}
+			if got := support.Supports("windsurf"); got != tt.windsurf {
+				t.Errorf("Windsurf support: expected %v, got %v", tt.windsurf, got)
+			}
+		})
 	}
+}
 
-	// AfterResponse is Cursor-only
-	support = AfterResponse.GetToolSupport()
-	if support.Claude || !support.Cursor || support.Windsurf {
-		t.Error("AfterResponse should only be supported by Cursor")
+func TestEveryEventHasTool(t *testing.T) {
+	for _, event := range AllEvents() {
+		if len(event.GetToolSupport().Tools()) == 0 {
+			t.Errorf("Expected %s to be supported by at least one tool", event)
+		}
 	}
 }
 
@@ -0,0 +1,242 @@
+// Package hookstest replays synthetic events against a hooks configuration
+// to test hook programs without running an AI assistant.
+//
+// Simulate plays the part of a tool: it renders a canonical payload as the
+// JSON the tool would write to stdin, selects the hook entries the tool
+// would run (Claude, Codex and Gemini match tool names against regular
+// expressions; Kiro matches tool names and MCP servers; Cursor, Windsurf
+// and Copilot run every hook for the event), runs each command hook in a
+// sandbox directory with the payload on stdin and the hook's timeout
+// enforced, and reads the responses back as the decision the tool would
+// act on.
+//
+// In tests, Run does the same in a temporary directory:
+//
+//	func TestGuard(t *testing.T) {
+//	    cfg := hooks.NewConfig()
+//	    cfg.AddHookWithMatcher(hooks.BeforeCommand, "Bash", hooks.NewCommandHook("./guard"))
+//	    report := hookstest.Run(t, cfg, "claude", &hooks.Payload{
+//	        Event:   hooks.BeforeCommand,
+//	        Command: "rm -rf /",
+//	    })
+//	    if report.Decision.Action != hooks.ActionDeny {
+//	        t.Errorf("Expected deny, got %q", report.Decision.Action)
+//	    }
+//	}
+package hookstest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/hooks/runtime"
+)
+
+// DefaultTimeout is the timeout for hooks that do not set one, matching
+// the default of most tools.
+const DefaultTimeout = 60 * time.Second
+
+// DefaultSessionID is the session ID sent in payloads that do not set one.
+const DefaultSessionID = "hookstest"
+
+// Options configure a simulation.
+type Options struct {
+	// Dir is the sandbox directory hooks run in and the payload's working
+	// directory. Relative hook working directories are resolved against
+	// it. Defaults to a new temporary directory, removed afterwards.
+	Dir string
+
+	// Env holds extra environment variables ("KEY=value") for hooks, in
+	// addition to the current environment.
+	Env []string
+
+	// Timeout is the timeout for hooks that do not set one. Defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Result is the outcome of running one hook.
+type Result struct {
+	// Event and Matcher identify the hook entry the hook belongs to.
+	Event   core.Event `json:"event"`
+	Matcher string     `json:"matcher,omitempty"`
+	Hook    core.Hook  `json:"hook"`
+
+	// Skipped is set for hooks that cannot be simulated, such as prompt
+	// hooks, which need a model.
+	Skipped bool `json:"skipped,omitempty"`
+
+	ExitCode int           `json:"exit_code"`
+	Stdout   string        `json:"stdout,omitempty"`
+	Stderr   string        `json:"stderr,omitempty"`
+	TimedOut bool          `json:"timed_out,omitempty"`
+	Duration time.Duration `json:"duration"`
+
+	// Decision is the hook's decision as the tool reads it.
+	Decision core.Decision `json:"decision"`
+
+	// Err is set when the hook could not be started.
+	Err error `json:"-"`
+}
+
+// Report is the outcome of a simulated event.
+type Report struct {
+	// Tool is the simulated tool.
+	Tool string `json:"tool"`
+
+	// Payload is the canonical payload as the hooks see it, and Data the
+	// JSON written to their stdin.
+	Payload *core.Payload   `json:"payload"`
+	Data    json.RawMessage `json:"data"`
+
+	// Results holds one result per matching hook, in the order the hooks
+	// ran.
+	Results []Result `json:"results"`
+
+	// Decision is the decision that takes effect when all results are
+	// combined.
+	Decision core.Decision `json:"decision"`
+}
+
+// Simulate sends an event to the hooks in cfg as tool would and reports
+// the resulting decision. Fields not set in p get the tool's defaults; the
+// payload's working directory is always the sandbox.
+func Simulate(cfg *core.Config, tool string, p *core.Payload, opts Options) (*Report, error) {
+	sim, err := core.DefaultRegistry.Simulator(tool)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Dir == "" {
+		dir, err := os.MkdirTemp("", "hookstest-")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+		opts.Dir = dir
+	}
+
+	in := *p
+	in.Cwd = opts.Dir
+	if in.SessionID == "" {
+		in.SessionID = DefaultSessionID
+	}
+	data, nativeEvent, err := sim.EncodePayload(&in)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := sim.DecodePayload(data, nativeEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Tool: tool, Payload: payload, Data: data}
+	if cfg.DisableAllHooks {
+		return report, nil
+	}
+	env := append(os.Environ(), opts.Env...)
+	env = append(env, runtime.EnvTool+"="+tool, runtime.EnvEvent+"="+nativeEvent)
+
+	var decisions []core.Decision
+	for _, event := range core.AllEvents() {
+		for _, entry := range cfg.GetHooks(event) {
+			if !sim.Matches(event, entry, payload) {
+				continue
+			}
+			for _, hook := range entry.Hooks {
+				result := Result{Event: event, Matcher: entry.Matcher, Hook: hook}
+				if hook.IsCommand() {
+					resp := runHook(&result, data, env, opts)
+					if result.Err == nil && !result.TimedOut {
+						result.Decision = sim.DecodeDecision(payload, resp)
+					}
+				} else {
+					result.Skipped = true
+				}
+				report.Results = append(report.Results, result)
+				decisions = append(decisions, result.Decision)
+			}
+		}
+	}
+	report.Decision = core.CombineDecisions(decisions...)
+	return report, nil
+}
+
+// Run simulates an event in a temporary directory and fails the test if
+// the simulation cannot run.
+func Run(tb testing.TB, cfg *core.Config, tool string, p *core.Payload) *Report {
+	tb.Helper()
+	report, err := Simulate(cfg, tool, p, Options{Dir: tb.TempDir()})
+	if err != nil {
+		tb.Fatalf("simulating %s for %s: %v", p.Event, tool, err)
+	}
+	return report
+}
+
+// runHook runs a command hook with data on stdin, records its outcome in
+// result and returns its response.
+func runHook(result *Result, data []byte, env []string, opts Options) *core.Response {
+	timeout := opts.Timeout
+	if result.Hook.Timeout > 0 {
+		timeout = time.Duration(result.Hook.Timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, result.Hook.Command)
+	cmd.Dir = opts.Dir
+	if dir := result.Hook.WorkingDir; dir != "" {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(opts.Dir, dir)
+		}
+		cmd.Dir = dir
+	}
+	cmd.Env = env
+	cmd.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Do not wait for background processes holding the output pipes open
+	// once the hook is killed.
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	err := cmd.Run()
+	result.Duration = time.Since(start)
+	result.Stdout = stdout.String()
+	result.Stderr = strings.TrimSpace(stderr.String())
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		result.TimedOut = true
+		result.ExitCode = -1
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		result.ExitCode = -1
+		result.Err = err
+	}
+	return &core.Response{ExitCode: result.ExitCode, Stdout: stdout.Bytes(), Stderr: result.Stderr}
+}
+
+// shellCommand returns a command that runs a hook command line the way the
+// tools do, through the system shell.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if goruntime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
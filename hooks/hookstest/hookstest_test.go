@@ -0,0 +1,212 @@
+package hookstest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+func skipWithoutShell(t *testing.T) {
+	t.Helper()
+	if goruntime.GOOS == "windows" {
+		t.Skip("hook scripts use sh")
+	}
+}
+
+func TestSimulateDenyByExitCode(t *testing.T) {
+	skipWithoutShell(t)
+
+	// Every tool but Copilot reads exit code 2 from a pre-command hook as a
+	// block with the reason on stderr.
+	for _, tool := range []string{"claude", "codex", "cursor", "gemini", "kiro", "windsurf"} {
+		t.Run(tool, func(t *testing.T) {
+			cfg := core.NewConfig()
+			cfg.AddHook(core.BeforeCommand, core.NewCommandHook(`grep -q "rm -rf" && { echo "destructive" >&2; exit 2; }; exit 0`))
+
+			report := Run(t, cfg, tool, &core.Payload{Event: core.BeforeCommand, Command: "rm -rf /"})
+			if len(report.Results) != 1 {
+				t.Fatalf("Expected 1 result, got %d", len(report.Results))
+			}
+			if report.Decision.Action != core.ActionDeny || report.Decision.Reason != "destructive" {
+				t.Errorf("Expected deny with reason, got %+v", report.Decision)
+			}
+
+			report = Run(t, cfg, tool, &core.Payload{Event: core.BeforeCommand, Command: "ls"})
+			if report.Decision.Action != "" {
+				t.Errorf("Expected no decision, got %+v", report.Decision)
+			}
+		})
+	}
+}
+
+func TestSimulateJSONOutput(t *testing.T) {
+	skipWithoutShell(t)
+
+	tests := []struct {
+		tool   string
+		output string
+		want   core.Action
+	}{
+		{"claude", `{"hookSpecificOutput":{"hookEventName":"PreToolUse","permissionDecision":"ask"}}`, core.ActionAsk},
+		{"claude", `{"hookSpecificOutput":{"hookEventName":"PreToolUse","permissionDecision":"allow","updatedInput":{"command":"ls"}}}`, core.ActionModify},
+		{"cursor", `{"permission":"ask"}`, core.ActionAsk},
+		{"gemini", `{"decision":"deny","reason":"no"}`, core.ActionDeny},
+		{"vscode", `{"permissionDecision":"deny","permissionDecisionReason":"no"}`, core.ActionDeny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool+"/"+string(tt.want), func(t *testing.T) {
+			cfg := core.NewConfig()
+			cfg.AddHook(core.BeforeCommand, core.NewCommandHook("cat >/dev/null; echo '"+tt.output+"'"))
+
+			report := Run(t, cfg, tt.tool, &core.Payload{Event: core.BeforeCommand, Command: "rm -rf /"})
+			if report.Decision.Action != tt.want {
+				t.Errorf("Expected %q, got %+v", tt.want, report.Decision)
+			}
+		})
+	}
+}
+
+func TestSimulateMatchers(t *testing.T) {
+	skipWithoutShell(t)
+
+	cfg := core.NewConfig()
+	cfg.AddHookWithMatcher(core.BeforeTool, "Write|Edit", core.NewCommandHook("echo write"))
+	cfg.AddHookWithMatcher(core.BeforeTool, "mcp__github__.*", core.NewCommandHook("echo github"))
+	cfg.AddHookWithMatcher(core.BeforeTool, "Bash", core.NewCommandHook("echo bash"))
+	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("echo command"))
+
+	tests := []struct {
+		name  string
+		input *core.Payload
+		want  []string
+	}{
+		{"bash", &core.Payload{Event: core.BeforeCommand, Command: "ls"}, []string{"echo command", "echo bash"}},
+		{"edit", &core.Payload{Event: core.BeforeFileWrite, ToolName: "Edit", FilePath: "a.go"}, []string{"echo write"}},
+		{"mcp", &core.Payload{Event: core.BeforeMCP, ToolName: "mcp__github__create_issue"}, []string{"echo github"}},
+		{"other mcp", &core.Payload{Event: core.BeforeMCP, ToolName: "mcp__slack__post"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Run(t, cfg, "claude", tt.input)
+			var got []string
+			for _, r := range report.Results {
+				got = append(got, r.Hook.Command)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected hooks %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected hooks %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSimulateKiroMatchers(t *testing.T) {
+	skipWithoutShell(t)
+
+	cfg := core.NewConfig()
+	cfg.AddHookWithMatcher(core.BeforeTool, "shell", core.NewCommandHook("echo shell"))
+	cfg.AddHookWithMatcher(core.BeforeTool, "@git", core.NewCommandHook("echo git"))
+
+	report := Run(t, cfg, "kiro", &core.Payload{Event: core.BeforeCommand, Command: "ls"})
+	if len(report.Results) != 1 || report.Results[0].Stdout != "shell\n" {
+		t.Errorf("Expected shell hook, got %+v", report.Results)
+	}
+	report = Run(t, cfg, "kiro", &core.Payload{Event: core.BeforeMCP, ToolName: "@git/status"})
+	if len(report.Results) != 1 || report.Results[0].Stdout != "git\n" {
+		t.Errorf("Expected git hook, got %+v", report.Results)
+	}
+}
+
+func TestSimulateTimeout(t *testing.T) {
+	skipWithoutShell(t)
+
+	cfg := core.NewConfig()
+	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("sleep 5; exit 2").WithTimeout(1))
+
+	report := Run(t, cfg, "claude", &core.Payload{Event: core.BeforeCommand, Command: "ls"})
+	r := report.Results[0]
+	if !r.TimedOut {
+		t.Error("Expected hook to time out")
+	}
+	if r.Duration.Seconds() >= 5 {
+		t.Errorf("Expected hook to be killed, ran %s", r.Duration)
+	}
+	if report.Decision.Action != "" {
+		t.Errorf("Expected no decision, got %+v", report.Decision)
+	}
+}
+
+func TestSimulateSandbox(t *testing.T) {
+	skipWithoutShell(t)
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "scripts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := core.NewConfig()
+	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("pwd; echo $ASSISTANTKIT_HOOK_TOOL").WithWorkingDir("scripts"))
+	cfg.AddHook(core.BeforeCommand, core.NewPromptHook("Is this safe?"))
+
+	report, err := Simulate(cfg, "codex", &core.Payload{Event: core.BeforeCommand, Command: "ls"}, Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	if report.Payload.Cwd != dir {
+		t.Errorf("Expected payload cwd %q, got %q", dir, report.Payload.Cwd)
+	}
+	if len(report.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(report.Results))
+	}
+	want := filepath.Join(dir, "scripts") + "\ncodex\n"
+	if got := report.Results[0].Stdout; got != want {
+		t.Errorf("Expected output %q, got %q", want, got)
+	}
+	if !report.Results[1].Skipped {
+		t.Error("Expected prompt hook to be skipped")
+	}
+}
+
+func TestSimulateErrors(t *testing.T) {
+	cfg := core.NewConfig()
+
+	_, err := Simulate(cfg, "unknown", &core.Payload{Event: core.BeforeCommand}, Options{})
+	if !errors.Is(err, core.ErrUnsupportedEvent) {
+		t.Errorf("Expected ErrUnsupportedEvent for unknown tool, got %v", err)
+	}
+
+	_, err = Simulate(cfg, "cursor", &core.Payload{Event: core.OnPermission}, Options{})
+	if !errors.Is(err, core.ErrUnsupportedEvent) {
+		t.Errorf("Expected ErrUnsupportedEvent for unsupported event, got %v", err)
+	}
+}
+
+func TestSimulateTranslatesMatchers(t *testing.T) {
+	skipWithoutShell(t)
+
+	// Canonical matchers select the tool's own tool names.
+	cfg := core.NewConfig()
+	cfg.AddHookWithMatcher(core.BeforeTool, "Bash", core.NewCommandHook("echo bash"))
+	cfg.AddHookWithMatcher(core.BeforeTool, "mcp__git__.*", core.NewCommandHook("echo git"))
+
+	for _, tool := range []string{"claude", "gemini", "kiro"} {
+		t.Run(tool, func(t *testing.T) {
+			report := Run(t, cfg, tool, &core.Payload{Event: core.BeforeCommand, Command: "ls"})
+			if len(report.Results) != 1 || report.Results[0].Stdout != "bash\n" {
+				t.Errorf("Expected bash hook, got %+v", report.Results)
+			}
+		})
+	}
+
+	report := Run(t, cfg, "kiro", &core.Payload{Event: core.BeforeMCP, ToolName: "@git/status"})
+	if len(report.Results) != 1 || report.Results[0].Stdout != "git\n" {
+		t.Errorf("Expected git hook, got %+v", report.Results)
+	}
+}
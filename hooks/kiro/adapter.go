@@ -0,0 +1,272 @@
+package kiro
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/merge"
+	"github.com/agentplexus/assistantkit/vocab"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "kiro"
+
+	// AgentsDir is the directory holding Kiro CLI agent files.
+	AgentsDir = ".kiro/agents"
+
+	// DefaultAgentName is the agent whose file holds hooks when no agent is named.
+	DefaultAgentName = "default"
+)
+
+// Adapter implements core.Adapter for Kiro CLI hooks.
+type Adapter struct{}
+
+// NewAdapter creates a new Kiro hooks adapter.
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Name returns the adapter name.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns the default agent file paths for Kiro hooks.
+func (a *Adapter) DefaultPaths() []string {
+	paths := []string{
+		AgentPath("", DefaultAgentName),
+	}
+
+	// User config
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, AgentPath(home, DefaultAgentName))
+	}
+
+	return paths
+}
+
+// SupportedEvents returns the events supported by Kiro CLI.
+func (a *Adapter) SupportedEvents() []core.Event {
+	return []core.Event{
+		core.BeforeFileRead, core.AfterFileRead,
+		core.BeforeFileWrite, core.AfterFileWrite,
+		core.BeforeCommand, core.AfterCommand,
+		core.BeforeMCP, core.AfterMCP,
+		core.BeforeTool, core.AfterTool,
+		core.BeforePrompt,
+		core.OnStop, core.OnSessionStart,
+	}
+}
+
+// Parse parses Kiro agent file data into the canonical format.
+func (a *Adapter) Parse(data []byte) (*core.Config, error) {
+	var kiroCfg Config
+	if err := json.Unmarshal(data, &kiroCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return a.ToCore(&kiroCfg), nil
+}
+
+// Marshal converts canonical config to Kiro format.
+func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	kiroCfg := a.FromCore(cfg)
+	return json.MarshalIndent(kiroCfg, "", "  ")
+}
+
+// ReadFile reads the hooks of a Kiro agent file.
+func (a *Adapter) ReadFile(path string) (*core.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Path: path, Err: err}
+	}
+	cfg, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteFile writes canonical config to a Kiro format file.
+func (a *Adapter) WriteFile(cfg *core.Config, path string) error {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	return nil
+}
+
+// ToCore converts Kiro hooks config to canonical format. Consecutive hooks
+// with the same matcher are grouped into one entry.
+func (a *Adapter) ToCore(kiroCfg *Config) *core.Config {
+	cfg := core.NewConfig()
+
+	for kiroEvent, hooks := range kiroCfg.Hooks {
+		for i, h := range hooks {
+			canonicalEvent := a.kiroToCanonicalEvent(kiroEvent, h.Matcher)
+			if canonicalEvent == "" {
+				continue
+			}
+
+			coreHook := core.Hook{
+				Type:    core.HookTypeCommand,
+				Command: h.Command,
+				Timeout: secondsFromMillis(h.TimeoutMs),
+			}
+
+			entries := cfg.Hooks[canonicalEvent]
+			if n := len(entries); i > 0 && n > 0 && hooks[i-1].Matcher == h.Matcher &&
+				a.kiroToCanonicalEvent(kiroEvent, hooks[i-1].Matcher) == canonicalEvent {
+				entries[n-1].Hooks = append(entries[n-1].Hooks, coreHook)
+				continue
+			}
+			cfg.Hooks[canonicalEvent] = append(entries, core.HookEntry{
+				Matcher: core.CanonicalMatcher(vocab.Kiro, canonicalEvent, h.Matcher),
+				Hooks:   []core.Hook{coreHook},
+			})
+		}
+	}
+
+	return cfg
+}
+
+// FromCore converts canonical config to Kiro format. Each hook carries the
+// matcher of its entry, and prompt hooks are skipped since Kiro only runs
+// commands.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	kiroCfg := NewConfig()
+
+	for _, event := range core.AllEvents() {
+		kiroEvent, matcher := a.canonicalToKiroEvent(event)
+		if kiroEvent == "" {
+			continue // Event not supported by Kiro
+		}
+
+		for _, entry := range cfg.Hooks[event] {
+			m := core.NativeMatcher(vocab.Kiro, event, entry.Matcher)
+			if m == "" {
+				m = matcher
+			}
+
+			for _, h := range entry.Hooks {
+				if h.Command == "" {
+					continue
+				}
+				kiroCfg.Hooks[kiroEvent] = append(kiroCfg.Hooks[kiroEvent], Hook{
+					Command:   h.Command,
+					Matcher:   m,
+					TimeoutMs: h.Timeout * 1000,
+				})
+			}
+		}
+	}
+
+	return kiroCfg
+}
+
+// Lossiness reports the canonical fields that the Kiro format drops or degrades.
+// MCP entries without a matcher cannot name every MCP tool and are read back
+// as generic tool hooks.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, a.SupportedEvents(), cfg,
+		core.FieldMatcher, core.FieldTimeout)
+	losses = append(losses, core.MovedEntries(AdapterName, cfg, a.readBack)...)
+	return append(losses, core.TranslatedMatchers(AdapterName, a.SupportedEvents(), cfg, vocab.Kiro)...)
+}
+
+// Merge writes cfg into an existing Kiro agent file, replacing only the
+// hooks key and keeping the rest of the agent definition.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "hooks")
+}
+
+// readBack returns the canonical event an entry is read back as.
+func (a *Adapter) readBack(event core.Event, entry core.HookEntry) core.Event {
+	kiroEvent, matcher := a.canonicalToKiroEvent(event)
+	if kiroEvent == "" {
+		return ""
+	}
+	if entry.Matcher != "" {
+		matcher = core.NativeMatcher(vocab.Kiro, event, entry.Matcher)
+	}
+	return a.kiroToCanonicalEvent(kiroEvent, matcher)
+}
+
+// kiroToCanonicalEvent converts a Kiro event to canonical event.
+func (a *Adapter) kiroToCanonicalEvent(kiroEvent KiroEvent, matcher string) core.Event {
+	if event, ok := reverseEventMapping[kiroEvent]; ok {
+		return event
+	}
+
+	switch kiroEvent {
+	case PreToolUse:
+		if event, ok := matcherToCanonicalEventBefore[matcher]; ok {
+			return event
+		}
+		// Default to BeforeMCP for unknown matchers (likely "@server" tools)
+		return core.BeforeMCP
+	case PostToolUse:
+		if event, ok := matcherToCanonicalEventAfter[matcher]; ok {
+			return event
+		}
+		return core.AfterMCP
+	}
+
+	return ""
+}
+
+// canonicalToKiroEvent converts a canonical event to Kiro event and matcher.
+func (a *Adapter) canonicalToKiroEvent(event core.Event) (KiroEvent, string) {
+	if kiroEvent, ok := eventMapping[event]; ok {
+		return kiroEvent, canonicalEventToMatcher[event]
+	}
+	return "", ""
+}
+
+// secondsFromMillis converts a Kiro timeout to whole seconds, rounding up
+// so that a short timeout is not read back as no timeout.
+func secondsFromMillis(ms int) int {
+	if ms <= 0 {
+		return 0
+	}
+	return (ms + 999) / 1000
+}
+
+// AgentPath returns the path of the named agent file under dir. An empty
+// dir gives the project-relative path.
+func AgentPath(dir, agent string) string {
+	return filepath.Join(dir, AgentsDir, agent+".json")
+}
+
+// ReadProjectConfig reads the hooks of the project-level default agent.
+func ReadProjectConfig() (*core.Config, error) {
+	adapter := NewAdapter()
+	return adapter.ReadFile(AgentPath("", DefaultAgentName))
+}
+
+// ReadUserConfig reads the hooks of the user-level default agent.
+func ReadUserConfig() (*core.Config, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	adapter := NewAdapter()
+	return adapter.ReadFile(AgentPath(home, DefaultAgentName))
+}
+
+// init registers the adapter with the default registry.
+func init() {
+	core.Register(NewAdapter())
+}
@@ -0,0 +1,146 @@
+package kiro
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+func TestAdapterName(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter.Name() != "kiro" {
+		t.Errorf("Expected name 'kiro', got %q", adapter.Name())
+	}
+}
+
+func TestAgentPath(t *testing.T) {
+	if got := AgentPath("", "reviewer"); got != filepath.Join(".kiro", "agents", "reviewer.json") {
+		t.Errorf("Unexpected agent path %q", got)
+	}
+	if paths := NewAdapter().DefaultPaths(); paths[0] != AgentPath("", DefaultAgentName) {
+		t.Errorf("Expected project default agent first, got %v", paths)
+	}
+}
+
+func TestAdapterParse(t *testing.T) {
+	data := `{
+		"name": "reviewer",
+		"hooks": {
+			"agentSpawn": [{"command": "git status"}],
+			"preToolUse": [
+				{"matcher": "execute_bash", "command": "./guard.sh", "timeout_ms": 2500},
+				{"matcher": "execute_bash", "command": "./audit.sh"},
+				{"matcher": "write", "command": "./lint.sh"},
+				{"matcher": "@git", "command": "./git.sh"},
+				{"matcher": "*", "command": "./any.sh"}
+			],
+			"stop": [{"command": "./stop.sh"}]
+		}
+	}`
+
+	cfg, err := NewAdapter().Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		event    core.Event
+		commands []string
+	}{
+		{core.OnSessionStart, []string{"git status"}},
+		{core.BeforeCommand, []string{"./guard.sh", "./audit.sh"}},
+		{core.BeforeFileWrite, []string{"./lint.sh"}},
+		{core.BeforeMCP, []string{"./git.sh"}},
+		{core.BeforeTool, []string{"./any.sh"}},
+		{core.OnStop, []string{"./stop.sh"}},
+	}
+	for _, tt := range tests {
+		entries := cfg.GetHooks(tt.event)
+		if len(entries) != 1 || len(entries[0].Hooks) != len(tt.commands) {
+			t.Errorf("Expected one entry with %d hooks for %s, got %+v", len(tt.commands), tt.event, entries)
+			continue
+		}
+		for i, command := range tt.commands {
+			if entries[0].Hooks[i].Command != command {
+				t.Errorf("Expected %s hook %d to run %s, got %s", tt.event, i, command, entries[0].Hooks[i].Command)
+			}
+		}
+	}
+	// Matchers are read back with canonical tool names.
+	matchers := map[core.Event]string{
+		core.BeforeCommand:   "Bash",
+		core.BeforeFileWrite: "Write",
+		core.BeforeMCP:       "mcp__git__.*",
+		core.BeforeTool:      "*",
+	}
+	for event, want := range matchers {
+		if m := cfg.GetHooks(event)[0].Matcher; m != want {
+			t.Errorf("Expected %s matcher %q, got %q", event, want, m)
+		}
+	}
+	if timeout := cfg.GetHooks(core.BeforeCommand)[0].Hooks[0].Timeout; timeout != 3 {
+		t.Errorf("Expected timeout 3, got %d", timeout)
+	}
+}
+
+func TestAdapterFromCore(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.Hooks[core.BeforeFileRead] = []core.HookEntry{{Hooks: []core.Hook{
+		core.NewCommandHook("./a.sh"), core.NewCommandHook("./b.sh").WithTimeout(5),
+	}}}
+	cfg.AddHookWithMatcher(core.AfterMCP, "mcp__github__.*", core.NewCommandHook("./gh.sh"))
+
+	kiroCfg := NewAdapter().FromCore(cfg)
+	pre := kiroCfg.Hooks[PreToolUse]
+	if len(pre) != 2 || pre[0].Matcher != "fs_read" || pre[1].Matcher != "fs_read" || pre[1].TimeoutMs != 5000 {
+		t.Errorf("Unexpected preToolUse hooks: %+v", pre)
+	}
+	if post := kiroCfg.Hooks[PostToolUse]; len(post) != 1 || post[0].Matcher != "@github" {
+		t.Errorf("Unexpected postToolUse hooks: %+v", post)
+	}
+}
+
+func TestAdapterLossiness(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddHook(core.BeforeMCP, core.NewCommandHook("./mcp.sh"))
+	cfg.AddHookWithMatcher(core.BeforeMCP, "mcp__git__.*", core.NewCommandHook("./git.sh"))
+	cfg.AddHookWithMatcher(core.BeforeFileWrite, "Write|Edit", core.NewCommandHook("./lint.sh"))
+	cfg.AddHook(core.OnNotification, core.NewCommandHook("./notify.sh"))
+
+	var got []string
+	for _, loss := range NewAdapter().Lossiness(cfg) {
+		got = append(got, loss.String())
+	}
+	expected := []string{
+		"kiro: hooks.on_notification dropped: event is not supported",
+		"kiro: hooks.before_mcp[0] degraded: entry is read back as event before_tool",
+		`kiro: hooks.before_file_write[0].matcher degraded: matcher "Write|Edit" is written as "fs_write" and read back as "Write"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected losses:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestAdapterMergeKeepsAgent(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddHook(core.OnStop, core.NewCommandHook("./stop.sh"))
+
+	data, err := NewAdapter().Merge(cfg, []byte(`{"name": "reviewer", "tools": ["read"]}`))
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"name": "reviewer"`) || !strings.Contains(string(data), "./stop.sh") {
+		t.Errorf("Expected agent kept and hooks added, got %s", data)
+	}
+}
+
+func TestAdapterReadFileNotFound(t *testing.T) {
+	_, err := NewAdapter().ReadFile(filepath.Join(t.TempDir(), "missing.json"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not-exist error, got %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+// Package kiro provides an adapter for Kiro CLI hooks configuration.
+//
+// Kiro CLI hooks are configured in the "hooks" section of custom agent files:
+//   - Project: .kiro/agents/<agent>.json
+//   - User: ~/.kiro/agents/<agent>.json
+//
+// Kiro CLI hook triggers:
+//   - agentSpawn: When the agent is activated
+//   - userPromptSubmit: When user submits a prompt
+//   - preToolUse: Before tool execution (can block)
+//   - postToolUse: After tool execution
+//   - stop: When the assistant finishes responding
+//
+// Unlike Claude, each trigger holds a flat list of hooks, and each hook
+// carries its own matcher. Matchers name built-in tools (e.g., "fs_write",
+// "execute_bash") or MCP servers ("@git", "@git/status"); canonical matchers
+// are translated with the vocab package. Timeouts are in milliseconds.
+package kiro
+
+import "github.com/agentplexus/assistantkit/hooks/core"
+
+// KiroEvent represents Kiro CLI hook trigger names.
+type KiroEvent string
+
+const (
+	AgentSpawn       KiroEvent = "agentSpawn"
+	UserPromptSubmit KiroEvent = "userPromptSubmit"
+	PreToolUse       KiroEvent = "preToolUse"
+	PostToolUse      KiroEvent = "postToolUse"
+	Stop             KiroEvent = "stop"
+)
+
+// Config represents the hooks section of a Kiro CLI agent file.
+type Config struct {
+	Hooks map[KiroEvent][]Hook `json:"hooks,omitempty"`
+}
+
+// Hook represents a single Kiro hook definition.
+type Hook struct {
+	// Command is the shell command to execute.
+	Command string `json:"command"`
+
+	// Matcher selects the tools that trigger this hook on tool events.
+	// Examples: "fs_write", "execute_bash", "@git", "*"
+	Matcher string `json:"matcher,omitempty"`
+
+	// TimeoutMs is the timeout in milliseconds for hook execution.
+	TimeoutMs int `json:"timeout_ms,omitempty"`
+
+	// MaxOutputSize limits the bytes of hook output passed to the agent.
+	MaxOutputSize int `json:"max_output_size,omitempty"`
+
+	// CacheTTLSeconds caches successful hook output for this many seconds.
+	CacheTTLSeconds int `json:"cache_ttl_seconds,omitempty"`
+}
+
+// NewConfig creates a new empty Kiro hooks config.
+func NewConfig() *Config {
+	return &Config{
+		Hooks: make(map[KiroEvent][]Hook),
+	}
+}
+
+// eventMapping maps canonical events to Kiro events.
+var eventMapping = map[core.Event]KiroEvent{
+	core.BeforeFileRead:  PreToolUse, // with matcher "fs_read"
+	core.AfterFileRead:   PostToolUse,
+	core.BeforeFileWrite: PreToolUse, // with matcher "fs_write"
+	core.AfterFileWrite:  PostToolUse,
+	core.BeforeCommand:   PreToolUse, // with matcher "execute_bash"
+	core.AfterCommand:    PostToolUse,
+	core.BeforeMCP:       PreToolUse, // needs an "@server" matcher
+	core.AfterMCP:        PostToolUse,
+	core.BeforeTool:      PreToolUse, // without matcher
+	core.AfterTool:       PostToolUse,
+	core.BeforePrompt:    UserPromptSubmit,
+	core.OnStop:          Stop,
+	core.OnSessionStart:  AgentSpawn,
+}
+
+// reverseEventMapping maps Kiro events back to canonical events.
+// Note: preToolUse/postToolUse need matcher context to determine exact canonical event.
+var reverseEventMapping = map[KiroEvent]core.Event{
+	UserPromptSubmit: core.BeforePrompt,
+	Stop:             core.OnStop,
+	AgentSpawn:       core.OnSessionStart,
+}
+
+// canonicalEventToMatcher maps canonical tool events to Kiro matchers.
+// Kiro has no matcher for every MCP tool, so MCP events are only preserved
+// when the entry names a server.
+var canonicalEventToMatcher = map[core.Event]string{
+	core.BeforeFileRead:  "fs_read",
+	core.AfterFileRead:   "fs_read",
+	core.BeforeFileWrite: "fs_write",
+	core.AfterFileWrite:  "fs_write",
+	core.BeforeCommand:   "execute_bash",
+	core.AfterCommand:    "execute_bash",
+}
+
+// matcherToCanonicalEventBefore maps matchers to canonical events for preToolUse.
+var matcherToCanonicalEventBefore = map[string]core.Event{
+	"":             core.BeforeTool,
+	"*":            core.BeforeTool,
+	"fs_read":      core.BeforeFileRead,
+	"read":         core.BeforeFileRead,
+	"fs_write":     core.BeforeFileWrite,
+	"write":        core.BeforeFileWrite,
+	"execute_bash": core.BeforeCommand,
+	"shell":        core.BeforeCommand,
+}
+
+// matcherToCanonicalEventAfter maps matchers to canonical events for postToolUse.
+var matcherToCanonicalEventAfter = map[string]core.Event{
+	"":             core.AfterTool,
+	"*":            core.AfterTool,
+	"fs_read":      core.AfterFileRead,
+	"read":         core.AfterFileRead,
+	"fs_write":     core.AfterFileWrite,
+	"write":        core.AfterFileWrite,
+	"execute_bash": core.AfterCommand,
+	"shell":        core.AfterCommand,
+}
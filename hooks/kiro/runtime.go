@@ -0,0 +1,164 @@
+package kiro
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/vocab"
+)
+
+// Payload is the JSON Kiro CLI writes to a hook's stdin.
+type Payload struct {
+	HookEventName KiroEvent      `json:"hook_event_name"`
+	Cwd           string         `json:"cwd,omitempty"`
+	ToolName      string         `json:"tool_name,omitempty"`
+	ToolInput     map[string]any `json:"tool_input,omitempty"`
+	ToolResponse  any            `json:"tool_response,omitempty"`
+	Prompt        string         `json:"prompt,omitempty"`
+}
+
+// Detect reports whether a payload was sent by Kiro CLI. Cursor also has a
+// "stop" event but always sends a conversation ID.
+func (a *Adapter) Detect(fields map[string]json.RawMessage) bool {
+	if core.HasField(fields, "conversation_id") {
+		return false
+	}
+	switch KiroEvent(core.StringField(fields, "hook_event_name")) {
+	case AgentSpawn, UserPromptSubmit, PreToolUse, PostToolUse, Stop:
+		return true
+	}
+	return false
+}
+
+// DecodePayload converts a Kiro CLI hook payload to canonical form.
+func (a *Adapter) DecodePayload(data []byte, nativeEvent string) (*core.Payload, error) {
+	var in Payload
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	if nativeEvent != "" {
+		in.HookEventName = KiroEvent(nativeEvent)
+	}
+
+	p := &core.Payload{
+		Adapter:     AdapterName,
+		NativeEvent: string(in.HookEventName),
+		Cwd:         in.Cwd,
+		ToolName:    in.ToolName,
+		ToolInput:   in.ToolInput,
+		ToolOutput:  in.ToolResponse,
+		Prompt:      in.Prompt,
+		Raw:         data,
+	}
+	switch in.HookEventName {
+	case PreToolUse:
+		p.Event = toolEvent(in.ToolName)
+	case PostToolUse:
+		p.Event = toolEvent(in.ToolName).AfterEvent()
+	default:
+		event, ok := reverseEventMapping[in.HookEventName]
+		if !ok {
+			return nil, &core.ParseError{Format: AdapterName, Err: core.ErrUnsupportedEvent}
+		}
+		p.Event = event
+	}
+	p.Command = p.InputString("command")
+	p.FilePath = p.InputString("path")
+	return p, nil
+}
+
+// EncodeDecision serializes a decision as a Kiro CLI exit code. Only
+// preToolUse hooks can block, with exit code 2 and the reason on stderr;
+// Kiro cannot prompt from a hook, so ask blocks too.
+func (a *Adapter) EncodeDecision(p *core.Payload, d core.Decision) (*core.Response, error) {
+	switch d.Action {
+	case "", core.ActionAllow:
+		return &core.Response{}, nil
+	case core.ActionDeny, core.ActionAsk:
+		if KiroEvent(p.NativeEvent) == PreToolUse {
+			return core.BlockResponse(d.Reason), nil
+		}
+	}
+	return nil, &core.DecisionError{Format: AdapterName, Event: p.NativeEvent, Action: d.Action}
+}
+
+// toolEvent returns the canonical before event for a call of the named
+// tool. MCP tools are named after their server with an "@" prefix.
+func toolEvent(name string) core.Event {
+	if event, ok := matcherToCanonicalEventBefore[name]; ok {
+		return event
+	}
+	if strings.HasPrefix(name, "@") {
+		return core.BeforeMCP
+	}
+	return core.BeforeTool
+}
+
+// toolNames maps canonical tool events to the tool named in simulated payloads.
+var toolNames = map[core.Event]string{
+	core.BeforeFileRead:  "fs_read",
+	core.AfterFileRead:   "fs_read",
+	core.BeforeFileWrite: "fs_write",
+	core.AfterFileWrite:  "fs_write",
+	core.BeforeCommand:   "execute_bash",
+	core.AfterCommand:    "execute_bash",
+	core.BeforeMCP:       "@test/tool",
+	core.AfterMCP:        "@test/tool",
+}
+
+// EncodePayload renders a canonical payload as Kiro CLI's hook stdin.
+func (a *Adapter) EncodePayload(p *core.Payload) ([]byte, string, error) {
+	event := KiroEvent(p.NativeEvent)
+	if event == "" {
+		if event, _ = a.canonicalToKiroEvent(p.Event); event == "" {
+			return nil, "", &core.ConversionError{To: AdapterName, Event: p.Event, Err: core.ErrUnsupportedEvent}
+		}
+	}
+	out := Payload{
+		HookEventName: event,
+		Cwd:           p.Cwd,
+		Prompt:        p.Prompt,
+	}
+	if event == PreToolUse || event == PostToolUse {
+		out.ToolName = p.ToolName
+		if out.ToolName == "" {
+			out.ToolName = toolNames[p.Event]
+		}
+		out.ToolInput = p.ToolInputWith("command", "path")
+		out.ToolResponse = p.ToolOutput
+	}
+	data, err := json.Marshal(out)
+	return data, string(event), err
+}
+
+// Matches reports whether Kiro CLI runs an entry registered for event when
+// it sends p. A matcher names a tool, by its full or short name, or an MCP
+// server ("@git") whose tools ("@git/status") it matches.
+func (a *Adapter) Matches(event core.Event, entry core.HookEntry, p *core.Payload) bool {
+	native, matcher := a.canonicalToKiroEvent(event)
+	if native == "" || string(native) != p.NativeEvent {
+		return false
+	}
+	if entry.Matcher != "" {
+		matcher = core.NativeMatcher(vocab.Kiro, event, entry.Matcher)
+	}
+	if native != PreToolUse && native != PostToolUse {
+		return true
+	}
+	// Short names ("shell") match the tool's full name ("execute_bash").
+	if tool, ok := vocab.Kiro.Canonical(matcher); ok {
+		matcher, _ = vocab.Kiro.Native(tool)
+	}
+	return matcher == "" || matcher == "*" || matcher == p.ToolName ||
+		(strings.HasPrefix(matcher, "@") && strings.HasPrefix(p.ToolName, matcher+"/"))
+}
+
+// DecodeDecision reads a Kiro CLI hook's response: exit code 2 blocks a
+// preToolUse hook, and everything else lets the action proceed.
+func (a *Adapter) DecodeDecision(p *core.Payload, resp *core.Response) core.Decision {
+	if resp.ExitCode == core.ExitBlock && KiroEvent(p.NativeEvent) == PreToolUse {
+		return core.Decision{Action: core.ActionDeny, Reason: resp.Stderr}
+	}
+	return core.Decision{}
+}
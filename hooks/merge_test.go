@@ -0,0 +1,102 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+func TestMergeKeepsUnmanagedSettings(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddHook(BeforeCommand, NewCommandHook("./check.sh"))
+
+	names := AdapterNames()
+	sort.Strings(names)
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			adapter, _ := GetAdapter(name)
+			merger, ok := adapter.(core.Merger)
+			if !ok {
+				t.Fatalf("Adapter %q does not implement Merger", name)
+			}
+
+			existing := `{"model": "opus", "hooks": {}, "permissions": {"allow": ["Bash(ls)"]}}`
+			merged, err := merger.Merge(cfg, []byte(existing))
+			if err != nil {
+				t.Fatalf("Merge failed: %v", err)
+			}
+			s := string(merged)
+			if !strings.HasPrefix(s, `{"model": "opus", "hooks": {`) ||
+				!strings.Contains(s, `}, "permissions": {"allow": ["Bash(ls)"]}`) {
+				t.Errorf("Expected unmanaged settings to be kept in order, got:\n%s", s)
+			}
+
+			got, err := adapter.Parse(merged)
+			if err != nil {
+				t.Fatalf("Parse failed: %v\n%s", err, merged)
+			}
+			if got.HookCount() != 1 {
+				t.Errorf("Expected 1 hook after merge, got %d", got.HookCount())
+			}
+		})
+	}
+}
+
+func TestMergeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	existing := `{
+  "permissions": {
+    "allow": ["Bash(npm test)"]
+  },
+  "disableAllHooks": true,
+  "model": "opus"
+}
+`
+	if err := os.WriteFile(path, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := NewConfig()
+	cfg.AddHookWithMatcher(BeforeCommand, "Bash", NewCommandHook("./check.sh"))
+	result, err := MergeFile(cfg, "claude", path, false)
+	if err != nil {
+		t.Fatalf("MergeFile failed: %v", err)
+	}
+	if !result.Changed || !strings.Contains(result.Diff, `-  "disableAllHooks": true,`) {
+		t.Errorf("Expected diff removing disableAllHooks, got:\n%s", result.Diff)
+	}
+
+	data, _ := os.ReadFile(path)
+	expected := `{
+  "permissions": {
+    "allow": ["Bash(npm test)"]
+  },
+  "model": "opus",
+  "hooks": {
+    "PreToolUse": [
+      {
+        "matcher": "Bash",
+        "hooks": [
+          {
+            "type": "command",
+            "command": "./check.sh"
+          }
+        ]
+      }
+    ]
+  }
+}
+`
+	if string(data) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, data)
+	}
+
+	if _, err := MergeFile(cfg, "unknown", path, true); err == nil {
+		t.Error("Expected error for unknown adapter")
+	}
+}
@@ -0,0 +1,237 @@
+package hooks
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"testing/quick"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+var (
+	matchers = []string{"", "", "Bash", "Read", "Write|Edit", "mcp__github__search"}
+	words    = []string{"alpha", "bravo", "charlie", "delta", "echo"}
+)
+
+// randomConfig is a canonical config generated for property-based tests.
+type randomConfig struct {
+	cfg *Config
+}
+
+// Generate implements quick.Generator.
+func (randomConfig) Generate(r *rand.Rand, size int) reflect.Value {
+	cfg := NewConfig()
+	cfg.Version = r.Intn(2)
+	cfg.DisableAllHooks = r.Intn(4) == 0
+	cfg.AllowManagedHooksOnly = r.Intn(4) == 0
+
+	events := AllEvents()
+	for i := 0; i < 1+r.Intn(3); i++ {
+		event := events[r.Intn(len(events))]
+		for j := 0; j < 1+r.Intn(2); j++ {
+			entry := HookEntry{Matcher: matchers[r.Intn(len(matchers))]}
+			for k := 0; k < 1+r.Intn(2); k++ {
+				entry.Hooks = append(entry.Hooks, randomHook(r))
+			}
+			cfg.Hooks[event] = append(cfg.Hooks[event], entry)
+		}
+	}
+	return reflect.ValueOf(randomConfig{cfg: cfg})
+}
+
+func randomHook(r *rand.Rand) Hook {
+	var h Hook
+	if r.Intn(4) == 0 {
+		h = NewPromptHook("check " + words[r.Intn(len(words))])
+	} else {
+		h = Hook{Command: "./" + words[r.Intn(len(words))] + ".sh"}
+		if r.Intn(2) == 0 {
+			h.Type = HookTypeCommand
+		}
+		h.ShowOutput = r.Intn(2) == 0
+		if r.Intn(3) == 0 {
+			h.WorkingDir = "/tmp/" + words[r.Intn(len(words))]
+		}
+	}
+	h.Timeout = r.Intn(2) * (1 + r.Intn(60))
+	return h
+}
+
+// item is a hook flattened out of its entry, with the Loss paths of the
+// entry and the hook.
+type item struct {
+	entry   string
+	path    string
+	matcher string
+	hook    Hook
+}
+
+func flatten(event Event, entries []HookEntry) []item {
+	var items []item
+	for i, entry := range entries {
+		for j, h := range entry.Hooks {
+			items = append(items, item{
+				entry:   core.EntryPath(event, i),
+				path:    core.HookPath(event, i, j),
+				matcher: entry.Matcher,
+				hook:    h,
+			})
+		}
+	}
+	return items
+}
+
+// matches reports whether got preserves every field of want that is not lost.
+func matches(want, got item, lost map[string]bool) bool {
+	if want.matcher != "" && !lost[want.entry+"."+core.FieldMatcher] && want.matcher != got.matcher {
+		return false
+	}
+	if want.hook.IsPrompt() != got.hook.IsPrompt() ||
+		want.hook.Command != got.hook.Command || want.hook.Prompt != got.hook.Prompt {
+		return false
+	}
+	if !lost[want.path+"."+core.FieldTimeout] && want.hook.Timeout != got.hook.Timeout {
+		return false
+	}
+	if !lost[want.path+"."+core.FieldShowOutput] && want.hook.ShowOutput != got.hook.ShowOutput {
+		return false
+	}
+	if !lost[want.path+"."+core.FieldWorkingDir] && want.hook.WorkingDir != got.hook.WorkingDir {
+		return false
+	}
+	return true
+}
+
+// checkRoundTrip asserts that Parse(Marshal(cfg)) preserves every hook and
+// field that the adapter does not report as lost. Hooks in entries that are
+// degraded, or whose matcher is, move to another event, so they are only counted.
+func checkRoundTrip(adapter Adapter, cfg *Config) error {
+	lost := make(map[string]bool)
+	for _, loss := range core.Lossiness(adapter, cfg) {
+		lost[loss.Path] = true
+	}
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	got, err := adapter.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parse: %w\n%s", err, data)
+	}
+
+	if cfg.Version > 0 && !lost[core.FieldVersion] && got.Version != cfg.Version {
+		return fmt.Errorf("version changed: expected %d, got %d", cfg.Version, got.Version)
+	}
+	if !lost[core.FieldDisableAllHooks] && got.DisableAllHooks != cfg.DisableAllHooks {
+		return fmt.Errorf("disableAllHooks changed\n%s", data)
+	}
+	if !lost[core.FieldAllowManagedHooksOnly] && got.AllowManagedHooksOnly != cfg.AllowManagedHooksOnly {
+		return fmt.Errorf("allowManagedHooksOnly changed\n%s", data)
+	}
+
+	kept := 0
+	for event, entries := range cfg.Hooks {
+		if lost["hooks."+string(event)] {
+			continue
+		}
+		var want []item
+		for _, it := range flatten(event, entries) {
+			if lost[it.path] {
+				continue
+			}
+			kept++
+			if !lost[it.entry] && (it.matcher == "" || !lost[it.entry+"."+core.FieldMatcher]) {
+				want = append(want, it)
+			}
+		}
+
+		// The preserved hooks must appear in order for the event.
+		have := flatten(event, got.Hooks[event])
+		n := 0
+		for _, h := range have {
+			if n < len(want) && matches(want[n], h, lost) {
+				n++
+			}
+		}
+		if n < len(want) {
+			return fmt.Errorf("%s not reported as lost but changed: expected %+v, got %+v\n%s",
+				want[n].path, want[n], have, data)
+		}
+	}
+
+	if got.HookCount() != kept {
+		return fmt.Errorf("expected %d hooks after round trip, got %d\n%s", kept, got.HookCount(), data)
+	}
+	return nil
+}
+
+func TestRoundTripPreservesSupportedFields(t *testing.T) {
+	names := AdapterNames()
+	sort.Strings(names)
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			adapter, _ := GetAdapter(name)
+			if _, ok := adapter.(core.LossReporter); !ok {
+				t.Fatalf("Adapter %q does not implement LossReporter", name)
+			}
+
+			var failure error
+			property := func(rc randomConfig) bool {
+				failure = checkRoundTrip(adapter, rc.cfg)
+				return failure == nil
+			}
+			qc := &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(1))}
+			if err := quick.Check(property, qc); err != nil {
+				t.Errorf("Round trip failed: %v", failure)
+			}
+		})
+	}
+}
+
+func TestLossiness(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddHook(BeforeCommand, NewPromptHook("Is this command safe?"))
+	cfg.AddHook(BeforeCommand, NewCommandHook("./check.sh").WithTimeout(30))
+	cfg.AddHookWithMatcher(BeforeMCP, "Bash", NewCommandHook("./mcp.sh"))
+	cfg.AddHook(AfterThought, NewCommandHook("./log.sh"))
+
+	tests := []struct {
+		tool     string
+		expected []string
+	}{
+		{"claude", []string{
+			"claude: hooks.after_thought dropped: event is not supported",
+			"claude: hooks.before_mcp[0].matcher degraded: matcher \"Bash\" is read back as event before_command",
+		}},
+		{"cursor", []string{
+			"cursor: hooks.before_command[0].hooks[0] dropped: prompt hooks are not supported",
+			"cursor: hooks.before_command[0].hooks[1].timeout dropped",
+			"cursor: hooks.before_mcp[0].matcher dropped",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			losses, err := Lossiness(cfg, tt.tool)
+			if err != nil {
+				t.Fatalf("Lossiness failed: %v", err)
+			}
+			var got []string
+			for _, loss := range losses {
+				got = append(got, loss.String())
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := Lossiness(cfg, "unknown"); err == nil {
+		t.Error("Expected error for unknown adapter")
+	}
+}
@@ -0,0 +1,162 @@
+// Package runtime helps write hook programs that run under any supported
+// AI assistant.
+//
+// A hook program reads the JSON payload the tool writes to stdin, decides
+// what to do, and answers with an exit code and stdout JSON in the tool's
+// own format. The runtime normalizes the payload into a canonical
+// core.Payload, whose Event is a canonical hook event refined by the tool
+// being called, and serializes the handler's canonical Decision back for
+// the tool that ran the hook:
+//
+//	func main() {
+//	    runtime.Main(func(p *hooks.Payload) (hooks.Decision, error) {
+//	        if p.Event == hooks.BeforeCommand && strings.Contains(p.Command, "rm -rf") {
+//	            return runtime.Deny("destructive command"), nil
+//	        }
+//	        return runtime.Allow(), nil
+//	    })
+//	}
+//
+// The tool is detected from the payload. Register the hook with the
+// ASSISTANTKIT_HOOK_TOOL environment variable set to name the tool
+// explicitly, which is needed for Codex since its payloads have the same
+// shape as Claude Code's. ASSISTANTKIT_HOOK_EVENT likewise names the tool's
+// event for tools whose payloads do not include it.
+//
+// Not every tool can express every decision. Ask is sent as deny where the
+// tool cannot prompt, so a handler that asks never silently allows; other
+// decisions a tool cannot express for an event are reported as errors
+// wrapping core.ErrUnsupportedDecision.
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+
+	// Import adapters to register them
+	_ "github.com/agentplexus/assistantkit/hooks/claude"
+	_ "github.com/agentplexus/assistantkit/hooks/codex"
+	_ "github.com/agentplexus/assistantkit/hooks/cursor"
+	_ "github.com/agentplexus/assistantkit/hooks/gemini"
+	_ "github.com/agentplexus/assistantkit/hooks/kiro"
+	_ "github.com/agentplexus/assistantkit/hooks/vscode"
+	_ "github.com/agentplexus/assistantkit/hooks/windsurf"
+)
+
+// Environment variables that override payload detection.
+const (
+	// EnvTool names the tool that runs the hook (e.g., "codex").
+	EnvTool = "ASSISTANTKIT_HOOK_TOOL"
+
+	// EnvEvent names the tool's own event (e.g., "preToolUse").
+	EnvEvent = "ASSISTANTKIT_HOOK_EVENT"
+)
+
+// ExitError is the exit code used when the payload cannot be handled. Tools
+// treat it as a failed hook rather than a decision.
+const ExitError = 1
+
+// Handler decides what to do with a hook payload.
+type Handler func(p *core.Payload) (core.Decision, error)
+
+// Allow returns a decision that approves the action.
+func Allow() core.Decision {
+	return core.Decision{Action: core.ActionAllow}
+}
+
+// Deny returns a decision that blocks the action with a reason.
+func Deny(reason string) core.Decision {
+	return core.Decision{Action: core.ActionDeny, Reason: reason}
+}
+
+// Ask returns a decision that asks the user to confirm the action.
+func Ask(reason string) core.Decision {
+	return core.Decision{Action: core.ActionAsk, Reason: reason}
+}
+
+// Modify returns a decision that approves the action with new tool input.
+func Modify(input map[string]any, reason string) core.Decision {
+	return core.Decision{Action: core.ActionModify, Reason: reason, Input: input}
+}
+
+// Decode converts a payload to canonical form. If tool is empty, the tool
+// is detected from the payload; if event is empty, the tool's event is
+// taken from the payload.
+func Decode(tool, event string, data []byte) (*core.Payload, error) {
+	return core.DefaultRegistry.DecodePayload(tool, data, event)
+}
+
+// Encode serializes a decision for the tool that sent the payload.
+func Encode(p *core.Payload, d core.Decision) (*core.Response, error) {
+	return core.DefaultRegistry.EncodeDecision(p, d)
+}
+
+// Handle decodes a payload, runs the handler and encodes its decision.
+func Handle(tool, event string, data []byte, h Handler) (*core.Response, error) {
+	p, err := Decode(tool, event, data)
+	if err != nil {
+		return nil, err
+	}
+	d, err := h(p)
+	if err != nil {
+		return nil, err
+	}
+	return Encode(p, d)
+}
+
+// Runner runs a handler against a payload read from Stdin.
+type Runner struct {
+	// Tool and Event override payload detection; see Decode.
+	Tool  string
+	Event string
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// NewRunner returns a runner for the current process, configured from
+// EnvTool and EnvEvent.
+func NewRunner() *Runner {
+	return &Runner{
+		Tool:   os.Getenv(EnvTool),
+		Event:  os.Getenv(EnvEvent),
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+}
+
+// Run reads the payload, runs the handler, writes the response and returns
+// the exit code. Errors are written to Stderr with ExitError.
+func (r *Runner) Run(h Handler) int {
+	data, err := io.ReadAll(r.Stdin)
+	if err != nil {
+		return r.fail(err)
+	}
+	resp, err := Handle(r.Tool, r.Event, data, h)
+	if err != nil {
+		return r.fail(err)
+	}
+	if _, err := r.Stdout.Write(resp.Stdout); err != nil {
+		return r.fail(err)
+	}
+	if resp.Stderr != "" {
+		fmt.Fprintln(r.Stderr, resp.Stderr)
+	}
+	return resp.ExitCode
+}
+
+// fail reports an error and returns ExitError.
+func (r *Runner) fail(err error) int {
+	fmt.Fprintln(r.Stderr, "hook:", err)
+	return ExitError
+}
+
+// Main runs the handler for the current process and exits with its code.
+func Main(h Handler) {
+	os.Exit(NewRunner().Run(h))
+}
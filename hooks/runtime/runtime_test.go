@@ -0,0 +1,218 @@
+package runtime
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+// payloads holds a pre-tool shell payload from each tool.
+var payloads = map[string]string{
+	"claude":   `{"session_id":"s1","transcript_path":"/t.jsonl","cwd":"/repo","hook_event_name":"PreToolUse","tool_name":"Bash","tool_input":{"command":"rm -rf /"}}`,
+	"codex":    `{"session_id":"s1","cwd":"/repo","hook_event_name":"PreToolUse","tool_name":"Bash","tool_input":{"command":"rm -rf /"}}`,
+	"cursor":   `{"conversation_id":"s1","generation_id":"g1","hook_event_name":"beforeShellExecution","workspace_roots":["/repo"],"command":"rm -rf /","cwd":""}`,
+	"gemini":   `{"session_id":"s1","cwd":"/repo","hook_event_name":"BeforeTool","timestamp":"2025-01-01T00:00:00Z","tool_name":"run_shell_command","tool_input":{"command":"rm -rf /"}}`,
+	"kiro":     `{"hook_event_name":"preToolUse","cwd":"/repo","tool_name":"execute_bash","tool_input":{"command":"rm -rf /"}}`,
+	"vscode":   `{"timestamp":1704614600000,"cwd":"/repo","toolName":"bash","toolArgs":"{\"command\":\"rm -rf /\"}"}`,
+	"windsurf": `{"agent_action_name":"pre_run_command","trajectory_id":"s1","execution_id":"e1","timestamp":"2025-01-01T00:00:00Z","tool_info":{"command_line":"rm -rf /","cwd":"/repo"}}`,
+}
+
+func TestDecodeCommandPayloads(t *testing.T) {
+	for tool, payload := range payloads {
+		t.Run(tool, func(t *testing.T) {
+			explicit := ""
+			if tool == "codex" {
+				explicit = tool
+			}
+			p, err := Decode(explicit, "", []byte(payload))
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if p.Adapter != tool {
+				t.Errorf("Expected adapter %q, got %q", tool, p.Adapter)
+			}
+			if p.Event != core.BeforeCommand {
+				t.Errorf("Expected event %q, got %q", core.BeforeCommand, p.Event)
+			}
+			if p.Command != "rm -rf /" {
+				t.Errorf("Expected command 'rm -rf /', got %q", p.Command)
+			}
+			if p.Cwd != "/repo" {
+				t.Errorf("Expected cwd '/repo', got %q", p.Cwd)
+			}
+		})
+	}
+}
+
+func TestEncodeDeny(t *testing.T) {
+	tests := []struct {
+		tool     string
+		exitCode int
+		stdout   string
+		stderr   string
+	}{
+		{"claude", 0, `{"hookSpecificOutput":{"hookEventName":"PreToolUse","permissionDecision":"deny","permissionDecisionReason":"destructive"}}`, ""},
+		{"codex", 0, `{"hookSpecificOutput":{"hookEventName":"PreToolUse","permissionDecision":"deny","permissionDecisionReason":"destructive"}}`, ""},
+		{"cursor", 0, `{"permission":"deny","user_message":"destructive","agent_message":"destructive"}`, ""},
+		{"gemini", 0, `{"decision":"deny","reason":"destructive"}`, ""},
+		{"kiro", 2, "", "destructive"},
+		{"vscode", 0, `{"permissionDecision":"deny","permissionDecisionReason":"destructive"}`, ""},
+		{"windsurf", 2, "", "destructive"},
+	}
+
+	deny := func(p *core.Payload) (core.Decision, error) {
+		if strings.Contains(p.Command, "rm -rf") {
+			return Deny("destructive"), nil
+		}
+		return Allow(), nil
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			resp, err := Handle(tt.tool, "", []byte(payloads[tt.tool]), deny)
+			if err != nil {
+				t.Fatalf("Handle failed: %v", err)
+			}
+			if resp.ExitCode != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, resp.ExitCode)
+			}
+			if got := strings.TrimSpace(string(resp.Stdout)); got != tt.stdout {
+				t.Errorf("Expected stdout %s, got %s", tt.stdout, got)
+			}
+			if resp.Stderr != tt.stderr {
+				t.Errorf("Expected stderr %q, got %q", tt.stderr, resp.Stderr)
+			}
+		})
+	}
+}
+
+func TestEncodeAskAndModify(t *testing.T) {
+	tests := []struct {
+		tool     string
+		decision core.Decision
+		stdout   string
+		err      bool
+	}{
+		{"claude", Ask("confirm"), `{"hookSpecificOutput":{"hookEventName":"PreToolUse","permissionDecision":"ask","permissionDecisionReason":"confirm"}}`, false},
+		{"claude", Modify(map[string]any{"command": "ls"}, ""), `{"hookSpecificOutput":{"hookEventName":"PreToolUse","permissionDecision":"allow","updatedInput":{"command":"ls"}}}`, false},
+		{"cursor", Ask("confirm"), `{"permission":"ask","user_message":"confirm","agent_message":"confirm"}`, false},
+		{"cursor", Modify(map[string]any{"command": "ls"}, ""), "", true},
+		{"gemini", Modify(map[string]any{"command": "ls"}, ""), `{"decision":"allow","hookSpecificOutput":{"hookEventName":"BeforeTool","tool_input":{"command":"ls"}}}`, false},
+		{"vscode", Ask("confirm"), `{"permissionDecision":"deny","permissionDecisionReason":"confirm"}`, false},
+		{"windsurf", Modify(map[string]any{"command": "ls"}, ""), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool+"/"+string(tt.decision.Action), func(t *testing.T) {
+			resp, err := Handle(tt.tool, "", []byte(payloads[tt.tool]), func(*core.Payload) (core.Decision, error) {
+				return tt.decision, nil
+			})
+			if tt.err {
+				if !errors.Is(err, core.ErrUnsupportedDecision) {
+					t.Errorf("Expected ErrUnsupportedDecision, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Handle failed: %v", err)
+			}
+			if got := strings.TrimSpace(string(resp.Stdout)); got != tt.stdout {
+				t.Errorf("Expected stdout %s, got %s", tt.stdout, got)
+			}
+		})
+	}
+}
+
+func TestNoDecision(t *testing.T) {
+	for tool, payload := range payloads {
+		resp, err := Handle(tool, "", []byte(payload), func(*core.Payload) (core.Decision, error) {
+			return core.Decision{}, nil
+		})
+		if err != nil {
+			t.Fatalf("%s: Handle failed: %v", tool, err)
+		}
+		if resp.ExitCode != 0 || len(resp.Stdout) != 0 {
+			t.Errorf("%s: Expected no output, got exit %d, stdout %q", tool, resp.ExitCode, resp.Stdout)
+		}
+	}
+}
+
+func TestDecodeToolEvents(t *testing.T) {
+	tests := []struct {
+		tool     string
+		payload  string
+		expected core.Event
+		path     string
+	}{
+		{"claude", `{"session_id":"s","hook_event_name":"PostToolUse","tool_name":"Edit","tool_input":{"file_path":"a.go"}}`, core.AfterFileWrite, "a.go"},
+		{"claude", `{"session_id":"s","hook_event_name":"PreToolUse","tool_name":"mcp__github__create_issue"}`, core.BeforeMCP, ""},
+		{"claude", `{"session_id":"s","hook_event_name":"PreToolUse","tool_name":"Grep"}`, core.BeforeTool, ""},
+		{"claude", `{"session_id":"s","hook_event_name":"UserPromptSubmit","prompt":"hi"}`, core.BeforePrompt, ""},
+		{"cursor", `{"conversation_id":"c","hook_event_name":"beforeReadFile","file_path":"a.go"}`, core.BeforeFileRead, "a.go"},
+		{"cursor", `{"conversation_id":"c","hook_event_name":"stop","status":"completed"}`, core.OnStop, ""},
+		{"gemini", `{"session_id":"s","hook_event_name":"SessionStart","timestamp":"t"}`, core.OnSessionStart, ""},
+		{"gemini", `{"session_id":"s","hook_event_name":"AfterTool","timestamp":"t","tool_name":"write_file","tool_input":{"file_path":"a.go"}}`, core.AfterFileWrite, "a.go"},
+		{"kiro", `{"hook_event_name":"stop","cwd":"/repo"}`, core.OnStop, ""},
+		{"kiro", `{"hook_event_name":"preToolUse","tool_name":"@git/status"}`, core.BeforeMCP, ""},
+		{"vscode", `{"timestamp":1,"cwd":"/repo","toolName":"edit","toolArgs":"{\"path\":\"a.go\"}","toolResult":{"resultType":"success"}}`, core.AfterFileWrite, "a.go"},
+		{"vscode", `{"timestamp":1,"cwd":"/repo","prompt":"hi"}`, core.BeforePrompt, ""},
+		{"windsurf", `{"agent_action_name":"post_write_code","tool_info":{"file_path":"a.go","edits":[]}}`, core.AfterFileWrite, "a.go"},
+	}
+	for _, tt := range tests {
+		tool, err := core.DefaultRegistry.Detect([]byte(tt.payload))
+		if err != nil || tool != tt.tool {
+			t.Errorf("Expected %s payload to be detected, got %q, %v", tt.tool, tool, err)
+			continue
+		}
+		p, err := Decode("", "", []byte(tt.payload))
+		if err != nil {
+			t.Errorf("%s: Decode failed: %v", tt.tool, err)
+			continue
+		}
+		if p.Event != tt.expected {
+			t.Errorf("%s %s: Expected event %q, got %q", tt.tool, p.NativeEvent, tt.expected, p.Event)
+		}
+		if p.FilePath != tt.path {
+			t.Errorf("%s %s: Expected file path %q, got %q", tt.tool, p.NativeEvent, tt.path, p.FilePath)
+		}
+	}
+}
+
+func TestDecodeUnknownPayload(t *testing.T) {
+	_, err := Decode("", "", []byte(`{"hello":"world"}`))
+	if !errors.Is(err, core.ErrUnknownPayload) {
+		t.Errorf("Expected ErrUnknownPayload, got %v", err)
+	}
+	_, err = Decode("claude", "", []byte(`{"hook_event_name":"Unknown"}`))
+	if !errors.Is(err, core.ErrUnsupportedEvent) {
+		t.Errorf("Expected ErrUnsupportedEvent, got %v", err)
+	}
+}
+
+func TestRunner(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	r := &Runner{
+		Stdin:  strings.NewReader(payloads["windsurf"]),
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}
+	code := r.Run(func(p *core.Payload) (core.Decision, error) {
+		return Deny("blocked by policy"), nil
+	})
+	if code != core.ExitBlock {
+		t.Errorf("Expected exit code %d, got %d", core.ExitBlock, code)
+	}
+	if stderr.String() != "blocked by policy\n" {
+		t.Errorf("Expected reason on stderr, got %q", stderr.String())
+	}
+
+	stderr.Reset()
+	r.Stdin = strings.NewReader(payloads["claude"])
+	code = r.Run(func(p *core.Payload) (core.Decision, error) {
+		return core.Decision{}, errors.New("policy unavailable")
+	})
+	if code != ExitError || !strings.Contains(stderr.String(), "policy unavailable") {
+		t.Errorf("Expected handler error, got exit %d, stderr %q", code, stderr.String())
+	}
+}
@@ -0,0 +1,229 @@
+package vscode
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/merge"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "vscode"
+
+	// ConfigFileName is the hooks config file name.
+	ConfigFileName = "hooks.json"
+
+	// ProjectConfigDir is the project config directory.
+	ProjectConfigDir = ".github/hooks"
+)
+
+// Adapter implements core.Adapter for VS Code (GitHub Copilot) hooks.
+type Adapter struct{}
+
+// NewAdapter creates a new VS Code hooks adapter.
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Name returns the adapter name.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns the default config file paths for Copilot hooks.
+// Copilot hooks are defined per repository only.
+func (a *Adapter) DefaultPaths() []string {
+	return []string{
+		filepath.Join(ProjectConfigDir, ConfigFileName),
+	}
+}
+
+// SupportedEvents returns the events supported by Copilot.
+func (a *Adapter) SupportedEvents() []core.Event {
+	return []core.Event{
+		core.BeforeFileRead, core.AfterFileRead,
+		core.BeforeFileWrite, core.AfterFileWrite,
+		core.BeforeCommand, core.AfterCommand,
+		core.BeforeMCP, core.AfterMCP,
+		core.BeforeTool, core.AfterTool,
+		core.BeforePrompt,
+		core.OnSessionStart, core.OnSessionEnd,
+	}
+}
+
+// Parse parses Copilot hooks config data into the canonical format.
+func (a *Adapter) Parse(data []byte) (*core.Config, error) {
+	var vscodeCfg Config
+	if err := json.Unmarshal(data, &vscodeCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return a.ToCore(&vscodeCfg), nil
+}
+
+// Marshal converts canonical config to Copilot format.
+func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	vscodeCfg := a.FromCore(cfg)
+	return json.MarshalIndent(vscodeCfg, "", "  ")
+}
+
+// ReadFile reads a Copilot hooks config file.
+func (a *Adapter) ReadFile(path string) (*core.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Path: path, Err: err}
+	}
+	cfg, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteFile writes canonical config to a Copilot format file.
+func (a *Adapter) WriteFile(cfg *core.Config, path string) error {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	return nil
+}
+
+// ToCore converts Copilot hooks config to canonical format. The hooks of
+// each event are collected into a single entry. The bash command is used
+// when set, otherwise the PowerShell command.
+func (a *Adapter) ToCore(vscodeCfg *Config) *core.Config {
+	cfg := core.NewConfig()
+	cfg.Version = vscodeCfg.Version
+
+	for vscodeEvent, hooks := range vscodeCfg.Hooks {
+		canonicalEvent, ok := reverseEventMapping[vscodeEvent]
+		if !ok || len(hooks) == 0 {
+			continue
+		}
+
+		var coreHooks []core.Hook
+		for _, h := range hooks {
+			command := h.Bash
+			if command == "" {
+				command = h.PowerShell
+			}
+			coreHooks = append(coreHooks, core.Hook{
+				Type:       core.HookTypeCommand,
+				Command:    command,
+				Timeout:    h.TimeoutSec,
+				WorkingDir: h.Cwd,
+			})
+		}
+
+		cfg.Hooks[canonicalEvent] = append(cfg.Hooks[canonicalEvent], core.HookEntry{
+			Hooks: coreHooks,
+		})
+	}
+
+	return cfg
+}
+
+// FromCore converts canonical config to Copilot format. Commands are
+// written as bash commands, and prompt hooks are skipped.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	vscodeCfg := NewConfig()
+	if cfg.Version > 0 {
+		vscodeCfg.Version = cfg.Version
+	}
+
+	for _, event := range core.AllEvents() {
+		vscodeEvent, ok := eventMapping[event]
+		if !ok {
+			continue // Event not supported by Copilot
+		}
+
+		for _, entry := range cfg.Hooks[event] {
+			for _, h := range entry.Hooks {
+				if h.Command == "" {
+					continue
+				}
+				vscodeCfg.Hooks[vscodeEvent] = append(vscodeCfg.Hooks[vscodeEvent], Hook{
+					Type:       "command",
+					Bash:       h.Command,
+					Cwd:        h.WorkingDir,
+					TimeoutSec: h.Timeout,
+				})
+			}
+		}
+	}
+
+	return vscodeCfg
+}
+
+// Lossiness reports the canonical fields that the Copilot format drops or degrades.
+// Copilot hooks have no matcher, so entries of tool-specific events run
+// for every tool and are read back as before_tool or after_tool.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, a.SupportedEvents(), cfg,
+		core.FieldVersion, core.FieldTimeout, core.FieldWorkingDir)
+
+	for _, event := range core.AllEvents() {
+		vscodeEvent, ok := eventMapping[event]
+		if !ok {
+			continue
+		}
+		got := reverseEventMapping[vscodeEvent]
+		if got == event {
+			continue
+		}
+		for i := range cfg.Hooks[event] {
+			losses = append(losses, core.Loss{
+				Adapter: AdapterName,
+				Path:    core.EntryPath(event, i),
+				Kind:    core.LossDegraded,
+				Detail:  fmt.Sprintf("entry runs for every tool and is read back as event %s", got),
+			})
+		}
+	}
+	return losses
+}
+
+// Merge writes cfg into an existing Copilot hooks file, replacing only
+// the version and hooks keys and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "version", "hooks")
+}
+
+// ProjectConfigPath returns the project hooks config path.
+func ProjectConfigPath() string {
+	return filepath.Join(ProjectConfigDir, ConfigFileName)
+}
+
+// ReadProjectConfig reads the project-level .github/hooks/hooks.json.
+func ReadProjectConfig() (*core.Config, error) {
+	adapter := NewAdapter()
+	return adapter.ReadFile(ProjectConfigPath())
+}
+
+// WriteProjectConfig writes to the project-level .github/hooks/hooks.json.
+func WriteProjectConfig(cfg *core.Config) error {
+	if err := os.MkdirAll(ProjectConfigDir, 0755); err != nil {
+		return err
+	}
+	adapter := NewAdapter()
+	return adapter.WriteFile(cfg, ProjectConfigPath())
+}
+
+// init registers the adapter with the default registry.
+func init() {
+	core.Register(NewAdapter())
+}
@@ -0,0 +1,115 @@
+package vscode
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+func TestAdapterName(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter.Name() != "vscode" {
+		t.Errorf("Expected name 'vscode', got %q", adapter.Name())
+	}
+	if paths := adapter.DefaultPaths(); len(paths) != 1 || paths[0] != ProjectConfigPath() {
+		t.Errorf("Expected only the project path, got %v", paths)
+	}
+}
+
+func TestAdapterParse(t *testing.T) {
+	data := `{
+		"version": 1,
+		"hooks": {
+			"sessionStart": [{"type": "command", "bash": "./start.sh", "powershell": "./start.ps1"}],
+			"preToolUse": [
+				{"type": "command", "bash": "./guard.sh", "cwd": "scripts", "timeoutSec": 15},
+				{"type": "command", "powershell": "./audit.ps1"}
+			],
+			"errorOccurred": [{"type": "command", "bash": "./error.sh"}]
+		}
+	}`
+
+	cfg, err := NewAdapter().Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if cfg.Version != 1 {
+		t.Errorf("Expected version 1, got %d", cfg.Version)
+	}
+	if cfg.HookCount() != 3 {
+		t.Errorf("Expected 3 hooks (errorOccurred has no canonical event), got %d", cfg.HookCount())
+	}
+
+	entries := cfg.GetHooks(core.BeforeTool)
+	if len(entries) != 1 || len(entries[0].Hooks) != 2 {
+		t.Fatalf("Expected one before_tool entry with 2 hooks, got %+v", entries)
+	}
+	guard := entries[0].Hooks[0]
+	if guard.Command != "./guard.sh" || guard.WorkingDir != "scripts" || guard.Timeout != 15 {
+		t.Errorf("Unexpected hook %+v", guard)
+	}
+	if audit := entries[0].Hooks[1]; audit.Command != "./audit.ps1" {
+		t.Errorf("Expected PowerShell command fallback, got %q", audit.Command)
+	}
+}
+
+func TestAdapterMarshal(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddHook(core.BeforeCommand, core.NewCommandHook("./guard.sh").WithTimeout(10))
+	cfg.AddHook(core.BeforePrompt, core.NewCommandHook("./prompt.sh"))
+
+	data, err := NewAdapter().Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var vscodeCfg Config
+	if err := json.Unmarshal(data, &vscodeCfg); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if vscodeCfg.Version != ConfigVersion {
+		t.Errorf("Expected version %d, got %d", ConfigVersion, vscodeCfg.Version)
+	}
+	pre := vscodeCfg.Hooks[PreToolUse]
+	if len(pre) != 1 || pre[0].Bash != "./guard.sh" || pre[0].TimeoutSec != 10 || pre[0].Type != "command" {
+		t.Errorf("Unexpected preToolUse hooks: %+v", pre)
+	}
+	if prompt := vscodeCfg.Hooks[UserPromptSubmitted]; len(prompt) != 1 {
+		t.Errorf("Expected userPromptSubmitted hook, got %+v", prompt)
+	}
+}
+
+func TestAdapterLossiness(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddHookWithMatcher(core.BeforeCommand, "Bash", core.NewCommandHook("./guard.sh"))
+	cfg.AddHook(core.AfterTool, core.NewCommandHook("./log.sh").WithShowOutput(true))
+	cfg.AddHook(core.OnStop, core.NewCommandHook("./stop.sh"))
+
+	var got []string
+	for _, loss := range NewAdapter().Lossiness(cfg) {
+		got = append(got, loss.String())
+	}
+	expected := []string{
+		"vscode: hooks.after_tool[0].hooks[0].showOutput dropped",
+		"vscode: hooks.before_command[0].matcher dropped",
+		"vscode: hooks.on_stop dropped: event is not supported",
+		"vscode: hooks.before_command[0] degraded: entry runs for every tool and is read back as event before_tool",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected losses:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestAdapterMerge(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddHook(core.OnSessionEnd, core.NewCommandHook("./end.sh"))
+
+	data, err := NewAdapter().Merge(cfg, []byte(`{"version": 1, "comment": "team hooks", "hooks": {"sessionStart": []}}`))
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if !strings.Contains(string(data), "team hooks") || strings.Contains(string(data), "sessionStart") {
+		t.Errorf("Expected comment kept and hooks replaced, got %s", data)
+	}
+}
@@ -0,0 +1,100 @@
+// Package vscode provides an adapter for GitHub Copilot hooks configuration
+// as used by VS Code agent mode and the Copilot coding agent.
+//
+// Copilot hooks are configured in JSON files in the repository:
+//   - Project: .github/hooks/hooks.json
+//
+// Copilot hook events:
+//   - sessionStart: At session start
+//   - sessionEnd: At session end
+//   - userPromptSubmitted: When user submits a prompt
+//   - preToolUse: Before tool execution (can deny)
+//   - postToolUse: After tool execution
+//   - errorOccurred: When an error occurs
+//
+// Hooks have no matcher; a tool hook runs for every tool and inspects the
+// tool name in its input. Commands are given separately for bash and
+// PowerShell.
+package vscode
+
+import "github.com/agentplexus/assistantkit/hooks/core"
+
+// VSCodeEvent represents Copilot hook event names.
+type VSCodeEvent string
+
+const (
+	SessionStart        VSCodeEvent = "sessionStart"
+	SessionEnd          VSCodeEvent = "sessionEnd"
+	UserPromptSubmitted VSCodeEvent = "userPromptSubmitted"
+	PreToolUse          VSCodeEvent = "preToolUse"
+	PostToolUse         VSCodeEvent = "postToolUse"
+	ErrorOccurred       VSCodeEvent = "errorOccurred"
+)
+
+// ConfigVersion is the hooks file format version written by the adapter.
+const ConfigVersion = 1
+
+// Config represents a Copilot hooks.json file.
+type Config struct {
+	Version int                    `json:"version"`
+	Hooks   map[VSCodeEvent][]Hook `json:"hooks,omitempty"`
+}
+
+// Hook represents a single Copilot hook definition.
+type Hook struct {
+	// Type is always "command".
+	Type string `json:"type"`
+
+	// Bash is the command to run on Linux and macOS.
+	Bash string `json:"bash,omitempty"`
+
+	// PowerShell is the command to run on Windows.
+	PowerShell string `json:"powershell,omitempty"`
+
+	// Cwd is the working directory, relative to the repository root.
+	Cwd string `json:"cwd,omitempty"`
+
+	// Env sets environment variables for the command.
+	Env map[string]string `json:"env,omitempty"`
+
+	// TimeoutSec is the timeout in seconds for hook execution.
+	TimeoutSec int `json:"timeoutSec,omitempty"`
+
+	// Comment documents the hook.
+	Comment string `json:"comment,omitempty"`
+}
+
+// NewConfig creates a new empty Copilot hooks config.
+func NewConfig() *Config {
+	return &Config{
+		Version: ConfigVersion,
+		Hooks:   make(map[VSCodeEvent][]Hook),
+	}
+}
+
+// eventMapping maps canonical events to Copilot events. Tool-specific
+// events run for every tool since Copilot hooks have no matcher.
+var eventMapping = map[core.Event]VSCodeEvent{
+	core.BeforeFileRead:  PreToolUse,
+	core.AfterFileRead:   PostToolUse,
+	core.BeforeFileWrite: PreToolUse,
+	core.AfterFileWrite:  PostToolUse,
+	core.BeforeCommand:   PreToolUse,
+	core.AfterCommand:    PostToolUse,
+	core.BeforeMCP:       PreToolUse,
+	core.AfterMCP:        PostToolUse,
+	core.BeforeTool:      PreToolUse,
+	core.AfterTool:       PostToolUse,
+	core.BeforePrompt:    UserPromptSubmitted,
+	core.OnSessionStart:  SessionStart,
+	core.OnSessionEnd:    SessionEnd,
+}
+
+// reverseEventMapping maps Copilot events back to canonical events.
+var reverseEventMapping = map[VSCodeEvent]core.Event{
+	PreToolUse:          core.BeforeTool,
+	PostToolUse:         core.AfterTool,
+	UserPromptSubmitted: core.BeforePrompt,
+	SessionStart:        core.OnSessionStart,
+	SessionEnd:          core.OnSessionEnd,
+}
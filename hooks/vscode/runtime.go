@@ -0,0 +1,203 @@
+package vscode
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+// Payload is the JSON Copilot writes to a hook's stdin. It does not name
+// the event, so the event is inferred from the fields that are set.
+type Payload struct {
+	Timestamp     json.Number     `json:"timestamp,omitempty"`
+	Cwd           string          `json:"cwd,omitempty"`
+	ToolName      string          `json:"toolName,omitempty"`
+	ToolArgs      string          `json:"toolArgs,omitempty"`
+	ToolResult    json.RawMessage `json:"toolResult,omitempty"`
+	Prompt        string          `json:"prompt,omitempty"`
+	Source        string          `json:"source,omitempty"`
+	InitialPrompt string          `json:"initialPrompt,omitempty"`
+	Reason        string          `json:"reason,omitempty"`
+	Error         json.RawMessage `json:"error,omitempty"`
+}
+
+// Output is the JSON a Copilot preToolUse hook writes to stdout.
+type Output struct {
+	PermissionDecision       string `json:"permissionDecision,omitempty"`
+	PermissionDecisionReason string `json:"permissionDecisionReason,omitempty"`
+}
+
+// toolEvents maps Copilot tool names to canonical before events.
+var toolEvents = map[string]core.Event{
+	"bash":       core.BeforeCommand,
+	"powershell": core.BeforeCommand,
+	"view":       core.BeforeFileRead,
+	"edit":       core.BeforeFileWrite,
+	"create":     core.BeforeFileWrite,
+}
+
+// Detect reports whether a payload was sent by Copilot: it has a timestamp
+// and a working directory but, unlike the other tools, no event name.
+func (a *Adapter) Detect(fields map[string]json.RawMessage) bool {
+	return core.HasField(fields, "timestamp") && core.HasField(fields, "cwd") &&
+		!core.HasField(fields, "hook_event_name") && !core.HasField(fields, "agent_action_name")
+}
+
+// DecodePayload converts a Copilot hook payload to canonical form.
+func (a *Adapter) DecodePayload(data []byte, nativeEvent string) (*core.Payload, error) {
+	var in Payload
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	event := VSCodeEvent(nativeEvent)
+	if event == "" {
+		event = inferEvent(&in)
+	}
+
+	p := &core.Payload{
+		Adapter:     AdapterName,
+		NativeEvent: string(event),
+		Cwd:         in.Cwd,
+		ToolName:    in.ToolName,
+		Prompt:      in.Prompt,
+		Raw:         data,
+	}
+	if p.Prompt == "" {
+		p.Prompt = in.InitialPrompt
+	}
+	if in.ToolArgs != "" {
+		_ = json.Unmarshal([]byte(in.ToolArgs), &p.ToolInput)
+	}
+	if len(in.ToolResult) > 0 {
+		p.ToolOutput = in.ToolResult
+	}
+
+	switch event {
+	case PreToolUse:
+		p.Event = toolEvent(in.ToolName)
+	case PostToolUse:
+		p.Event = toolEvent(in.ToolName).AfterEvent()
+	default:
+		canonical, ok := reverseEventMapping[event]
+		if !ok {
+			return nil, &core.ParseError{Format: AdapterName, Err: core.ErrUnsupportedEvent}
+		}
+		p.Event = canonical
+	}
+	p.Command = p.InputString("command")
+	p.FilePath = p.InputString("path")
+	return p, nil
+}
+
+// EncodeDecision serializes a decision as Copilot hook output. Only deny is
+// honored for preToolUse, so ask is sent as deny and allow needs no output.
+func (a *Adapter) EncodeDecision(p *core.Payload, d core.Decision) (*core.Response, error) {
+	switch d.Action {
+	case "", core.ActionAllow:
+		return &core.Response{}, nil
+	case core.ActionDeny, core.ActionAsk:
+		if VSCodeEvent(p.NativeEvent) == PreToolUse {
+			return core.JSONResponse(Output{PermissionDecision: string(core.ActionDeny), PermissionDecisionReason: d.Reason})
+		}
+	}
+	return nil, &core.DecisionError{Format: AdapterName, Event: p.NativeEvent, Action: d.Action}
+}
+
+// inferEvent returns the event a payload was sent for.
+func inferEvent(in *Payload) VSCodeEvent {
+	switch {
+	case len(in.ToolResult) > 0:
+		return PostToolUse
+	case in.ToolName != "":
+		return PreToolUse
+	case len(in.Error) > 0:
+		return ErrorOccurred
+	case in.Prompt != "":
+		return UserPromptSubmitted
+	case in.Reason != "":
+		return SessionEnd
+	default:
+		return SessionStart
+	}
+}
+
+// toolEvent returns the canonical before event for a call of the named tool.
+func toolEvent(name string) core.Event {
+	if event, ok := toolEvents[name]; ok {
+		return event
+	}
+	return core.BeforeTool
+}
+
+// toolNames maps canonical tool events to the tool named in simulated payloads.
+var toolNames = map[core.Event]string{
+	core.BeforeFileRead:  "view",
+	core.AfterFileRead:   "view",
+	core.BeforeFileWrite: "edit",
+	core.AfterFileWrite:  "edit",
+	core.BeforeCommand:   "bash",
+	core.AfterCommand:    "bash",
+}
+
+// EncodePayload renders a canonical payload as Copilot's hook stdin.
+func (a *Adapter) EncodePayload(p *core.Payload) ([]byte, string, error) {
+	event := VSCodeEvent(p.NativeEvent)
+	if event == "" {
+		if event = eventMapping[p.Event]; event == "" {
+			return nil, "", &core.ConversionError{To: AdapterName, Event: p.Event, Err: core.ErrUnsupportedEvent}
+		}
+	}
+	out := Payload{
+		Timestamp: json.Number(strconv.FormatInt(time.Now().UnixMilli(), 10)),
+		Cwd:       p.Cwd,
+	}
+	switch event {
+	case PreToolUse, PostToolUse:
+		out.ToolName = p.ToolName
+		if out.ToolName == "" {
+			out.ToolName = toolNames[p.Event]
+		}
+		if input := p.ToolInputWith("command", "path"); input != nil {
+			args, err := json.Marshal(input)
+			if err != nil {
+				return nil, "", err
+			}
+			out.ToolArgs = string(args)
+		}
+		if event == PostToolUse {
+			out.ToolResult = json.RawMessage(`{"resultType":"success"}`)
+		}
+	case UserPromptSubmitted:
+		out.Prompt = p.Prompt
+	case SessionStart:
+		out.Source = "new"
+		out.InitialPrompt = p.Prompt
+	case SessionEnd:
+		out.Reason = "complete"
+	}
+	data, err := json.Marshal(out)
+	return data, string(event), err
+}
+
+// Matches reports whether Copilot runs an entry registered for event when it
+// sends p. Copilot hooks have no matcher, so a hook for any tool event runs
+// for every tool.
+func (a *Adapter) Matches(event core.Event, entry core.HookEntry, p *core.Payload) bool {
+	native, ok := eventMapping[event]
+	return ok && string(native) == p.NativeEvent
+}
+
+// DecodeDecision reads a Copilot hook's response. Only a preToolUse deny is
+// honored.
+func (a *Adapter) DecodeDecision(p *core.Payload, resp *core.Response) core.Decision {
+	var out Output
+	if VSCodeEvent(p.NativeEvent) != PreToolUse || resp.ExitCode != 0 || !core.DecodeJSON(resp, &out) {
+		return core.Decision{}
+	}
+	if out.PermissionDecision == string(core.ActionDeny) {
+		return core.Decision{Action: core.ActionDeny, Reason: out.PermissionDecisionReason}
+	}
+	return core.Decision{}
+}
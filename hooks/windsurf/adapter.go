@@ -7,6 +7,7 @@ import (
 	"runtime"
 
 	"github.com/agentplexus/assistantkit/hooks/core"
+	"github.com/agentplexus/assistantkit/merge"
 )
 
 const (
@@ -169,6 +170,23 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	return windsurfCfg
 }
 
+// Lossiness reports the canonical fields that the Windsurf format drops or degrades.
+// Windsurf hooks are commands without matchers, prompts or timeouts.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	return core.DroppedFields(AdapterName, a.SupportedEvents(), cfg,
+		core.FieldShowOutput, core.FieldWorkingDir)
+}
+
+// Merge writes cfg into an existing Windsurf settings file, replacing only
+// the hooks key and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "hooks")
+}
+
 // WorkspaceConfigPath returns the workspace hooks config path.
 func WorkspaceConfigPath() string {
 	return filepath.Join(WorkspaceConfigDir, ConfigFileName)
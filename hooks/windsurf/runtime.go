@@ -0,0 +1,125 @@
+package windsurf
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/assistantkit/hooks/core"
+)
+
+// Payload is the JSON Windsurf writes to a hook's stdin.
+type Payload struct {
+	AgentActionName WindsurfEvent  `json:"agent_action_name"`
+	TrajectoryID    string         `json:"trajectory_id,omitempty"`
+	ExecutionID     string         `json:"execution_id,omitempty"`
+	Timestamp       string         `json:"timestamp,omitempty"`
+	ToolInfo        map[string]any `json:"tool_info,omitempty"`
+}
+
+// Detect reports whether a payload was sent by Windsurf.
+func (a *Adapter) Detect(fields map[string]json.RawMessage) bool {
+	_, ok := reverseEventMapping[WindsurfEvent(core.StringField(fields, "agent_action_name"))]
+	return ok
+}
+
+// DecodePayload converts a Windsurf hook payload to canonical form. The
+// tool_info object becomes the tool input.
+func (a *Adapter) DecodePayload(data []byte, nativeEvent string) (*core.Payload, error) {
+	var in Payload
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	if nativeEvent != "" {
+		in.AgentActionName = WindsurfEvent(nativeEvent)
+	}
+	event, ok := reverseEventMapping[in.AgentActionName]
+	if !ok {
+		return nil, &core.ParseError{Format: AdapterName, Err: core.ErrUnsupportedEvent}
+	}
+
+	p := &core.Payload{
+		Adapter:     AdapterName,
+		Event:       event,
+		NativeEvent: string(in.AgentActionName),
+		SessionID:   in.TrajectoryID,
+		ToolInput:   in.ToolInfo,
+		Raw:         data,
+	}
+	p.Cwd = p.InputString("cwd")
+	p.Command = p.InputString("command_line")
+	p.FilePath = p.InputString("file_path")
+	p.Prompt = p.InputString("user_prompt")
+	p.ToolName = p.InputString("mcp_tool_name")
+	if args, ok := in.ToolInfo["mcp_tool_arguments"].(map[string]any); ok {
+		p.ToolInput = args
+	}
+	return p, nil
+}
+
+// EncodeDecision serializes a decision as a Windsurf exit code. Pre hooks
+// block with exit code 2 and the reason on stderr; Windsurf cannot prompt,
+// so ask blocks too. Post hooks cannot block.
+func (a *Adapter) EncodeDecision(p *core.Payload, d core.Decision) (*core.Response, error) {
+	switch d.Action {
+	case "", core.ActionAllow:
+		return &core.Response{}, nil
+	case core.ActionDeny, core.ActionAsk:
+		if strings.HasPrefix(p.NativeEvent, "pre_") {
+			return core.BlockResponse(d.Reason), nil
+		}
+	}
+	return nil, &core.DecisionError{Format: AdapterName, Event: p.NativeEvent, Action: d.Action}
+}
+
+// EncodePayload renders a canonical payload as Windsurf's hook stdin.
+func (a *Adapter) EncodePayload(p *core.Payload) ([]byte, string, error) {
+	event := WindsurfEvent(p.NativeEvent)
+	if event == "" {
+		if event = eventMapping[p.Event]; event == "" {
+			return nil, "", &core.ConversionError{To: AdapterName, Event: p.Event, Err: core.ErrUnsupportedEvent}
+		}
+	}
+	info := make(map[string]any)
+	set := func(key, value string) {
+		if value != "" {
+			info[key] = value
+		}
+	}
+	set("cwd", p.Cwd)
+	set("command_line", p.Command)
+	set("file_path", p.FilePath)
+	set("user_prompt", p.Prompt)
+	if event == PreMCPToolUse || event == PostMCPToolUse {
+		set("mcp_server_name", "test")
+		set("mcp_tool_name", p.ToolName)
+		if p.ToolInput != nil {
+			info["mcp_tool_arguments"] = p.ToolInput
+		}
+	}
+	out := Payload{
+		AgentActionName: event,
+		TrajectoryID:    p.SessionID,
+		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+		ToolInfo:        info,
+	}
+	data, err := json.Marshal(out)
+	return data, string(event), err
+}
+
+// Matches reports whether Windsurf runs an entry registered for event when
+// it sends p. Windsurf hooks have no matcher, so every entry for the event
+// runs.
+func (a *Adapter) Matches(event core.Event, entry core.HookEntry, p *core.Payload) bool {
+	native, ok := eventMapping[event]
+	return ok && string(native) == p.NativeEvent
+}
+
+// DecodeDecision reads a Windsurf hook's response: exit code 2 blocks a pre
+// hook, and everything else lets the action proceed.
+func (a *Adapter) DecodeDecision(p *core.Payload, resp *core.Response) core.Decision {
+	if resp.ExitCode == core.ExitBlock && strings.HasPrefix(p.NativeEvent, "pre_") {
+		return core.Decision{Action: core.ActionDeny, Reason: resp.Stderr}
+	}
+	return core.Decision{}
+}
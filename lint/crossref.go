@@ -0,0 +1,168 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agentplexus/assistantkit/models"
+	"github.com/agentplexus/assistantkit/requirements"
+)
+
+// Cross-reference rule identifiers.
+const (
+	RuleUnknownAgent       = "unknown-agent"
+	RuleUnknownSkill       = "unknown-skill"
+	RuleUnknownTeam        = "unknown-team"
+	RuleUnknownRequirement = "unknown-requirement"
+	RuleAgentNotInTeam     = "agent-not-in-team"
+	RuleUnusedAgent        = "unused-agent"
+	RuleUnusedSkill        = "unused-skill"
+	RuleUnknownModel       = "unknown-model"
+)
+
+// crossReference checks references between the loaded specs: task agents,
+// agent skills, deployment teams, agent requirements and agent models.
+func (l *linter) crossReference() {
+	agentsByRef := make(map[string]*agentFile)
+	for _, af := range l.agents {
+		agentsByRef[af.agent.Name] = af
+		agentsByRef[af.agent.QualifiedName()] = af
+	}
+	skillNames := make(map[string]bool)
+	for _, sf := range l.skills {
+		skillNames[sf.skill.Name] = true
+	}
+
+	usedAgents := make(map[*agentFile]bool)
+	usedSkills := make(map[string]bool)
+
+	// Teams reference agents by name in Agents, Manager and Tasks.
+	for _, tf := range l.teams {
+		team := tf.team
+		members := make(map[string]bool)
+		for _, name := range team.Agents {
+			members[name] = true
+			if af, ok := agentsByRef[name]; ok {
+				usedAgents[af] = true
+			} else {
+				l.add(tf.path, valueLine(tf.data, name, fieldLine(tf.data, "agents", 0)), "agents", SeverityError,
+					RuleUnknownAgent, fmt.Sprintf("agent %q is not defined in agents/", name))
+			}
+		}
+
+		checkRef := func(ref, field string, line int) {
+			if ref == "" {
+				return
+			}
+			if af, ok := agentsByRef[ref]; ok {
+				usedAgents[af] = true
+			} else {
+				l.add(tf.path, line, field, SeverityError, RuleUnknownAgent,
+					fmt.Sprintf("agent %q is not defined in agents/", ref))
+			}
+			if len(team.Agents) > 0 && !members[ref] {
+				l.add(tf.path, line, field, SeverityWarning, RuleAgentNotInTeam,
+					fmt.Sprintf("agent %q is not listed in the team's agents", ref))
+			}
+		}
+
+		if team.Manager != "" {
+			checkRef(team.Manager, "manager", fieldLine(tf.data, "manager", 0))
+		}
+		for _, task := range team.Tasks {
+			taskLine := valueLine(tf.data, task.Name, fieldLine(tf.data, "tasks", 0))
+			line := fieldLine(tf.data, "agent", taskLine)
+			checkRef(task.Agent, "tasks."+task.Name+".agent", line)
+		}
+	}
+
+	// Agents reference skills and requirements.
+	for _, af := range l.agents {
+		for _, skill := range af.agent.Skills {
+			usedSkills[skill] = true
+			if !skillNames[skill] {
+				l.add(af.path, valueLine(af.data, skill, fieldLine(af.data, "skills", 0)), "skills", SeverityError,
+					RuleUnknownSkill, fmt.Sprintf("skill %q is not defined in skills/", skill))
+			}
+		}
+		for _, req := range af.agent.Requires {
+			if requirements.DefaultRegistry.Get(req) == nil {
+				l.add(af.path, valueLine(af.data, req, fieldLine(af.data, "requires", 0)), "requires", SeverityWarning,
+					RuleUnknownRequirement, fmt.Sprintf("requirement %q is not in the requirements registry", req))
+			}
+		}
+	}
+
+	// Deployments reference a team by name.
+	teamNames := make(map[string]bool)
+	for _, tf := range l.teams {
+		teamNames[tf.team.Name] = true
+	}
+	for _, df := range l.deployments {
+		name := df.deployment.Team
+		if name == "" || teamNames[name] {
+			continue
+		}
+		line := fieldLine(df.data, "team", 0)
+		if len(l.teams) == 0 {
+			l.add(df.path, line, "team", SeverityWarning, RuleUnknownTeam,
+				fmt.Sprintf("team %q is referenced but no teams are defined in teams/", name))
+		} else {
+			l.add(df.path, line, "team", SeverityError, RuleUnknownTeam,
+				fmt.Sprintf("team %q is not defined in teams/", name))
+		}
+	}
+
+	// Agent models must resolve on every deployment target's platform.
+	for _, df := range l.deployments {
+		for i, target := range df.deployment.Targets {
+			platform := modelPlatform(string(target.Platform))
+			for _, af := range l.agents {
+				if af.agent.Model == "" {
+					continue
+				}
+				_, err := models.Resolve(platform, af.agent.Model, df.models[i])
+				if errors.Is(err, models.ErrUnknownModel) {
+					l.add(af.path, fieldLine(af.data, "model", 0), "model", SeverityWarning, RuleUnknownModel,
+						fmt.Sprintf("%v (target %s); map it in the target's models", err, target.Name))
+				}
+			}
+		}
+	}
+
+	// Unused agents are only reported when teams exist to use them.
+	if len(l.teams) > 0 {
+		for _, af := range l.agents {
+			if !usedAgents[af] {
+				l.add(af.path, 0, "", SeverityInfo, RuleUnusedAgent,
+					fmt.Sprintf("agent %q is not used by any team", af.agent.Name))
+			}
+		}
+	}
+
+	// Unused skills are only reported when agents reference skills at all.
+	if len(usedSkills) > 0 {
+		for _, sf := range l.skills {
+			if !usedSkills[sf.skill.Name] {
+				l.add(sf.path, 0, "", SeverityInfo, RuleUnusedSkill,
+					fmt.Sprintf("skill %q is not used by any agent", sf.skill.Name))
+			}
+		}
+	}
+}
+
+// modelPlatform returns the models table name for a deployment platform.
+func modelPlatform(platform string) string {
+	switch platform {
+	case "claude-code":
+		return "claude"
+	case "kiro-cli":
+		return "kiro"
+	case "gemini-cli":
+		return "gemini"
+	case "agentkit-local":
+		return "agentkit"
+	default:
+		return platform
+	}
+}
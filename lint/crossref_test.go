@@ -0,0 +1,137 @@
+package lint
+
+import "testing"
+
+func TestCrossReference(t *testing.T) {
+	dir := writeSpecs(t, map[string]string{
+		"agents/lead.md": `---
+name: lead
+description: Leads the team
+skills:
+  - review
+  - missing-skill
+requires:
+  - git
+  - not-a-real-tool
+---
+
+Lead the work.
+`,
+		"agents/helper.md": "---\nname: helper\ndescription: Helps\n---\nHelp.\n",
+		"agents/idle.md":   "---\nname: idle\ndescription: Unused\n---\nIdle.\n",
+		"skills/review.md": "---\nname: review\ndescription: Review code\n---\nReview.\n",
+		"skills/spare.md":  "---\nname: spare\ndescription: Spare skill\n---\nSpare.\n",
+		"teams/release.json": `{
+  "name": "release",
+  "process": "sequential",
+  "agents": ["lead", "ghost"],
+  "tasks": [
+    {"name": "plan", "agent": "lead"},
+    {"name": "build", "agent": "helper", "depends_on": ["plan"]},
+    {"name": "ship", "agent": "nobody", "depends_on": ["build"]}
+  ]
+}`,
+		"deployments/local.json": `{"team": "other", "targets": [{"name": "c", "platform": "claude-code"}]}`,
+	})
+
+	diags, err := Specs(dir)
+	if err != nil {
+		t.Fatalf("Specs failed: %v", err)
+	}
+
+	checks := []struct {
+		file  string
+		rule  string
+		count int
+		line  int
+	}{
+		{"release.json", RuleUnknownAgent, 2, 0},   // "ghost" in agents, "nobody" in tasks
+		{"release.json", RuleAgentNotInTeam, 2, 0}, // helper and nobody are not team members
+		{"lead.md", RuleUnknownSkill, 1, 6},
+		{"lead.md", RuleUnknownRequirement, 1, 9},
+		{"local.json", RuleUnknownTeam, 1, 1},
+		{"idle.md", RuleUnusedAgent, 1, 0},
+		{"spare.md", RuleUnusedSkill, 1, 0},
+		{"review.md", RuleUnusedSkill, 0, 0},
+		{"lead.md", RuleUnusedAgent, 0, 0},
+	}
+	for _, c := range checks {
+		found := find(diags, c.file, c.rule)
+		if len(found) != c.count {
+			t.Errorf("Expected %d %s diagnostic(s) in %s, got %d: %v", c.count, c.rule, c.file, len(found), found)
+			continue
+		}
+		if c.line > 0 && found[0].Line != c.line {
+			t.Errorf("Expected %s in %s at line %d, got %d", c.rule, c.file, c.line, found[0].Line)
+		}
+	}
+
+	ship := find(diags, "release.json", RuleUnknownAgent)
+	if len(ship) == 2 && ship[1].Field != "tasks.ship.agent" {
+		t.Errorf("Expected second unknown agent on tasks.ship.agent, got %s", ship[1].Field)
+	}
+
+	if unknownTeam := find(diags, "local.json", RuleUnknownTeam); len(unknownTeam) == 1 && unknownTeam[0].Severity != SeverityError {
+		t.Errorf("Expected unknown team to be an error when teams exist, got %s", unknownTeam[0].Severity)
+	}
+}
+
+func TestCrossReferenceNoTeams(t *testing.T) {
+	dir := writeSpecs(t, map[string]string{
+		"agents/solo.md":         "---\nname: solo\ndescription: Works alone\n---\nWork.\n",
+		"deployments/local.json": `{"team": "my-team", "targets": [{"name": "c", "platform": "claude-code"}]}`,
+	})
+
+	diags, err := Specs(dir)
+	if err != nil {
+		t.Fatalf("Specs failed: %v", err)
+	}
+
+	unknownTeam := find(diags, "local.json", RuleUnknownTeam)
+	if len(unknownTeam) != 1 || unknownTeam[0].Severity != SeverityWarning {
+		t.Errorf("Expected one unknown-team warning, got %v", unknownTeam)
+	}
+	if len(find(diags, "solo.md", RuleUnusedAgent)) != 0 {
+		t.Error("Unused agents should not be reported when no teams are defined")
+	}
+}
+
+func TestCrossReferenceModels(t *testing.T) {
+	dir := writeSpecs(t, map[string]string{
+		"agents/lead.md":   "---\nname: lead\ndescription: Leads\nmodel: sonnet\n---\nLead.\n",
+		"agents/coder.md":  "---\nname: coder\ndescription: Codes\nmodel: gpt-4o\n---\nCode.\n",
+		"agents/writer.md": "---\nname: writer\ndescription: Writes\nmodel: fast\n---\nWrite.\n",
+		"deployments/local.json": `{
+  "team": "",
+  "targets": [
+    {"name": "k", "platform": "kiro-cli", "models": {"fast": "claude-haiku"}},
+    {"name": "g", "platform": "gemini-cli", "models": {"fast": ""}}
+  ]
+}`,
+	})
+
+	diags, err := Specs(dir)
+	if err != nil {
+		t.Fatalf("Specs failed: %v", err)
+	}
+
+	if found := find(diags, "lead.md", RuleUnknownModel); len(found) != 0 {
+		t.Errorf("Expected canonical model to resolve everywhere, got %v", found)
+	}
+	// gpt-4o is neither a Kiro nor a Gemini model.
+	coder := find(diags, "coder.md", RuleUnknownModel)
+	if len(coder) != 2 {
+		t.Fatalf("Expected 2 unknown-model diagnostics for coder, got %v", coder)
+	}
+	if coder[0].Line != 4 || coder[0].Severity != SeverityWarning {
+		t.Errorf("Expected a warning at line 4, got %v", coder[0])
+	}
+	// "fast" is overridden on both targets; the empty override is an error.
+	if found := find(diags, "writer.md", RuleUnknownModel); len(found) != 0 {
+		t.Errorf("Expected overridden model to resolve, got %v", found)
+	}
+	empty := find(diags, "local.json", RuleInvalidField)
+	if len(empty) != 1 || empty[0].Field != "targets[1].models.fast" {
+		t.Errorf("Expected an invalid-field error for the empty override, got %v", empty)
+	}
+}
@@ -0,0 +1,115 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Severity indicates how serious a diagnostic is.
+type Severity string
+
+const (
+	// SeverityError marks a problem that will break generation or the generated plugin.
+	SeverityError Severity = "error"
+
+	// SeverityWarning marks a likely mistake that does not block generation.
+	SeverityWarning Severity = "warning"
+
+	// SeverityInfo marks an informational finding.
+	SeverityInfo Severity = "info"
+)
+
+// rank orders severities from most to least serious.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Diagnostic describes a single problem found in a spec file.
+type Diagnostic struct {
+	// File is the path of the file containing the problem.
+	File string `json:"file"`
+
+	// Line is the 1-based line number, or 0 if unknown.
+	Line int `json:"line,omitempty"`
+
+	// Field is the spec field the problem relates to (e.g., "tasks.build.agent").
+	Field string `json:"field,omitempty"`
+
+	// Severity is the diagnostic severity.
+	Severity Severity `json:"severity"`
+
+	// Rule is a stable identifier for the check that produced the diagnostic.
+	Rule string `json:"rule"`
+
+	// Message is a human-readable description of the problem.
+	Message string `json:"message"`
+}
+
+// String formats the diagnostic as "file:line: severity: message [rule]".
+func (d Diagnostic) String() string {
+	loc := d.File
+	if d.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", d.File, d.Line)
+	}
+	if d.Field != "" {
+		return fmt.Sprintf("%s: %s: %s: %s [%s]", loc, d.Severity, d.Field, d.Message, d.Rule)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", loc, d.Severity, d.Message, d.Rule)
+}
+
+// Diagnostics is a list of diagnostics.
+type Diagnostics []Diagnostic
+
+// HasErrors returns true if any diagnostic has error severity.
+func (d Diagnostics) HasErrors() bool {
+	return d.Count(SeverityError) > 0
+}
+
+// Count returns the number of diagnostics with the given severity.
+func (d Diagnostics) Count(severity Severity) int {
+	count := 0
+	for _, diag := range d {
+		if diag.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// Sort orders diagnostics by file, line, severity and rule.
+func (d Diagnostics) Sort() {
+	sort.SliceStable(d, func(i, j int) bool {
+		a, b := d[i], d[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Severity != b.Severity {
+			return a.Severity.rank() < b.Severity.rank()
+		}
+		return a.Rule < b.Rule
+	})
+}
+
+// Rules returns the distinct rule identifiers in the diagnostics, sorted.
+func (d Diagnostics) Rules() []string {
+	seen := make(map[string]bool)
+	var rules []string
+	for _, diag := range d {
+		if !seen[diag.Rule] {
+			seen[diag.Rule] = true
+			rules = append(rules, diag.Rule)
+		}
+	}
+	sort.Strings(rules)
+	return rules
+}
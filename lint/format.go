@@ -0,0 +1,187 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// Format identifies an output format for diagnostics.
+type Format string
+
+const (
+	// FormatText is one diagnostic per line followed by a summary.
+	FormatText Format = "text"
+
+	// FormatJSON is a JSON object with the diagnostics and counts.
+	FormatJSON Format = "json"
+
+	// FormatSARIF is SARIF 2.1.0, suitable for code scanning annotations.
+	FormatSARIF Format = "sarif"
+)
+
+// Write writes diagnostics to w in the given format.
+func Write(w io.Writer, diags Diagnostics, format Format) error {
+	switch format {
+	case FormatText, "":
+		return WriteText(w, diags)
+	case FormatJSON:
+		return WriteJSON(w, diags)
+	case FormatSARIF:
+		return WriteSARIF(w, diags)
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+// WriteText writes diagnostics as plain text, one per line, with a summary.
+func WriteText(w io.Writer, diags Diagnostics) error {
+	for _, d := range diags {
+		if _, err := fmt.Fprintln(w, d.String()); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d error(s), %d warning(s), %d info\n",
+		diags.Count(SeverityError), diags.Count(SeverityWarning), diags.Count(SeverityInfo))
+	return err
+}
+
+// jsonReport is the JSON output document.
+type jsonReport struct {
+	Errors      int          `json:"errors"`
+	Warnings    int          `json:"warnings"`
+	Infos       int          `json:"infos"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// WriteJSON writes diagnostics as an indented JSON document.
+func WriteJSON(w io.Writer, diags Diagnostics) error {
+	report := jsonReport{
+		Errors:      diags.Count(SeverityError),
+		Warnings:    diags.Count(SeverityWarning),
+		Infos:       diags.Count(SeverityInfo),
+		Diagnostics: diags,
+	}
+	if report.Diagnostics == nil {
+		report.Diagnostics = []Diagnostic{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// SARIF 2.1.0 document types (subset).
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID string `json:"id"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	sarifRegion struct {
+		StartLine int `json:"startLine"`
+	}
+)
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// WriteSARIF writes diagnostics as a SARIF 2.1.0 log.
+func WriteSARIF(w io.Writer, diags Diagnostics) error {
+	rules := []sarifRule{}
+	for _, id := range diags.Rules() {
+		rules = append(rules, sarifRule{ID: id})
+	}
+
+	results := []sarifResult{}
+	for _, d := range diags {
+		text := d.Message
+		if d.Field != "" {
+			text = d.Field + ": " + d.Message
+		}
+		loc := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(d.File)},
+			},
+		}
+		if d.Line > 0 {
+			loc.PhysicalLocation.Region = &sarifRegion{StartLine: d.Line}
+		}
+		results = append(results, sarifResult{
+			RuleID:    d.Rule,
+			Level:     sarifLevel(d.Severity),
+			Message:   sarifMessage{Text: text},
+			Locations: []sarifLocation{loc},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "assistantkit",
+				InformationURI: "https://github.com/agentplexus/assistantkit",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
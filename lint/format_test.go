@@ -0,0 +1,71 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var sampleDiags = Diagnostics{
+	{File: "specs/plugin.json", Line: 2, Field: "name", Severity: SeverityError, Rule: RuleMissingField, Message: "plugin name is required"},
+	{File: "specs/agents/a.md", Severity: SeverityWarning, Rule: RuleMissingField, Message: "agent description is not set"},
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sampleDiags, FormatText); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "specs/plugin.json:2: error: name: plugin name is required [missing-field]") {
+		t.Errorf("Unexpected text output:\n%s", out)
+	}
+	if !strings.Contains(out, "1 error(s), 1 warning(s)") {
+		t.Errorf("Expected summary line, got:\n%s", out)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sampleDiags, FormatJSON); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	var report jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if report.Errors != 1 || report.Warnings != 1 || len(report.Diagnostics) != 2 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sampleDiags, FormatSARIF); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("Invalid SARIF: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF log: %+v", log)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].Level != "error" || results[0].Locations[0].PhysicalLocation.Region.StartLine != 2 {
+		t.Errorf("Unexpected first result: %+v", results[0])
+	}
+	if results[1].Locations[0].PhysicalLocation.Region != nil {
+		t.Error("Expected no region when line is unknown")
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, sampleDiags, Format("xml")); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
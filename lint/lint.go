@@ -0,0 +1,656 @@
+// Package lint validates a specs directory and reports every problem found
+// as structured diagnostics.
+//
+// The specs directory is the input to "assistantkit generate":
+//
+//	specs/
+//	├── plugin.json
+//	├── agents/       (*.md with YAML frontmatter, or *.json)
+//	├── commands/     (*.md or *.json)
+//	├── skills/       (*.md, or <name>/skill.json)
+//	├── teams/        (*.json or *.yaml)
+//	└── deployments/  (*.json)
+//
+// Unlike the Validate methods on the individual types, linting does not stop
+// at the first problem. Each diagnostic carries the file, line, field and
+// severity so it can be rendered as text, JSON or SARIF.
+//
+// After each file is checked on its own, references between files are
+// resolved: task and manager agents must exist in agents/, agent skills in
+// skills/, the deployment team in teams/, and agent requirements in
+// requirements.DefaultRegistry. Unused agents and skills are reported as
+// informational diagnostics.
+//
+// Example:
+//
+//	diags, err := lint.Specs("specs")
+//	if err != nil {
+//	    return err
+//	}
+//	lint.Write(os.Stdout, diags, lint.FormatText)
+//	if diags.HasErrors() {
+//	    os.Exit(1)
+//	}
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	agentscore "github.com/agentplexus/assistantkit/agents/core"
+	commandscore "github.com/agentplexus/assistantkit/commands/core"
+	hookscore "github.com/agentplexus/assistantkit/hooks/core"
+	mcpcore "github.com/agentplexus/assistantkit/mcp/core"
+	pluginscore "github.com/agentplexus/assistantkit/plugins/core"
+	skillscore "github.com/agentplexus/assistantkit/skills/core"
+	teamscore "github.com/agentplexus/assistantkit/teams/core"
+	multiagentspec "github.com/agentplexus/multi-agent-spec/sdk/go"
+)
+
+// Rule identifiers.
+const (
+	RuleParseError       = "parse-error"
+	RuleMissingField     = "missing-field"
+	RuleInvalidField     = "invalid-field"
+	RuleDuplicateName    = "duplicate-name"
+	RuleNameMismatch     = "name-mismatch"
+	RuleMissingFile      = "missing-file"
+	RuleInvalidMCPServer = "invalid-mcp-server"
+	RuleInvalidHook      = "invalid-hook"
+	RuleInvalidTeam      = "invalid-team"
+	RuleDependencyCycle  = "dependency-cycle"
+	RuleUnknownPlatform  = "unknown-platform"
+	RulePartialPlatform  = "partial-platform"
+)
+
+// Specs lints the specs directory at dir and returns all diagnostics found,
+// sorted by file and line. Missing optional subdirectories are not errors.
+// The returned error is non-nil only if dir cannot be read.
+func Specs(dir string) (Diagnostics, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", dir)
+	}
+
+	l := &linter{dir: dir}
+	l.lintPlugin()
+	l.lintAgents()
+	l.lintCommands()
+	l.lintSkills()
+	l.lintTeams()
+	l.lintDeployments()
+	l.crossReference()
+
+	l.diags.Sort()
+	return l.diags, nil
+}
+
+// pluginSpec mirrors the plugin.json fields used by generate.
+type pluginSpec struct {
+	pluginscore.Plugin
+	MCPServers map[string]pluginMCPServer `json:"mcpServers,omitempty"`
+}
+
+// pluginMCPServer is an MCP server entry in plugin.json.
+type pluginMCPServer struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+}
+
+// specFile is a loaded spec file with its raw content for line lookups.
+type specFile struct {
+	path string
+	data []byte
+}
+
+type agentFile struct {
+	specFile
+	agent *agentscore.Agent
+}
+
+type commandFile struct {
+	specFile
+	command *commandscore.Command
+}
+
+type skillFile struct {
+	specFile
+	skill *skillscore.Skill
+}
+
+type teamFile struct {
+	specFile
+	team *teamscore.Team
+}
+
+type deploymentFile struct {
+	specFile
+	deployment *multiagentspec.Deployment
+
+	// models holds the model overrides of each target, which the
+	// multi-agent-spec Target type does not carry.
+	models []map[string]string
+}
+
+// deploymentModels mirrors the per-target model overrides read by generate.
+type deploymentModels struct {
+	Targets []struct {
+		Models map[string]string `json:"models,omitempty"`
+	} `json:"targets"`
+}
+
+// linter accumulates diagnostics and the specs loaded while linting.
+type linter struct {
+	dir   string
+	diags Diagnostics
+
+	plugin      *pluginSpec
+	agents      []*agentFile
+	commands    []*commandFile
+	skills      []*skillFile
+	teams       []*teamFile
+	deployments []*deploymentFile
+}
+
+func (l *linter) add(file string, line int, field string, severity Severity, rule, message string) {
+	l.diags = append(l.diags, Diagnostic{
+		File:     filepath.ToSlash(file),
+		Line:     line,
+		Field:    field,
+		Severity: severity,
+		Rule:     rule,
+		Message:  message,
+	})
+}
+
+// readDir returns the files in a specs subdirectory with one of the given
+// extensions. A missing directory yields no files.
+func (l *linter) readDir(sub string, exts ...string) []string {
+	dir := filepath.Join(l.dir, sub)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			l.add(dir, 0, "", SeverityError, RuleParseError, err.Error())
+		}
+		return nil
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		for _, want := range exts {
+			if ext == want {
+				paths = append(paths, filepath.Join(dir, entry.Name()))
+				break
+			}
+		}
+	}
+	return paths
+}
+
+// readFile reads a spec file, recording a diagnostic on failure.
+func (l *linter) readFile(path string) ([]byte, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		l.add(path, 0, "", SeverityError, RuleParseError, err.Error())
+		return nil, false
+	}
+	return data, true
+}
+
+// parseFailed records a parse error with the best available line number.
+func (l *linter) parseFailed(path string, data []byte, err error, yamlOffset int) {
+	l.add(path, errorLine(data, err, yamlOffset), "", SeverityError, RuleParseError, err.Error())
+}
+
+// isMarkdown reports whether a spec file should be parsed as Markdown.
+func isMarkdown(path string, data []byte) bool {
+	return filepath.Ext(path) == ".md" || bytes.HasPrefix(data, []byte("---"))
+}
+
+// baseName returns the file name without its extension.
+func baseName(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+func (l *linter) lintPlugin() {
+	path := filepath.Join(l.dir, "plugin.json")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return // plugin.json is optional
+	}
+	data, ok := l.readFile(path)
+	if !ok {
+		return
+	}
+
+	var plugin pluginSpec
+	if err := json.Unmarshal(data, &plugin); err != nil {
+		l.parseFailed(path, data, err, 0)
+		return
+	}
+	l.plugin = &plugin
+
+	if plugin.Name == "" {
+		l.add(path, fieldLine(data, "name", 0), "name", SeverityError, RuleMissingField, "plugin name is required")
+	}
+	if plugin.Version == "" {
+		l.add(path, fieldLine(data, "version", 0), "version", SeverityWarning, RuleMissingField, "plugin version is not set")
+	}
+	if plugin.Description == "" {
+		l.add(path, fieldLine(data, "description", 0), "description", SeverityWarning, RuleMissingField, "plugin description is not set")
+	}
+
+	cfg := mcpcore.NewConfig()
+	for name, server := range plugin.MCPServers {
+		cfg.AddServer(name, mcpcore.Server{Command: server.Command, Args: server.Args})
+	}
+	serversLine := fieldLine(data, "mcpServers", 0)
+	for _, err := range cfg.ValidateAll() {
+		if sve, ok := err.(*mcpcore.ServerValidationError); ok {
+			l.add(path, fieldLine(data, sve.Name, serversLine), "mcpServers."+sve.Name,
+				SeverityError, RuleInvalidMCPServer, sve.Err.Error())
+		}
+	}
+
+	if plugin.Hooks != "" {
+		l.lintHooksFile(path, data, plugin.Hooks)
+	}
+}
+
+// lintHooksFile validates the canonical hooks file referenced by plugin.json.
+func (l *linter) lintHooksFile(pluginPath string, pluginData []byte, ref string) {
+	hooksPath := ref
+	if !filepath.IsAbs(hooksPath) {
+		hooksPath = filepath.Join(l.dir, ref)
+	}
+	data, err := os.ReadFile(hooksPath)
+	if err != nil {
+		l.add(pluginPath, fieldLine(pluginData, "hooks", 0), "hooks", SeverityError, RuleMissingFile,
+			fmt.Sprintf("hooks file not found: %s", ref))
+		return
+	}
+
+	var cfg hookscore.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		l.parseFailed(hooksPath, data, err, 0)
+		return
+	}
+	for _, err := range cfg.ValidateAll() {
+		if hve, ok := err.(*hookscore.HookValidationError); ok {
+			field := fmt.Sprintf("hooks.%s[%d].hooks[%d]", hve.Event, hve.EntryIndex, hve.HookIndex)
+			l.add(hooksPath, fieldLine(data, string(hve.Event), 0), field, SeverityError, RuleInvalidHook, hve.Err.Error())
+		}
+	}
+}
+
+func (l *linter) lintAgents() {
+	seen := make(map[string]string)
+	for _, path := range l.readDir("agents", ".md", ".json") {
+		data, ok := l.readFile(path)
+		if !ok {
+			continue
+		}
+
+		var agent *agentscore.Agent
+		if isMarkdown(path, data) {
+			parsed, err := multiagentspec.ParseAgentMarkdown(data)
+			if err != nil {
+				l.parseFailed(path, data, err, 1)
+				continue
+			}
+			agent = parsed
+		} else {
+			agent = &agentscore.Agent{}
+			if err := json.Unmarshal(data, agent); err != nil {
+				l.parseFailed(path, data, err, 0)
+				continue
+			}
+		}
+
+		l.checkName(path, data, "agent", agent.Name, seen)
+		if agent.Name == "" {
+			agent.Name = baseName(path)
+		}
+		if agent.Description == "" {
+			l.add(path, 0, "description", SeverityWarning, RuleMissingField, "agent description is not set")
+		}
+		if strings.TrimSpace(agent.Instructions) == "" {
+			l.add(path, 0, "instructions", SeverityWarning, RuleMissingField, "agent has no instructions")
+		}
+
+		l.agents = append(l.agents, &agentFile{specFile: specFile{path, data}, agent: agent})
+	}
+}
+
+func (l *linter) lintCommands() {
+	seen := make(map[string]string)
+	for _, path := range l.readDir("commands", ".md", ".json") {
+		data, ok := l.readFile(path)
+		if !ok {
+			continue
+		}
+
+		var cmd *commandscore.Command
+		if isMarkdown(path, data) {
+			parsed, err := commandscore.ParseCommandMarkdown(data)
+			if err != nil {
+				l.parseFailed(path, data, err, 1)
+				continue
+			}
+			cmd = parsed
+		} else {
+			cmd = &commandscore.Command{}
+			if err := json.Unmarshal(data, cmd); err != nil {
+				l.parseFailed(path, data, err, 0)
+				continue
+			}
+		}
+
+		l.checkName(path, data, "command", cmd.Name, seen)
+		if cmd.Name == "" {
+			cmd.Name = baseName(path)
+		}
+		if cmd.Description == "" {
+			l.add(path, 0, "description", SeverityWarning, RuleMissingField, "command description is not set")
+		}
+		if strings.TrimSpace(cmd.Instructions) == "" {
+			l.add(path, 0, "instructions", SeverityWarning, RuleMissingField, "command has no instructions")
+		}
+
+		argNames := make(map[string]bool)
+		for i, arg := range cmd.Arguments {
+			field := fmt.Sprintf("arguments[%d]", i)
+			line := valueLine(data, arg.Name, fieldLine(data, "arguments", 0))
+			if arg.Name == "" {
+				l.add(path, 0, field+".name", SeverityError, RuleMissingField, "argument name is required")
+				continue
+			}
+			if argNames[arg.Name] {
+				l.add(path, line, field+".name", SeverityError, RuleDuplicateName, "duplicate argument: "+arg.Name)
+			}
+			argNames[arg.Name] = true
+			switch arg.Type {
+			case "", "string", "number", "boolean":
+			default:
+				l.add(path, line, field+".type", SeverityWarning, RuleInvalidField,
+					fmt.Sprintf("unknown argument type %q (expected string, number or boolean)", arg.Type))
+			}
+			if arg.Pattern != "" {
+				if _, err := regexp.Compile(arg.Pattern); err != nil {
+					l.add(path, line, field+".pattern", SeverityError, RuleInvalidField, "invalid pattern: "+err.Error())
+				}
+			}
+			if arg.Required && arg.Default != "" {
+				l.add(path, line, field+".default", SeverityInfo, RuleInvalidField, "required argument has a default that is never used")
+			}
+		}
+
+		l.commands = append(l.commands, &commandFile{specFile: specFile{path, data}, command: cmd})
+	}
+}
+
+func (l *linter) lintSkills() {
+	paths := l.readDir("skills", ".md")
+
+	// Subdirectories with skill.json
+	skillsDir := filepath.Join(l.dir, "skills")
+	if entries, err := os.ReadDir(skillsDir); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(skillsDir, entry.Name(), "skill.json")
+			if _, err := os.Stat(path); err == nil {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	seen := make(map[string]string)
+	for _, path := range paths {
+		data, ok := l.readFile(path)
+		if !ok {
+			continue
+		}
+
+		var skill *skillscore.Skill
+		if isMarkdown(path, data) {
+			parsed, err := skillscore.ParseSkillMarkdown(data)
+			if err != nil {
+				l.parseFailed(path, data, err, 1)
+				continue
+			}
+			skill = parsed
+		} else {
+			skill = &skillscore.Skill{}
+			if err := json.Unmarshal(data, skill); err != nil {
+				l.parseFailed(path, data, err, 0)
+				continue
+			}
+		}
+
+		name := skill.Name
+		fileName := baseName(path)
+		if filepath.Base(path) == "skill.json" {
+			fileName = filepath.Base(filepath.Dir(path))
+		}
+		l.checkNameAgainst(path, data, "skill", name, fileName, seen)
+		if skill.Name == "" {
+			skill.Name = fileName
+		}
+		if skill.Description == "" {
+			l.add(path, 0, "description", SeverityWarning, RuleMissingField,
+				"skill description is not set; assistants use it to decide when to apply the skill")
+		}
+		if strings.TrimSpace(skill.Instructions) == "" {
+			l.add(path, 0, "instructions", SeverityWarning, RuleMissingField, "skill has no instructions")
+		}
+
+		l.skills = append(l.skills, &skillFile{specFile: specFile{path, data}, skill: skill})
+	}
+}
+
+func (l *linter) lintTeams() {
+	seen := make(map[string]string)
+	for _, path := range l.readDir("teams", ".json", ".yaml", ".yml") {
+		data, ok := l.readFile(path)
+		if !ok {
+			continue
+		}
+
+		var (
+			team *teamscore.Team
+			err  error
+		)
+		if filepath.Ext(path) == ".json" {
+			team, err = teamscore.ParseJSON(data, path)
+		} else {
+			team, err = teamscore.ParseYAML(data, path)
+		}
+		if err != nil {
+			l.parseFailed(path, data, err, 0)
+			continue
+		}
+
+		if team.Name != "" {
+			if prev, dup := seen[team.Name]; dup {
+				l.add(path, fieldLine(data, "name", 0), "name", SeverityError, RuleDuplicateName,
+					fmt.Sprintf("team %q is also defined in %s", team.Name, filepath.ToSlash(prev)))
+			}
+			seen[team.Name] = path
+		}
+
+		unknownDeps := false
+		for _, verr := range team.ValidateAll() {
+			l.add(path, teamFieldLine(data, verr.Field), verr.Field, SeverityError, RuleInvalidTeam, verr.Message)
+			if strings.HasSuffix(verr.Field, ".depends_on") || strings.HasSuffix(verr.Field, ".when") ||
+				strings.HasSuffix(verr.Field, ".on_failure") {
+				unknownDeps = true
+			}
+		}
+
+		taskNames := make(map[string]bool)
+		for _, task := range team.Tasks {
+			if task.Name == "" {
+				l.add(path, fieldLine(data, "tasks", 0), "tasks", SeverityError, RuleMissingField, "task name is required")
+				continue
+			}
+			if taskNames[task.Name] {
+				l.add(path, valueLine(data, task.Name, 0), "tasks."+task.Name, SeverityError, RuleDuplicateName,
+					"duplicate task: "+task.Name)
+			}
+			taskNames[task.Name] = true
+			if task.Agent == "" {
+				l.add(path, valueLine(data, task.Name, 0), "tasks."+task.Name+".agent", SeverityError, RuleMissingField,
+					"task has no agent")
+			}
+		}
+
+		// Unknown dependencies, conditions and fallbacks also fail the sort,
+		// so only report cycles when every task reference resolves.
+		if _, err := team.TopologicalSort(); err != nil && !unknownDeps {
+			l.add(path, fieldLine(data, "tasks", 0), "tasks", SeverityError, RuleDependencyCycle, "circular dependency between tasks")
+		}
+
+		l.teams = append(l.teams, &teamFile{specFile: specFile{path, data}, team: team})
+	}
+}
+
+// teamFieldLine locates a team validation field such as "tasks.build.depends_on".
+func teamFieldLine(data []byte, field string) int {
+	parts := strings.Split(field, ".")
+	if len(parts) == 3 && parts[0] == "tasks" {
+		taskLine := valueLine(data, parts[1], fieldLine(data, "tasks", 0))
+		if line := fieldLine(data, parts[2], taskLine); line > 0 {
+			return line
+		}
+		return taskLine
+	}
+	return fieldLine(data, parts[0], 0)
+}
+
+// knownPlatforms lists the deployment platforms accepted by generate, mapped
+// to whether a complete plugin (not just agents) is generated for them.
+var knownPlatforms = map[string]bool{
+	"claude": true,
+	"kiro":   true,
+	"gemini": true,
+	string(multiagentspec.PlatformClaudeCode):    true,
+	string(multiagentspec.PlatformKiroCLI):       true,
+	string(multiagentspec.PlatformGeminiCLI):     true,
+	string(multiagentspec.PlatformADKGo):         false,
+	string(multiagentspec.PlatformCrewAI):        false,
+	string(multiagentspec.PlatformAutoGen):       false,
+	string(multiagentspec.PlatformAWSAgentCore):  false,
+	string(multiagentspec.PlatformAWSEKS):        false,
+	string(multiagentspec.PlatformAzureAKS):      false,
+	string(multiagentspec.PlatformGCPGKE):        false,
+	string(multiagentspec.PlatformKubernetes):    false,
+	string(multiagentspec.PlatformDockerCompose): false,
+	string(multiagentspec.PlatformAgentKitLocal): false,
+}
+
+func (l *linter) lintDeployments() {
+	for _, path := range l.readDir("deployments", ".json") {
+		data, ok := l.readFile(path)
+		if !ok {
+			continue
+		}
+
+		var deployment multiagentspec.Deployment
+		if err := json.Unmarshal(data, &deployment); err != nil {
+			l.parseFailed(path, data, err, 0)
+			continue
+		}
+		var overrides deploymentModels
+		if err := json.Unmarshal(data, &overrides); err != nil {
+			l.parseFailed(path, data, err, 0)
+			continue
+		}
+		targetModels := make([]map[string]string, len(deployment.Targets))
+		for i := range overrides.Targets {
+			targetModels[i] = overrides.Targets[i].Models
+		}
+
+		if deployment.Team == "" {
+			l.add(path, 0, "team", SeverityWarning, RuleMissingField, "deployment does not name a team")
+		}
+		if len(deployment.Targets) == 0 {
+			l.add(path, fieldLine(data, "targets", 0), "targets", SeverityError, RuleMissingField, "at least one target is required")
+		}
+
+		targetsLine := fieldLine(data, "targets", 0)
+		names := make(map[string]bool)
+		for i, target := range deployment.Targets {
+			field := fmt.Sprintf("targets[%d]", i)
+			line := valueLine(data, target.Name, targetsLine)
+			if target.Name == "" {
+				l.add(path, targetsLine, field+".name", SeverityError, RuleMissingField, "target name is required")
+			} else {
+				if names[target.Name] {
+					l.add(path, line, field+".name", SeverityError, RuleDuplicateName, "duplicate target: "+target.Name)
+				}
+				names[target.Name] = true
+			}
+
+			modelsLine := fieldLine(data, "models", line)
+			for model, id := range targetModels[i] {
+				if id == "" {
+					l.add(path, fieldLine(data, model, modelsLine), field+".models."+model, SeverityError, RuleInvalidField,
+						fmt.Sprintf("model override for %q is empty", model))
+				}
+			}
+
+			platform := string(target.Platform)
+			platformLine := fieldLine(data, "platform", line)
+			if platform == "" {
+				l.add(path, line, field+".platform", SeverityError, RuleMissingField, "target platform is required")
+				continue
+			}
+			complete, known := knownPlatforms[platform]
+			switch {
+			case !known:
+				l.add(path, platformLine, field+".platform", SeverityError, RuleUnknownPlatform, "unknown platform: "+platform)
+			case !complete:
+				l.add(path, platformLine, field+".platform", SeverityInfo, RulePartialPlatform,
+					fmt.Sprintf("platform %s is not fully supported by generate; only agents are generated", platform))
+			}
+		}
+
+		l.deployments = append(l.deployments, &deploymentFile{specFile: specFile{path, data}, deployment: &deployment, models: targetModels})
+	}
+}
+
+// checkName checks a spec name against its file name and earlier specs.
+func (l *linter) checkName(path string, data []byte, kind, name string, seen map[string]string) {
+	l.checkNameAgainst(path, data, kind, name, baseName(path), seen)
+}
+
+// checkNameAgainst checks a spec name against an expected name (usually the
+// file name) and records it for duplicate detection.
+func (l *linter) checkNameAgainst(path string, data []byte, kind, name, expected string, seen map[string]string) {
+	effective := name
+	if effective == "" {
+		effective = expected
+	} else if name != expected {
+		l.add(path, fieldLine(data, "name", 0), "name", SeverityWarning, RuleNameMismatch,
+			fmt.Sprintf("%s name %q does not match file name %q", kind, name, expected))
+	}
+	if prev, dup := seen[effective]; dup {
+		l.add(path, fieldLine(data, "name", 0), "name", SeverityError, RuleDuplicateName,
+			fmt.Sprintf("%s %q is also defined in %s", kind, effective, filepath.ToSlash(prev)))
+	}
+	seen[effective] = path
+}
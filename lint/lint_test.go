@@ -0,0 +1,166 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeSpecs writes files (relative path -> content) under a temp specs dir.
+func writeSpecs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// find returns the diagnostics for a rule in a file (by base name).
+func find(diags Diagnostics, file, rule string) []Diagnostic {
+	var found []Diagnostic
+	for _, d := range diags {
+		if filepath.Base(d.File) == file && d.Rule == rule {
+			found = append(found, d)
+		}
+	}
+	return found
+}
+
+const validAgent = `---
+name: reviewer
+description: Reviews code
+---
+
+You review code.
+`
+
+func TestSpecsValid(t *testing.T) {
+	dir := writeSpecs(t, map[string]string{
+		"plugin.json":        `{"name": "demo", "version": "1.0.0", "description": "Demo plugin"}`,
+		"agents/reviewer.md": validAgent,
+		"commands/review.md": "---\nname: review\ndescription: Review code\n---\n\nReview the code.\n",
+		"teams/demo.json": `{
+  "name": "demo",
+  "process": "sequential",
+  "agents": ["reviewer"],
+  "tasks": [{"name": "review", "agent": "reviewer"}]
+}`,
+		"deployments/local.json": `{"team": "demo", "targets": [{"name": "local-claude", "platform": "claude-code", "output": "out"}]}`,
+	})
+
+	diags, err := Specs(dir)
+	if err != nil {
+		t.Fatalf("Specs failed: %v", err)
+	}
+	if diags.HasErrors() || diags.Count(SeverityWarning) > 0 {
+		t.Errorf("Expected no errors or warnings, got %v", diags)
+	}
+}
+
+func TestSpecsCollectsAllProblems(t *testing.T) {
+	dir := writeSpecs(t, map[string]string{
+		"plugin.json": `{
+  "name": "",
+  "version": "1.0.0",
+  "description": "Demo",
+  "mcpServers": {
+    "broken": {"args": ["x"]}
+  }
+}`,
+		"agents/reviewer.md": validAgent,
+		"agents/bad.md":      "---\nname: [unclosed\n---\n",
+		"commands/review.json": `{
+  "name": "review",
+  "description": "Review",
+  "instructions": "Review it",
+  "arguments": [
+    {"name": "target", "type": "string", "pattern": "("}
+  ]
+}`,
+		"teams/demo.json": `{
+  "name": "demo",
+  "process": "hierarchical",
+  "tasks": [
+    {"name": "a", "agent": "reviewer", "depends_on": ["missing"]}
+  ]
+}`,
+		"deployments/local.json": `{"team": "demo", "targets": [{"name": "x", "platform": "nope"}]}`,
+	})
+
+	diags, err := Specs(dir)
+	if err != nil {
+		t.Fatalf("Specs failed: %v", err)
+	}
+
+	checks := []struct {
+		file string
+		rule string
+		line int
+	}{
+		{"plugin.json", RuleMissingField, 2},
+		{"plugin.json", RuleInvalidMCPServer, 6},
+		{"bad.md", RuleParseError, 0},
+		{"review.json", RuleInvalidField, 6},
+		{"demo.json", RuleInvalidTeam, 0},
+		{"local.json", RuleUnknownPlatform, 1},
+	}
+	for _, c := range checks {
+		found := find(diags, c.file, c.rule)
+		if len(found) == 0 {
+			t.Errorf("Expected %s diagnostic in %s, got %v", c.rule, c.file, diags)
+			continue
+		}
+		if c.line > 0 && found[0].Line != c.line {
+			t.Errorf("Expected %s in %s at line %d, got %d", c.rule, c.file, c.line, found[0].Line)
+		}
+	}
+
+	// Hierarchical team without manager and the unknown dependency are both reported
+	if got := len(find(diags, "demo.json", RuleInvalidTeam)); got != 2 {
+		t.Errorf("Expected 2 team diagnostics, got %d", got)
+	}
+	if got := len(find(diags, "demo.json", RuleDependencyCycle)); got != 0 {
+		t.Errorf("Unknown dependency should not be reported as a cycle, got %d", got)
+	}
+}
+
+func TestSpecsDuplicateAndCycle(t *testing.T) {
+	dir := writeSpecs(t, map[string]string{
+		"agents/a.md": "---\nname: shared\ndescription: A\n---\nA\n",
+		"agents/b.md": "---\nname: shared\ndescription: B\n---\nB\n",
+		"teams/loop.yaml": `name: loop
+process: parallel
+tasks:
+  - name: one
+    agent: shared
+    depends_on: [two]
+  - name: two
+    agent: shared
+    depends_on: [one]
+`,
+	})
+
+	diags, err := Specs(dir)
+	if err != nil {
+		t.Fatalf("Specs failed: %v", err)
+	}
+	if len(find(diags, "b.md", RuleDuplicateName)) != 1 {
+		t.Errorf("Expected duplicate agent diagnostic, got %v", diags)
+	}
+	if len(find(diags, "loop.yaml", RuleDependencyCycle)) != 1 {
+		t.Errorf("Expected dependency cycle diagnostic, got %v", diags)
+	}
+}
+
+func TestSpecsMissingDir(t *testing.T) {
+	if _, err := Specs(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for missing specs directory")
+	}
+}
@@ -0,0 +1,110 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// yamlLinePattern matches the line number in yaml.v3 error messages.
+var yamlLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// errorLine extracts a 1-based line number from a JSON or YAML parse error.
+// The offset is added to YAML line numbers to account for content that
+// precedes the parsed document (e.g., the opening "---" of frontmatter).
+// It returns 0 if no position is available.
+func errorLine(data []byte, err error, yamlOffset int) int {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return offsetLine(data, syntaxErr.Offset)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return offsetLine(data, typeErr.Offset)
+	}
+	if m := yamlLinePattern.FindStringSubmatch(err.Error()); m != nil {
+		if n, convErr := strconv.Atoi(m[1]); convErr == nil {
+			return n + yamlOffset
+		}
+	}
+	return 0
+}
+
+// offsetLine converts a byte offset into a 1-based line number.
+func offsetLine(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// fieldLine returns the 1-based line of the first occurrence of key as a
+// JSON object key ("key":) or a YAML mapping key (key:). When after is
+// greater than zero the search starts at that line. It returns 0 if the
+// key is not found.
+func fieldLine(data []byte, key string, after int) int {
+	if key == "" {
+		return 0
+	}
+	jsonKey := strconv.Quote(key)
+	lines := strings.Split(string(data), "\n")
+	start := 0
+	if after > 0 {
+		start = after - 1
+	}
+	for i := start; i < len(lines); i++ {
+		if hasJSONKey(lines[i], jsonKey) {
+			return i + 1
+		}
+		trimmed := strings.TrimSpace(lines[i])
+		trimmed = strings.TrimPrefix(trimmed, "- ")
+		if strings.HasPrefix(trimmed, key+":") {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// hasJSONKey reports whether line contains the quoted key followed by a colon.
+func hasJSONKey(line, quotedKey string) bool {
+	for {
+		idx := strings.Index(line, quotedKey)
+		if idx < 0 {
+			return false
+		}
+		line = line[idx+len(quotedKey):]
+		if strings.HasPrefix(strings.TrimSpace(line), ":") {
+			return true
+		}
+	}
+}
+
+// valueLine returns the 1-based line of the first occurrence of value as a
+// quoted JSON string or a bare YAML scalar at or after the given line. It
+// returns 0 if the value is not found.
+func valueLine(data []byte, value string, after int) int {
+	if value == "" {
+		return 0
+	}
+	quoted := strconv.Quote(value)
+	lines := strings.Split(string(data), "\n")
+	start := 0
+	if after > 0 {
+		start = after - 1
+	}
+	for i := start; i < len(lines); i++ {
+		line := lines[i]
+		if strings.Contains(line, quoted) {
+			return i + 1
+		}
+		trimmed := strings.TrimSpace(line)
+		trimmed = strings.TrimPrefix(trimmed, "- ")
+		if trimmed == value || strings.HasSuffix(trimmed, ": "+value) {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -7,6 +7,7 @@ import (
 	"runtime"
 
 	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
 )
 
 const (
@@ -117,15 +118,17 @@ func (a *Adapter) ToCore(claudeCfg *Config) *core.Config {
 			URL:     server.URL,
 			Headers: server.Headers,
 		}
+		if server.OAuth != nil {
+			coreServer.OAuth = &core.OAuth{
+				ClientID:     server.OAuth.ClientID,
+				CallbackPort: server.OAuth.CallbackPort,
+			}
+		}
 
 		// Set transport type
-		switch server.Type {
-		case "stdio":
-			coreServer.Transport = core.TransportStdio
-		case "http":
-			coreServer.Transport = core.TransportHTTP
-		case "sse":
-			coreServer.Transport = core.TransportSSE
+		switch transport := core.ParseTransport(server.Type); transport {
+		case core.TransportStdio, core.TransportHTTP, core.TransportSSE:
+			coreServer.Transport = transport
 		default:
 			// Infer from fields
 			if server.Command != "" {
@@ -149,9 +152,15 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 		claudeServer := ServerConfig{
 			Command: server.Command,
 			Args:    server.Args,
-			Env:     server.Env,
+			Env:     core.RenderEnvPlaceholders(name, core.FieldEnv, server.Env),
 			URL:     server.URL,
-			Headers: server.Headers,
+			Headers: core.RenderEnvPlaceholders(name, core.FieldHeaders, server.Headers),
+		}
+		if server.OAuth != nil && (server.OAuth.ClientID != "" || server.OAuth.CallbackPort > 0) {
+			claudeServer.OAuth = &OAuthConfig{
+				ClientID:     server.OAuth.ClientID,
+				CallbackPort: server.OAuth.CallbackPort,
+			}
 		}
 
 		// Set type if explicitly specified
@@ -165,6 +174,24 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	return claudeCfg
 }
 
+// Lossiness reports the canonical fields that the Claude format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldURL, core.FieldHeaders, core.FieldOAuthClientID, core.FieldOAuthCallbackPort)
+	return append(losses, core.SecretLosses(AdapterName, cfg)...)
+}
+
+// Merge writes cfg into an existing Claude config, replacing only the
+// mcpServers key and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "mcpServers")
+}
+
 // ReadProjectConfig reads the project-level .mcp.json file.
 func ReadProjectConfig() (*core.Config, error) {
 	adapter := NewAdapter()
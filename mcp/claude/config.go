@@ -45,6 +45,19 @@ type ServerConfig struct {
 
 	// Headers contains HTTP headers for authentication.
 	Headers map[string]string `json:"headers,omitempty"`
+
+	// OAuth configures a pre-registered OAuth client for remote servers
+	// without dynamic client registration.
+	OAuth *OAuthConfig `json:"oauth,omitempty"`
+}
+
+// OAuthConfig is the OAuth client of a remote server in Claude's format.
+type OAuthConfig struct {
+	// ClientID is the pre-registered client ID.
+	ClientID string `json:"clientId,omitempty"`
+
+	// CallbackPort is the local port of the redirect URI.
+	CallbackPort int `json:"callbackPort,omitempty"`
 }
 
 // NewConfig creates a new empty Claude config.
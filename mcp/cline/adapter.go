@@ -14,6 +14,7 @@ import (
 	"runtime"
 
 	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
 )
 
 const (
@@ -124,12 +125,12 @@ func (a *Adapter) ToCore(clineCfg *Config) *core.Config {
 		}
 
 		// Set transport type
-		switch server.Type {
-		case "stdio":
+		switch core.ParseTransport(server.Type) {
+		case core.TransportStdio:
 			coreServer.Transport = core.TransportStdio
-		case "http":
+		case core.TransportHTTP:
 			coreServer.Transport = core.TransportHTTP
-		case "sse":
+		case core.TransportSSE:
 			coreServer.Transport = core.TransportSSE
 		default:
 			if server.Command != "" {
@@ -155,14 +156,18 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 		clineServer := ServerConfig{
 			Command:     server.Command,
 			Args:        server.Args,
-			Env:         server.Env,
+			Env:         core.RenderEnvPlaceholders(name, core.FieldEnv, server.Env),
 			URL:         server.URL,
-			Headers:     server.Headers,
+			Headers:     core.RenderEnvPlaceholders(name, core.FieldHeaders, server.Headers),
 			AlwaysAllow: server.AlwaysAllow,
 			Disabled:    !server.IsEnabled(),
 		}
 
-		if server.Transport != "" {
+		// Older versions read remote servers without a type as SSE, so
+		// always name the Streamable HTTP transport.
+		if server.InferTransport() == core.TransportHTTP {
+			clineServer.Type = TypeStreamableHTTP
+		} else if server.Transport != "" {
 			clineServer.Type = server.Transport.String()
 		}
 
@@ -172,6 +177,24 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	return clineCfg
 }
 
+// Lossiness reports the canonical fields that the Cline format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldURL, core.FieldHeaders, core.FieldAlwaysAllow, core.FieldEnabled)
+	return append(losses, core.SecretLosses(AdapterName, cfg)...)
+}
+
+// Merge writes cfg into an existing Cline config, replacing only the
+// mcpServers key and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "mcpServers")
+}
+
 // init registers the adapter with the default registry.
 func init() {
 	core.Register(NewAdapter())
@@ -5,9 +5,12 @@ type Config struct {
 	MCPServers map[string]ServerConfig `json:"mcpServers"`
 }
 
+// TypeStreamableHTTP is Cline's type for the Streamable HTTP transport.
+const TypeStreamableHTTP = "streamableHttp"
+
 // ServerConfig represents a Cline MCP server configuration.
 type ServerConfig struct {
-	// Type specifies the transport type.
+	// Type specifies the transport type: "stdio", "streamableHttp" or "sse".
 	Type string `json:"type,omitempty"`
 
 	// --- STDIO Server Fields ---
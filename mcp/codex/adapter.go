@@ -1,7 +1,8 @@
 // Package codex provides an adapter for OpenAI Codex CLI MCP configuration.
 //
 // Codex uses TOML format instead of JSON, with additional features:
-//   - bearer_token_env_var for OAuth
+//   - bearer_token_env_var for static tokens, and scopes for OAuth logins
+//   - env_vars and env_http_headers for secrets read from the environment
 //   - enabled_tools / disabled_tools for tool filtering
 //   - startup_timeout_sec / tool_timeout_sec for timeouts
 //   - enabled flag to disable without deleting
@@ -14,6 +15,7 @@ import (
 	"path/filepath"
 
 	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
 	"github.com/pelletier/go-toml/v2"
 )
 
@@ -111,6 +113,25 @@ func (a *Adapter) ToCore(codexCfg *Config) *core.Config {
 			ToolTimeoutSec:    server.ToolTimeoutSec,
 			Enabled:           server.Enabled,
 		}
+		if len(server.Scopes) > 0 {
+			coreServer.OAuth = &core.OAuth{Scopes: server.Scopes}
+		}
+
+		// Forwarded variables and env-backed headers become secret references
+		for _, name := range server.EnvVars {
+			if _, ok := coreServer.Env[name]; !ok {
+				if coreServer.Env == nil {
+					coreServer.Env = make(map[string]string)
+				}
+				coreServer.Env[name] = envRef(name)
+			}
+		}
+		for header, name := range server.EnvHTTPHeaders {
+			if coreServer.Headers == nil {
+				coreServer.Headers = make(map[string]string)
+			}
+			coreServer.Headers[header] = envRef(name)
+		}
 
 		// Infer transport
 		if server.Command != "" {
@@ -132,20 +153,26 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	}
 
 	for name, server := range cfg.Servers {
+		secrets := renderSecrets(name, server)
 		codexServer := ServerConfig{
 			Command:           server.Command,
 			Args:              server.Args,
-			Env:               server.Env,
+			Env:               secrets.Env,
+			EnvVars:           secrets.EnvVars,
 			Cwd:               server.Cwd,
 			URL:               server.URL,
-			HTTPHeaders:       server.Headers,
-			BearerTokenEnvVar: server.BearerTokenEnvVar,
+			HTTPHeaders:       secrets.HTTPHeaders,
+			EnvHTTPHeaders:    secrets.EnvHTTPHeaders,
+			BearerTokenEnvVar: secrets.BearerTokenEnvVar,
 			EnabledTools:      server.EnabledTools,
 			DisabledTools:     server.DisabledTools,
 			StartupTimeoutSec: server.StartupTimeoutSec,
 			ToolTimeoutSec:    server.ToolTimeoutSec,
 			Enabled:           server.Enabled,
 		}
+		if server.OAuth != nil {
+			codexServer.Scopes = server.OAuth.Scopes
+		}
 
 		codexCfg.MCPServers[name] = codexServer
 	}
@@ -153,6 +180,61 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	return codexCfg
 }
 
+// Lossiness reports the canonical fields that the Codex format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv, core.FieldCwd,
+		core.FieldURL, core.FieldHeaders, core.FieldBearerTokenEnvVar,
+		core.FieldEnabledTools, core.FieldDisabledTools, core.FieldEnabled,
+		core.FieldStartupTimeoutSec, core.FieldToolTimeoutSec,
+		core.FieldOAuthScopes, core.FieldOAuthClientID, core.FieldOAuthCallbackPort)
+	losses = append(losses, core.DegradedTransports(AdapterName, cfg, core.TransportStdio, core.TransportHTTP)...)
+	losses = append(losses, oauthLosses(cfg)...)
+	losses = append(losses, core.PlaintextSecrets(AdapterName, cfg)...)
+	for _, name := range sortedNames(cfg) {
+		losses = append(losses, renderSecrets(name, cfg.Servers[name]).Losses...)
+	}
+	return losses
+}
+
+// oauthLosses reports the OAuth settings Codex has no per-server field for.
+func oauthLosses(cfg *core.Config) []core.Loss {
+	var losses []core.Loss
+	for _, name := range sortedNames(cfg) {
+		oauth := cfg.Servers[name].OAuth
+		if oauth == nil {
+			continue
+		}
+		if oauth.ClientID != "" {
+			losses = append(losses, core.Loss{
+				Adapter: AdapterName,
+				Path:    "servers." + name + "." + core.FieldOAuthClientID,
+				Kind:    core.LossDropped,
+				Detail:  "Codex registers its OAuth client dynamically",
+			})
+		}
+		if oauth.CallbackPort > 0 {
+			losses = append(losses, core.Loss{
+				Adapter: AdapterName,
+				Path:    "servers." + name + "." + core.FieldOAuthCallbackPort,
+				Kind:    core.LossDropped,
+				Detail:  "set mcp_oauth_callback_port at the top level of config.toml",
+			})
+		}
+	}
+	return losses
+}
+
+// Merge writes cfg into an existing Codex config.toml, replacing only the
+// [mcp_servers] tables and keeping all other sections and comments.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.TOML(existing, data, "mcp_servers")
+}
+
 // ConfigPath returns the default Codex config path.
 func ConfigPath() (string, error) {
 	home, err := os.UserHomeDir()
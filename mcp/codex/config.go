@@ -20,6 +20,7 @@ type ServerConfig struct {
 	BearerTokenEnvVar string            `toml:"bearer_token_env_var,omitempty"`
 	HTTPHeaders       map[string]string `toml:"http_headers,omitempty"`
 	EnvHTTPHeaders    map[string]string `toml:"env_http_headers,omitempty"` // Header name -> env var name
+	Scopes            []string          `toml:"scopes,omitempty"`           // OAuth scopes for "codex mcp login"
 
 	// --- Tool Control ---
 	EnabledTools  []string `toml:"enabled_tools,omitempty"`
@@ -0,0 +1,117 @@
+package codex
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+// bearerPattern matches an Authorization header value that is a bearer token
+// read from a single secret reference.
+var bearerPattern = regexp.MustCompile(`^Bearer (\$\{[^}]+\})$`)
+
+// secrets is the Codex rendering of a server's Env and Headers. Codex does not
+// expand placeholders; instead it forwards whitelisted environment variables
+// and reads header values from environment variables.
+type secrets struct {
+	Env               map[string]string
+	EnvVars           []string
+	HTTPHeaders       map[string]string
+	EnvHTTPHeaders    map[string]string
+	BearerTokenEnvVar string
+	Losses            []core.Loss
+}
+
+// renderSecrets maps the secret references of a canonical server to the
+// native Codex fields and records the ones that cannot be mapped exactly.
+func renderSecrets(name string, server core.Server) secrets {
+	s := secrets{BearerTokenEnvVar: server.BearerTokenEnvVar}
+	degrade := func(field, detail string) {
+		s.Losses = append(s.Losses, core.Loss{
+			Adapter: AdapterName,
+			Path:    "servers." + name + "." + field,
+			Kind:    core.LossDegraded,
+			Detail:  detail,
+		})
+	}
+	literal := func(field, key, value string) string {
+		if len(core.SecretRefs(value)) > 0 {
+			degrade(field, fmt.Sprintf("%s: secret references inside a value are not expanded and are written literally", key))
+		}
+		return value
+	}
+
+	seen := make(map[string]bool)
+	for _, key := range sortedKeys(server.Env) {
+		value := server.Env[key]
+		ref, ok := core.ParseSecretRef(value)
+		if !ok {
+			if s.Env == nil {
+				s.Env = make(map[string]string)
+			}
+			s.Env[key] = literal(core.FieldEnv, key, value)
+			continue
+		}
+		envVar := core.SecretVar(name, core.FieldEnv, key, ref)
+		switch {
+		case ref.Source != core.SecretEnv:
+			degrade(core.FieldEnv, fmt.Sprintf("%s: %s is read from environment variable %s", key, ref, envVar))
+		case envVar != key:
+			degrade(core.FieldEnv, fmt.Sprintf("%s: %s is forwarded as %s since Codex cannot rename variables", key, ref, envVar))
+		}
+		if !seen[envVar] {
+			seen[envVar] = true
+			s.EnvVars = append(s.EnvVars, envVar)
+		}
+	}
+
+	for _, key := range sortedKeys(server.Headers) {
+		value := server.Headers[key]
+		if m := bearerPattern.FindStringSubmatch(value); m != nil && key == "Authorization" && s.BearerTokenEnvVar == "" {
+			if ref, ok := core.ParseSecretRef(m[1]); ok {
+				s.BearerTokenEnvVar = core.SecretVar(name, core.FieldHeaders, key, ref)
+				degrade(core.FieldHeaders, fmt.Sprintf("%s: %s is written as bearer_token_env_var %s", key, value, s.BearerTokenEnvVar))
+				continue
+			}
+		}
+		ref, ok := core.ParseSecretRef(value)
+		if !ok {
+			if s.HTTPHeaders == nil {
+				s.HTTPHeaders = make(map[string]string)
+			}
+			s.HTTPHeaders[key] = literal(core.FieldHeaders, key, value)
+			continue
+		}
+		envVar := core.SecretVar(name, core.FieldHeaders, key, ref)
+		if ref.Source != core.SecretEnv {
+			degrade(core.FieldHeaders, fmt.Sprintf("%s: %s is read from environment variable %s", key, ref, envVar))
+		}
+		if s.EnvHTTPHeaders == nil {
+			s.EnvHTTPHeaders = make(map[string]string)
+		}
+		s.EnvHTTPHeaders[key] = envVar
+	}
+	return s
+}
+
+// envRef returns the canonical reference to an environment variable.
+func envRef(name string) string {
+	return core.SecretRef{Source: core.SecretEnv, Name: name}.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedNames(cfg *core.Config) []string {
+	names := cfg.ServerNames()
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,287 @@
+// Package continuedev provides an adapter for Continue MCP configuration.
+//
+// Continue reads MCP servers from YAML blocks in .continue/mcpServers/ and
+// from the mcpServers list of ~/.continue/config.yaml. Both use the same
+// list of named servers; the adapter writes a block file named
+// assistantkit.yaml, and Merge replaces only the mcpServers key of an
+// existing file. The package is not named continue, which is a Go keyword.
+//
+// Field mapping:
+//
+//	canonical       Continue
+//	transport       type (stdio, sse, streamable-http)
+//	command, args   command, args
+//	env, cwd        env, cwd
+//	url             url
+//	headers         requestOptions.headers
+//
+// Secret references are written as ${{ secrets.NAME }} templates, which
+// Continue resolves from .env files and the environment.
+//
+// File locations:
+//   - Project: <project>/.continue/mcpServers/assistantkit.yaml
+//   - User: ~/.continue/config.yaml
+package continuedev
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "continue"
+
+	// ProjectConfigDir is the project config directory.
+	ProjectConfigDir = ".continue"
+
+	// BlocksDir is the directory of MCP server blocks.
+	BlocksDir = "mcpServers"
+
+	// BlockFileName is the name of the block file the adapter writes.
+	BlockFileName = "assistantkit.yaml"
+
+	// UserConfigFileName is the user-level config file name.
+	UserConfigFileName = "config.yaml"
+
+	// BlockName, BlockVersion and BlockSchema identify the block file.
+	BlockName    = "assistantkit"
+	BlockVersion = "0.0.1"
+	BlockSchema  = "v1"
+)
+
+// secretPattern matches Continue's ${{ secrets.NAME }} templates.
+var secretPattern = regexp.MustCompile(`\$\{\{\s*secrets\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// Adapter implements core.Adapter for Continue.
+type Adapter struct{}
+
+// NewAdapter creates a new Continue adapter.
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Name returns the adapter name.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns the default config file paths for Continue.
+func (a *Adapter) DefaultPaths() []string {
+	paths := []string{
+		filepath.Join(ProjectConfigDir, BlocksDir, BlockFileName),
+	}
+
+	// User config
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ProjectConfigDir, UserConfigFileName))
+	}
+
+	return paths
+}
+
+// Parse parses Continue YAML data into the canonical format.
+func (a *Adapter) Parse(data []byte) (*core.Config, error) {
+	var continueCfg Config
+	if err := yaml.Unmarshal(data, &continueCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return a.ToCore(&continueCfg), nil
+}
+
+// Marshal converts canonical config to a Continue block file.
+func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(a.FromCore(cfg)); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadFile reads a Continue config file.
+func (a *Adapter) ReadFile(path string) (*core.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Path: path, Err: err}
+	}
+	cfg, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteFile writes canonical config to a Continue block file.
+func (a *Adapter) WriteFile(cfg *core.Config, path string) error {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	// Ensure directory exists
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	return nil
+}
+
+// ToCore converts Continue config to canonical format.
+func (a *Adapter) ToCore(continueCfg *Config) *core.Config {
+	cfg := core.NewConfig()
+
+	for _, server := range continueCfg.MCPServers {
+		coreServer := core.Server{
+			Command: server.Command,
+			Args:    server.Args,
+			Env:     parseSecrets(server.Env),
+			Cwd:     server.Cwd,
+			URL:     server.URL,
+		}
+		if server.RequestOptions != nil {
+			coreServer.Headers = parseSecrets(server.RequestOptions.Headers)
+		}
+
+		// Set transport type
+		switch core.ParseTransport(server.Type) {
+		case core.TransportStdio:
+			coreServer.Transport = core.TransportStdio
+		case core.TransportHTTP:
+			coreServer.Transport = core.TransportHTTP
+		case core.TransportSSE:
+			coreServer.Transport = core.TransportSSE
+		default:
+			if server.Command != "" {
+				coreServer.Transport = core.TransportStdio
+			} else if server.URL != "" {
+				coreServer.Transport = core.TransportHTTP
+			}
+		}
+
+		cfg.Servers[server.Name] = coreServer
+	}
+
+	return cfg
+}
+
+// FromCore converts canonical config to a Continue block, with the servers
+// in name order.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	continueCfg := NewConfig()
+
+	names := cfg.ServerNames()
+	sort.Strings(names)
+	for _, name := range names {
+		server := cfg.Servers[name]
+		continueServer := ServerConfig{
+			Name:    name,
+			Command: server.Command,
+			Args:    server.Args,
+			Env:     renderSecrets(name, core.FieldEnv, server.Env),
+			Cwd:     server.Cwd,
+			URL:     server.URL,
+		}
+		if len(server.Headers) > 0 {
+			continueServer.RequestOptions = &RequestOptions{
+				Headers: renderSecrets(name, core.FieldHeaders, server.Headers),
+			}
+		}
+
+		switch server.InferTransport() {
+		case core.TransportStdio:
+			continueServer.Type = TypeStdio
+		case core.TransportHTTP:
+			continueServer.Type = TypeStreamableHTTP
+		case core.TransportSSE:
+			continueServer.Type = TypeSSE
+		}
+
+		continueCfg.MCPServers = append(continueCfg.MCPServers, continueServer)
+	}
+
+	return continueCfg
+}
+
+// renderSecrets returns a copy of values with every secret reference
+// written as a ${{ secrets.NAME }} template. The name is chosen by
+// core.SecretVar.
+func renderSecrets(server, field string, values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	out := make(map[string]string, len(values))
+	for key, value := range values {
+		out[key] = core.ReplaceSecretRefs(value, func(ref core.SecretRef) string {
+			return "${{ secrets." + core.SecretVar(server, field, key, ref) + " }}"
+		})
+	}
+	return out
+}
+
+// parseSecrets returns a copy of values with every ${{ secrets.NAME }}
+// template written as a ${NAME} reference.
+func parseSecrets(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	out := make(map[string]string, len(values))
+	for key, value := range values {
+		out[key] = secretPattern.ReplaceAllString(value, "$${$1}")
+	}
+	return out
+}
+
+// Lossiness reports the canonical fields that the Continue format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldCwd, core.FieldURL, core.FieldHeaders)
+	return append(losses, core.SecretLosses(AdapterName, cfg)...)
+}
+
+// Merge writes cfg into an existing Continue config, replacing only the
+// mcpServers key and keeping all other settings and comments.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.YAML(existing, data, "mcpServers")
+}
+
+// ProjectConfigPath returns the path of the block file for a given project root.
+func ProjectConfigPath(projectRoot string) string {
+	return filepath.Join(projectRoot, ProjectConfigDir, BlocksDir, BlockFileName)
+}
+
+// UserConfigPath returns the user-level config path.
+func UserConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ProjectConfigDir, UserConfigFileName), nil
+}
+
+// init registers the adapter with the default registry.
+func init() {
+	core.Register(NewAdapter())
+}
@@ -0,0 +1,227 @@
+package continuedev
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+func TestAdapterName(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter.Name() != "continue" {
+		t.Errorf("Expected name 'continue', got %q", adapter.Name())
+	}
+}
+
+func TestAdapterDefaultPaths(t *testing.T) {
+	adapter := NewAdapter()
+	paths := adapter.DefaultPaths()
+
+	if len(paths) == 0 {
+		t.Fatal("Expected at least one default path")
+	}
+	// First path should be the project block file
+	if paths[0] != filepath.Join(ProjectConfigDir, BlocksDir, BlockFileName) {
+		t.Errorf("Expected project block path first, got %q", paths[0])
+	}
+}
+
+func TestAdapterParse(t *testing.T) {
+	adapter := NewAdapter()
+
+	yamlData := []byte(`name: Team MCP servers
+version: 0.0.1
+schema: v1
+mcpServers:
+  - name: github
+    command: npx
+    args:
+      - -y
+      - "@modelcontextprotocol/server-github"
+    env:
+      GITHUB_TOKEN: ${{ secrets.GITHUB_TOKEN }}
+    cwd: /src/app
+  - name: remote
+    type: streamable-http
+    url: https://api.example.com/mcp
+    requestOptions:
+      headers:
+        Authorization: Bearer ${{ secrets.API_TOKEN }}
+  - name: events
+    type: sse
+    url: https://api.example.com/sse
+`)
+
+	cfg, err := adapter.Parse(yamlData)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(cfg.Servers) != 3 {
+		t.Errorf("Expected 3 servers, got %d", len(cfg.Servers))
+	}
+
+	github, ok := cfg.GetServer("github")
+	if !ok {
+		t.Fatal("github server not found")
+	}
+	if github.Transport != core.TransportStdio {
+		t.Errorf("Expected stdio transport, got %v", github.Transport)
+	}
+	if github.Env["GITHUB_TOKEN"] != "${GITHUB_TOKEN}" {
+		t.Errorf("Expected secret template to be read as ${GITHUB_TOKEN}, got %q", github.Env["GITHUB_TOKEN"])
+	}
+	if github.Cwd != "/src/app" {
+		t.Errorf("Expected cwd '/src/app', got %q", github.Cwd)
+	}
+
+	remote, ok := cfg.GetServer("remote")
+	if !ok {
+		t.Fatal("remote server not found")
+	}
+	if remote.Transport != core.TransportHTTP {
+		t.Errorf("Expected http transport, got %v", remote.Transport)
+	}
+	if remote.Headers["Authorization"] != "Bearer ${API_TOKEN}" {
+		t.Errorf("Expected header secret to be read as ${API_TOKEN}, got %q", remote.Headers["Authorization"])
+	}
+
+	events, _ := cfg.GetServer("events")
+	if events.Transport != core.TransportSSE {
+		t.Errorf("Expected sse transport, got %v", events.Transport)
+	}
+}
+
+func TestAdapterMarshal(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("zeta", core.Server{
+		Transport: core.TransportHTTP,
+		URL:       "https://api.example.com/mcp",
+		Headers:   map[string]string{"Authorization": "Bearer ${keyring:zeta/token}"},
+	})
+	cfg.AddServer("alpha", core.Server{
+		Command: "npx",
+		Args:    []string{"-y", "test-server"},
+		Env:     map[string]string{"API_KEY": "${env:API_KEY}"},
+	})
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	out := string(data)
+	for _, s := range []string{"name: assistantkit", "schema: v1", "type: streamable-http",
+		"API_KEY: ${{ secrets.API_KEY }}", "Authorization: Bearer ${{ secrets.ZETA_TOKEN }}"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected %s in:\n%s", s, out)
+		}
+	}
+	if strings.Index(out, "name: alpha") > strings.Index(out, "name: zeta") {
+		t.Errorf("Expected servers in name order:\n%s", out)
+	}
+
+	// Round-trip
+	cfg2, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse after marshal failed: %v", err)
+	}
+	alpha, ok := cfg2.GetServer("alpha")
+	if !ok {
+		t.Fatal("alpha not found after round-trip")
+	}
+	if alpha.Command != "npx" || len(alpha.Args) != 2 || alpha.Env["API_KEY"] != "${API_KEY}" {
+		t.Errorf("Expected alpha to round-trip, got %+v", alpha)
+	}
+}
+
+func TestAdapterLossiness(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("test", core.Server{
+		Command:       "npx",
+		DisabledTools: []string{"delete"},
+	})
+
+	losses := adapter.Lossiness(cfg)
+	if len(losses) != 1 || losses[0].Path != "servers.test.disabledTools" {
+		t.Errorf("Expected disabledTools to be dropped, got %v", losses)
+	}
+}
+
+func TestAdapterMerge(t *testing.T) {
+	adapter := NewAdapter()
+
+	existing := []byte(`name: My assistant
+version: 1.0.0
+# Models are not managed
+models:
+  - name: gpt-4o
+    provider: openai
+mcpServers:
+  - name: stale
+    command: stale
+`)
+
+	cfg := core.NewConfig()
+	cfg.AddServer("github", core.Server{Command: "npx"})
+
+	merged, err := adapter.Merge(cfg, existing)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	out := string(merged)
+	for _, s := range []string{"name: My assistant", "# Models are not managed", "provider: openai", "name: github"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected %s in:\n%s", s, out)
+		}
+	}
+	if strings.Contains(out, "stale") {
+		t.Errorf("Expected stale server to be removed:\n%s", out)
+	}
+}
+
+func TestAdapterReadWriteFile(t *testing.T) {
+	adapter := NewAdapter()
+	tmpDir := t.TempDir()
+	path := ProjectConfigPath(tmpDir)
+
+	cfg := core.NewConfig()
+	cfg.AddServer("file-test", core.Server{
+		Command: "echo",
+	})
+
+	if err := adapter.WriteFile(cfg, path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	loaded, err := adapter.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	if len(loaded.Servers) != 1 {
+		t.Errorf("Expected 1 server, got %d", len(loaded.Servers))
+	}
+}
+
+func TestAdapterReadFileNotFound(t *testing.T) {
+	adapter := NewAdapter()
+
+	_, err := adapter.ReadFile("/nonexistent/path.yaml")
+	if err == nil {
+		t.Error("Expected error for nonexistent file")
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	cfg := NewConfig()
+
+	if cfg.Name != BlockName || cfg.Schema != BlockSchema {
+		t.Errorf("Expected block name and schema to be set, got %+v", cfg)
+	}
+}
@@ -0,0 +1,52 @@
+package continuedev
+
+// Config represents a Continue MCP server block (.continue/mcpServers/*.yaml)
+// or the MCP section of a Continue config.yaml.
+type Config struct {
+	// Name, Version and Schema identify a block file.
+	Name    string `yaml:"name,omitempty"`
+	Version string `yaml:"version,omitempty"`
+	Schema  string `yaml:"schema,omitempty"`
+
+	MCPServers []ServerConfig `yaml:"mcpServers"`
+}
+
+// Continue transport types.
+const (
+	TypeStdio          = "stdio"
+	TypeSSE            = "sse"
+	TypeStreamableHTTP = "streamable-http"
+)
+
+// ServerConfig represents a Continue MCP server.
+type ServerConfig struct {
+	// Name identifies the server.
+	Name string `yaml:"name"`
+
+	// Type specifies the transport type: "stdio", "sse" or "streamable-http".
+	Type string `yaml:"type,omitempty"`
+
+	// --- STDIO Server Fields ---
+	Command string            `yaml:"command,omitempty"`
+	Args    []string          `yaml:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+	Cwd     string            `yaml:"cwd,omitempty"`
+
+	// --- Remote Server Fields ---
+	URL            string          `yaml:"url,omitempty"`
+	RequestOptions *RequestOptions `yaml:"requestOptions,omitempty"`
+}
+
+// RequestOptions configures the requests made to a remote server.
+type RequestOptions struct {
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// NewConfig creates a new Continue MCP server block.
+func NewConfig() *Config {
+	return &Config{
+		Name:    BlockName,
+		Version: BlockVersion,
+		Schema:  BlockSchema,
+	}
+}
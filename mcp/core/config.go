@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/fs"
 	"os"
+	"sort"
 )
 
 // DefaultFileMode is the default permission mode for configuration files.
@@ -152,6 +153,22 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ValidateAll checks every server and returns all validation errors,
+// ordered by server name.
+func (c *Config) ValidateAll() []error {
+	names := c.ServerNames()
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		server := c.Servers[name]
+		if err := server.Validate(); err != nil {
+			errs = append(errs, &ServerValidationError{Name: name, Err: err})
+		}
+	}
+	return errs
+}
+
 // MarshalJSON implements json.Marshaler.
 func (c *Config) MarshalJSON() ([]byte, error) {
 	type Alias Config
@@ -98,6 +98,26 @@ func TestConfigMerge(t *testing.T) {
 	}
 }
 
+func TestConfigValidateAll(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddServer("ok", Server{Command: "npx"})
+	cfg.AddServer("b-empty", Server{})
+	cfg.AddServer("a-both", Server{Command: "npx", URL: "https://example.com/mcp"})
+
+	errs := cfg.ValidateAll()
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %v", len(errs), errs)
+	}
+	first, ok := errs[0].(*ServerValidationError)
+	if !ok || first.Name != "a-both" || first.Err != ErrBothCommandAndURL {
+		t.Errorf("Unexpected first error: %v", errs[0])
+	}
+	second, ok := errs[1].(*ServerValidationError)
+	if !ok || second.Name != "b-empty" || second.Err != ErrNoCommandOrURL {
+		t.Errorf("Unexpected second error: %v", errs[1])
+	}
+}
+
 func TestConfigJSON(t *testing.T) {
 	cfg := NewConfig()
 	cfg.AddServer("test", Server{
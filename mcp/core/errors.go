@@ -13,6 +13,12 @@ var (
 	// ErrBothCommandAndURL is returned when a server has both command and URL.
 	ErrBothCommandAndURL = errors.New("server cannot have both command and url")
 
+	// ErrOAuthNotRemote is returned when a stdio server has OAuth settings.
+	ErrOAuthNotRemote = errors.New("oauth requires a remote server (url)")
+
+	// ErrInvalidCallbackPort is returned when an OAuth callback port is out of range.
+	ErrInvalidCallbackPort = errors.New("oauth callbackPort must be between 1 and 65535")
+
 	// ErrInvalidTransport is returned when a transport type is invalid.
 	ErrInvalidTransport = errors.New("invalid transport type")
 
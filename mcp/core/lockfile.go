@@ -0,0 +1,147 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LockfileVersion is the current lockfile format version.
+const LockfileVersion = 1
+
+// Lockfile records the servers last synced to each target, so that a later
+// sync can tell manual edits apart from changes to the canonical config.
+type Lockfile struct {
+	// Version is the lockfile format version.
+	Version int `json:"version"`
+
+	// Targets holds the last-synced state of each target, sorted by path.
+	Targets []LockTarget `json:"targets"`
+}
+
+// LockTarget is the last-synced state of one target file.
+type LockTarget struct {
+	// Tool is the adapter name of the target.
+	Tool string `json:"tool"`
+
+	// Path is the target file path.
+	Path string `json:"path"`
+
+	// SyncedAt is when the target was last synced.
+	SyncedAt time.Time `json:"syncedAt"`
+
+	// Servers holds the canonical servers as written to the target, read
+	// back through the target's adapter. Env and header values are stored
+	// as hashes (see LockServers), so that secrets are not written to the
+	// lockfile.
+	Servers map[string]Server `json:"servers"`
+}
+
+// lockHashPrefix marks a hashed env or header value.
+const lockHashPrefix = "sha256:"
+
+// LockServers returns copies of servers with every env and header value
+// replaced by its SHA-256 hash. Values that are already hashed are kept,
+// so the result can be compared with servers read from any lockfile.
+func LockServers(servers map[string]Server) map[string]Server {
+	if servers == nil {
+		return nil
+	}
+	locked := make(map[string]Server, len(servers))
+	for name, s := range servers {
+		locked[name] = lockServer(s)
+	}
+	return locked
+}
+
+// lockServer returns a copy of s with hashed env and header values.
+func lockServer(s Server) Server {
+	s.Env = hashValues(s.Env)
+	s.Headers = hashValues(s.Headers)
+	return s
+}
+
+func hashValues(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	hashed := make(map[string]string, len(values))
+	for key, value := range values {
+		if strings.HasPrefix(value, lockHashPrefix) {
+			hashed[key] = value
+			continue
+		}
+		sum := sha256.Sum256([]byte(value))
+		hashed[key] = lockHashPrefix + hex.EncodeToString(sum[:])
+	}
+	return hashed
+}
+
+// NewLockfile creates an empty lockfile.
+func NewLockfile() *Lockfile {
+	return &Lockfile{Version: LockfileVersion}
+}
+
+// ReadLockfile reads a lockfile. A missing file yields an empty lockfile.
+func ReadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewLockfile(), nil
+	}
+	if err != nil {
+		return nil, &ParseError{Format: "lockfile", Path: path, Err: err}
+	}
+	var lock Lockfile
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, &ParseError{Format: "lockfile", Path: path, Err: err}
+	}
+	return &lock, nil
+}
+
+// WriteFile writes the lockfile, creating its directory if needed.
+func (l *Lockfile) WriteFile(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return &WriteError{Format: "lockfile", Path: path, Err: err}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return &WriteError{Format: "lockfile", Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return &WriteError{Format: "lockfile", Path: path, Err: err}
+	}
+	return nil
+}
+
+// Target returns the last-synced state of the target, or nil if it has
+// never been synced.
+func (l *Lockfile) Target(tool, path string) *LockTarget {
+	for i := range l.Targets {
+		if l.Targets[i].Tool == tool && l.Targets[i].Path == path {
+			return &l.Targets[i]
+		}
+	}
+	return nil
+}
+
+// SetTarget records the last-synced state of a target.
+func (l *Lockfile) SetTarget(target LockTarget) {
+	if existing := l.Target(target.Tool, target.Path); existing != nil {
+		*existing = target
+		return
+	}
+	l.Targets = append(l.Targets, target)
+	sort.Slice(l.Targets, func(i, j int) bool {
+		if l.Targets[i].Path != l.Targets[j].Path {
+			return l.Targets[i].Path < l.Targets[j].Path
+		}
+		return l.Targets[i].Tool < l.Targets[j].Tool
+	})
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLockfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".assistantkit", "mcp.lock.json")
+
+	lock, err := ReadLockfile(path)
+	if err != nil {
+		t.Fatalf("ReadLockfile failed: %v", err)
+	}
+	if lock.Version != LockfileVersion || len(lock.Targets) != 0 {
+		t.Errorf("Expected empty lockfile, got %+v", lock)
+	}
+
+	lock.SetTarget(LockTarget{Tool: "cursor", Path: "b.json", Servers: map[string]Server{"a": {Command: "a"}}})
+	lock.SetTarget(LockTarget{Tool: "claude", Path: "a.json"})
+	lock.SetTarget(LockTarget{Tool: "cursor", Path: "b.json", Servers: map[string]Server{"b": {Command: "b"}}})
+	if len(lock.Targets) != 2 || lock.Targets[0].Path != "a.json" {
+		t.Fatalf("Expected 2 targets sorted by path, got %+v", lock.Targets)
+	}
+
+	if err := lock.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	read, err := ReadLockfile(path)
+	if err != nil {
+		t.Fatalf("ReadLockfile failed: %v", err)
+	}
+	target := read.Target("cursor", "b.json")
+	if target == nil || target.Servers["b"].Command != "b" {
+		t.Errorf("Expected cursor target with server b, got %+v", target)
+	}
+	if read.Target("cursor", "a.json") != nil {
+		t.Error("Expected no target for unknown tool/path")
+	}
+}
+
+func TestLockServers(t *testing.T) {
+	servers := map[string]Server{
+		"github": {Command: "npx", Env: map[string]string{"GITHUB_TOKEN": "secret"}},
+		"docs":   {URL: "https://example.com", Headers: map[string]string{"Authorization": "Bearer secret"}},
+	}
+	locked := LockServers(servers)
+	token := locked["github"].Env["GITHUB_TOKEN"]
+	if !strings.HasPrefix(token, "sha256:") || strings.Contains(token, "secret") {
+		t.Errorf("Expected hashed env value, got %q", token)
+	}
+	if auth := locked["docs"].Headers["Authorization"]; !strings.HasPrefix(auth, "sha256:") {
+		t.Errorf("Expected hashed header value, got %q", auth)
+	}
+	if servers["github"].Env["GITHUB_TOKEN"] != "secret" {
+		t.Error("Expected the original servers to be unchanged")
+	}
+	if again := LockServers(locked); again["github"].Env["GITHUB_TOKEN"] != token {
+		t.Error("Expected hashing to be idempotent")
+	}
+}
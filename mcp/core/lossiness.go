@@ -0,0 +1,207 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+)
+
+// LossKind describes how a field is affected when written to a target format.
+type LossKind string
+
+const (
+	// LossDropped means the field is not written at all.
+	LossDropped LossKind = "dropped"
+
+	// LossDegraded means the field is written but reads back with a different value.
+	LossDegraded LossKind = "degraded"
+
+	// LossExposed means the field is written with a plaintext secret.
+	LossExposed LossKind = "exposed"
+)
+
+// Canonical field names used in Loss paths. They match the JSON names of
+// the Server and Config fields.
+const (
+	FieldTransport         = "transport"
+	FieldCommand           = "command"
+	FieldArgs              = "args"
+	FieldEnv               = "env"
+	FieldEnvFile           = "envFile"
+	FieldCwd               = "cwd"
+	FieldURL               = "url"
+	FieldHeaders           = "headers"
+	FieldBearerTokenEnvVar = "bearerTokenEnvVar"
+	FieldOAuthClientID     = "oauth.clientId"
+	FieldOAuthScopes       = "oauth.scopes"
+	FieldOAuthCallbackPort = "oauth.callbackPort"
+	FieldEnabledTools      = "enabledTools"
+	FieldDisabledTools     = "disabledTools"
+	FieldAlwaysAllow       = "alwaysAllow"
+	FieldEnabled           = "enabled"
+	FieldStartupTimeoutSec = "startupTimeoutSec"
+	FieldToolTimeoutSec    = "toolTimeoutSec"
+	FieldNetworkTimeoutSec = "networkTimeoutSec"
+	FieldInputs            = "inputs"
+)
+
+// Loss describes a canonical field that a target format cannot represent exactly.
+type Loss struct {
+	// Adapter is the name of the target adapter.
+	Adapter string `json:"adapter"`
+
+	// Path locates the field in the canonical config (e.g., "servers.github.alwaysAllow").
+	Path string `json:"path"`
+
+	// Kind is whether the field is dropped or degraded.
+	Kind LossKind `json:"kind"`
+
+	// Detail explains the loss.
+	Detail string `json:"detail,omitempty"`
+}
+
+// String returns a one-line description of the loss.
+func (l Loss) String() string {
+	s := fmt.Sprintf("%s: %s %s", l.Adapter, l.Path, l.Kind)
+	if l.Detail != "" {
+		s += ": " + l.Detail
+	}
+	return s
+}
+
+// LossReporter is implemented by adapters that can report which fields of a
+// canonical config they drop or degrade when marshaling.
+type LossReporter interface {
+	// Lossiness returns the losses incurred by marshaling cfg with this adapter.
+	Lossiness(cfg *Config) []Loss
+}
+
+// Lossiness returns the losses incurred by marshaling cfg with the given
+// adapter. It returns nil if the adapter does not implement LossReporter.
+func Lossiness(adapter Adapter, cfg *Config) []Loss {
+	if r, ok := adapter.(LossReporter); ok {
+		return r.Lossiness(cfg)
+	}
+	return nil
+}
+
+// Lossiness returns the losses incurred by marshaling cfg with the named adapter.
+func (r *AdapterRegistry) Lossiness(cfg *Config, to string) ([]Loss, error) {
+	adapter, ok := r.Get(to)
+	if !ok {
+		return nil, ErrServerNotFound
+	}
+	return Lossiness(adapter, cfg), nil
+}
+
+// SetFields returns the names of the fields that are set on the server.
+// Enabled is only reported when the server is disabled, since enabled is the
+// default for every format.
+func (s *Server) SetFields() []string {
+	var fields []string
+	add := func(set bool, name string) {
+		if set {
+			fields = append(fields, name)
+		}
+	}
+	add(s.Transport != "", FieldTransport)
+	add(s.Command != "", FieldCommand)
+	add(len(s.Args) > 0, FieldArgs)
+	add(len(s.Env) > 0, FieldEnv)
+	add(s.EnvFile != "", FieldEnvFile)
+	add(s.Cwd != "", FieldCwd)
+	add(s.URL != "", FieldURL)
+	add(len(s.Headers) > 0, FieldHeaders)
+	add(s.BearerTokenEnvVar != "", FieldBearerTokenEnvVar)
+	if s.OAuth != nil {
+		add(s.OAuth.ClientID != "", FieldOAuthClientID)
+		add(len(s.OAuth.Scopes) > 0, FieldOAuthScopes)
+		add(s.OAuth.CallbackPort > 0, FieldOAuthCallbackPort)
+	}
+	add(len(s.EnabledTools) > 0, FieldEnabledTools)
+	add(len(s.DisabledTools) > 0, FieldDisabledTools)
+	add(len(s.AlwaysAllow) > 0, FieldAlwaysAllow)
+	add(!s.IsEnabled(), FieldEnabled)
+	add(s.StartupTimeoutSec > 0, FieldStartupTimeoutSec)
+	add(s.ToolTimeoutSec > 0, FieldToolTimeoutSec)
+	add(s.NetworkTimeoutSec > 0, FieldNetworkTimeoutSec)
+	return fields
+}
+
+// DroppedFields returns a LossDropped entry for every field set in cfg that
+// is not in supported. Servers are reported in name order.
+func DroppedFields(adapter string, cfg *Config, supported ...string) []Loss {
+	keep := make(map[string]bool, len(supported))
+	for _, f := range supported {
+		keep[f] = true
+	}
+
+	var losses []Loss
+	if len(cfg.Inputs) > 0 && !keep[FieldInputs] {
+		losses = append(losses, Loss{
+			Adapter: adapter,
+			Path:    FieldInputs,
+			Kind:    LossDropped,
+			Detail:  "input variables are not supported",
+		})
+	}
+
+	for _, name := range sortedServerNames(cfg) {
+		server := cfg.Servers[name]
+		for _, field := range server.SetFields() {
+			if keep[field] {
+				continue
+			}
+			losses = append(losses, Loss{
+				Adapter: adapter,
+				Path:    "servers." + name + "." + field,
+				Kind:    LossDropped,
+			})
+		}
+	}
+	return losses
+}
+
+// DegradedTransports returns a LossDegraded entry for every server whose
+// transport is not in supported. Such servers are read back with the
+// transport inferred from their command or URL.
+func DegradedTransports(adapter string, cfg *Config, supported ...TransportType) []Loss {
+	keep := make(map[TransportType]bool, len(supported))
+	for _, t := range supported {
+		keep[t] = true
+	}
+
+	var losses []Loss
+	for _, name := range sortedServerNames(cfg) {
+		server := cfg.Servers[name]
+		transport := server.InferTransport()
+		if transport == "" || keep[transport] {
+			continue
+		}
+		losses = append(losses, Loss{
+			Adapter: adapter,
+			Path:    "servers." + name + "." + FieldTransport,
+			Kind:    LossDegraded,
+			Detail:  fmt.Sprintf("%s transport is not supported and is read back as %s", transport, inferredTransport(server)),
+		})
+	}
+	return losses
+}
+
+// inferredTransport returns the transport a format without a type field
+// infers from the server's command or URL.
+func inferredTransport(s Server) TransportType {
+	if s.Command != "" {
+		return TransportStdio
+	}
+	return TransportHTTP
+}
+
+// sortedServerNames returns the server names in cfg sorted alphabetically.
+func sortedServerNames(cfg *Config) []string {
+	names := make([]string, 0, len(cfg.Servers))
+	for name := range cfg.Servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
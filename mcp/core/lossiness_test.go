@@ -0,0 +1,54 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestServerSetFields(t *testing.T) {
+	s := Server{Command: "npx", Args: []string{"-y"}, AlwaysAllow: []string{"read"}}
+	s.SetEnabled(true)
+	expected := []string{FieldCommand, FieldArgs, FieldAlwaysAllow}
+	if got := s.SetFields(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	s.SetEnabled(false)
+	if got := s.SetFields(); got[len(got)-1] != FieldEnabled {
+		t.Errorf("Expected disabled server to report %q, got %v", FieldEnabled, got)
+	}
+}
+
+func TestDroppedFields(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddServer("b", Server{Command: "npx", Cwd: "/tmp"})
+	cfg.AddServer("a", Server{URL: "https://example.com", Headers: map[string]string{"X": "1"}})
+	cfg.AddInput(InputVariable{Type: "promptString", ID: "key"})
+
+	losses := DroppedFields("test", cfg, FieldCommand, FieldURL)
+	var paths []string
+	for _, loss := range losses {
+		if loss.Kind != LossDropped {
+			t.Errorf("Expected kind %q, got %q", LossDropped, loss.Kind)
+		}
+		paths = append(paths, loss.Path)
+	}
+	expected := []string{"inputs", "servers.a.headers", "servers.b.cwd"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected %v, got %v", expected, paths)
+	}
+}
+
+func TestDegradedTransports(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddServer("local", Server{Command: "npx"})
+	cfg.AddServer("stream", Server{Transport: TransportSSE, URL: "https://example.com/sse"})
+
+	losses := DegradedTransports("test", cfg, TransportStdio, TransportHTTP)
+	if len(losses) != 1 {
+		t.Fatalf("Expected 1 loss, got %d: %v", len(losses), losses)
+	}
+	if losses[0].Path != "servers.stream.transport" || losses[0].Kind != LossDegraded {
+		t.Errorf("Unexpected loss: %v", losses[0])
+	}
+}
@@ -0,0 +1,303 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// DefaultLocalSecretsFile is the project-relative path of the local secrets
+// file read by the local provider. It should not be committed.
+const DefaultLocalSecretsFile = ".assistantkit/secrets.env"
+
+// ErrSecretNotFound is returned when a provider has no value for a secret.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// ErrNoSecretProvider is returned when no provider is registered for a source.
+var ErrNoSecretProvider = errors.New("no secret provider")
+
+// SecretError wraps an error resolving a secret reference.
+type SecretError struct {
+	Ref SecretRef
+	Err error
+}
+
+func (e *SecretError) Error() string {
+	return fmt.Sprintf("resolving secret %s: %v", e.Ref, e.Err)
+}
+
+func (e *SecretError) Unwrap() error {
+	return e.Err
+}
+
+// SecretProvider looks up the value of a secret by name.
+type SecretProvider interface {
+	// Lookup returns the secret value for name.
+	Lookup(name string) (string, error)
+}
+
+// SecretProviderFunc adapts a function to the SecretProvider interface.
+type SecretProviderFunc func(name string) (string, error)
+
+// Lookup calls f(name).
+func (f SecretProviderFunc) Lookup(name string) (string, error) {
+	return f(name)
+}
+
+// EnvProvider reads secrets from environment variables.
+type EnvProvider struct{}
+
+// Lookup returns the value of the environment variable name.
+func (EnvProvider) Lookup(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+// FileProvider reads secrets from files. A leading ~ is expanded to the
+// home directory, and surrounding whitespace is trimmed.
+type FileProvider struct{}
+
+// Lookup returns the trimmed content of the file at path name.
+func (FileProvider) Lookup(name string) (string, error) {
+	path := name
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// CommandProvider reads secrets from the standard output of a command, such
+// as "op read op://vault/item/field". The command line is split on
+// whitespace and run without a shell.
+type CommandProvider struct {
+	// Run runs a command and returns its standard output. If nil, the
+	// command is executed with os/exec.
+	Run func(name string, args ...string) ([]byte, error)
+}
+
+// Lookup runs the command line name and returns its trimmed output.
+func (p CommandProvider) Lookup(name string) (string, error) {
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty command")
+	}
+	run := p.Run
+	if run == nil {
+		run = runCommand
+	}
+	out, err := run(fields[0], fields[1:]...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// KeyringProvider reads secrets from the OS keyring. Names have the form
+// service/account. It uses the security tool on macOS and secret-tool
+// (libsecret) on Linux.
+type KeyringProvider struct {
+	// Run runs a command and returns its standard output. If nil, the
+	// command is executed with os/exec.
+	Run func(name string, args ...string) ([]byte, error)
+}
+
+// Lookup returns the password stored for the service/account name.
+func (p KeyringProvider) Lookup(name string) (string, error) {
+	service, account, ok := strings.Cut(name, "/")
+	if !ok || service == "" || account == "" {
+		return "", fmt.Errorf("keyring secret %q must have the form service/account", name)
+	}
+	run := p.Run
+	if run == nil {
+		run = runCommand
+	}
+
+	var out []byte
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		out, err = run("security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux":
+		out, err = run("secret-tool", "lookup", "service", service, "account", account)
+	default:
+		return "", fmt.Errorf("keyring is not supported on %s", runtime.GOOS)
+	}
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimSpace(string(out))
+	if value == "" {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+// runCommand runs a command and returns its standard output.
+func runCommand(name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil && stderr.Len() > 0 {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, err
+}
+
+// LocalProvider reads secrets from a local KEY=VALUE file that is kept out of
+// version control. It stands in for a keyring or secret manager during
+// development and in CI. The file is read on first use.
+type LocalProvider struct {
+	// Path is the secrets file. Lines are KEY=VALUE pairs; blank lines and
+	// lines starting with # are ignored, and values may be quoted.
+	Path string
+
+	once   sync.Once
+	values map[string]string
+	err    error
+}
+
+// NewLocalProvider creates a provider that reads secrets from path.
+func NewLocalProvider(path string) *LocalProvider {
+	return &LocalProvider{Path: path}
+}
+
+// Lookup returns the value of key name in the secrets file.
+func (p *LocalProvider) Lookup(name string) (string, error) {
+	p.once.Do(func() {
+		p.values, p.err = ReadSecretsFile(p.Path)
+	})
+	if p.err != nil {
+		return "", p.err
+	}
+	value, ok := p.values[name]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+// ReadSecretsFile reads a KEY=VALUE secrets file.
+func ReadSecretsFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, n)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return values, scanner.Err()
+}
+
+// SecretResolver resolves secret references with a provider per source.
+type SecretResolver struct {
+	providers map[SecretSource]SecretProvider
+}
+
+// NewSecretResolver creates a resolver with the default providers: the
+// environment, files, the OS keyring, commands, and the local secrets file
+// at DefaultLocalSecretsFile.
+func NewSecretResolver() *SecretResolver {
+	r := &SecretResolver{providers: make(map[SecretSource]SecretProvider)}
+	r.Register(SecretEnv, EnvProvider{})
+	r.Register(SecretFile, FileProvider{})
+	r.Register(SecretKeyring, KeyringProvider{})
+	r.Register(SecretCommand, CommandProvider{})
+	r.Register(SecretLocal, NewLocalProvider(DefaultLocalSecretsFile))
+	return r
+}
+
+// Register sets the provider for a source, replacing any existing one.
+func (r *SecretResolver) Register(source SecretSource, provider SecretProvider) {
+	r.providers[source] = provider
+}
+
+// Lookup returns the value of a single secret reference.
+func (r *SecretResolver) Lookup(ref SecretRef) (string, error) {
+	provider, ok := r.providers[ref.Source]
+	if !ok {
+		return "", &SecretError{Ref: ref, Err: ErrNoSecretProvider}
+	}
+	value, err := provider.Lookup(ref.Name)
+	if err != nil {
+		return "", &SecretError{Ref: ref, Err: err}
+	}
+	return value, nil
+}
+
+// Resolve returns value with every secret reference replaced by its value.
+func (r *SecretResolver) Resolve(value string) (string, error) {
+	var firstErr error
+	resolved := ReplaceSecretRefs(value, func(ref SecretRef) string {
+		secret, err := r.Lookup(ref)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return secret
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return resolved, nil
+}
+
+// ResolveServer returns a copy of s with the secret references in Env and
+// Headers replaced by their values. Use it only for processes started
+// directly; the result must never be written to a config file.
+func (r *SecretResolver) ResolveServer(s Server) (Server, error) {
+	resolve := func(values map[string]string) (map[string]string, error) {
+		if values == nil {
+			return nil, nil
+		}
+		out := make(map[string]string, len(values))
+		for key, value := range values {
+			v, err := r.Resolve(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			out[key] = v
+		}
+		return out, nil
+	}
+
+	var err error
+	if s.Env, err = resolve(s.Env); err != nil {
+		return Server{}, err
+	}
+	if s.Headers, err = resolve(s.Headers); err != nil {
+		return Server{}, err
+	}
+	return s, nil
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestSecretProviders(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ASSISTANTKIT_TEST_TOKEN", "from-env")
+
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	localFile := filepath.Join(dir, "secrets.env")
+	local := "# local secrets\nexport API_KEY=\"from-local\"\nOTHER=x\n"
+	if err := os.WriteFile(localFile, []byte(local), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var ran []string
+	run := func(name string, args ...string) ([]byte, error) {
+		ran = append([]string{name}, args...)
+		return []byte("from-command\n"), nil
+	}
+
+	r := NewSecretResolver()
+	r.Register(SecretLocal, NewLocalProvider(localFile))
+	r.Register(SecretCommand, CommandProvider{Run: run})
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"${env:ASSISTANTKIT_TEST_TOKEN}", "from-env"},
+		{"Bearer ${ASSISTANTKIT_TEST_TOKEN}", "Bearer from-env"},
+		{"${file:" + tokenFile + "}", "from-file"},
+		{"${local:API_KEY}", "from-local"},
+		{"${cmd:op read op://dev/github/token}", "from-command"},
+		{"plain", "plain"},
+	}
+	for _, tt := range tests {
+		got, err := r.Resolve(tt.value)
+		if err != nil {
+			t.Errorf("Resolve(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("Resolve(%q) = %q, expected %q", tt.value, got, tt.expected)
+		}
+	}
+	if expected := []string{"op", "read", "op://dev/github/token"}; !reflect.DeepEqual(ran, expected) {
+		t.Errorf("Expected command %v, got %v", expected, ran)
+	}
+}
+
+func TestSecretResolverErrors(t *testing.T) {
+	r := NewSecretResolver()
+	r.Register(SecretLocal, NewLocalProvider(filepath.Join(t.TempDir(), "missing.env")))
+
+	_, err := r.Resolve("${env:ASSISTANTKIT_TEST_UNSET}")
+	if !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Expected ErrSecretNotFound, got %v", err)
+	}
+	var secretErr *SecretError
+	if !errors.As(err, &secretErr) || secretErr.Ref.Name != "ASSISTANTKIT_TEST_UNSET" {
+		t.Errorf("Expected SecretError for the reference, got %v", err)
+	}
+
+	if _, err := r.Resolve("${local:KEY}"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected missing local secrets file error, got %v", err)
+	}
+
+	empty := &SecretResolver{providers: map[SecretSource]SecretProvider{}}
+	if _, err := empty.Resolve("${keyring:svc/acct}"); !errors.Is(err, ErrNoSecretProvider) {
+		t.Errorf("Expected ErrNoSecretProvider, got %v", err)
+	}
+}
+
+func TestKeyringProvider(t *testing.T) {
+	var ran []string
+	p := KeyringProvider{Run: func(name string, args ...string) ([]byte, error) {
+		ran = append([]string{name}, args...)
+		return []byte("s3cret\n"), nil
+	}}
+
+	if _, err := p.Lookup("no-account"); err == nil {
+		t.Error("Expected error for name without account")
+	}
+
+	got, err := p.Lookup("github/me")
+	switch runtime.GOOS {
+	case "darwin", "linux":
+		if err != nil || got != "s3cret" {
+			t.Fatalf("Expected s3cret, got %q, %v", got, err)
+		}
+		if ran[0] != "security" && ran[0] != "secret-tool" {
+			t.Errorf("Unexpected keyring command %v", ran)
+		}
+	default:
+		if err == nil {
+			t.Error("Expected error on unsupported OS")
+		}
+	}
+}
+
+func TestResolveServer(t *testing.T) {
+	t.Setenv("ASSISTANTKIT_TEST_TOKEN", "tok")
+	r := NewSecretResolver()
+
+	s := Server{
+		URL:     "https://example.com/mcp",
+		Headers: map[string]string{"Authorization": "Bearer ${env:ASSISTANTKIT_TEST_TOKEN}"},
+	}
+	resolved, err := r.ResolveServer(s)
+	if err != nil {
+		t.Fatalf("ResolveServer failed: %v", err)
+	}
+	if resolved.Headers["Authorization"] != "Bearer tok" {
+		t.Errorf("Expected resolved header, got %q", resolved.Headers["Authorization"])
+	}
+	if s.Headers["Authorization"] != "Bearer ${env:ASSISTANTKIT_TEST_TOKEN}" {
+		t.Error("ResolveServer modified the original server")
+	}
+
+	s.Env = map[string]string{"KEY": "${env:ASSISTANTKIT_TEST_UNSET}"}
+	if _, err := r.ResolveServer(s); err == nil {
+		t.Error("Expected error for unset variable")
+	}
+}
@@ -0,0 +1,65 @@
+package core
+
+// ProxyServerName is the name of the server entry that WithProxy adds.
+const ProxyServerName = "assistantkit-proxy"
+
+// proxyFields are the fields the proxy enforces on behalf of formats that
+// drop them. AlwaysAllow is not among them: approving tool calls is up to
+// the assistant, so a proxy cannot restore it.
+var proxyFields = []string{FieldEnabledTools, FieldDisabledTools, FieldToolTimeoutSec}
+
+// ProxyServer returns a stdio server that runs "assistantkit mcp proxy" on
+// the canonical config at configPath, for the named servers or for all
+// enabled servers if none are named. The format is given explicitly, since
+// the proxy would otherwise detect it from the file name, and a canonical
+// config may be named like a tool's file (e.g., .mcp.json).
+func ProxyServer(configPath string, servers ...string) Server {
+	args := []string{"mcp", "proxy", "--config", configPath, "--from", "canonical"}
+	for _, name := range servers {
+		args = append(args, "--server", name)
+	}
+	return Server{Command: "assistantkit", Args: args}
+}
+
+// ProxiedServers returns the names of the servers whose tool filters or
+// tool timeout the adapter drops, in name order.
+func ProxiedServers(cfg *Config, adapter Adapter) []string {
+	dropped := make(map[string]bool)
+	for _, loss := range Lossiness(adapter, cfg) {
+		if loss.Kind == LossDropped {
+			dropped[loss.Path] = true
+		}
+	}
+	var names []string
+	for _, name := range sortedServerNames(cfg) {
+		for _, field := range proxyFields {
+			if dropped["servers."+name+"."+field] {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// WithProxy returns cfg with the servers whose tool filters or tool timeout
+// the adapter drops replaced by a single ProxyServer entry named
+// ProxyServerName, which applies them. configPath is the path of the
+// canonical config the proxy reads, as the assistant will resolve it.
+// If the adapter drops none of them, cfg is returned unchanged.
+func WithProxy(cfg *Config, adapter Adapter, configPath string) *Config {
+	proxied := ProxiedServers(cfg, adapter)
+	if len(proxied) == 0 {
+		return cfg
+	}
+	out := NewConfig()
+	out.Inputs = cfg.Inputs
+	for name, server := range cfg.Servers {
+		out.AddServer(name, server)
+	}
+	for _, name := range proxied {
+		out.RemoveServer(name)
+	}
+	out.AddServer(ProxyServerName, ProxyServer(configPath, proxied...))
+	return out
+}
@@ -0,0 +1,264 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SecretSource identifies where the value of a secret reference comes from.
+type SecretSource string
+
+const (
+	// SecretEnv reads the secret from an environment variable: ${env:GITHUB_TOKEN}.
+	// The shorthand ${GITHUB_TOKEN} is equivalent.
+	SecretEnv SecretSource = "env"
+
+	// SecretFile reads the secret from a file: ${file:~/.config/github/token}.
+	SecretFile SecretSource = "file"
+
+	// SecretKeyring reads the secret from the OS keyring: ${keyring:service/account}.
+	SecretKeyring SecretSource = "keyring"
+
+	// SecretCommand reads the secret from the output of a command, such as
+	// the 1Password CLI: ${cmd:op read op://vault/github/token}.
+	SecretCommand SecretSource = "cmd"
+
+	// SecretLocal reads the secret from an uncommitted local secrets file,
+	// standing in for a keyring during development and in CI: ${local:GITHUB_TOKEN}.
+	SecretLocal SecretSource = "local"
+)
+
+// SecretSources returns all secret sources.
+func SecretSources() []SecretSource {
+	return []SecretSource{SecretEnv, SecretFile, SecretKeyring, SecretCommand, SecretLocal}
+}
+
+// SecretRef is a reference to a secret in a server's Env or Headers value.
+// References are written as ${source:name} and may be embedded in a larger
+// value, for example "Bearer ${env:API_TOKEN}".
+type SecretRef struct {
+	// Source is where the secret is read from.
+	Source SecretSource `json:"source"`
+
+	// Name identifies the secret within the source: a variable name, a file
+	// path, a keyring service/account pair, a command line or a local key.
+	Name string `json:"name"`
+}
+
+// String returns the reference in canonical ${source:name} form.
+func (r SecretRef) String() string {
+	return "${" + string(r.Source) + ":" + r.Name + "}"
+}
+
+// secretRefPattern matches ${source:name} references and the ${VAR} shorthand
+// for environment variables. Other ${...} placeholders, such as VS Code's
+// ${input:id}, are not secret references.
+var secretRefPattern = regexp.MustCompile(`\$\{(?:(env|file|keyring|cmd|local):([^}]+)|([A-Za-z_][A-Za-z0-9_]*))\}`)
+
+// placeholderPattern matches any ${...} placeholder.
+var placeholderPattern = regexp.MustCompile(`\$\{[^}]*\}`)
+
+func refFromMatch(m []string) SecretRef {
+	if m[3] != "" {
+		return SecretRef{Source: SecretEnv, Name: m[3]}
+	}
+	return SecretRef{Source: SecretSource(m[1]), Name: m[2]}
+}
+
+// ParseSecretRef parses a value that consists of exactly one secret reference.
+func ParseSecretRef(value string) (SecretRef, bool) {
+	m := secretRefPattern.FindStringSubmatch(value)
+	if m == nil || m[0] != value {
+		return SecretRef{}, false
+	}
+	return refFromMatch(m), true
+}
+
+// SecretRefs returns the secret references in value, in order.
+func SecretRefs(value string) []SecretRef {
+	var refs []SecretRef
+	for _, m := range secretRefPattern.FindAllStringSubmatch(value, -1) {
+		refs = append(refs, refFromMatch(m))
+	}
+	return refs
+}
+
+// ReplaceSecretRefs returns value with every secret reference replaced by
+// the result of fn.
+func ReplaceSecretRefs(value string, fn func(SecretRef) string) string {
+	return secretRefPattern.ReplaceAllStringFunc(value, func(s string) string {
+		return fn(refFromMatch(secretRefPattern.FindStringSubmatch(s)))
+	})
+}
+
+// SecretVar returns the environment variable a tool reads a secret from when
+// it can only expand environment variables. Environment references use their
+// own variable. Other sources use the env key they are assigned to, or for
+// headers a variable named after the server and header, such as
+// GITHUB_TOKEN for the Authorization header of the github server.
+func SecretVar(server, field, key string, ref SecretRef) string {
+	if ref.Source == SecretEnv {
+		return ref.Name
+	}
+	if field == FieldEnv {
+		return key
+	}
+	suffix := key
+	if strings.EqualFold(key, "Authorization") {
+		suffix = "TOKEN"
+	}
+	return envName(server + "_" + suffix)
+}
+
+// envName converts s to an upper-case environment variable name.
+func envName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// RenderEnvPlaceholders returns a copy of values with every secret reference
+// written as a ${VAR} placeholder, the form expanded by most tools. The
+// variable is chosen by SecretVar.
+func RenderEnvPlaceholders(server, field string, values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	out := make(map[string]string, len(values))
+	for key, value := range values {
+		out[key] = ReplaceSecretRefs(value, func(ref SecretRef) string {
+			return "${" + SecretVar(server, field, key, ref) + "}"
+		})
+	}
+	return out
+}
+
+// secretKeywords are substrings of env var and header names that hold secrets.
+var secretKeywords = []string{
+	"TOKEN", "SECRET", "PASSWORD", "PASSWD", "APIKEY", "API_KEY", "ACCESS_KEY",
+	"PRIVATE_KEY", "CREDENTIAL", "AUTHORIZATION", "AUTH_", "COOKIE",
+}
+
+// nonSecretSuffixes mark names that point at a secret rather than hold one.
+var nonSecretSuffixes = []string{"_FILE", "_PATH", "_URL", "_VAR", "_ENV"}
+
+// secretPrefixes are prefixes of well-known credential formats.
+var secretPrefixes = []string{
+	"ghp_", "gho_", "ghs_", "ghu_", "github_pat_", "glpat-", "sk-", "xoxb-", "xoxp-", "AKIA",
+}
+
+// IsPlaintextSecret reports whether value, assigned to the env var or header
+// key, looks like a literal secret. Values made only of placeholders (and an
+// authorization scheme such as "Bearer") are not plaintext.
+func IsPlaintextSecret(key, value string) bool {
+	literal := strings.TrimSpace(placeholderPattern.ReplaceAllString(value, ""))
+	for _, scheme := range []string{"Bearer", "Basic", "Token"} {
+		literal = strings.TrimSpace(strings.TrimPrefix(literal, scheme))
+	}
+	if literal == "" {
+		return false
+	}
+	for _, prefix := range secretPrefixes {
+		if strings.HasPrefix(literal, prefix) {
+			return true
+		}
+	}
+
+	name := envName(key)
+	for _, suffix := range nonSecretSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return false
+		}
+	}
+	for _, keyword := range secretKeywords {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// PlaintextSecrets returns a LossExposed entry for every Env or Headers value
+// in cfg that looks like a literal secret and would be written to the target
+// file as is. Servers are reported in name order.
+func PlaintextSecrets(adapter string, cfg *Config) []Loss {
+	var losses []Loss
+	for _, name := range sortedServerNames(cfg) {
+		server := cfg.Servers[name]
+		for _, field := range []string{FieldEnv, FieldHeaders} {
+			values := server.Env
+			if field == FieldHeaders {
+				values = server.Headers
+			}
+			for _, key := range sortedKeys(values) {
+				if !IsPlaintextSecret(key, values[key]) {
+					continue
+				}
+				ref := SecretRef{Source: SecretEnv, Name: SecretVar(name, field, key, SecretRef{})}
+				losses = append(losses, Loss{
+					Adapter: adapter,
+					Path:    "servers." + name + "." + field,
+					Kind:    LossExposed,
+					Detail:  fmt.Sprintf("%s looks like a plaintext secret; use a reference such as %s", key, ref),
+				})
+			}
+		}
+	}
+	return losses
+}
+
+// IndirectSecrets returns a LossDegraded entry for every secret reference in
+// cfg that a tool expanding only ${VAR} placeholders cannot read itself. Such
+// secrets must be exported to the named variable before the tool starts.
+func IndirectSecrets(adapter string, cfg *Config) []Loss {
+	var losses []Loss
+	for _, name := range sortedServerNames(cfg) {
+		server := cfg.Servers[name]
+		for _, field := range []string{FieldEnv, FieldHeaders} {
+			values := server.Env
+			if field == FieldHeaders {
+				values = server.Headers
+			}
+			for _, key := range sortedKeys(values) {
+				for _, ref := range SecretRefs(values[key]) {
+					if ref.Source == SecretEnv {
+						continue
+					}
+					losses = append(losses, Loss{
+						Adapter: adapter,
+						Path:    "servers." + name + "." + field,
+						Kind:    LossDegraded,
+						Detail: fmt.Sprintf("%s: %s is read from environment variable %s",
+							key, ref, SecretVar(name, field, key, ref)),
+					})
+				}
+			}
+		}
+	}
+	return losses
+}
+
+// SecretLosses returns the secret-related losses for a tool that expands
+// ${VAR} placeholders: plaintext secrets and references that must be
+// exported as environment variables.
+func SecretLosses(adapter string, cfg *Config) []Loss {
+	return append(PlaintextSecrets(adapter, cfg), IndirectSecrets(adapter, cfg)...)
+}
+
+// sortedKeys returns the keys of m sorted alphabetically.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSecretRef(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected SecretRef
+		ok       bool
+	}{
+		{"${env:GITHUB_TOKEN}", SecretRef{SecretEnv, "GITHUB_TOKEN"}, true},
+		{"${GITHUB_TOKEN}", SecretRef{SecretEnv, "GITHUB_TOKEN"}, true},
+		{"${file:~/.config/gh/token}", SecretRef{SecretFile, "~/.config/gh/token"}, true},
+		{"${keyring:github/token}", SecretRef{SecretKeyring, "github/token"}, true},
+		{"${cmd:op read op://dev/github/token}", SecretRef{SecretCommand, "op read op://dev/github/token"}, true},
+		{"${local:GITHUB_TOKEN}", SecretRef{SecretLocal, "GITHUB_TOKEN"}, true},
+		{"${input:github-token}", SecretRef{}, false},
+		{"Bearer ${env:TOKEN}", SecretRef{}, false},
+		{"literal", SecretRef{}, false},
+	}
+
+	for _, tt := range tests {
+		ref, ok := ParseSecretRef(tt.value)
+		if ok != tt.ok || ref != tt.expected {
+			t.Errorf("ParseSecretRef(%q) = %v, %v; expected %v, %v", tt.value, ref, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestReplaceSecretRefs(t *testing.T) {
+	value := "Bearer ${env:TOKEN} ${keyring:svc/acct} ${input:keep}"
+	refs := SecretRefs(value)
+	expected := []SecretRef{{SecretEnv, "TOKEN"}, {SecretKeyring, "svc/acct"}}
+	if !reflect.DeepEqual(refs, expected) {
+		t.Errorf("Expected %v, got %v", expected, refs)
+	}
+
+	got := ReplaceSecretRefs(value, func(ref SecretRef) string { return "<" + ref.Name + ">" })
+	if got != "Bearer <TOKEN> <svc/acct> ${input:keep}" {
+		t.Errorf("Unexpected replacement: %q", got)
+	}
+}
+
+func TestSecretVar(t *testing.T) {
+	tests := []struct {
+		server, field, key string
+		ref                SecretRef
+		expected           string
+	}{
+		{"github", FieldEnv, "TOKEN", SecretRef{SecretEnv, "GH_TOKEN"}, "GH_TOKEN"},
+		{"github", FieldEnv, "GITHUB_TOKEN", SecretRef{SecretKeyring, "github/token"}, "GITHUB_TOKEN"},
+		{"github", FieldHeaders, "Authorization", SecretRef{SecretFile, "/run/token"}, "GITHUB_TOKEN"},
+		{"my-api", FieldHeaders, "X-Api-Key", SecretRef{SecretLocal, "KEY"}, "MY_API_X_API_KEY"},
+	}
+	for _, tt := range tests {
+		if got := SecretVar(tt.server, tt.field, tt.key, tt.ref); got != tt.expected {
+			t.Errorf("SecretVar(%s, %s, %s, %v) = %q, expected %q", tt.server, tt.field, tt.key, tt.ref, got, tt.expected)
+		}
+	}
+}
+
+func TestRenderEnvPlaceholders(t *testing.T) {
+	got := RenderEnvPlaceholders("github", FieldHeaders, map[string]string{
+		"Authorization": "Bearer ${keyring:github/token}",
+		"X-Org":         "${env:ORG}",
+		"X-Static":      "value",
+	})
+	expected := map[string]string{
+		"Authorization": "Bearer ${GITHUB_TOKEN}",
+		"X-Org":         "${ORG}",
+		"X-Static":      "value",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if RenderEnvPlaceholders("github", FieldEnv, nil) != nil {
+		t.Error("Expected nil for nil values")
+	}
+}
+
+func TestIsPlaintextSecret(t *testing.T) {
+	tests := []struct {
+		key, value string
+		expected   bool
+	}{
+		{"GITHUB_TOKEN", "abc123", true},
+		{"GITHUB_TOKEN", "${GITHUB_TOKEN}", false},
+		{"GITHUB_TOKEN", "${input:github-token}", false},
+		{"Authorization", "Bearer abc123", true},
+		{"Authorization", "Bearer ${env:TOKEN}", false},
+		{"X-Api-Key", "abc123", true},
+		{"DEBUG", "ghp_abcdefghijklmnop", true},
+		{"GITHUB_TOKEN_FILE", "/run/secrets/token", false},
+		{"LOG_LEVEL", "debug", false},
+		{"API_KEY", "", false},
+	}
+	for _, tt := range tests {
+		if got := IsPlaintextSecret(tt.key, tt.value); got != tt.expected {
+			t.Errorf("IsPlaintextSecret(%q, %q) = %v, expected %v", tt.key, tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestSecretLosses(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddServer("github", Server{
+		Command: "npx",
+		Env: map[string]string{
+			"GITHUB_TOKEN": "ghp_secret",
+			"ORG_TOKEN":    "${keyring:github/org}",
+			"USER_TOKEN":   "${env:USER_TOKEN}",
+		},
+	})
+
+	var got []string
+	for _, loss := range SecretLosses("test", cfg) {
+		got = append(got, loss.String())
+	}
+	expected := []string{
+		"test: servers.github.env exposed: GITHUB_TOKEN looks like a plaintext secret; use a reference such as ${env:GITHUB_TOKEN}",
+		"test: servers.github.env degraded: ORG_TOKEN: ${keyring:github/org} is read from environment variable ORG_TOKEN",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
@@ -35,6 +35,10 @@ type Server struct {
 	// BearerTokenEnvVar is the name of an env var containing a bearer token (Codex feature).
 	BearerTokenEnvVar string `json:"bearerTokenEnvVar,omitempty"`
 
+	// OAuth configures the OAuth client used to authorize with a remote
+	// server, for servers that do not support dynamic client registration.
+	OAuth *OAuth `json:"oauth,omitempty"`
+
 	// --- Tool Control Fields ---
 
 	// EnabledTools is an allow-list of tools to expose (Codex/Cline feature).
@@ -63,6 +67,20 @@ type Server struct {
 	NetworkTimeoutSec int `json:"networkTimeoutSec,omitempty"`
 }
 
+// OAuth is the OAuth client configuration of a remote server. The tool
+// performs the authorization flow and stores the tokens itself.
+type OAuth struct {
+	// ClientID is the pre-registered OAuth client ID.
+	ClientID string `json:"clientId,omitempty"`
+
+	// Scopes are the scopes to request.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// CallbackPort is the local port of the redirect URI
+	// (http://localhost:<port>/callback).
+	CallbackPort int `json:"callbackPort,omitempty"`
+}
+
 // IsStdio returns true if the server is configured for stdio transport.
 func (s *Server) IsStdio() bool {
 	if s.Transport != "" {
@@ -124,5 +142,13 @@ func (s *Server) Validate() error {
 	if s.Command != "" && s.URL != "" {
 		return ErrBothCommandAndURL
 	}
+	if s.OAuth != nil {
+		if s.Command != "" {
+			return ErrOAuthNotRemote
+		}
+		if s.OAuth.CallbackPort < 0 || s.OAuth.CallbackPort > 65535 {
+			return ErrInvalidCallbackPort
+		}
+	}
 	return nil
 }
@@ -177,6 +177,21 @@ func TestServerValidate(t *testing.T) {
 			server:    Server{Command: "npx", URL: "http://example.com"},
 			wantError: true,
 		},
+		{
+			name:      "http server with oauth",
+			server:    Server{URL: "http://example.com", OAuth: &OAuth{ClientID: "abc", CallbackPort: 8765}},
+			wantError: false,
+		},
+		{
+			name:      "stdio server with oauth",
+			server:    Server{Command: "npx", OAuth: &OAuth{ClientID: "abc"}},
+			wantError: true,
+		},
+		{
+			name:      "invalid callback port",
+			server:    Server{URL: "http://example.com", OAuth: &OAuth{CallbackPort: 70000}},
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -188,3 +203,19 @@ func TestServerValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTransport(t *testing.T) {
+	tests := map[string]TransportType{
+		"stdio":           TransportStdio,
+		"http":            TransportHTTP,
+		"streamable-http": TransportHTTP,
+		"streamableHttp":  TransportHTTP,
+		"sse":             TransportSSE,
+		"websocket":       "websocket",
+	}
+	for name, expected := range tests {
+		if got := ParseTransport(name); got != expected {
+			t.Errorf("ParseTransport(%q) = %q, want %q", name, got, expected)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/agentplexus/assistantkit/merge"
+)
+
+// ErrSyncConflict is returned when a conflict cannot be resolved.
+var ErrSyncConflict = errors.New("sync conflict")
+
+// SyncStrategy decides how conflicts between the canonical config and
+// manual edits to a target file are resolved.
+type SyncStrategy string
+
+const (
+	// StrategyOurs resolves conflicts with the canonical config.
+	StrategyOurs SyncStrategy = "ours"
+
+	// StrategyTheirs resolves conflicts by keeping the target file's version.
+	StrategyTheirs SyncStrategy = "theirs"
+
+	// StrategyPrompt resolves each conflict by asking the SyncOptions.Resolver.
+	StrategyPrompt SyncStrategy = "prompt"
+)
+
+// ChangeKind is what a sync does to a server in a target file.
+type ChangeKind string
+
+const (
+	// ChangeAdd adds a canonical server to the target.
+	ChangeAdd ChangeKind = "add"
+
+	// ChangeUpdate replaces the target's server with the canonical one.
+	ChangeUpdate ChangeKind = "update"
+
+	// ChangeRemove removes a server that was removed from the canonical config.
+	ChangeRemove ChangeKind = "remove"
+
+	// ChangeKeep keeps a manually edited server in the target.
+	ChangeKeep ChangeKind = "keep"
+)
+
+// SyncTarget is a tool config file kept in sync with the canonical config.
+type SyncTarget struct {
+	// Tool is the adapter name (e.g., "cursor").
+	Tool string `json:"tool"`
+
+	// Path is the config file path.
+	Path string `json:"path"`
+}
+
+// Conflict is a server that was changed in the target file since the last
+// sync and differs from the canonical config. A nil server is absent.
+type Conflict struct {
+	Target SyncTarget `json:"target"`
+	Server string     `json:"server"`
+
+	// Ours is the canonical server, as the target's format represents it.
+	Ours *Server `json:"ours,omitempty"`
+
+	// Theirs is the server currently in the target file.
+	Theirs *Server `json:"theirs,omitempty"`
+
+	// Base is the server as last synced, or nil if it was never synced.
+	Base *Server `json:"base,omitempty"`
+}
+
+// ServerChange describes what a sync does to one server.
+type ServerChange struct {
+	Server string     `json:"server"`
+	Kind   ChangeKind `json:"kind"`
+
+	// Conflict is true if the server was edited in the target file.
+	Conflict bool `json:"conflict,omitempty"`
+}
+
+// SyncResult describes the sync of one target.
+type SyncResult struct {
+	Target  SyncTarget     `json:"target"`
+	Changes []ServerChange `json:"changes,omitempty"`
+
+	// Merge reports the write to the target file; nil if nothing changed.
+	Merge *merge.Result `json:"merge,omitempty"`
+}
+
+// SyncOptions configures a sync.
+type SyncOptions struct {
+	// Strategy resolves conflicts. Defaults to StrategyPrompt.
+	Strategy SyncStrategy
+
+	// Resolver is called for each conflict with StrategyPrompt. It returns
+	// StrategyOurs or StrategyTheirs.
+	Resolver func(Conflict) (SyncStrategy, error)
+
+	// DryRun reports changes without writing target files or the lockfile.
+	DryRun bool
+
+	// Proxy, if set, is the path of the canonical config as the targets'
+	// tools will resolve it. Servers whose tool filters or tool timeout a
+	// target drops are then synced to it as a single proxy entry that
+	// applies them (see WithProxy).
+	Proxy string
+}
+
+// Sync pushes the canonical config to each target and records the synced
+// servers in lock.
+//
+// Each server is compared three ways: the canonical version (ours), the
+// version last synced according to lock (base), and the version in the
+// target file (theirs). If theirs still matches base, ours is applied. If
+// theirs was edited since the last sync and differs from ours, the server
+// is in conflict and resolved with opts.Strategy. Servers in the target
+// that were never synced and are not in the canonical config are left
+// alone, as are all settings outside the MCP section.
+//
+// The lockfile records ours as the synced state, so a server kept with
+// StrategyTheirs is reported as a conflict again on the next sync until
+// the canonical config and the target agree.
+func (r *AdapterRegistry) Sync(source *Config, targets []SyncTarget, lock *Lockfile, opts SyncOptions) ([]*SyncResult, error) {
+	if opts.Strategy == "" {
+		opts.Strategy = StrategyPrompt
+	}
+	var results []*SyncResult
+	for _, target := range targets {
+		result, err := r.syncTarget(source, target, lock, opts)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (r *AdapterRegistry) syncTarget(source *Config, target SyncTarget, lock *Lockfile, opts SyncOptions) (*SyncResult, error) {
+	adapter, ok := r.Get(target.Tool)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", target.Tool, ErrServerNotFound)
+	}
+
+	if opts.Proxy != "" {
+		source = WithProxy(source, adapter, opts.Proxy)
+	}
+
+	// Read ours back through the adapter so that fields the target cannot
+	// represent do not show up as differences.
+	data, err := adapter.Marshal(source)
+	if err != nil {
+		return nil, &WriteError{Format: target.Tool, Path: target.Path, Err: err}
+	}
+	ours, err := adapter.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	theirs := NewConfig()
+	if data, err := os.ReadFile(target.Path); err == nil {
+		if theirs, err = adapter.Parse(data); err != nil {
+			if pe, ok := err.(*ParseError); ok {
+				pe.Path = target.Path
+			}
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, &ParseError{Format: target.Tool, Path: target.Path, Err: err}
+	}
+
+	base := map[string]Server{}
+	if locked := lock.Target(target.Tool, target.Path); locked != nil && locked.Servers != nil {
+		// Lockfiles written before values were hashed hold them in plain text.
+		base = LockServers(locked.Servers)
+	}
+
+	result := &SyncResult{Target: target}
+	final := NewConfig()
+	final.Merge(theirs)
+	final.Merge(&Config{Inputs: source.Inputs})
+
+	for _, name := range unionNames(ours.Servers, theirs.Servers, base) {
+		o, inOurs := lookup(ours.Servers, name)
+		t, inTheirs := lookup(theirs.Servers, name)
+		b, _ := lookup(base, name)
+
+		switch {
+		case sameServer(o, t):
+			continue
+		case o == nil && b == nil:
+			// Not managed by sync.
+			continue
+		}
+
+		// Compare theirs with the base on hashed env and header values.
+		var lt *Server
+		if t != nil {
+			hashed := lockServer(*t)
+			lt = &hashed
+		}
+		conflict := !sameServer(lt, b)
+		resolution := StrategyOurs
+		if conflict {
+			resolution, err = resolve(Conflict{Target: target, Server: name, Ours: o, Theirs: t, Base: b}, opts)
+			if err != nil {
+				return result, err
+			}
+		}
+
+		change := ServerChange{Server: name, Conflict: conflict}
+		switch {
+		case resolution == StrategyTheirs:
+			change.Kind = ChangeKeep
+		case !inOurs:
+			change.Kind = ChangeRemove
+			final.RemoveServer(name)
+		case inTheirs:
+			change.Kind = ChangeUpdate
+			final.AddServer(name, *o)
+		default:
+			change.Kind = ChangeAdd
+			final.AddServer(name, *o)
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	if hasWrites(result.Changes) {
+		result.Merge, err = MergeFile(adapter, final, target.Path, opts.DryRun)
+		if err != nil {
+			return result, err
+		}
+	}
+	if !opts.DryRun {
+		lock.SetTarget(LockTarget{
+			Tool:     target.Tool,
+			Path:     target.Path,
+			SyncedAt: time.Now().UTC(),
+			Servers:  LockServers(ours.Servers),
+		})
+	}
+	return result, nil
+}
+
+// resolve returns the resolution of a conflict under the options' strategy.
+func resolve(c Conflict, opts SyncOptions) (SyncStrategy, error) {
+	switch opts.Strategy {
+	case StrategyOurs, StrategyTheirs:
+		return opts.Strategy, nil
+	case StrategyPrompt:
+		if opts.Resolver == nil {
+			return "", fmt.Errorf("%s: server %q: %w", c.Target.Path, c.Server, ErrSyncConflict)
+		}
+		resolution, err := opts.Resolver(c)
+		if err != nil {
+			return "", err
+		}
+		if resolution != StrategyOurs && resolution != StrategyTheirs {
+			return "", fmt.Errorf("%s: server %q: invalid resolution %q: %w", c.Target.Path, c.Server, resolution, ErrSyncConflict)
+		}
+		return resolution, nil
+	default:
+		return "", fmt.Errorf("unknown sync strategy: %s", opts.Strategy)
+	}
+}
+
+func hasWrites(changes []ServerChange) bool {
+	for _, c := range changes {
+		if c.Kind != ChangeKeep {
+			return true
+		}
+	}
+	return false
+}
+
+func lookup(servers map[string]Server, name string) (*Server, bool) {
+	s, ok := servers[name]
+	if !ok {
+		return nil, false
+	}
+	return &s, true
+}
+
+// sameServer reports whether two servers are equivalent. Unset and
+// inferred transports, and an unset and true enabled flag, are equal.
+func sameServer(a, b *Server) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return serverKey(*a) == serverKey(*b)
+}
+
+func serverKey(s Server) string {
+	s.Transport = s.InferTransport()
+	if s.IsEnabled() {
+		s.Enabled = nil
+	}
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+func unionNames(maps ...map[string]Server) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, m := range maps {
+		for name := range m {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
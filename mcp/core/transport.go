@@ -9,7 +9,9 @@ const (
 	// TransportStdio represents local servers using standard input/output streams.
 	TransportStdio TransportType = "stdio"
 
-	// TransportHTTP represents remote servers using HTTP/Streamable HTTP transport.
+	// TransportHTTP represents remote servers using the Streamable HTTP
+	// transport. Formats that name it differently ("streamable-http",
+	// "streamableHttp") are read as http by ParseTransport.
 	TransportHTTP TransportType = "http"
 
 	// TransportSSE represents remote servers using Server-Sent Events (legacy).
@@ -40,3 +42,21 @@ func (t TransportType) Valid() bool {
 		return false
 	}
 }
+
+// ParseTransport returns the transport type named by s, accepting the
+// names tools use for the Streamable HTTP transport. Unknown names are
+// returned unchanged, so Valid reports them.
+func ParseTransport(s string) TransportType {
+	switch s {
+	case "streamable-http", "streamableHttp", "streamable_http", "streamable":
+		return TransportHTTP
+	default:
+		return TransportType(s)
+	}
+}
+
+// UnmarshalText accepts the names ParseTransport accepts.
+func (t *TransportType) UnmarshalText(text []byte) error {
+	*t = ParseTransport(string(text))
+	return nil
+}
@@ -1,16 +1,19 @@
 // Package cursor provides an adapter for Cursor IDE MCP configuration.
 //
-// Cursor uses the same format as Claude Desktop, with config files at:
+// Cursor uses the same format as Claude Desktop, with static OAuth settings
+// under "auth" in place of Claude's "oauth", and config files at:
 //   - Global: ~/.cursor/mcp.json
 //   - Project: .cursor/mcp.json
 package cursor
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 
 	"github.com/agentplexus/assistantkit/mcp/claude"
 	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
 )
 
 const (
@@ -58,19 +61,69 @@ func (a *Adapter) DefaultPaths() []string {
 
 // Parse parses Cursor config data into the canonical format.
 func (a *Adapter) Parse(data []byte) (*core.Config, error) {
-	cfg, err := a.claudeAdapter.Parse(data)
-	if err != nil {
-		if pe, ok := err.(*core.ParseError); ok {
-			pe.Format = AdapterName
-		}
-		return nil, err
+	var cursorCfg Config
+	if err := json.Unmarshal(data, &cursorCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
 	}
-	return cfg, nil
+	return a.ToCore(&cursorCfg), nil
 }
 
 // Marshal converts canonical config to Cursor format.
 func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
-	return a.claudeAdapter.Marshal(cfg)
+	return json.MarshalIndent(a.FromCore(cfg), "", "  ")
+}
+
+// ToCore converts Cursor config to canonical format.
+func (a *Adapter) ToCore(cursorCfg *Config) *core.Config {
+	claudeCfg := claude.NewConfig()
+	for name, server := range cursorCfg.MCPServers {
+		claudeCfg.AddServer(name, server.ServerConfig)
+	}
+	cfg := a.claudeAdapter.ToCore(claudeCfg)
+
+	for name, server := range cursorCfg.MCPServers {
+		coreServer := cfg.Servers[name]
+		coreServer.OAuth = nil
+		if server.Auth != nil {
+			coreServer.OAuth = &core.OAuth{ClientID: server.Auth.ClientID, Scopes: server.Auth.Scopes}
+		}
+		cfg.Servers[name] = coreServer
+	}
+	return cfg
+}
+
+// FromCore converts canonical config to Cursor format.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	cursorCfg := NewConfig()
+	for name, server := range a.claudeAdapter.FromCore(cfg).MCPServers {
+		// Cursor's redirect URI is fixed, so Claude's callback port does
+		// not apply.
+		server.OAuth = nil
+		cursorServer := ServerConfig{ServerConfig: server}
+		if oauth := cfg.Servers[name].OAuth; oauth != nil && (oauth.ClientID != "" || len(oauth.Scopes) > 0) {
+			cursorServer.Auth = &AuthConfig{ClientID: oauth.ClientID, Scopes: oauth.Scopes}
+		}
+		cursorCfg.MCPServers[name] = cursorServer
+	}
+	return cursorCfg
+}
+
+// Lossiness reports the canonical fields that the Cursor format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldURL, core.FieldHeaders, core.FieldOAuthClientID, core.FieldOAuthScopes)
+	return append(losses, core.SecretLosses(AdapterName, cfg)...)
+}
+
+// Merge writes cfg into an existing Cursor config, replacing only the
+// mcpServers key and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "mcpServers")
 }
 
 // ReadFile reads a Cursor config file.
@@ -2,13 +2,35 @@ package cursor
 
 import "github.com/agentplexus/assistantkit/mcp/claude"
 
-// Config is an alias for Claude's config since the format is identical.
-type Config = claude.Config
+// Config represents the Cursor MCP configuration file format: Claude's
+// format, with Cursor's static OAuth settings.
+type Config struct {
+	// MCPServers maps server names to their configurations.
+	MCPServers map[string]ServerConfig `json:"mcpServers"`
+}
+
+// ServerConfig represents a single MCP server in Cursor's format.
+type ServerConfig struct {
+	claude.ServerConfig
+
+	// Auth configures static OAuth for remote servers without dynamic
+	// client registration. Cursor's redirect URI is fixed
+	// (cursor://anysphere.cursor-mcp/oauth/callback).
+	Auth *AuthConfig `json:"auth,omitempty"`
+}
+
+// AuthConfig is the static OAuth client of a remote server.
+type AuthConfig struct {
+	// ClientID is the pre-registered client ID.
+	ClientID string `json:"CLIENT_ID,omitempty"`
 
-// ServerConfig is an alias for Claude's server config.
-type ServerConfig = claude.ServerConfig
+	// Scopes are the scopes to request.
+	Scopes []string `json:"scopes,omitempty"`
+}
 
 // NewConfig creates a new Cursor config.
 func NewConfig() *Config {
-	return claude.NewConfig()
+	return &Config{
+		MCPServers: make(map[string]ServerConfig),
+	}
 }
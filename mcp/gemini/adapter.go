@@ -0,0 +1,308 @@
+// Package gemini provides an adapter for Google Gemini CLI MCP configuration.
+//
+// Gemini CLI reads MCP servers from the mcpServers key of settings.json,
+// next to its other settings (including hooks). Use Merge or mcp.MergeFile
+// to update the servers without replacing the rest of the file.
+//
+// Key differences from Claude:
+//   - url is an SSE endpoint; Streamable HTTP servers use httpUrl
+//   - timeout is in milliseconds
+//   - includeTools and excludeTools filter the tools a server exposes
+//   - trust skips confirmation for all of a server's tools; it is written
+//     when alwaysAllow lists exactly the server's enabledTools
+//   - oauth takes a redirectUri instead of a callback port
+//
+// File locations:
+//   - Project: <project>/.gemini/settings.json
+//   - User: ~/.gemini/settings.json
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "gemini"
+
+	// ConfigFileName is the settings file name.
+	ConfigFileName = "settings.json"
+
+	// ProjectConfigDir is the project config directory.
+	ProjectConfigDir = ".gemini"
+)
+
+// Adapter implements core.Adapter for Gemini CLI.
+type Adapter struct{}
+
+// NewAdapter creates a new Gemini CLI adapter.
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Name returns the adapter name.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns the default config file paths for Gemini CLI.
+func (a *Adapter) DefaultPaths() []string {
+	paths := []string{
+		filepath.Join(ProjectConfigDir, ConfigFileName),
+	}
+
+	// User config
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ProjectConfigDir, ConfigFileName))
+	}
+
+	return paths
+}
+
+// Parse parses Gemini CLI settings data into the canonical format.
+func (a *Adapter) Parse(data []byte) (*core.Config, error) {
+	var geminiCfg Config
+	if err := json.Unmarshal(data, &geminiCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return a.ToCore(&geminiCfg), nil
+}
+
+// Marshal converts canonical config to Gemini CLI format.
+func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	geminiCfg := a.FromCore(cfg)
+	return json.MarshalIndent(geminiCfg, "", "  ")
+}
+
+// ReadFile reads a Gemini CLI settings file.
+func (a *Adapter) ReadFile(path string) (*core.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Path: path, Err: err}
+	}
+	cfg, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteFile writes canonical config to a Gemini CLI settings file,
+// replacing its contents.
+func (a *Adapter) WriteFile(cfg *core.Config, path string) error {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	// Ensure directory exists
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	return nil
+}
+
+// ToCore converts Gemini CLI config to canonical format.
+func (a *Adapter) ToCore(geminiCfg *Config) *core.Config {
+	cfg := core.NewConfig()
+
+	for name, server := range geminiCfg.MCPServers {
+		coreServer := core.Server{
+			Command:       server.Command,
+			Args:          server.Args,
+			Env:           server.Env,
+			Cwd:           server.Cwd,
+			Headers:       server.Headers,
+			EnabledTools:  server.IncludeTools,
+			DisabledTools: server.ExcludeTools,
+		}
+
+		// Set transport type from the endpoint field
+		switch {
+		case server.Command != "":
+			coreServer.Transport = core.TransportStdio
+		case server.HTTPURL != "":
+			coreServer.URL = server.HTTPURL
+			coreServer.Transport = core.TransportHTTP
+		case server.URL != "":
+			coreServer.URL = server.URL
+			coreServer.Transport = core.TransportSSE
+		}
+
+		// Convert milliseconds to seconds, rounding up
+		if server.Timeout > 0 {
+			coreServer.ToolTimeoutSec = (server.Timeout + 999) / 1000
+		}
+
+		// Trusting a server with an allow-list approves exactly those tools
+		if server.Trust && len(server.IncludeTools) > 0 {
+			coreServer.AlwaysAllow = slices.Clone(server.IncludeTools)
+		}
+
+		if server.OAuth != nil {
+			coreServer.OAuth = &core.OAuth{
+				ClientID:     server.OAuth.ClientID,
+				Scopes:       server.OAuth.Scopes,
+				CallbackPort: callbackPort(server.OAuth.RedirectURI),
+			}
+		}
+
+		cfg.Servers[name] = coreServer
+	}
+
+	return cfg
+}
+
+// FromCore converts canonical config to Gemini CLI format.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	geminiCfg := &Config{
+		MCPServers: make(map[string]ServerConfig),
+	}
+
+	for name, server := range cfg.Servers {
+		geminiServer := ServerConfig{
+			Command:      server.Command,
+			Args:         server.Args,
+			Env:          core.RenderEnvPlaceholders(name, core.FieldEnv, server.Env),
+			Cwd:          server.Cwd,
+			Headers:      core.RenderEnvPlaceholders(name, core.FieldHeaders, server.Headers),
+			Timeout:      server.ToolTimeoutSec * 1000,
+			Trust:        trusted(server),
+			IncludeTools: server.EnabledTools,
+			ExcludeTools: server.DisabledTools,
+		}
+
+		if server.URL != "" {
+			if server.InferTransport() == core.TransportSSE {
+				geminiServer.URL = server.URL
+			} else {
+				geminiServer.HTTPURL = server.URL
+			}
+		}
+
+		if server.OAuth != nil {
+			geminiServer.OAuth = &OAuthConfig{
+				Enabled:  true,
+				ClientID: server.OAuth.ClientID,
+				Scopes:   server.OAuth.Scopes,
+			}
+			if server.OAuth.CallbackPort > 0 {
+				geminiServer.OAuth.RedirectURI = fmt.Sprintf("http://localhost:%d/oauth/callback", server.OAuth.CallbackPort)
+			}
+		}
+
+		geminiCfg.MCPServers[name] = geminiServer
+	}
+
+	return geminiCfg
+}
+
+// trusted reports whether the server's always-allowed tools can be written
+// as trust, which approves every tool the server exposes.
+func trusted(server core.Server) bool {
+	return len(server.AlwaysAllow) > 0 && slices.Equal(server.AlwaysAllow, server.EnabledTools)
+}
+
+// callbackPort returns the port of a redirect URI, or 0 if it has none.
+func callbackPort(redirectURI string) int {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return 0
+	}
+	port, _ := strconv.Atoi(u.Port())
+	return port
+}
+
+// Lossiness reports the canonical fields that the Gemini CLI format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldCwd, core.FieldURL, core.FieldHeaders,
+		core.FieldOAuthClientID, core.FieldOAuthScopes, core.FieldOAuthCallbackPort,
+		core.FieldEnabledTools, core.FieldDisabledTools, core.FieldAlwaysAllow,
+		core.FieldToolTimeoutSec)
+	losses = append(losses, trustLosses(cfg)...)
+	return append(losses, core.SecretLosses(AdapterName, cfg)...)
+}
+
+// trustLosses reports the always-allowed tools that cannot be written as trust.
+func trustLosses(cfg *core.Config) []core.Loss {
+	names := cfg.ServerNames()
+	sort.Strings(names)
+
+	var losses []core.Loss
+	for _, name := range names {
+		server := cfg.Servers[name]
+		if len(server.AlwaysAllow) == 0 || trusted(server) {
+			continue
+		}
+		losses = append(losses, core.Loss{
+			Adapter: AdapterName,
+			Path:    "servers." + name + "." + core.FieldAlwaysAllow,
+			Kind:    core.LossDropped,
+			Detail:  "Gemini CLI trusts either all of a server's tools or none; list the same tools in enabledTools to trust them",
+		})
+	}
+	return losses
+}
+
+// Merge writes cfg into an existing Gemini CLI settings.json, replacing only
+// the mcpServers key and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "mcpServers")
+}
+
+// ProjectConfigPath returns the project settings path for a given project root.
+func ProjectConfigPath(projectRoot string) string {
+	return filepath.Join(projectRoot, ProjectConfigDir, ConfigFileName)
+}
+
+// UserConfigPath returns the user-level settings path.
+func UserConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ProjectConfigDir, ConfigFileName), nil
+}
+
+// ReadProjectConfig reads the MCP servers from the project-level .gemini/settings.json.
+func ReadProjectConfig() (*core.Config, error) {
+	adapter := NewAdapter()
+	return adapter.ReadFile(filepath.Join(ProjectConfigDir, ConfigFileName))
+}
+
+// ReadUserConfig reads the MCP servers from the user-level ~/.gemini/settings.json.
+func ReadUserConfig() (*core.Config, error) {
+	path, err := UserConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	adapter := NewAdapter()
+	return adapter.ReadFile(path)
+}
+
+// init registers the adapter with the default registry.
+func init() {
+	core.Register(NewAdapter())
+}
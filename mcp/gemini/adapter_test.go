@@ -0,0 +1,214 @@
+package gemini
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+func TestAdapterName(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter.Name() != "gemini" {
+		t.Errorf("Expected name 'gemini', got %q", adapter.Name())
+	}
+}
+
+func TestAdapterDefaultPaths(t *testing.T) {
+	adapter := NewAdapter()
+	paths := adapter.DefaultPaths()
+	if len(paths) < 1 {
+		t.Fatalf("Expected at least 1 default path, got %d", len(paths))
+	}
+	expected := filepath.Join(".gemini", "settings.json")
+	if paths[0] != expected {
+		t.Errorf("Expected first path %q, got %q", expected, paths[0])
+	}
+}
+
+func TestAdapterParse(t *testing.T) {
+	adapter := NewAdapter()
+
+	data := []byte(`{
+		"theme": "GitHub",
+		"mcpServers": {
+			"github": {
+				"command": "npx",
+				"args": ["-y", "@modelcontextprotocol/server-github"],
+				"env": {"GITHUB_TOKEN": "$GITHUB_TOKEN"},
+				"cwd": "./tools",
+				"timeout": 1500,
+				"trust": true,
+				"includeTools": ["search", "list_issues"]
+			},
+			"docs": {
+				"httpUrl": "https://docs.example.com/mcp",
+				"headers": {"X-Team": "core"},
+				"oauth": {"enabled": true, "clientId": "abc", "scopes": ["read"], "redirectUri": "http://localhost:8765/oauth/callback"}
+			},
+			"events": {
+				"url": "https://events.example.com/sse",
+				"excludeTools": ["delete"]
+			}
+		}
+	}`)
+
+	cfg, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(cfg.Servers) != 3 {
+		t.Fatalf("Expected 3 servers, got %d", len(cfg.Servers))
+	}
+
+	github := cfg.Servers["github"]
+	if github.Transport != core.TransportStdio || github.Cwd != "./tools" {
+		t.Errorf("Expected a stdio server in ./tools, got %+v", github)
+	}
+	if github.ToolTimeoutSec != 2 {
+		t.Errorf("Expected timeout rounded up to 2s, got %d", github.ToolTimeoutSec)
+	}
+	if !reflect.DeepEqual(github.AlwaysAllow, []string{"search", "list_issues"}) {
+		t.Errorf("Expected trust to allow the included tools, got %v", github.AlwaysAllow)
+	}
+
+	docs := cfg.Servers["docs"]
+	if docs.Transport != core.TransportHTTP || docs.URL != "https://docs.example.com/mcp" {
+		t.Errorf("Expected httpUrl to be read as http, got %+v", docs)
+	}
+	expected := &core.OAuth{ClientID: "abc", Scopes: []string{"read"}, CallbackPort: 8765}
+	if !reflect.DeepEqual(docs.OAuth, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, docs.OAuth)
+	}
+
+	events := cfg.Servers["events"]
+	if events.Transport != core.TransportSSE || !reflect.DeepEqual(events.DisabledTools, []string{"delete"}) {
+		t.Errorf("Expected url to be read as sse, got %+v", events)
+	}
+}
+
+func TestAdapterMarshal(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("github", core.Server{
+		Command:        "npx",
+		Env:            map[string]string{"GITHUB_TOKEN": "${env:GH_TOKEN}"},
+		EnabledTools:   []string{"search"},
+		AlwaysAllow:    []string{"search"},
+		ToolTimeoutSec: 30,
+	})
+	cfg.AddServer("events", core.Server{
+		Transport: core.TransportSSE,
+		URL:       "https://events.example.com/sse",
+	})
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, want := range []string{
+		`"GITHUB_TOKEN": "${GH_TOKEN}"`,
+		`"timeout": 30000`,
+		`"trust": true`,
+		`"includeTools": [`,
+		`"url": "https://events.example.com/sse"`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected %s in:\n%s", want, data)
+		}
+	}
+}
+
+func TestAdapterLossiness(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("github", core.Server{
+		Command:           "npx",
+		EnvFile:           ".env",
+		AlwaysAllow:       []string{"search"},
+		StartupTimeoutSec: 10,
+	})
+
+	var got []string
+	for _, loss := range adapter.Lossiness(cfg) {
+		got = append(got, loss.Path)
+	}
+	expected := []string{"servers.github.envFile", "servers.github.startupTimeoutSec", "servers.github.alwaysAllow"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestAdapterMerge(t *testing.T) {
+	adapter := NewAdapter()
+
+	existing := []byte(`{
+  "theme": "GitHub",
+  "hooks": {"BeforeTool": []},
+  "mcpServers": {"old": {"command": "old"}}
+}`)
+	cfg := core.NewConfig()
+	cfg.AddServer("github", core.Server{Command: "npx"})
+
+	data, err := adapter.Merge(cfg, existing)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	out := string(data)
+	if !strings.Contains(out, `"theme": "GitHub"`) || !strings.Contains(out, `"BeforeTool"`) {
+		t.Errorf("Expected other settings to be kept, got:\n%s", out)
+	}
+	if strings.Contains(out, `"old"`) || !strings.Contains(out, `"github"`) {
+		t.Errorf("Expected mcpServers to be replaced, got:\n%s", out)
+	}
+}
+
+func TestAdapterReadWriteFile(t *testing.T) {
+	adapter := NewAdapter()
+	path := filepath.Join(t.TempDir(), ".gemini", "settings.json")
+
+	cfg := core.NewConfig()
+	cfg.AddServer("docs", core.Server{URL: "https://docs.example.com/mcp"})
+
+	if err := adapter.WriteFile(cfg, path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	read, err := adapter.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if server, ok := read.GetServer("docs"); !ok || server.Transport != core.TransportHTTP {
+		t.Errorf("Expected the http server back, got %+v", read.Servers)
+	}
+
+	if _, err := adapter.ReadFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+	if _, err := adapter.Parse([]byte("{")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestUserConfigPath(t *testing.T) {
+	path, err := UserConfigPath()
+	if err != nil {
+		t.Fatalf("UserConfigPath() error = %v", err)
+	}
+
+	home, _ := os.UserHomeDir()
+	expected := filepath.Join(home, ".gemini", "settings.json")
+	if path != expected {
+		t.Errorf("Expected %q, got %q", expected, path)
+	}
+}
+
+func TestAdapterRegistered(t *testing.T) {
+	if _, ok := core.GetAdapter("gemini"); !ok {
+		t.Fatal("gemini adapter should be registered")
+	}
+}
@@ -0,0 +1,78 @@
+package gemini
+
+// Config represents the MCP section of a Gemini CLI settings.json file.
+type Config struct {
+	MCPServers map[string]ServerConfig `json:"mcpServers"`
+}
+
+// ServerConfig represents a Gemini CLI MCP server configuration.
+// The transport is chosen by which field is set: command (stdio),
+// url (SSE) or httpUrl (Streamable HTTP).
+type ServerConfig struct {
+	// --- STDIO Server Fields ---
+
+	// Command is the executable to launch for stdio servers.
+	Command string `json:"command,omitempty"`
+
+	// Args are the command-line arguments passed to the command.
+	Args []string `json:"args,omitempty"`
+
+	// Env contains environment variables for the server process.
+	// Values can use $VAR or ${VAR} syntax for substitution.
+	Env map[string]string `json:"env,omitempty"`
+
+	// Cwd is the working directory for the server process.
+	Cwd string `json:"cwd,omitempty"`
+
+	// --- Remote Server Fields ---
+
+	// URL is the endpoint of an SSE server.
+	URL string `json:"url,omitempty"`
+
+	// HTTPURL is the endpoint of a Streamable HTTP server.
+	HTTPURL string `json:"httpUrl,omitempty"`
+
+	// Headers contains HTTP headers for authentication or configuration.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// OAuth configures the OAuth client for a remote server.
+	OAuth *OAuthConfig `json:"oauth,omitempty"`
+
+	// --- Tool Control ---
+
+	// Timeout is the request timeout in milliseconds.
+	Timeout int `json:"timeout,omitempty"`
+
+	// Trust skips confirmation for every tool call to the server.
+	Trust bool `json:"trust,omitempty"`
+
+	// IncludeTools is an allow-list of tools to expose.
+	IncludeTools []string `json:"includeTools,omitempty"`
+
+	// ExcludeTools is a deny-list of tools to hide. It takes precedence
+	// over IncludeTools.
+	ExcludeTools []string `json:"excludeTools,omitempty"`
+}
+
+// OAuthConfig is the OAuth client configuration of a remote server.
+type OAuthConfig struct {
+	// Enabled turns on OAuth for the server.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ClientID is the pre-registered OAuth client ID.
+	ClientID string `json:"clientId,omitempty"`
+
+	// Scopes are the scopes to request.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// RedirectURI is the local callback URI
+	// (default: http://localhost:7777/oauth/callback).
+	RedirectURI string `json:"redirectUri,omitempty"`
+}
+
+// NewConfig creates a new Gemini CLI config.
+func NewConfig() *Config {
+	return &Config{
+		MCPServers: make(map[string]ServerConfig),
+	}
+}
@@ -0,0 +1,352 @@
+// Package goose provides an adapter for Goose MCP configuration.
+//
+// Goose calls MCP servers extensions and reads them from the extensions key
+// of its config.yaml, next to built-in extensions and provider settings.
+// Merge replaces only the MCP server extensions and keeps everything else.
+//
+// Field mapping:
+//
+//	canonical       Goose
+//	transport       type (stdio, sse, streamable_http)
+//	command, args   cmd, args
+//	env             envs, or env_keys for secret references
+//	url, headers    uri, headers
+//	enabled         enabled
+//	toolTimeoutSec  timeout
+//	enabledTools    available_tools
+//
+// Goose does not expand placeholders. An env value that is a single secret
+// reference is written as an env_keys entry, which Goose reads from its
+// secret store or the environment.
+//
+// File location: ~/.config/goose/config.yaml
+package goose
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "goose"
+
+	// ConfigFileName is the config file name.
+	ConfigFileName = "config.yaml"
+)
+
+// Adapter implements core.Adapter for Goose.
+type Adapter struct{}
+
+// NewAdapter creates a new Goose adapter.
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Name returns the adapter name.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns the default config file paths for Goose.
+func (a *Adapter) DefaultPaths() []string {
+	if path, err := ConfigPath(); err == nil {
+		return []string{path}
+	}
+	return []string{}
+}
+
+// Parse parses Goose config data into the canonical format.
+func (a *Adapter) Parse(data []byte) (*core.Config, error) {
+	var gooseCfg Config
+	if err := yaml.Unmarshal(data, &gooseCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return a.ToCore(&gooseCfg), nil
+}
+
+// Marshal converts canonical config to Goose format.
+func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	return marshalYAML(a.FromCore(cfg))
+}
+
+// ReadFile reads a Goose config file.
+func (a *Adapter) ReadFile(path string) (*core.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Path: path, Err: err}
+	}
+	cfg, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteFile writes canonical config to a Goose config file, replacing its
+// contents.
+func (a *Adapter) WriteFile(cfg *core.Config, path string) error {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	// Ensure directory exists
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	return nil
+}
+
+// ToCore converts Goose config to canonical format. Extensions that are
+// not MCP servers are skipped.
+func (a *Adapter) ToCore(gooseCfg *Config) *core.Config {
+	cfg := core.NewConfig()
+
+	for name, ext := range gooseCfg.Extensions {
+		if !isMCP(ext.Type) {
+			continue
+		}
+		coreServer := core.Server{
+			Command:        ext.Cmd,
+			Args:           ext.Args,
+			URL:            ext.URI,
+			Headers:        ext.Headers,
+			EnabledTools:   ext.AvailableTools,
+			ToolTimeoutSec: ext.Timeout,
+		}
+
+		// Env keys are read from the secret store or the environment
+		if len(ext.Envs) > 0 || len(ext.EnvKeys) > 0 {
+			coreServer.Env = make(map[string]string, len(ext.Envs)+len(ext.EnvKeys))
+			for key, value := range ext.Envs {
+				coreServer.Env[key] = value
+			}
+			for _, key := range ext.EnvKeys {
+				if _, ok := coreServer.Env[key]; !ok {
+					coreServer.Env[key] = "${" + key + "}"
+				}
+			}
+		}
+
+		switch ext.Type {
+		case TypeStdio:
+			coreServer.Transport = core.TransportStdio
+		case TypeSSE:
+			coreServer.Transport = core.TransportSSE
+		case TypeStreamableHTTP:
+			coreServer.Transport = core.TransportHTTP
+		}
+
+		if !ext.Enabled {
+			coreServer.SetEnabled(false)
+		}
+
+		cfg.Servers[name] = coreServer
+	}
+
+	return cfg
+}
+
+// FromCore converts canonical config to Goose format.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	gooseCfg := NewConfig()
+
+	for name, server := range cfg.Servers {
+		env := renderEnv(name, server)
+		ext := ExtensionConfig{
+			Name:           name,
+			Enabled:        server.IsEnabled(),
+			Cmd:            server.Command,
+			Args:           server.Args,
+			Envs:           env.Envs,
+			EnvKeys:        env.EnvKeys,
+			URI:            server.URL,
+			Headers:        server.Headers,
+			Timeout:        server.ToolTimeoutSec,
+			AvailableTools: server.EnabledTools,
+		}
+
+		switch server.InferTransport() {
+		case core.TransportStdio:
+			ext.Type = TypeStdio
+		case core.TransportHTTP:
+			ext.Type = TypeStreamableHTTP
+		case core.TransportSSE:
+			ext.Type = TypeSSE
+		}
+
+		gooseCfg.Extensions[name] = ext
+	}
+
+	return gooseCfg
+}
+
+// isMCP reports whether an extension type is an MCP server.
+func isMCP(extType string) bool {
+	return extType == TypeStdio || extType == TypeSSE || extType == TypeStreamableHTTP
+}
+
+// env is a server's environment in Goose form.
+type env struct {
+	Envs    map[string]string
+	EnvKeys []string
+	Losses  []core.Loss
+}
+
+// renderEnv maps the env of a canonical server to Goose's envs and
+// env_keys and records the secret references it cannot map exactly.
+func renderEnv(name string, server core.Server) env {
+	var e env
+	degrade := func(field, detail string) {
+		e.Losses = append(e.Losses, core.Loss{
+			Adapter: AdapterName,
+			Path:    "servers." + name + "." + field,
+			Kind:    core.LossDegraded,
+			Detail:  detail,
+		})
+	}
+
+	seen := make(map[string]bool)
+	for _, key := range sortedKeys(server.Env) {
+		value := server.Env[key]
+		ref, ok := core.ParseSecretRef(value)
+		if !ok {
+			if len(core.SecretRefs(value)) > 0 {
+				degrade(core.FieldEnv, fmt.Sprintf("%s: secret references inside a value are not expanded and are written literally", key))
+			}
+			if e.Envs == nil {
+				e.Envs = make(map[string]string)
+			}
+			e.Envs[key] = value
+			continue
+		}
+		envVar := core.SecretVar(name, core.FieldEnv, key, ref)
+		switch {
+		case ref.Source != core.SecretEnv:
+			degrade(core.FieldEnv, fmt.Sprintf("%s: %s is read from Goose's secret store or environment variable %s", key, ref, envVar))
+		case envVar != key:
+			degrade(core.FieldEnv, fmt.Sprintf("%s: %s is forwarded as %s since Goose cannot rename variables", key, ref, envVar))
+		}
+		if !seen[envVar] {
+			seen[envVar] = true
+			e.EnvKeys = append(e.EnvKeys, envVar)
+		}
+	}
+
+	for _, key := range sortedKeys(server.Headers) {
+		if len(core.SecretRefs(server.Headers[key])) > 0 {
+			degrade(core.FieldHeaders, fmt.Sprintf("%s: secret references in headers are not expanded and are written literally", key))
+		}
+	}
+	return e
+}
+
+// Lossiness reports the canonical fields that the Goose format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldURL, core.FieldHeaders, core.FieldEnabled,
+		core.FieldToolTimeoutSec, core.FieldEnabledTools)
+	losses = append(losses, core.PlaintextSecrets(AdapterName, cfg)...)
+
+	names := cfg.ServerNames()
+	sort.Strings(names)
+	for _, name := range names {
+		losses = append(losses, renderEnv(name, cfg.Servers[name]).Losses...)
+	}
+	return losses
+}
+
+// Merge writes cfg into an existing Goose config, replacing the MCP server
+// extensions and keeping other extensions, settings and comments.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	extensions := make(map[string]any)
+	for name, ext := range a.FromCore(cfg).Extensions {
+		extensions[name] = ext
+	}
+
+	var current struct {
+		Extensions map[string]yaml.Node `yaml:"extensions"`
+	}
+	if err := yaml.Unmarshal(existing, &current); err != nil {
+		return nil, err
+	}
+	for name, node := range current.Extensions {
+		var ext struct {
+			Type string `yaml:"type"`
+		}
+		if err := node.Decode(&ext); err != nil || isMCP(ext.Type) {
+			continue
+		}
+		if _, ok := extensions[name]; !ok {
+			extensions[name] = &node
+		}
+	}
+
+	data, err := marshalYAML(map[string]any{"extensions": extensions})
+	if err != nil {
+		return nil, err
+	}
+	return merge.YAML(existing, data, "extensions")
+}
+
+// marshalYAML encodes v with the two-space indentation Goose writes.
+func marshalYAML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sortedKeys returns the keys of m sorted alphabetically.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ConfigPath returns the default Goose config path.
+func ConfigPath() (string, error) {
+	if runtime.GOOS == "windows" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "Block", "goose", "config", ConfigFileName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "goose", ConfigFileName), nil
+}
+
+// init registers the adapter with the default registry.
+func init() {
+	core.Register(NewAdapter())
+}
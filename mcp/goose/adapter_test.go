@@ -0,0 +1,268 @@
+package goose
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+func TestAdapterName(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter.Name() != "goose" {
+		t.Errorf("Expected name 'goose', got %q", adapter.Name())
+	}
+}
+
+func TestAdapterDefaultPaths(t *testing.T) {
+	adapter := NewAdapter()
+	paths := adapter.DefaultPaths()
+
+	if len(paths) != 1 {
+		t.Fatalf("Expected one default path, got %v", paths)
+	}
+	if filepath.Base(paths[0]) != ConfigFileName {
+		t.Errorf("Expected %s, got %q", ConfigFileName, paths[0])
+	}
+}
+
+func TestAdapterParse(t *testing.T) {
+	adapter := NewAdapter()
+
+	yamlData := []byte(`GOOSE_PROVIDER: openai
+extensions:
+  developer:
+    name: developer
+    type: builtin
+    enabled: true
+    timeout: 300
+  github:
+    name: github
+    type: stdio
+    enabled: true
+    cmd: npx
+    args:
+      - -y
+      - "@modelcontextprotocol/server-github"
+    envs:
+      DEBUG: "1"
+    env_keys:
+      - GITHUB_TOKEN
+    timeout: 300
+    available_tools:
+      - search_repositories
+  remote:
+    name: remote
+    type: streamable_http
+    enabled: false
+    uri: https://api.example.com/mcp
+    headers:
+      X-Team: platform
+  events:
+    name: events
+    type: sse
+    enabled: true
+    uri: https://api.example.com/sse
+`)
+
+	cfg, err := adapter.Parse(yamlData)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(cfg.Servers) != 3 {
+		t.Errorf("Expected 3 servers without the builtin extension, got %d", len(cfg.Servers))
+	}
+
+	github, ok := cfg.GetServer("github")
+	if !ok {
+		t.Fatal("github server not found")
+	}
+	if github.Transport != core.TransportStdio {
+		t.Errorf("Expected stdio transport, got %v", github.Transport)
+	}
+	wantEnv := map[string]string{"DEBUG": "1", "GITHUB_TOKEN": "${GITHUB_TOKEN}"}
+	if !reflect.DeepEqual(github.Env, wantEnv) {
+		t.Errorf("Expected env %v, got %v", wantEnv, github.Env)
+	}
+	if github.ToolTimeoutSec != 300 {
+		t.Errorf("Expected tool timeout 300, got %d", github.ToolTimeoutSec)
+	}
+	if len(github.EnabledTools) != 1 {
+		t.Errorf("Expected 1 enabled tool, got %d", len(github.EnabledTools))
+	}
+
+	remote, ok := cfg.GetServer("remote")
+	if !ok {
+		t.Fatal("remote server not found")
+	}
+	if remote.Transport != core.TransportHTTP {
+		t.Errorf("Expected http transport, got %v", remote.Transport)
+	}
+	if remote.IsEnabled() {
+		t.Error("Expected remote server to be disabled")
+	}
+
+	events, _ := cfg.GetServer("events")
+	if events.Transport != core.TransportSSE {
+		t.Errorf("Expected sse transport, got %v", events.Transport)
+	}
+}
+
+func TestAdapterMarshal(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("test", core.Server{
+		Command: "npx",
+		Args:    []string{"-y", "test-server"},
+		Env: map[string]string{
+			"API_KEY": "${env:API_KEY}",
+			"DEBUG":   "1",
+		},
+		ToolTimeoutSec: 60,
+	})
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	out := string(data)
+	for _, s := range []string{"type: stdio", "enabled: true", "cmd: npx", "env_keys:\n      - API_KEY", "DEBUG: \"1\"", "timeout: 60"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected %q in:\n%s", s, out)
+		}
+	}
+
+	// Round-trip
+	cfg2, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse after marshal failed: %v", err)
+	}
+	server, ok := cfg2.GetServer("test")
+	if !ok {
+		t.Fatal("test not found after round-trip")
+	}
+	if server.Env["API_KEY"] != "${API_KEY}" || server.Env["DEBUG"] != "1" {
+		t.Errorf("Expected env to round-trip, got %v", server.Env)
+	}
+	if server.ToolTimeoutSec != 60 {
+		t.Errorf("Expected tool timeout 60, got %d", server.ToolTimeoutSec)
+	}
+}
+
+func TestAdapterLossiness(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("test", core.Server{
+		Command: "npx",
+		Cwd:     "/src/app",
+		Env: map[string]string{
+			"API_KEY": "${keyring:test/api}",
+			"TOKEN":   "${env:GITHUB_TOKEN}",
+			"URL":     "https://${env:HOST}/api",
+		},
+	})
+
+	var got []string
+	for _, loss := range adapter.Lossiness(cfg) {
+		got = append(got, string(loss.Kind)+" "+loss.Path)
+	}
+	want := []string{
+		"dropped servers.test.cwd",
+		"degraded servers.test.env",
+		"degraded servers.test.env",
+		"degraded servers.test.env",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected losses %v, got %v", want, got)
+	}
+}
+
+func TestAdapterMergeKeepsBuiltinExtensions(t *testing.T) {
+	adapter := NewAdapter()
+
+	existing := []byte(`# Provider settings are not managed
+GOOSE_PROVIDER: openai
+GOOSE_MODEL: gpt-4o
+extensions:
+  developer:
+    name: developer
+    type: builtin
+    enabled: true
+  stale:
+    name: stale
+    type: stdio
+    enabled: true
+    cmd: stale
+`)
+
+	cfg := core.NewConfig()
+	cfg.AddServer("github", core.Server{Command: "npx"})
+
+	merged, err := adapter.Merge(cfg, existing)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	out := string(merged)
+	for _, s := range []string{"# Provider settings are not managed", "GOOSE_MODEL: gpt-4o", "type: builtin", "github:"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected %q in:\n%s", s, out)
+		}
+	}
+	if strings.Contains(out, "stale") {
+		t.Errorf("Expected stale server to be removed:\n%s", out)
+	}
+
+	merged2, err := adapter.Merge(cfg, merged)
+	if err != nil {
+		t.Fatalf("Second merge failed: %v", err)
+	}
+	if string(merged2) != out {
+		t.Errorf("Expected merge to be idempotent, got:\n%s", merged2)
+	}
+}
+
+func TestAdapterReadWriteFile(t *testing.T) {
+	adapter := NewAdapter()
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "goose", ConfigFileName)
+
+	cfg := core.NewConfig()
+	cfg.AddServer("file-test", core.Server{
+		Command: "echo",
+	})
+
+	if err := adapter.WriteFile(cfg, path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	loaded, err := adapter.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	if len(loaded.Servers) != 1 {
+		t.Errorf("Expected 1 server, got %d", len(loaded.Servers))
+	}
+}
+
+func TestAdapterReadFileNotFound(t *testing.T) {
+	adapter := NewAdapter()
+
+	_, err := adapter.ReadFile("/nonexistent/config.yaml")
+	if err == nil {
+		t.Error("Expected error for nonexistent file")
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	cfg := NewConfig()
+
+	if cfg.Extensions == nil {
+		t.Error("Expected Extensions to be initialized")
+	}
+}
@@ -0,0 +1,61 @@
+package goose
+
+// Config represents the extensions section of a Goose config.yaml.
+type Config struct {
+	Extensions map[string]ExtensionConfig `yaml:"extensions"`
+}
+
+// Goose extension types that are MCP servers. Other types, such as
+// "builtin" and "platform", are extensions shipped with Goose.
+const (
+	TypeStdio          = "stdio"
+	TypeSSE            = "sse"
+	TypeStreamableHTTP = "streamable_http"
+)
+
+// ExtensionConfig represents a Goose extension.
+type ExtensionConfig struct {
+	// Name is the extension name, the same as its key.
+	Name string `yaml:"name"`
+
+	// Type is the extension type: "stdio", "sse" or "streamable_http" for
+	// MCP servers.
+	Type string `yaml:"type"`
+
+	// Enabled indicates whether the extension is enabled.
+	Enabled bool `yaml:"enabled"`
+
+	// Description describes the extension.
+	Description string `yaml:"description,omitempty"`
+
+	// --- STDIO Server Fields ---
+	Cmd  string   `yaml:"cmd,omitempty"`
+	Args []string `yaml:"args,omitempty"`
+
+	// Envs contains environment variables for the server process, written
+	// literally.
+	Envs map[string]string `yaml:"envs,omitempty"`
+
+	// EnvKeys lists environment variables whose values Goose reads from
+	// its secret store or the environment.
+	EnvKeys []string `yaml:"env_keys,omitempty"`
+
+	// --- Remote Server Fields ---
+	URI     string            `yaml:"uri,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// --- Tool Control ---
+
+	// Timeout is the timeout for tool calls in seconds.
+	Timeout int `yaml:"timeout,omitempty"`
+
+	// AvailableTools is an allow-list of tools to expose.
+	AvailableTools []string `yaml:"available_tools,omitempty"`
+}
+
+// NewConfig creates a new Goose config.
+func NewConfig() *Config {
+	return &Config{
+		Extensions: make(map[string]ExtensionConfig),
+	}
+}
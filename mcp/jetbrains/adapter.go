@@ -0,0 +1,205 @@
+// Package jetbrains provides an adapter for the JSON form of JetBrains AI
+// Assistant MCP settings.
+//
+// AI Assistant stores MCP servers in the IDE settings rather than in a
+// file of its own. Settings | Tools | AI Assistant | Model Context
+// Protocol (MCP) imports and exports them as JSON in Claude's mcpServers
+// format, which this adapter reads and writes.
+//
+// Field mapping:
+//
+//	canonical       JetBrains AI Assistant
+//	command, args   command, args
+//	env             env
+//	url, headers    url, headers (Streamable HTTP)
+//
+// AI Assistant does not expand placeholders, so secret references are
+// written literally and reported as degraded.
+package jetbrains
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "jetbrains"
+)
+
+// Adapter implements core.Adapter for JetBrains AI Assistant.
+type Adapter struct{}
+
+// NewAdapter creates a new JetBrains AI Assistant adapter.
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Name returns the adapter name.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns the default config file paths for JetBrains AI
+// Assistant. There are none: the JSON is pasted into the IDE settings.
+func (a *Adapter) DefaultPaths() []string {
+	return []string{}
+}
+
+// Parse parses JetBrains AI Assistant JSON into the canonical format.
+func (a *Adapter) Parse(data []byte) (*core.Config, error) {
+	var jbCfg Config
+	if err := json.Unmarshal(data, &jbCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return a.ToCore(&jbCfg), nil
+}
+
+// Marshal converts canonical config to JetBrains AI Assistant JSON.
+func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	jbCfg := a.FromCore(cfg)
+	return json.MarshalIndent(jbCfg, "", "  ")
+}
+
+// ReadFile reads an exported JetBrains AI Assistant JSON file.
+func (a *Adapter) ReadFile(path string) (*core.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Path: path, Err: err}
+	}
+	cfg, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteFile writes canonical config to a JSON file for import into
+// JetBrains AI Assistant.
+func (a *Adapter) WriteFile(cfg *core.Config, path string) error {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	// Ensure directory exists
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	return nil
+}
+
+// ToCore converts JetBrains AI Assistant config to canonical format.
+func (a *Adapter) ToCore(jbCfg *Config) *core.Config {
+	cfg := core.NewConfig()
+
+	for name, server := range jbCfg.MCPServers {
+		coreServer := core.Server{
+			Command: server.Command,
+			Args:    server.Args,
+			Env:     server.Env,
+			URL:     server.URL,
+			Headers: server.Headers,
+		}
+
+		// Infer transport type
+		if server.Command != "" {
+			coreServer.Transport = core.TransportStdio
+		} else if server.URL != "" {
+			coreServer.Transport = core.TransportHTTP
+		}
+
+		cfg.Servers[name] = coreServer
+	}
+
+	return cfg
+}
+
+// FromCore converts canonical config to JetBrains AI Assistant format.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	jbCfg := NewConfig()
+
+	for name, server := range cfg.Servers {
+		jbCfg.MCPServers[name] = ServerConfig{
+			Command: server.Command,
+			Args:    server.Args,
+			Env:     server.Env,
+			URL:     server.URL,
+			Headers: server.Headers,
+		}
+	}
+
+	return jbCfg
+}
+
+// Lossiness reports the canonical fields that the JetBrains AI Assistant
+// format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldURL, core.FieldHeaders)
+	losses = append(losses, core.DegradedTransports(AdapterName, cfg, core.TransportStdio, core.TransportHTTP)...)
+	losses = append(losses, core.PlaintextSecrets(AdapterName, cfg)...)
+	return append(losses, literalSecrets(cfg)...)
+}
+
+// literalSecrets reports the values that contain secret references, which
+// AI Assistant passes to the server unexpanded.
+func literalSecrets(cfg *core.Config) []core.Loss {
+	var losses []core.Loss
+	names := cfg.ServerNames()
+	sort.Strings(names)
+	for _, name := range names {
+		server := cfg.Servers[name]
+		for _, field := range []string{core.FieldEnv, core.FieldHeaders} {
+			values := server.Env
+			if field == core.FieldHeaders {
+				values = server.Headers
+			}
+			keys := make([]string, 0, len(values))
+			for key := range values {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				if len(core.SecretRefs(values[key])) == 0 {
+					continue
+				}
+				losses = append(losses, core.Loss{
+					Adapter: AdapterName,
+					Path:    "servers." + name + "." + field,
+					Kind:    core.LossDegraded,
+					Detail:  fmt.Sprintf("%s: secret references are not expanded and are written literally", key),
+				})
+			}
+		}
+	}
+	return losses
+}
+
+// Merge writes cfg into an existing JetBrains AI Assistant JSON document,
+// replacing only the mcpServers key.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "mcpServers")
+}
+
+// init registers the adapter with the default registry.
+func init() {
+	core.Register(NewAdapter())
+}
@@ -0,0 +1,193 @@
+package jetbrains
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+func TestAdapterName(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter.Name() != "jetbrains" {
+		t.Errorf("Expected name 'jetbrains', got %q", adapter.Name())
+	}
+}
+
+func TestAdapterDefaultPaths(t *testing.T) {
+	adapter := NewAdapter()
+
+	// The servers live in the IDE settings
+	if paths := adapter.DefaultPaths(); len(paths) != 0 {
+		t.Errorf("Expected no default paths, got %v", paths)
+	}
+}
+
+func TestAdapterParse(t *testing.T) {
+	adapter := NewAdapter()
+
+	jsonData := []byte(`{
+		"mcpServers": {
+			"github": {
+				"command": "npx",
+				"args": ["-y", "@modelcontextprotocol/server-github"],
+				"env": {"GITHUB_TOKEN": "ghp_example"}
+			},
+			"remote": {
+				"url": "https://api.example.com/mcp",
+				"headers": {"X-Team": "platform"}
+			}
+		}
+	}`)
+
+	cfg, err := adapter.Parse(jsonData)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(cfg.Servers) != 2 {
+		t.Errorf("Expected 2 servers, got %d", len(cfg.Servers))
+	}
+
+	github, ok := cfg.GetServer("github")
+	if !ok {
+		t.Fatal("github server not found")
+	}
+	if github.Transport != core.TransportStdio {
+		t.Errorf("Expected stdio transport, got %v", github.Transport)
+	}
+	if len(github.Args) != 2 {
+		t.Errorf("Expected 2 args, got %d", len(github.Args))
+	}
+
+	remote, ok := cfg.GetServer("remote")
+	if !ok {
+		t.Fatal("remote server not found")
+	}
+	if remote.Transport != core.TransportHTTP {
+		t.Errorf("Expected http transport, got %v", remote.Transport)
+	}
+	if remote.Headers["X-Team"] != "platform" {
+		t.Errorf("Expected header to be read, got %v", remote.Headers)
+	}
+}
+
+func TestAdapterMarshal(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("test", core.Server{
+		Transport: core.TransportStdio,
+		Command:   "npx",
+		Args:      []string{"-y", "test-server"},
+		Env:       map[string]string{"DEBUG": "1"},
+	})
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), `"type"`) {
+		t.Errorf("Expected no type field:\n%s", data)
+	}
+
+	// Round-trip
+	cfg2, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse after marshal failed: %v", err)
+	}
+	server, ok := cfg2.GetServer("test")
+	if !ok {
+		t.Fatal("test not found after round-trip")
+	}
+	if !reflect.DeepEqual(server, cfg.Servers["test"]) {
+		t.Errorf("Expected %+v after round-trip, got %+v", cfg.Servers["test"], server)
+	}
+}
+
+func TestAdapterLossiness(t *testing.T) {
+	adapter := NewAdapter()
+
+	enabled := false
+	cfg := core.NewConfig()
+	cfg.AddServer("test", core.Server{
+		Command: "npx",
+		Env:     map[string]string{"GITHUB_TOKEN": "${env:GITHUB_TOKEN}"},
+		Enabled: &enabled,
+	})
+
+	var got []string
+	for _, loss := range adapter.Lossiness(cfg) {
+		got = append(got, string(loss.Kind)+" "+loss.Path)
+	}
+	want := []string{"dropped servers.test.enabled", "degraded servers.test.env"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected losses %v, got %v", want, got)
+	}
+
+	// References are written as is
+	data, _ := adapter.Marshal(cfg)
+	if !strings.Contains(string(data), `"GITHUB_TOKEN": "${env:GITHUB_TOKEN}"`) {
+		t.Errorf("Expected reference to be written literally:\n%s", data)
+	}
+}
+
+func TestAdapterMerge(t *testing.T) {
+	adapter := NewAdapter()
+
+	existing := []byte(`{"unmanaged": true, "mcpServers": {"stale": {"command": "stale"}}}`)
+
+	cfg := core.NewConfig()
+	cfg.AddServer("github", core.Server{Command: "npx"})
+
+	merged, err := adapter.Merge(cfg, existing)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if !strings.Contains(string(merged), `"unmanaged": true`) || strings.Contains(string(merged), "stale") {
+		t.Errorf("Expected only mcpServers to be replaced:\n%s", merged)
+	}
+}
+
+func TestAdapterReadWriteFile(t *testing.T) {
+	adapter := NewAdapter()
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "mcp.json")
+
+	cfg := core.NewConfig()
+	cfg.AddServer("file-test", core.Server{
+		Command: "echo",
+	})
+
+	if err := adapter.WriteFile(cfg, path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	loaded, err := adapter.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	if len(loaded.Servers) != 1 {
+		t.Errorf("Expected 1 server, got %d", len(loaded.Servers))
+	}
+}
+
+func TestAdapterReadFileNotFound(t *testing.T) {
+	adapter := NewAdapter()
+
+	_, err := adapter.ReadFile("/nonexistent/mcp.json")
+	if err == nil {
+		t.Error("Expected error for nonexistent file")
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	cfg := NewConfig()
+
+	if cfg.MCPServers == nil {
+		t.Error("Expected MCPServers to be initialized")
+	}
+}
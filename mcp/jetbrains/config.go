@@ -0,0 +1,30 @@
+package jetbrains
+
+// Config represents the JSON form of the JetBrains AI Assistant MCP
+// settings, as shown by "As JSON" in Settings | Tools | AI Assistant |
+// Model Context Protocol (MCP).
+type Config struct {
+	// MCPServers maps server names to their configurations.
+	MCPServers map[string]ServerConfig `json:"mcpServers"`
+}
+
+// ServerConfig represents a single MCP server in JetBrains AI Assistant's
+// format. The transport is inferred from Command (stdio) or URL
+// (Streamable HTTP).
+type ServerConfig struct {
+	// --- STDIO Server Fields ---
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+
+	// --- Remote Server Fields ---
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// NewConfig creates a new JetBrains AI Assistant config.
+func NewConfig() *Config {
+	return &Config{
+		MCPServers: make(map[string]ServerConfig),
+	}
+}
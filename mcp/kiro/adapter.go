@@ -19,6 +19,7 @@ import (
 	"path/filepath"
 
 	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
 )
 
 const (
@@ -152,9 +153,9 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 		kiroServer := ServerConfig{
 			Command: server.Command,
 			Args:    server.Args,
-			Env:     server.Env,
+			Env:     core.RenderEnvPlaceholders(name, core.FieldEnv, server.Env),
 			URL:     server.URL,
-			Headers: server.Headers,
+			Headers: core.RenderEnvPlaceholders(name, core.FieldHeaders, server.Headers),
 		}
 
 		// Convert enabled to disabled
@@ -168,6 +169,25 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	return kiroCfg
 }
 
+// Lossiness reports the canonical fields that the Kiro format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldURL, core.FieldHeaders, core.FieldEnabled)
+	losses = append(losses, core.DegradedTransports(AdapterName, cfg, core.TransportStdio, core.TransportHTTP)...)
+	return append(losses, core.SecretLosses(AdapterName, cfg)...)
+}
+
+// Merge writes cfg into an existing Kiro config, replacing only the
+// mcpServers key and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "mcpServers")
+}
+
 // WorkspaceConfigPath returns the workspace config path for a given project root.
 func WorkspaceConfigPath(projectRoot string) string {
 	return filepath.Join(projectRoot, ProjectConfigDir, SettingsDir, ConfigFileName)
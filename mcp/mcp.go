@@ -10,11 +10,27 @@
 //   - Cline VS Code extension (cline_mcp_settings.json)
 //   - Roo Code VS Code extension (mcp_settings.json)
 //   - AWS Kiro CLI (.kiro/settings/mcp.json)
+//   - Google Gemini CLI (.gemini/settings.json)
+//   - Zed (context_servers in settings.json)
+//   - Continue (.continue/mcpServers/*.yaml)
+//   - Goose (~/.config/goose/config.yaml)
+//   - OpenCode (opencode.json)
+//   - JetBrains AI Assistant (exported mcpServers JSON)
 //
 // The package provides:
 //   - A canonical Config type that represents MCP configuration
 //   - Adapters for reading/writing tool-specific formats
 //   - Conversion between different tool formats
+//   - Lossiness reports for fields a target format cannot represent
+//   - Merge-aware writes that update only the managed section of existing files
+//   - Syncing one canonical config to many tools, with conflict detection
+//   - Secret references (${env:...}, ${file:...}, ${keyring:...}, ${cmd:...},
+//     ${local:...}) rendered in each tool's native form
+//
+// The protocol subpackage speaks MCP itself. The probe subpackage uses it
+// to start configured servers and check their tools, the proxy subpackage
+// to serve them as one server that applies their tool filters, and the
+// specserver subpackage to serve canonical commands, skills and context.
 //
 // Example usage:
 //
@@ -33,16 +49,23 @@ package mcp
 
 import (
 	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
 
 	// Import adapters to register them
 	_ "github.com/agentplexus/assistantkit/mcp/claude"
 	_ "github.com/agentplexus/assistantkit/mcp/cline"
 	_ "github.com/agentplexus/assistantkit/mcp/codex"
+	_ "github.com/agentplexus/assistantkit/mcp/continuedev"
 	_ "github.com/agentplexus/assistantkit/mcp/cursor"
+	_ "github.com/agentplexus/assistantkit/mcp/gemini"
+	_ "github.com/agentplexus/assistantkit/mcp/goose"
+	_ "github.com/agentplexus/assistantkit/mcp/jetbrains"
 	_ "github.com/agentplexus/assistantkit/mcp/kiro"
+	_ "github.com/agentplexus/assistantkit/mcp/opencode"
 	_ "github.com/agentplexus/assistantkit/mcp/roo"
 	_ "github.com/agentplexus/assistantkit/mcp/vscode"
 	_ "github.com/agentplexus/assistantkit/mcp/windsurf"
+	_ "github.com/agentplexus/assistantkit/mcp/zed"
 )
 
 // Re-export core types for convenience
@@ -61,6 +84,45 @@ type (
 
 	// Adapter is the interface for tool-specific adapters.
 	Adapter = core.Adapter
+
+	// Loss describes a field that a target format drops or degrades.
+	Loss = core.Loss
+
+	// MergeResult describes the outcome of merging into an existing file.
+	MergeResult = merge.Result
+
+	// SyncTarget is a tool config file kept in sync with a canonical config.
+	SyncTarget = core.SyncTarget
+
+	// SyncOptions configures a sync.
+	SyncOptions = core.SyncOptions
+
+	// SyncResult describes the sync of one target.
+	SyncResult = core.SyncResult
+
+	// ServerChange describes what a sync does to one server.
+	ServerChange = core.ServerChange
+
+	// SyncStrategy decides how sync conflicts are resolved.
+	SyncStrategy = core.SyncStrategy
+
+	// Conflict is a server edited in a target file since the last sync.
+	Conflict = core.Conflict
+
+	// Lockfile records the servers last synced to each target.
+	Lockfile = core.Lockfile
+
+	// SecretRef is a reference to a secret in an Env or Headers value.
+	SecretRef = core.SecretRef
+
+	// SecretSource identifies where a secret reference is read from.
+	SecretSource = core.SecretSource
+
+	// SecretProvider looks up the value of a secret by name.
+	SecretProvider = core.SecretProvider
+
+	// SecretResolver resolves secret references with a provider per source.
+	SecretResolver = core.SecretResolver
 )
 
 // Transport type constants
@@ -70,13 +132,46 @@ const (
 	TransportSSE   = core.TransportSSE
 )
 
+// Sync strategy constants
+const (
+	StrategyOurs   = core.StrategyOurs
+	StrategyTheirs = core.StrategyTheirs
+	StrategyPrompt = core.StrategyPrompt
+)
+
+// Secret source constants
+const (
+	SecretEnv     = core.SecretEnv
+	SecretFile    = core.SecretFile
+	SecretKeyring = core.SecretKeyring
+	SecretCommand = core.SecretCommand
+	SecretLocal   = core.SecretLocal
+)
+
 // NewConfig creates a new empty configuration.
 func NewConfig() *Config {
 	return core.NewConfig()
 }
 
+// ReadFile reads a canonical config from a JSON file.
+func ReadFile(path string) (*Config, error) {
+	return core.ReadFile(path)
+}
+
+// ParseSecretRef parses a value that consists of exactly one secret reference.
+// Example: ParseSecretRef("${keyring:github/token}")
+func ParseSecretRef(value string) (SecretRef, bool) {
+	return core.ParseSecretRef(value)
+}
+
+// NewSecretResolver creates a resolver with the default secret providers.
+func NewSecretResolver() *SecretResolver {
+	return core.NewSecretResolver()
+}
+
 // GetAdapter returns an adapter by name from the default registry.
-// Supported names: "claude", "cursor", "windsurf", "vscode", "codex", "cline", "roo", "kiro"
+// Supported names: "claude", "cursor", "windsurf", "vscode", "codex", "cline", "roo", "kiro", "gemini",
+// "zed", "continue", "goose", "opencode", "jetbrains"
 func GetAdapter(name string) (Adapter, bool) {
 	return core.GetAdapter(name)
 }
@@ -87,6 +182,31 @@ func Convert(data []byte, from, to string) ([]byte, error) {
 	return core.Convert(data, from, to)
 }
 
+// Lossiness returns the fields of cfg that the named tool's format drops or
+// degrades. Example: Lossiness(cfg, "claude")
+func Lossiness(cfg *Config, to string) ([]Loss, error) {
+	return core.DefaultRegistry.Lossiness(cfg, to)
+}
+
+// MergeFile writes cfg to path in the named tool's format, replacing only
+// the MCP servers in an existing file and keeping all other settings. If dryRun
+// is true, the file is not written and the result only reports the diff.
+// Example: MergeFile(cfg, "codex", "config.toml", false)
+func MergeFile(cfg *Config, to, path string, dryRun bool) (*MergeResult, error) {
+	return core.DefaultRegistry.MergeFile(cfg, to, path, dryRun)
+}
+
+// Sync pushes the canonical config to each target, using a three-way diff
+// against the state recorded in lock to detect manual edits.
+func Sync(source *Config, targets []SyncTarget, lock *Lockfile, opts SyncOptions) ([]*SyncResult, error) {
+	return core.DefaultRegistry.Sync(source, targets, lock, opts)
+}
+
+// ReadLockfile reads a sync lockfile. A missing file yields an empty lockfile.
+func ReadLockfile(path string) (*Lockfile, error) {
+	return core.ReadLockfile(path)
+}
+
 // AdapterNames returns the names of all registered adapters.
 func AdapterNames() []string {
 	return core.DefaultRegistry.Names()
@@ -95,13 +215,19 @@ func AdapterNames() []string {
 // SupportedTools returns a list of supported AI coding tools.
 func SupportedTools() []string {
 	return []string{
-		"claude",   // Claude Code / Claude Desktop
-		"cursor",   // Cursor IDE
-		"windsurf", // Windsurf (Codeium)
-		"vscode",   // VS Code / GitHub Copilot
-		"codex",    // OpenAI Codex CLI
-		"cline",    // Cline VS Code extension
-		"roo",      // Roo Code VS Code extension
-		"kiro",     // AWS Kiro CLI
+		"claude",    // Claude Code / Claude Desktop
+		"cursor",    // Cursor IDE
+		"windsurf",  // Windsurf (Codeium)
+		"vscode",    // VS Code / GitHub Copilot
+		"codex",     // OpenAI Codex CLI
+		"cline",     // Cline VS Code extension
+		"roo",       // Roo Code VS Code extension
+		"kiro",      // AWS Kiro CLI
+		"gemini",    // Google Gemini CLI
+		"zed",       // Zed editor
+		"continue",  // Continue IDE extension
+		"goose",     // Goose agent
+		"opencode",  // OpenCode
+		"jetbrains", // JetBrains AI Assistant
 	}
 }
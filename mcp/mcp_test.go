@@ -1,11 +1,14 @@
 package mcp
 
 import (
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 )
 
 func TestGetAdapter(t *testing.T) {
-	adapters := []string{"claude", "cursor", "windsurf", "vscode", "codex", "cline", "roo", "kiro"}
+	adapters := []string{"claude", "cursor", "windsurf", "vscode", "codex", "cline", "roo", "kiro", "gemini", "zed", "continue", "goose", "opencode", "jetbrains"}
 
 	for _, name := range adapters {
 		t.Run(name, func(t *testing.T) {
@@ -23,14 +26,14 @@ func TestGetAdapter(t *testing.T) {
 
 func TestAdapterNames(t *testing.T) {
 	names := AdapterNames()
-	if len(names) < 8 {
-		t.Errorf("Expected at least 8 adapters, got %d", len(names))
+	if len(names) < 14 {
+		t.Errorf("Expected at least 14 adapters, got %d", len(names))
 	}
 }
 
 func TestSupportedTools(t *testing.T) {
 	tools := SupportedTools()
-	expected := []string{"claude", "cursor", "windsurf", "vscode", "codex", "cline", "roo", "kiro"}
+	expected := []string{"claude", "cursor", "windsurf", "vscode", "codex", "cline", "roo", "kiro", "gemini", "zed", "continue", "goose", "opencode", "jetbrains"}
 
 	if len(tools) != len(expected) {
 		t.Errorf("Expected %d tools, got %d", len(expected), len(tools))
@@ -101,3 +104,71 @@ func TestTransportConstants(t *testing.T) {
 		t.Errorf("TransportSSE mismatch")
 	}
 }
+
+func TestRemoteServer(t *testing.T) {
+	var cfg Config
+	err := json.Unmarshal([]byte(`{"servers": {"api": {
+		"transport": "streamable-http",
+		"url": "https://api.example.com/mcp",
+		"oauth": {"clientId": "abc", "scopes": ["read"], "callbackPort": 8765}
+	}}}`), &cfg)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if cfg.Servers["api"].Transport != TransportHTTP {
+		t.Fatalf("Expected streamable-http to be read as http, got %q", cfg.Servers["api"].Transport)
+	}
+
+	tests := []struct {
+		tool     string
+		contains []string
+		dropped  []string
+	}{
+		{"claude", []string{`"type": "http"`, `"oauth": {`, `"clientId": "abc"`, `"callbackPort": 8765`}, []string{"oauth.scopes"}},
+		{"cursor", []string{`"url": "https://api.example.com/mcp"`, `"CLIENT_ID": "abc"`, `"scopes": [`}, []string{"oauth.callbackPort"}},
+		{"vscode", []string{`"type": "http"`}, []string{"oauth.clientId", "oauth.scopes", "oauth.callbackPort"}},
+		{"codex", []string{`url = 'https://api.example.com/mcp'`, `scopes = ['read']`}, []string{"oauth.clientId", "oauth.callbackPort"}},
+		{"windsurf", []string{`"serverUrl": "https://api.example.com/mcp"`}, []string{"oauth.clientId", "oauth.scopes", "oauth.callbackPort"}},
+		{"cline", []string{`"type": "streamableHttp"`}, []string{"oauth.clientId", "oauth.scopes", "oauth.callbackPort"}},
+		{"roo", []string{`"type": "streamable-http"`}, []string{"oauth.clientId", "oauth.scopes", "oauth.callbackPort"}},
+		{"gemini", []string{`"httpUrl": "https://api.example.com/mcp"`, `"redirectUri": "http://localhost:8765/oauth/callback"`}, nil},
+		{"zed", []string{`"url": "https://api.example.com/mcp"`}, []string{"oauth.clientId", "oauth.scopes", "oauth.callbackPort"}},
+		{"continue", []string{"type: streamable-http"}, []string{"oauth.clientId", "oauth.scopes", "oauth.callbackPort"}},
+		{"goose", []string{"type: streamable_http", "uri: https://api.example.com/mcp"}, []string{"oauth.clientId", "oauth.scopes", "oauth.callbackPort"}},
+		{"opencode", []string{`"type": "remote"`, `"clientId": "abc"`, `"scope": "read"`}, []string{"oauth.callbackPort"}},
+		{"jetbrains", []string{`"url": "https://api.example.com/mcp"`}, []string{"oauth.clientId", "oauth.scopes", "oauth.callbackPort"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			adapter, _ := GetAdapter(tt.tool)
+			data, err := adapter.Marshal(&cfg)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(string(data), s) {
+					t.Errorf("Expected %s in:\n%s", s, data)
+				}
+			}
+
+			back, err := adapter.Parse(data)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if server := back.Servers["api"]; server.InferTransport() != TransportHTTP {
+				t.Errorf("Expected http transport after round trip, got %q", server.InferTransport())
+			}
+
+			losses, _ := Lossiness(&cfg, tt.tool)
+			var dropped []string
+			for _, loss := range losses {
+				if field, ok := strings.CutPrefix(loss.Path, "servers.api."); ok && strings.HasPrefix(field, "oauth.") {
+					dropped = append(dropped, field)
+				}
+			}
+			if !reflect.DeepEqual(dropped, tt.dropped) {
+				t.Errorf("Expected dropped %v, got %v", tt.dropped, dropped)
+			}
+		})
+	}
+}
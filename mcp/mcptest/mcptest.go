@@ -0,0 +1,230 @@
+// Package mcptest provides a fake MCP server for tests.
+//
+// The fake server offers the tools, prompts and resources named in its
+// Options. Tools echo their arguments, prompts render their arguments and
+// resources contain their own name. It can be served over HTTP or SSE in
+// the test process, or over stdio by the test binary itself, in which
+// case the test's TestMain must call Main:
+//
+//	func TestMain(m *testing.M) {
+//	    mcptest.Main()
+//	    os.Exit(m.Run())
+//	}
+//
+//	func TestProbe(t *testing.T) {
+//	    server := mcptest.Stdio(t, mcptest.Options{Tools: []string{"search"}})
+//	    client, err := protocol.Connect(ctx, server)
+//	    ...
+//	}
+package mcptest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/mcp/protocol"
+)
+
+// EnvVar is the environment variable that makes Main serve the fake server.
+// It holds the server's Options as JSON.
+const EnvVar = "ASSISTANTKIT_MCPTEST"
+
+// Options configure the fake server.
+type Options struct {
+	// Name is the server name announced on initialize. Defaults to
+	// "mcptest".
+	Name string `json:"name,omitempty"`
+
+	// Tools, Prompts and Resources name what the server offers. A nil
+	// list leaves the capability out; an empty list offers none.
+	Tools     []string `json:"tools"`
+	Prompts   []string `json:"prompts"`
+	Resources []string `json:"resources"`
+
+	// StartupDelay delays serving over stdio, to test startup timeouts.
+	StartupDelay time.Duration `json:"startupDelay,omitempty"`
+
+	// ListDelay delays the list responses, to test list timeouts.
+	ListDelay time.Duration `json:"listDelay,omitempty"`
+
+	// CallDelay delays the tool call responses, to test tool timeouts.
+	CallDelay time.Duration `json:"callDelay,omitempty"`
+}
+
+// NewServer returns the fake server.
+func NewServer(opts Options) *protocol.Server {
+	name := opts.Name
+	if name == "" {
+		name = "mcptest"
+	}
+	s := &protocol.Server{Info: protocol.Implementation{Name: name, Version: "1.0.0"}}
+
+	if opts.Tools != nil {
+		s.ListTools = func(ctx context.Context) ([]protocol.Tool, error) {
+			if err := sleep(ctx, opts.ListDelay); err != nil {
+				return nil, err
+			}
+			tools := make([]protocol.Tool, 0, len(opts.Tools))
+			for _, tool := range opts.Tools {
+				tools = append(tools, protocol.Tool{
+					Name:        tool,
+					Description: "Echoes its arguments",
+					InputSchema: json.RawMessage(`{"type":"object"}`),
+				})
+			}
+			return tools, nil
+		}
+		s.CallTool = func(ctx context.Context, params protocol.CallToolParams) (*protocol.CallToolResult, error) {
+			if !slices.Contains(opts.Tools, params.Name) {
+				return nil, protocol.InvalidParams("unknown tool: %s", params.Name)
+			}
+			if err := sleep(ctx, opts.CallDelay); err != nil {
+				return nil, err
+			}
+			args, _ := json.Marshal(params.Arguments)
+			return &protocol.CallToolResult{
+				Content: []protocol.Content{protocol.TextContent(fmt.Sprintf("%s %s", params.Name, args))},
+			}, nil
+		}
+	}
+
+	if opts.Prompts != nil {
+		s.ListPrompts = func(ctx context.Context) ([]protocol.Prompt, error) {
+			if err := sleep(ctx, opts.ListDelay); err != nil {
+				return nil, err
+			}
+			prompts := make([]protocol.Prompt, 0, len(opts.Prompts))
+			for _, prompt := range opts.Prompts {
+				prompts = append(prompts, protocol.Prompt{Name: prompt})
+			}
+			return prompts, nil
+		}
+		s.GetPrompt = func(ctx context.Context, params protocol.GetPromptParams) (*protocol.GetPromptResult, error) {
+			if !slices.Contains(opts.Prompts, params.Name) {
+				return nil, protocol.InvalidParams("unknown prompt: %s", params.Name)
+			}
+			text := params.Name
+			keys := make([]string, 0, len(params.Arguments))
+			for key := range params.Arguments {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				text += " " + key + "=" + params.Arguments[key]
+			}
+			return &protocol.GetPromptResult{
+				Messages: []protocol.PromptMessage{{Role: "user", Content: protocol.TextContent(text)}},
+			}, nil
+		}
+	}
+
+	if opts.Resources != nil {
+		s.ListResources = func(ctx context.Context) ([]protocol.Resource, error) {
+			if err := sleep(ctx, opts.ListDelay); err != nil {
+				return nil, err
+			}
+			resources := make([]protocol.Resource, 0, len(opts.Resources))
+			for _, resource := range opts.Resources {
+				resources = append(resources, protocol.Resource{URI: URI(resource), Name: resource, MIMEType: "text/plain"})
+			}
+			return resources, nil
+		}
+		s.ReadResource = func(ctx context.Context, params protocol.ReadResourceParams) (*protocol.ReadResourceResult, error) {
+			for _, resource := range opts.Resources {
+				if URI(resource) == params.URI {
+					return &protocol.ReadResourceResult{Contents: []protocol.ResourceContents{
+						{URI: params.URI, MIMEType: "text/plain", Text: resource},
+					}}, nil
+				}
+			}
+			return nil, protocol.InvalidParams("unknown resource: %s", params.URI)
+		}
+	}
+	return s
+}
+
+// URI returns the URI of the named fake resource.
+func URI(name string) string {
+	return "mcptest://" + name
+}
+
+// Main serves the fake server on stdin and stdout and exits when the
+// process was started by Stdio. Otherwise it returns immediately.
+func Main() {
+	spec := os.Getenv(EnvVar)
+	if spec == "" {
+		return
+	}
+	var opts Options
+	if err := json.Unmarshal([]byte(spec), &opts); err != nil {
+		fmt.Fprintf(os.Stderr, "mcptest: invalid %s: %v\n", EnvVar, err)
+		os.Exit(2)
+	}
+	time.Sleep(opts.StartupDelay)
+	if err := NewServer(opts).ServeStdio(context.Background(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "mcptest: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// Stdio returns the configuration of a stdio server that runs the current
+// test binary as the fake server. The test's TestMain must call Main.
+func Stdio(t testing.TB, opts Options) core.Server {
+	t.Helper()
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("mcptest: %v", err)
+	}
+	spec, err := json.Marshal(opts)
+	if err != nil {
+		t.Fatalf("mcptest: %v", err)
+	}
+	return core.Server{
+		Command: exe,
+		Args:    []string{"-test.run=^$"},
+		Env:     map[string]string{EnvVar: string(spec)},
+	}
+}
+
+// HTTP starts the fake server on the streamable HTTP transport for the
+// duration of the test and returns its configuration.
+func HTTP(t testing.TB, opts Options) core.Server {
+	t.Helper()
+	srv := httptest.NewServer(NewServer(opts))
+	t.Cleanup(srv.Close)
+	return core.Server{Transport: core.TransportHTTP, URL: srv.URL + "/mcp"}
+}
+
+// SSE starts the fake server on the legacy HTTP+SSE transport for the
+// duration of the test and returns its configuration.
+func SSE(t testing.TB, opts Options) core.Server {
+	t.Helper()
+	srv := httptest.NewServer(NewServer(opts).SSEHandler())
+	t.Cleanup(func() {
+		// Event streams stay open until their clients hang up.
+		srv.CloseClientConnections()
+		srv.Close()
+	})
+	return core.Server{Transport: core.TransportSSE, URL: srv.URL + "/sse"}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,156 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+func mergeConfig() *Config {
+	cfg := NewConfig()
+	cfg.AddServer("github", Server{Command: "npx", Args: []string{"-y", "@modelcontextprotocol/server-github"}})
+	cfg.AddServer("remote", Server{Transport: TransportHTTP, URL: "https://example.com/mcp"})
+	return cfg
+}
+
+func TestMergeKeepsUnmanagedSettings(t *testing.T) {
+	names := AdapterNames()
+	sort.Strings(names)
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			adapter, _ := GetAdapter(name)
+			merger, ok := adapter.(core.Merger)
+			if !ok {
+				t.Fatalf("Adapter %q does not implement Merger", name)
+			}
+
+			old := NewConfig()
+			old.AddServer("stale", Server{Command: "stale"})
+			existing, err := adapter.Marshal(old)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			existing = withUnmanagedSetting(t, name, existing)
+
+			cfg := mergeConfig()
+			merged, err := merger.Merge(cfg, existing)
+			if err != nil {
+				t.Fatalf("Merge failed: %v", err)
+			}
+			if !strings.Contains(string(merged), "unmanaged") {
+				t.Errorf("Expected unmanaged setting to be kept:\n%s", merged)
+			}
+
+			got, err := adapter.Parse(merged)
+			if err != nil {
+				t.Fatalf("Parse failed: %v\n%s", err, merged)
+			}
+			want, _ := adapter.Parse(mustMarshal(t, adapter, cfg))
+			if !reflect.DeepEqual(got.Servers, want.Servers) {
+				t.Errorf("Expected servers %v, got %v", want.Servers, got.Servers)
+			}
+		})
+	}
+}
+
+// withUnmanagedSetting adds a setting the adapter does not manage.
+func withUnmanagedSetting(t *testing.T, name string, data []byte) []byte {
+	t.Helper()
+	switch name {
+	case "codex":
+		return append([]byte("# unmanaged\nmodel = \"o3\"\n\n"), data...)
+	case "continue", "goose":
+		return append([]byte("# unmanaged\nmodel: gpt-4o\n"), data...)
+	}
+	s := strings.TrimSpace(string(data))
+	return []byte(`{"unmanaged": true, ` + strings.TrimPrefix(s, "{"))
+}
+
+func mustMarshal(t *testing.T, adapter Adapter, cfg *Config) []byte {
+	t.Helper()
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	return data
+}
+
+func TestMergeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".claude.json")
+	existing := `{
+  "numStartups": 42,
+  "projects": {
+    "/src/app": {"allowedTools": []}
+  },
+  "mcpServers": {}
+}
+`
+	if err := os.WriteFile(path, []byte(existing), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := MergeFile(mergeConfig(), "claude", path, true)
+	if err != nil {
+		t.Fatalf("MergeFile failed: %v", err)
+	}
+	if !result.Changed || !strings.Contains(result.Diff, `+    "github": {`) {
+		t.Errorf("Expected diff adding github server, got:\n%s", result.Diff)
+	}
+	if data, _ := os.ReadFile(path); string(data) != existing {
+		t.Error("Expected dry run not to modify the file")
+	}
+
+	if _, err := MergeFile(mergeConfig(), "claude", path, false); err != nil {
+		t.Fatalf("MergeFile failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "{\n  \"numStartups\": 42,\n  \"projects\": {\n    \"/src/app\": {\"allowedTools\": []}\n  },\n  \"mcpServers\": {\n    \"github\": {") {
+		t.Errorf("Expected unmanaged keys to be kept in order, got:\n%s", data)
+	}
+
+	if _, err := MergeFile(mergeConfig(), "unknown", path, true); err == nil {
+		t.Error("Expected error for unknown adapter")
+	}
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MergeFile(mergeConfig(), "claude", path, true); err == nil {
+		t.Error("Expected error for invalid existing file")
+	}
+}
+
+func TestMergeCodexKeepsComments(t *testing.T) {
+	existing := `# Codex settings
+model = "o3"
+
+# Servers managed by assistantkit
+[mcp_servers.github]
+command = "old"
+
+[profiles.fast]
+model = "o4-mini" # faster
+`
+	adapter, _ := GetAdapter("codex")
+	merged, err := adapter.(core.Merger).Merge(mergeConfig(), []byte(existing))
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	for _, want := range []string{
+		"# Codex settings\nmodel = \"o3\"\n\n# Servers managed by assistantkit\n[mcp_servers.github]\n",
+		"[mcp_servers.remote]\n",
+		"\n[profiles.fast]\nmodel = \"o4-mini\" # faster\n",
+	} {
+		if !strings.Contains(string(merged), want) {
+			t.Errorf("Expected merged config to contain %q, got:\n%s", want, merged)
+		}
+	}
+	if strings.Contains(string(merged), "old") {
+		t.Errorf("Expected github server to be replaced, got:\n%s", merged)
+	}
+}
@@ -0,0 +1,316 @@
+// Package opencode provides an adapter for OpenCode MCP configuration.
+//
+// OpenCode reads MCP servers from the mcp key of opencode.json, which may
+// contain comments and also holds the model, agent and permission
+// settings. Use Merge or mcp.MergeFile to update the servers without
+// replacing the rest of the file.
+//
+// Field mapping:
+//
+//	canonical               OpenCode
+//	transport               type (local, remote)
+//	command, args           command (one list)
+//	env                     environment
+//	url, headers            url, headers
+//	enabled                 enabled
+//	startupTimeoutSec       timeout (milliseconds)
+//	oauth.clientId, scopes  oauth.clientId, oauth.scope
+//
+// Secret references are written as {env:NAME} and {file:path}
+// substitutions, which OpenCode expands when it loads the file.
+//
+// File locations:
+//   - Project: <project>/opencode.json
+//   - User: ~/.config/opencode/opencode.json
+package opencode
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "opencode"
+
+	// ConfigFileName is the config file name.
+	ConfigFileName = "opencode.json"
+
+	// SchemaURL is the JSON schema of OpenCode config files.
+	SchemaURL = "https://opencode.ai/config.json"
+)
+
+// substitutionPattern matches OpenCode's {env:NAME} and {file:path}
+// substitutions.
+var substitutionPattern = regexp.MustCompile(`\{(env|file):([^}]+)\}`)
+
+// Adapter implements core.Adapter for OpenCode.
+type Adapter struct{}
+
+// NewAdapter creates a new OpenCode adapter.
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Name returns the adapter name.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns the default config file paths for OpenCode.
+func (a *Adapter) DefaultPaths() []string {
+	paths := []string{ConfigFileName}
+	if path, err := UserConfigPath(); err == nil {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// Parse parses OpenCode config data into the canonical format.
+func (a *Adapter) Parse(data []byte) (*core.Config, error) {
+	var openCfg Config
+	if err := json.Unmarshal(merge.StripJSONC(data), &openCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return a.ToCore(&openCfg), nil
+}
+
+// Marshal converts canonical config to OpenCode format.
+func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	openCfg := a.FromCore(cfg)
+	return json.MarshalIndent(openCfg, "", "  ")
+}
+
+// ReadFile reads an OpenCode config file.
+func (a *Adapter) ReadFile(path string) (*core.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Path: path, Err: err}
+	}
+	cfg, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteFile writes canonical config to an OpenCode config file, replacing
+// its contents.
+func (a *Adapter) WriteFile(cfg *core.Config, path string) error {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	// Ensure directory exists
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	return nil
+}
+
+// ToCore converts OpenCode config to canonical format.
+func (a *Adapter) ToCore(openCfg *Config) *core.Config {
+	cfg := core.NewConfig()
+
+	for name, server := range openCfg.MCP {
+		coreServer := core.Server{
+			Env:               parseSecrets(server.Environment),
+			URL:               server.URL,
+			Headers:           parseSecrets(server.Headers),
+			Enabled:           server.Enabled,
+			StartupTimeoutSec: (server.Timeout + 999) / 1000,
+		}
+		if len(server.Command) > 0 {
+			coreServer.Command = server.Command[0]
+			coreServer.Args = server.Command[1:]
+		}
+		if server.OAuth != nil && !server.OAuth.Disabled {
+			coreServer.OAuth = &core.OAuth{
+				ClientID: server.OAuth.ClientID,
+				Scopes:   strings.Fields(server.OAuth.Scope),
+			}
+		}
+
+		// Remote servers use Streamable HTTP and fall back to SSE
+		switch server.Type {
+		case TypeLocal:
+			coreServer.Transport = core.TransportStdio
+		case TypeRemote:
+			coreServer.Transport = core.TransportHTTP
+		}
+
+		cfg.Servers[name] = coreServer
+	}
+
+	return cfg
+}
+
+// FromCore converts canonical config to OpenCode format.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	openCfg := NewConfig()
+
+	for name, server := range cfg.Servers {
+		openServer := ServerConfig{
+			Environment: renderSecrets(name, core.FieldEnv, server.Env),
+			URL:         server.URL,
+			Headers:     renderSecrets(name, core.FieldHeaders, server.Headers),
+			Timeout:     server.StartupTimeoutSec * 1000,
+		}
+		if server.Command != "" {
+			openServer.Command = append([]string{server.Command}, server.Args...)
+		}
+		if server.OAuth != nil && (server.OAuth.ClientID != "" || len(server.OAuth.Scopes) > 0) {
+			openServer.OAuth = &OAuthConfig{
+				ClientID: server.OAuth.ClientID,
+				Scope:    strings.Join(server.OAuth.Scopes, " "),
+			}
+		}
+
+		if server.InferTransport() == core.TransportStdio {
+			openServer.Type = TypeLocal
+		} else {
+			openServer.Type = TypeRemote
+		}
+
+		if !server.IsEnabled() {
+			enabled := false
+			openServer.Enabled = &enabled
+		}
+
+		openCfg.MCP[name] = openServer
+	}
+
+	return openCfg
+}
+
+// renderSecrets returns a copy of values with every secret reference
+// written as an OpenCode substitution. Environment and file references
+// keep their source; other sources are read from the variable chosen by
+// core.SecretVar.
+func renderSecrets(server, field string, values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	out := make(map[string]string, len(values))
+	for key, value := range values {
+		out[key] = core.ReplaceSecretRefs(value, func(ref core.SecretRef) string {
+			if ref.Source == core.SecretFile {
+				return "{file:" + ref.Name + "}"
+			}
+			return "{env:" + core.SecretVar(server, field, key, ref) + "}"
+		})
+	}
+	return out
+}
+
+// parseSecrets returns a copy of values with every OpenCode substitution
+// written as a secret reference.
+func parseSecrets(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	out := make(map[string]string, len(values))
+	for key, value := range values {
+		out[key] = substitutionPattern.ReplaceAllStringFunc(value, func(s string) string {
+			m := substitutionPattern.FindStringSubmatch(s)
+			if m[1] == string(core.SecretEnv) {
+				return "${" + m[2] + "}"
+			}
+			return "${" + m[1] + ":" + m[2] + "}"
+		})
+	}
+	return out
+}
+
+// Lossiness reports the canonical fields that the OpenCode format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldURL, core.FieldHeaders, core.FieldEnabled, core.FieldStartupTimeoutSec,
+		core.FieldOAuthClientID, core.FieldOAuthScopes)
+	losses = append(losses, core.DegradedTransports(AdapterName, cfg, core.TransportStdio, core.TransportHTTP)...)
+	losses = append(losses, core.PlaintextSecrets(AdapterName, cfg)...)
+	return append(losses, indirectSecrets(cfg)...)
+}
+
+// indirectSecrets reports the secret references OpenCode cannot read
+// itself, which must be exported as environment variables.
+func indirectSecrets(cfg *core.Config) []core.Loss {
+	var losses []core.Loss
+	names := cfg.ServerNames()
+	sort.Strings(names)
+	for _, name := range names {
+		server := cfg.Servers[name]
+		for _, field := range []string{core.FieldEnv, core.FieldHeaders} {
+			values := server.Env
+			if field == core.FieldHeaders {
+				values = server.Headers
+			}
+			keys := make([]string, 0, len(values))
+			for key := range values {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				for _, ref := range core.SecretRefs(values[key]) {
+					if ref.Source == core.SecretEnv || ref.Source == core.SecretFile {
+						continue
+					}
+					losses = append(losses, core.Loss{
+						Adapter: AdapterName,
+						Path:    "servers." + name + "." + field,
+						Kind:    core.LossDegraded,
+						Detail: fmt.Sprintf("%s: %s is read from environment variable %s",
+							key, ref, core.SecretVar(name, field, key, ref)),
+					})
+				}
+			}
+		}
+	}
+	return losses
+}
+
+// Merge writes cfg into an existing OpenCode config, replacing only the
+// mcp key and keeping all other settings and comments.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "mcp")
+}
+
+// ProjectConfigPath returns the project config path for a given project root.
+func ProjectConfigPath(projectRoot string) string {
+	return filepath.Join(projectRoot, ConfigFileName)
+}
+
+// UserConfigPath returns the user-level config path. OpenCode uses
+// ~/.config on every platform.
+func UserConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "opencode", ConfigFileName), nil
+}
+
+// init registers the adapter with the default registry.
+func init() {
+	core.Register(NewAdapter())
+}
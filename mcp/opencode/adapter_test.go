@@ -0,0 +1,267 @@
+package opencode
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+func TestAdapterName(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter.Name() != "opencode" {
+		t.Errorf("Expected name 'opencode', got %q", adapter.Name())
+	}
+}
+
+func TestAdapterDefaultPaths(t *testing.T) {
+	adapter := NewAdapter()
+	paths := adapter.DefaultPaths()
+
+	if len(paths) == 0 {
+		t.Fatal("Expected at least one default path")
+	}
+	// First path should be project config
+	if paths[0] != ConfigFileName {
+		t.Errorf("Expected project config path first, got %q", paths[0])
+	}
+}
+
+func TestAdapterParse(t *testing.T) {
+	adapter := NewAdapter()
+
+	jsonData := []byte(`{
+		"$schema": "https://opencode.ai/config.json",
+		// Models are not MCP servers
+		"model": "anthropic/claude-sonnet-4-5",
+		"mcp": {
+			"github": {
+				"type": "local",
+				"command": ["npx", "-y", "@modelcontextprotocol/server-github"],
+				"environment": {"GITHUB_TOKEN": "{env:GITHUB_TOKEN}"},
+				"timeout": 10000,
+			},
+			"remote": {
+				"type": "remote",
+				"url": "https://api.example.com/mcp",
+				"headers": {"Authorization": "Bearer {file:~/.secrets/api}"},
+				"oauth": {"clientId": "abc", "scope": "read write"},
+				"enabled": false
+			},
+			"public": {
+				"type": "remote",
+				"url": "https://public.example.com/mcp",
+				"oauth": false
+			}
+		}
+	}`)
+
+	cfg, err := adapter.Parse(jsonData)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(cfg.Servers) != 3 {
+		t.Errorf("Expected 3 servers, got %d", len(cfg.Servers))
+	}
+
+	github, ok := cfg.GetServer("github")
+	if !ok {
+		t.Fatal("github server not found")
+	}
+	if github.Transport != core.TransportStdio {
+		t.Errorf("Expected stdio transport, got %v", github.Transport)
+	}
+	if github.Command != "npx" || len(github.Args) != 2 {
+		t.Errorf("Expected command list to be split, got %q %v", github.Command, github.Args)
+	}
+	if github.Env["GITHUB_TOKEN"] != "${GITHUB_TOKEN}" {
+		t.Errorf("Expected env substitution to be read as ${GITHUB_TOKEN}, got %q", github.Env["GITHUB_TOKEN"])
+	}
+	if github.StartupTimeoutSec != 10 {
+		t.Errorf("Expected startup timeout 10, got %d", github.StartupTimeoutSec)
+	}
+
+	remote, ok := cfg.GetServer("remote")
+	if !ok {
+		t.Fatal("remote server not found")
+	}
+	if remote.Transport != core.TransportHTTP {
+		t.Errorf("Expected http transport, got %v", remote.Transport)
+	}
+	if remote.Headers["Authorization"] != "Bearer ${file:~/.secrets/api}" {
+		t.Errorf("Expected file substitution to be read as a file reference, got %q", remote.Headers["Authorization"])
+	}
+	if remote.OAuth == nil || remote.OAuth.ClientID != "abc" || !reflect.DeepEqual(remote.OAuth.Scopes, []string{"read", "write"}) {
+		t.Errorf("Expected OAuth client and scopes, got %+v", remote.OAuth)
+	}
+	if remote.IsEnabled() {
+		t.Error("Expected remote server to be disabled")
+	}
+
+	public, _ := cfg.GetServer("public")
+	if public.OAuth != nil {
+		t.Errorf("Expected disabled OAuth to be read as no OAuth, got %+v", public.OAuth)
+	}
+}
+
+func TestAdapterMarshal(t *testing.T) {
+	adapter := NewAdapter()
+
+	enabled := false
+	cfg := core.NewConfig()
+	cfg.AddServer("test", core.Server{
+		Command:           "npx",
+		Args:              []string{"-y", "test-server"},
+		Env:               map[string]string{"API_KEY": "${env:API_KEY}", "TOKEN": "${keyring:test/token}"},
+		StartupTimeoutSec: 5,
+		Enabled:           &enabled,
+	})
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, s := range []string{`"$schema": "https://opencode.ai/config.json"`, `"type": "local"`,
+		`"API_KEY": "{env:API_KEY}"`, `"TOKEN": "{env:TOKEN}"`, `"timeout": 5000`, `"enabled": false`} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("Expected %s in:\n%s", s, data)
+		}
+	}
+
+	// Round-trip
+	cfg2, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse after marshal failed: %v", err)
+	}
+	server, ok := cfg2.GetServer("test")
+	if !ok {
+		t.Fatal("test not found after round-trip")
+	}
+	if server.Command != "npx" || !reflect.DeepEqual(server.Args, []string{"-y", "test-server"}) {
+		t.Errorf("Expected command and args to round-trip, got %q %v", server.Command, server.Args)
+	}
+	if server.StartupTimeoutSec != 5 {
+		t.Errorf("Expected startup timeout 5, got %d", server.StartupTimeoutSec)
+	}
+	if server.IsEnabled() {
+		t.Error("Expected server to be disabled")
+	}
+}
+
+func TestAdapterLossiness(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("events", core.Server{
+		Transport: core.TransportSSE,
+		URL:       "https://api.example.com/sse",
+		Headers: map[string]string{
+			"Authorization": "Bearer ${cmd:op read op://vault/api/token}",
+			"X-Key":         "${file:~/.secrets/key}",
+		},
+	})
+
+	var got []string
+	for _, loss := range adapter.Lossiness(cfg) {
+		got = append(got, string(loss.Kind)+" "+loss.Path)
+	}
+	want := []string{"degraded servers.events.transport", "degraded servers.events.headers"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected losses %v, got %v", want, got)
+	}
+}
+
+func TestOAuthConfigDisabled(t *testing.T) {
+	data, err := json.Marshal(ServerConfig{Type: TypeRemote, OAuth: &OAuthConfig{Disabled: true}})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"oauth":false`) {
+		t.Errorf("Expected disabled OAuth to be written as false, got %s", data)
+	}
+
+	var server ServerConfig
+	if err := json.Unmarshal(data, &server); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if server.OAuth == nil || !server.OAuth.Disabled {
+		t.Errorf("Expected disabled OAuth, got %+v", server.OAuth)
+	}
+}
+
+func TestAdapterMerge(t *testing.T) {
+	adapter := NewAdapter()
+
+	existing := []byte(`{
+  // Keep this comment
+  "$schema": "https://opencode.ai/config.json",
+  "model": "anthropic/claude-sonnet-4-5",
+  "mcp": {
+    "stale": {"type": "local", "command": ["stale"]}
+  }
+}
+`)
+
+	cfg := core.NewConfig()
+	cfg.AddServer("github", core.Server{Command: "npx"})
+
+	merged, err := adapter.Merge(cfg, existing)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	for _, s := range []string{"// Keep this comment", `"model": "anthropic/claude-sonnet-4-5"`, `"github"`} {
+		if !strings.Contains(string(merged), s) {
+			t.Errorf("Expected %s in:\n%s", s, merged)
+		}
+	}
+	if strings.Contains(string(merged), "stale") {
+		t.Errorf("Expected stale server to be removed:\n%s", merged)
+	}
+}
+
+func TestAdapterReadWriteFile(t *testing.T) {
+	adapter := NewAdapter()
+	tmpDir := t.TempDir()
+	path := ProjectConfigPath(tmpDir)
+
+	cfg := core.NewConfig()
+	cfg.AddServer("file-test", core.Server{
+		Command: "echo",
+	})
+
+	if err := adapter.WriteFile(cfg, path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	loaded, err := adapter.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	if len(loaded.Servers) != 1 {
+		t.Errorf("Expected 1 server, got %d", len(loaded.Servers))
+	}
+}
+
+func TestAdapterReadFileNotFound(t *testing.T) {
+	adapter := NewAdapter()
+
+	_, err := adapter.ReadFile("/nonexistent/opencode.json")
+	if err == nil {
+		t.Error("Expected error for nonexistent file")
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	cfg := NewConfig()
+
+	if cfg.MCP == nil {
+		t.Error("Expected MCP to be initialized")
+	}
+	if cfg.Schema != SchemaURL {
+		t.Errorf("Expected schema %q, got %q", SchemaURL, cfg.Schema)
+	}
+}
@@ -0,0 +1,93 @@
+package opencode
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Config represents the MCP section of an OpenCode opencode.json file.
+type Config struct {
+	// Schema is the JSON schema of the config file.
+	Schema string `json:"$schema,omitempty"`
+
+	// MCP maps server names to their configurations.
+	MCP map[string]ServerConfig `json:"mcp"`
+}
+
+// OpenCode server types.
+const (
+	TypeLocal  = "local"
+	TypeRemote = "remote"
+)
+
+// ServerConfig represents an OpenCode MCP server.
+type ServerConfig struct {
+	// Type is "local" for servers started as a process and "remote" for
+	// servers reached over HTTP.
+	Type string `json:"type"`
+
+	// --- Local Server Fields ---
+
+	// Command is the executable followed by its arguments.
+	Command []string `json:"command,omitempty"`
+
+	// Environment contains environment variables for the server process.
+	Environment map[string]string `json:"environment,omitempty"`
+
+	// --- Remote Server Fields ---
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// OAuth configures the OAuth client of a remote server.
+	OAuth *OAuthConfig `json:"oauth,omitempty"`
+
+	// Enabled enables or disables the server. Defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Timeout is the timeout for fetching tools from the server in
+	// milliseconds.
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// OAuthConfig is the OAuth client of a remote server in OpenCode's format.
+// It is written as false when OAuth is disabled for the server.
+type OAuthConfig struct {
+	// ClientID is the pre-registered client ID.
+	ClientID string `json:"clientId,omitempty"`
+
+	// ClientSecret is the client secret, if the client requires one.
+	ClientSecret string `json:"clientSecret,omitempty"`
+
+	// Scope is the space-separated list of scopes to request.
+	Scope string `json:"scope,omitempty"`
+
+	// Disabled disables OAuth for the server.
+	Disabled bool `json:"-"`
+}
+
+// MarshalJSON writes a disabled OAuth config as false.
+func (o OAuthConfig) MarshalJSON() ([]byte, error) {
+	if o.Disabled {
+		return []byte("false"), nil
+	}
+	type plain OAuthConfig
+	return json.Marshal(plain(o))
+}
+
+// UnmarshalJSON reads false as a disabled OAuth config.
+func (o *OAuthConfig) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("false")) {
+		*o = OAuthConfig{Disabled: true}
+		return nil
+	}
+	type plain OAuthConfig
+	return json.Unmarshal(data, (*plain)(o))
+}
+
+// NewConfig creates a new OpenCode config.
+func NewConfig() *Config {
+	return &Config{
+		Schema: SchemaURL,
+		MCP:    make(map[string]ServerConfig),
+	}
+}
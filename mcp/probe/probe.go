@@ -0,0 +1,305 @@
+// Package probe checks configured MCP servers by talking to them.
+//
+// Probe starts a stdio server's command, or connects to an HTTP or SSE
+// server's URL, performs the initialize handshake and lists the server's
+// tools, prompts and resources. The report has the server's capabilities
+// and the latency of each step, and flags every name in the server's
+// EnabledTools, DisabledTools and AlwaysAllow that the server does not
+// offer, with the closest tool name as a suggestion:
+//
+//	report := probe.Probe(ctx, "github", server, probe.Options{})
+//	for _, m := range report.Missing {
+//	    fmt.Printf("%s: %s is not a tool", m.Field, m.Tool)
+//	}
+//
+// The server's StartupTimeoutSec bounds starting or connecting and the
+// handshake, and its ToolTimeoutSec bounds each list request.
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/mcp/protocol"
+)
+
+// Default timeouts for servers that do not set StartupTimeoutSec or
+// ToolTimeoutSec.
+const (
+	DefaultStartupTimeout = 10 * time.Second
+	DefaultToolTimeout    = 60 * time.Second
+)
+
+// Options configure a probe.
+type Options struct {
+	// StartupTimeout is the startup timeout for servers that do not set
+	// StartupTimeoutSec. Defaults to DefaultStartupTimeout.
+	StartupTimeout time.Duration
+
+	// ToolTimeout is the timeout of each list request for servers that do
+	// not set ToolTimeoutSec. Defaults to DefaultToolTimeout.
+	ToolTimeout time.Duration
+}
+
+// Latency holds the duration of each step of a probe.
+type Latency struct {
+	// Initialize covers starting or connecting to the server and the
+	// initialize handshake.
+	Initialize time.Duration `json:"initialize"`
+	Tools      time.Duration `json:"tools,omitempty"`
+	Prompts    time.Duration `json:"prompts,omitempty"`
+	Resources  time.Duration `json:"resources,omitempty"`
+}
+
+// Missing is a tool named in a server's configuration that the server does
+// not offer.
+type Missing struct {
+	// Field is the configuration field naming the tool: enabledTools,
+	// disabledTools or alwaysAllow.
+	Field string `json:"field"`
+	Tool  string `json:"tool"`
+
+	// Suggestion is the server's tool with the closest name, if any is
+	// close enough to be a likely typo.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+func (m Missing) String() string {
+	s := fmt.Sprintf("%s: tool %q does not exist on the server", m.Field, m.Tool)
+	if m.Suggestion != "" {
+		s += fmt.Sprintf(" (did you mean %q?)", m.Suggestion)
+	}
+	return s
+}
+
+// Report is the outcome of probing one server.
+type Report struct {
+	Name      string             `json:"name"`
+	Transport core.TransportType `json:"transport"`
+
+	// Skipped is set for disabled servers, which are not probed.
+	Skipped bool `json:"skipped,omitempty"`
+
+	// Server, ProtocolVersion and Capabilities are the server's answer to
+	// initialize.
+	Server          protocol.Implementation     `json:"server"`
+	ProtocolVersion string                      `json:"protocol_version,omitempty"`
+	Capabilities    protocol.ServerCapabilities `json:"capabilities"`
+
+	Latency Latency `json:"latency"`
+
+	// Tools, Prompts and Resources list what the server offers: tool and
+	// prompt names and resource URIs.
+	Tools     []string `json:"tools,omitempty"`
+	Prompts   []string `json:"prompts,omitempty"`
+	Resources []string `json:"resources,omitempty"`
+
+	// Missing lists the configured tool names the server does not offer.
+	Missing []Missing `json:"missing,omitempty"`
+
+	// Warnings are failures of the prompt and resource lists, which do not
+	// fail the probe.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Err is set when the server could not be started, connected to,
+	// initialized or have its tools listed.
+	Err   error  `json:"-"`
+	Error string `json:"error,omitempty"`
+}
+
+// OK reports whether the server was probed without errors and offers every
+// tool its configuration names. Skipped servers are OK.
+func (r *Report) OK() bool {
+	return r.Err == nil && len(r.Missing) == 0
+}
+
+// Probe probes one server.
+func Probe(ctx context.Context, name string, server core.Server, opts Options) *Report {
+	report := &Report{Name: name, Transport: server.InferTransport()}
+	if !server.IsEnabled() {
+		report.Skipped = true
+		return report
+	}
+	report.fail(probe(ctx, server, opts, report))
+	return report
+}
+
+// All probes every server of a configuration, in name order.
+func All(ctx context.Context, cfg *core.Config, opts Options) []*Report {
+	names := cfg.ServerNames()
+	sort.Strings(names)
+	reports := make([]*Report, 0, len(names))
+	for _, name := range names {
+		reports = append(reports, Probe(ctx, name, cfg.Servers[name], opts))
+	}
+	return reports
+}
+
+func (r *Report) fail(err error) {
+	if err != nil {
+		r.Err = err
+		r.Error = err.Error()
+	}
+}
+
+func probe(ctx context.Context, server core.Server, opts Options, report *Report) error {
+	startupTimeout, toolTimeout := Timeouts(server, opts)
+
+	start := time.Now()
+	startupCtx, cancel := context.WithTimeout(ctx, startupTimeout)
+	defer cancel()
+	client, err := protocol.Connect(startupCtx, server)
+	if err != nil {
+		return TimeoutError(err, "startup", startupTimeout)
+	}
+	defer client.Close()
+	result, err := client.Initialize(startupCtx)
+	if err != nil {
+		return TimeoutError(err, "startup", startupTimeout)
+	}
+	report.Latency.Initialize = time.Since(start)
+	report.Server = result.ServerInfo
+	report.ProtocolVersion = result.ProtocolVersion
+	report.Capabilities = result.Capabilities
+
+	list := func(d *time.Duration, fn func(context.Context) ([]string, error)) ([]string, error) {
+		listCtx, cancel := context.WithTimeout(ctx, toolTimeout)
+		defer cancel()
+		start := time.Now()
+		names, err := fn(listCtx)
+		*d = time.Since(start)
+		return names, TimeoutError(err, "tool", toolTimeout)
+	}
+
+	if result.Capabilities.Tools != nil {
+		report.Tools, err = list(&report.Latency.Tools, func(ctx context.Context) ([]string, error) {
+			tools, err := client.ListTools(ctx)
+			names := make([]string, len(tools))
+			for i, tool := range tools {
+				names[i] = tool.Name
+			}
+			return names, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	report.Missing = CheckTools(server, report.Tools)
+
+	if result.Capabilities.Prompts != nil {
+		report.Prompts, err = list(&report.Latency.Prompts, func(ctx context.Context) ([]string, error) {
+			prompts, err := client.ListPrompts(ctx)
+			names := make([]string, len(prompts))
+			for i, prompt := range prompts {
+				names[i] = prompt.Name
+			}
+			return names, err
+		})
+		if err != nil {
+			report.Warnings = append(report.Warnings, err.Error())
+		}
+	}
+	if result.Capabilities.Resources != nil {
+		report.Resources, err = list(&report.Latency.Resources, func(ctx context.Context) ([]string, error) {
+			resources, err := client.ListResources(ctx)
+			uris := make([]string, len(resources))
+			for i, resource := range resources {
+				uris[i] = resource.URI
+			}
+			return uris, err
+		})
+		if err != nil {
+			report.Warnings = append(report.Warnings, err.Error())
+		}
+	}
+	return nil
+}
+
+// CheckTools returns the names in the server's EnabledTools, DisabledTools
+// and AlwaysAllow that are not among tools.
+func CheckTools(server core.Server, tools []string) []Missing {
+	offered := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		offered[tool] = true
+	}
+	var missing []Missing
+	check := func(field string, names []string) {
+		for _, name := range names {
+			if !offered[name] {
+				missing = append(missing, Missing{Field: field, Tool: name, Suggestion: suggest(name, tools)})
+			}
+		}
+	}
+	check("enabledTools", server.EnabledTools)
+	check("disabledTools", server.DisabledTools)
+	check("alwaysAllow", server.AlwaysAllow)
+	return missing
+}
+
+// suggest returns the tool closest to name, if the edit distance is small
+// enough for name to be a typo of it.
+func suggest(name string, tools []string) string {
+	sorted := append([]string(nil), tools...)
+	sort.Strings(sorted)
+	best, bestDist := "", len(name)/3+1
+	for _, tool := range sorted {
+		if d := distance(name, tool); d <= bestDist && (best == "" || d < distance(name, best)) {
+			best = tool
+		}
+	}
+	return best
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// Timeouts returns the startup and tool timeouts for server: its own
+// StartupTimeoutSec and ToolTimeoutSec if set, else those in opts, else the
+// defaults.
+func Timeouts(server core.Server, opts Options) (startup, tool time.Duration) {
+	return seconds(server.StartupTimeoutSec, opts.StartupTimeout, DefaultStartupTimeout),
+		seconds(server.ToolTimeoutSec, opts.ToolTimeout, DefaultToolTimeout)
+}
+
+// seconds returns the configured timeout in seconds if set, else fallback,
+// else def.
+func seconds(configured int, fallback, def time.Duration) time.Duration {
+	if configured > 0 {
+		return time.Duration(configured) * time.Second
+	}
+	if fallback > 0 {
+		return fallback
+	}
+	return def
+}
+
+// TimeoutError names the timeout that expired in err, such as "startup" or
+// "tool". Other errors are returned unchanged.
+func TimeoutError(err error, kind string, timeout time.Duration) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%s timeout of %s exceeded: %w", kind, timeout, err)
+	}
+	return err
+}
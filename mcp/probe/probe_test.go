@@ -0,0 +1,145 @@
+package probe
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/mcp/mcptest"
+)
+
+func TestMain(m *testing.M) {
+	mcptest.Main()
+	os.Exit(m.Run())
+}
+
+var fake = mcptest.Options{
+	Tools:     []string{"create_issue", "list_issues", "search_code"},
+	Prompts:   []string{"triage"},
+	Resources: []string{"readme"},
+}
+
+func TestProbeTransports(t *testing.T) {
+	servers := map[string]core.Server{
+		"stdio": mcptest.Stdio(t, fake),
+		"http":  mcptest.HTTP(t, fake),
+		"sse":   mcptest.SSE(t, fake),
+	}
+	for name, server := range servers {
+		t.Run(name, func(t *testing.T) {
+			report := Probe(context.Background(), name, server, Options{})
+			if report.Err != nil {
+				t.Fatalf("Probe failed: %v", report.Err)
+			}
+			if string(report.Transport) != name {
+				t.Errorf("Expected transport %s, got %s", name, report.Transport)
+			}
+			if report.Server.Name != "mcptest" || report.ProtocolVersion == "" {
+				t.Errorf("Expected server info from initialize, got %+v %q", report.Server, report.ProtocolVersion)
+			}
+			if report.Capabilities.Tools == nil || report.Capabilities.Prompts == nil || report.Capabilities.Resources == nil {
+				t.Errorf("Expected tools, prompts and resources capabilities, got %+v", report.Capabilities)
+			}
+			if len(report.Tools) != 3 || len(report.Prompts) != 1 || report.Resources[0] != mcptest.URI("readme") {
+				t.Errorf("Unexpected lists: %v %v %v", report.Tools, report.Prompts, report.Resources)
+			}
+			if report.Latency.Initialize <= 0 || report.Latency.Tools <= 0 {
+				t.Errorf("Expected latencies, got %+v", report.Latency)
+			}
+			if !report.OK() {
+				t.Errorf("Expected OK, got %+v", report)
+			}
+		})
+	}
+}
+
+func TestProbeMissingTools(t *testing.T) {
+	server := mcptest.HTTP(t, fake)
+	server.EnabledTools = []string{"create_issue", "list_isues"}
+	server.DisabledTools = []string{"delete_repo"}
+	server.AlwaysAllow = []string{"search_code"}
+
+	report := Probe(context.Background(), "github", server, Options{})
+	if report.Err != nil {
+		t.Fatalf("Probe failed: %v", report.Err)
+	}
+	expected := []Missing{
+		{Field: "enabledTools", Tool: "list_isues", Suggestion: "list_issues"},
+		{Field: "disabledTools", Tool: "delete_repo"},
+	}
+	if len(report.Missing) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, report.Missing)
+	}
+	for i := range expected {
+		if report.Missing[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected[i], report.Missing[i])
+		}
+	}
+	if report.OK() {
+		t.Error("Expected a report with missing tools not to be OK")
+	}
+	if s := report.Missing[0].String(); !strings.Contains(s, `did you mean "list_issues"`) {
+		t.Errorf("Expected a suggestion, got %s", s)
+	}
+}
+
+func TestProbeTimeouts(t *testing.T) {
+	slowStart := mcptest.Stdio(t, mcptest.Options{Tools: []string{}, StartupDelay: 5 * time.Second})
+	slowStart.StartupTimeoutSec = 1
+	report := Probe(context.Background(), "slow", slowStart, Options{})
+	if report.Err == nil || !strings.Contains(report.Error, "startup timeout of 1s exceeded") {
+		t.Errorf("Expected startup timeout, got %v", report.Err)
+	}
+
+	slowList := mcptest.HTTP(t, mcptest.Options{Tools: []string{"a"}, ListDelay: 5 * time.Second})
+	report = Probe(context.Background(), "slow", slowList, Options{ToolTimeout: 50 * time.Millisecond})
+	if report.Err == nil || !strings.Contains(report.Error, "tool timeout of 50ms exceeded") {
+		t.Errorf("Expected tool timeout, got %v", report.Err)
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	startup, tool := Timeouts(core.Server{}, Options{})
+	if startup != DefaultStartupTimeout || tool != DefaultToolTimeout {
+		t.Errorf("Expected default timeouts, got %s and %s", startup, tool)
+	}
+
+	startup, tool = Timeouts(core.Server{StartupTimeoutSec: 3}, Options{StartupTimeout: time.Second, ToolTimeout: 2 * time.Second})
+	if startup != 3*time.Second || tool != 2*time.Second {
+		t.Errorf("Expected 3s and 2s, got %s and %s", startup, tool)
+	}
+}
+
+func TestProbeErrors(t *testing.T) {
+	report := Probe(context.Background(), "missing", core.Server{Command: "assistantkit-no-such-server"}, Options{})
+	if report.Err == nil {
+		t.Error("Expected error for a command that does not exist")
+	}
+
+	// A server without the tools capability offers no tools at all.
+	server := mcptest.HTTP(t, mcptest.Options{Prompts: []string{"triage"}})
+	server.AlwaysAllow = []string{"search"}
+	report = Probe(context.Background(), "prompts-only", server, Options{})
+	if report.Err != nil || len(report.Missing) != 1 {
+		t.Errorf("Expected one missing tool, got %v, %v", report.Err, report.Missing)
+	}
+
+	disabled := mcptest.HTTP(t, fake)
+	disabled.SetEnabled(false)
+	if report := Probe(context.Background(), "off", disabled, Options{}); !report.Skipped || !report.OK() {
+		t.Errorf("Expected disabled server to be skipped, got %+v", report)
+	}
+}
+
+func TestAll(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddServer("b", mcptest.HTTP(t, fake))
+	cfg.AddServer("a", mcptest.HTTP(t, mcptest.Options{Tools: []string{}}))
+	reports := All(context.Background(), cfg, Options{})
+	if len(reports) != 2 || reports[0].Name != "a" || reports[1].Name != "b" {
+		t.Errorf("Expected reports in name order, got %v", reports)
+	}
+}
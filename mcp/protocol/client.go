@@ -0,0 +1,197 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/agentplexus/assistantkit"
+)
+
+// ClientInfo identifies assistantkit to servers.
+var ClientInfo = Implementation{Name: "assistantkit", Version: assistantkit.Version}
+
+// Client is an MCP client.
+type Client struct {
+	transport Transport
+	nextID    atomic.Int64
+}
+
+// NewClient returns a client that talks over t.
+func NewClient(t Transport) *Client {
+	return &Client{transport: t}
+}
+
+// Close closes the client's transport.
+func (c *Client) Close() error {
+	return c.transport.Close()
+}
+
+// Initialize performs the initialize handshake and sends the initialized
+// notification.
+func (c *Client) Initialize(ctx context.Context) (*InitializeResult, error) {
+	var result InitializeResult
+	params := InitializeParams{ProtocolVersion: Version, ClientInfo: ClientInfo}
+	if err := c.Call(ctx, MethodInitialize, params, &result); err != nil {
+		return nil, err
+	}
+	if err := c.Notify(ctx, MethodInitialized, nil); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Ping checks that the server is responsive.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.Call(ctx, MethodPing, nil, nil)
+}
+
+// ListTools returns all tools of the server, following pagination.
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	var tools []Tool
+	err := c.list(ctx, MethodToolsList, func(raw json.RawMessage) (string, error) {
+		var page ListToolsResult
+		err := json.Unmarshal(raw, &page)
+		tools = append(tools, page.Tools...)
+		return page.NextCursor, err
+	})
+	return tools, err
+}
+
+// ListPrompts returns all prompts of the server, following pagination.
+func (c *Client) ListPrompts(ctx context.Context) ([]Prompt, error) {
+	var prompts []Prompt
+	err := c.list(ctx, MethodPromptsList, func(raw json.RawMessage) (string, error) {
+		var page ListPromptsResult
+		err := json.Unmarshal(raw, &page)
+		prompts = append(prompts, page.Prompts...)
+		return page.NextCursor, err
+	})
+	return prompts, err
+}
+
+// ListResources returns all resources of the server, following pagination.
+func (c *Client) ListResources(ctx context.Context) ([]Resource, error) {
+	var resources []Resource
+	err := c.list(ctx, MethodResourcesList, func(raw json.RawMessage) (string, error) {
+		var page ListResourcesResult
+		err := json.Unmarshal(raw, &page)
+		resources = append(resources, page.Resources...)
+		return page.NextCursor, err
+	})
+	return resources, err
+}
+
+// CallTool calls a tool.
+func (c *Client) CallTool(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+	var result CallToolResult
+	if err := c.Call(ctx, MethodToolsCall, params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPrompt renders a prompt.
+func (c *Client) GetPrompt(ctx context.Context, params GetPromptParams) (*GetPromptResult, error) {
+	var result GetPromptResult
+	if err := c.Call(ctx, MethodPromptsGet, params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ReadResource reads a resource.
+func (c *Client) ReadResource(ctx context.Context, params ReadResourceParams) (*ReadResourceResult, error) {
+	var result ReadResourceResult
+	if err := c.Call(ctx, MethodResourcesRead, params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// maxPages bounds pagination against servers that keep returning cursors.
+const maxPages = 100
+
+// list calls a list method until the server returns no cursor.
+func (c *Client) list(ctx context.Context, method string, page func(json.RawMessage) (string, error)) error {
+	var cursor string
+	for i := 0; i < maxPages; i++ {
+		var raw json.RawMessage
+		if err := c.Call(ctx, method, ListParams{Cursor: cursor}, &raw); err != nil {
+			return err
+		}
+		next, err := page(raw)
+		if err != nil {
+			return fmt.Errorf("decoding %s result: %w", method, err)
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+	return fmt.Errorf("%s: more than %d pages", method, maxPages)
+}
+
+// Call sends a request and decodes its result into result, which may be
+// nil. A JSON-RPC error response is returned as an *Error.
+func (c *Client) Call(ctx context.Context, method string, params, result any) error {
+	msg, err := newMessage(method, params)
+	if err != nil {
+		return err
+	}
+	msg.ID = json.RawMessage(strconv.FormatInt(c.nextID.Add(1), 10))
+	resp, err := c.transport.RoundTrip(ctx, msg)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("%s: %w", method, resp.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	if raw, ok := result.(*json.RawMessage); ok {
+		*raw = resp.Result
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("decoding %s result: %w", method, err)
+	}
+	return nil
+}
+
+// Notify sends a notification.
+func (c *Client) Notify(ctx context.Context, method string, params any) error {
+	msg, err := newMessage(method, params)
+	if err != nil {
+		return err
+	}
+	if _, err := c.transport.RoundTrip(ctx, msg); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	return nil
+}
+
+func newMessage(method string, params any) (*Message, error) {
+	msg := &Message{JSONRPC: "2.0", Method: method}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		msg.Params = data
+	}
+	return msg, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
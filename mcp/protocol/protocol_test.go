@@ -0,0 +1,259 @@
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+func testServer() *Server {
+	return &Server{
+		Info: Implementation{Name: "test", Version: "1.0.0"},
+		ListTools: func(ctx context.Context) ([]Tool, error) {
+			return []Tool{{Name: "echo"}}, nil
+		},
+		CallTool: func(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+			if params.Name != "echo" {
+				return nil, InvalidParams("unknown tool: %s", params.Name)
+			}
+			return &CallToolResult{Content: []Content{TextContent(fmt.Sprint(params.Arguments["text"]))}}, nil
+		},
+		ListPrompts: func(ctx context.Context) ([]Prompt, error) {
+			return []Prompt{{Name: "greet", Arguments: []PromptArgument{{Name: "name", Required: true}}}}, nil
+		},
+		GetPrompt: func(ctx context.Context, params GetPromptParams) (*GetPromptResult, error) {
+			return &GetPromptResult{Messages: []PromptMessage{{Role: "user", Content: TextContent("Hello " + params.Arguments["name"])}}}, nil
+		},
+	}
+}
+
+func TestServerHandle(t *testing.T) {
+	s := testServer()
+	ctx := context.Background()
+
+	resp := s.Handle(ctx, &Message{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: MethodInitialize,
+		Params: json.RawMessage(`{"protocolVersion":"2024-11-05","capabilities":{},"clientInfo":{"name":"c"}}`)})
+	var init InitializeResult
+	if err := json.Unmarshal(resp.Result, &init); err != nil {
+		t.Fatalf("Decoding initialize result failed: %v", err)
+	}
+	if init.ProtocolVersion != "2024-11-05" {
+		t.Errorf("Expected the client's supported version, got %s", init.ProtocolVersion)
+	}
+	if init.Capabilities.Tools == nil || init.Capabilities.Prompts == nil || init.Capabilities.Resources != nil {
+		t.Errorf("Expected tools and prompts capabilities only, got %+v", init.Capabilities)
+	}
+
+	resp = s.Handle(ctx, &Message{JSONRPC: "2.0", ID: json.RawMessage("2"), Method: MethodInitialize,
+		Params: json.RawMessage(`{"protocolVersion":"1999-01-01"}`)})
+	if err := json.Unmarshal(resp.Result, &init); err != nil || init.ProtocolVersion != Version {
+		t.Errorf("Expected %s for an unsupported version, got %s", Version, init.ProtocolVersion)
+	}
+
+	resp = s.Handle(ctx, &Message{JSONRPC: "2.0", ID: json.RawMessage(`"x"`), Method: MethodResourcesList})
+	if resp.Error == nil || resp.Error.Code != CodeMethodNotFound || string(resp.ID) != `"x"` {
+		t.Errorf("Expected method not found for resources/list, got %+v", resp)
+	}
+
+	if resp := s.Handle(ctx, &Message{JSONRPC: "2.0", Method: MethodInitialized}); resp != nil {
+		t.Errorf("Expected no response to a notification, got %+v", resp)
+	}
+}
+
+func TestServeStdio(t *testing.T) {
+	in := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`not json`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`,
+	}, "\n") + "\n"
+	var out bytes.Buffer
+	if err := testServer().ServeStdio(context.Background(), strings.NewReader(in), &out); err != nil {
+		t.Fatalf("ServeStdio failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 responses, got %d:\n%s", len(lines), out.String())
+	}
+	if !strings.Contains(lines[0], `"name":"echo"`) || !strings.Contains(lines[1], `"code":-32700`) || !strings.Contains(lines[2], `"text":"hi"`) {
+		t.Errorf("Unexpected responses:\n%s", out.String())
+	}
+}
+
+func TestClient(t *testing.T) {
+	s := testServer()
+	servers := map[string]core.Server{}
+
+	streamable := httptest.NewServer(s)
+	defer streamable.Close()
+	servers["http"] = core.Server{Transport: core.TransportHTTP, URL: streamable.URL}
+
+	sse := httptest.NewServer(s.SSEHandler())
+	defer func() {
+		sse.CloseClientConnections()
+		sse.Close()
+	}()
+	servers["sse"] = core.Server{Transport: core.TransportSSE, URL: sse.URL + "/sse"}
+
+	for name, server := range servers {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			client, err := Connect(ctx, server)
+			if err != nil {
+				t.Fatalf("Connect failed: %v", err)
+			}
+			defer client.Close()
+
+			if _, err := client.Initialize(ctx); err != nil {
+				t.Fatalf("Initialize failed: %v", err)
+			}
+			if err := client.Ping(ctx); err != nil {
+				t.Errorf("Ping failed: %v", err)
+			}
+			result, err := client.CallTool(ctx, CallToolParams{Name: "echo", Arguments: map[string]any{"text": "hi"}})
+			if err != nil || result.Content[0].Text != "hi" {
+				t.Errorf("Expected echo, got %+v, %v", result, err)
+			}
+			prompt, err := client.GetPrompt(ctx, GetPromptParams{Name: "greet", Arguments: map[string]string{"name": "Ada"}})
+			if err != nil || prompt.Messages[0].Content.Text != "Hello Ada" {
+				t.Errorf("Expected greeting, got %+v, %v", prompt, err)
+			}
+
+			_, err = client.CallTool(ctx, CallToolParams{Name: "nope"})
+			var rpcErr *Error
+			if !errors.As(err, &rpcErr) || rpcErr.Code != CodeInvalidParams {
+				t.Errorf("Expected invalid params error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestServerRejectsForeignRequests(t *testing.T) {
+	s := testServer()
+	s.Addr = "localhost:8080"
+	ping := `{"jsonrpc":"2.0","id":1,"method":"ping"}`
+
+	tests := []struct {
+		name    string
+		host    string
+		origin  string
+		allowed bool
+	}{
+		{"no origin", "localhost:8080", "", true},
+		{"loopback origin", "127.0.0.1:8080", "http://localhost:3000", true},
+		{"foreign origin", "localhost:8080", "http://evil.example", false},
+		{"null origin", "localhost:8080", "null", false},
+		{"rebound host", "evil.example:8080", "", false},
+		{"other port", "localhost:9090", "", false},
+	}
+
+	// Allowed posts get a response over HTTP; over SSE they name no
+	// session, so the handler does not know them.
+	handlers := []struct {
+		transport string
+		handler   http.Handler
+		allowed   int
+	}{
+		{"http", s, http.StatusOK},
+		{"sse", s.SSEHandler(), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		for _, h := range handlers {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(ping))
+			req.Host = tt.host
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			h.handler.ServeHTTP(rec, req)
+
+			want := http.StatusForbidden
+			if tt.allowed {
+				want = h.allowed
+			}
+			if rec.Code != want {
+				t.Errorf("%s over %s: expected status %d, got %d", tt.name, h.transport, want, rec.Code)
+			}
+		}
+	}
+}
+
+func TestSSEPostAfterStreamCloses(t *testing.T) {
+	h := testServer().SSEHandler().(*sseHandler)
+	// An unbuffered channel that nothing reads stands in for a full buffer.
+	session := &sseSession{out: make(chan *Message), done: make(chan struct{})}
+	h.sessions["closed"] = session
+	close(session.done)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		body := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`)
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/sse?session=closed", body))
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected post to return once the stream is done")
+	}
+}
+
+func TestClientPaginationAndEventStream(t *testing.T) {
+	// A streamable HTTP server may answer with an event stream, and list
+	// results may be paginated.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg Message
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		var params ListParams
+		_ = json.Unmarshal(msg.Params, &params)
+		result := `{"tools":[{"name":"a"}],"nextCursor":"2"}`
+		if params.Cursor == "2" {
+			result = `{"tools":[{"name":"b"}]}`
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set(HeaderSessionID, "s1")
+		fmt.Fprintf(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n")
+		fmt.Fprintf(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":%s}\n\n", msg.ID, result)
+	})
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	client, err := Connect(context.Background(), core.Server{URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	tools, err := client.ListTools(context.Background())
+	if err != nil || len(tools) != 2 || tools[1].Name != "b" {
+		t.Errorf("Expected two pages of tools, got %v, %v", tools, err)
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	t.Setenv("PROBE_TOKEN", "secret")
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := ResolveSecrets("Bearer ${env:PROBE_TOKEN}"); err != nil || got != "Bearer secret" {
+		t.Errorf("Expected Bearer secret, got %q, %v", got, err)
+	}
+	if got, err := ResolveSecrets("${file:" + path + "}"); err != nil || got != "from-file" {
+		t.Errorf("Expected from-file, got %q, %v", got, err)
+	}
+	if _, err := ResolveSecrets("${keyring:github/token}"); err == nil {
+		t.Error("Expected error for a keyring secret")
+	}
+}
@@ -0,0 +1,374 @@
+package protocol
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Server serves tools, prompts and resources. The capabilities it announces
+// follow from the handlers that are set: a server with ListTools offers
+// tools, and so on.
+type Server struct {
+	// Info identifies the server.
+	Info Implementation
+
+	// Instructions are returned to clients on initialize.
+	Instructions string
+
+	// Addr is the address the HTTP transports listen on. Requests whose
+	// Host is neither Addr nor a loopback host on its port are rejected.
+	// If empty, any loopback host is accepted.
+	Addr string
+
+	ListTools     func(ctx context.Context) ([]Tool, error)
+	CallTool      func(ctx context.Context, params CallToolParams) (*CallToolResult, error)
+	ListPrompts   func(ctx context.Context) ([]Prompt, error)
+	GetPrompt     func(ctx context.Context, params GetPromptParams) (*GetPromptResult, error)
+	ListResources func(ctx context.Context) ([]Resource, error)
+	ReadResource  func(ctx context.Context, params ReadResourceParams) (*ReadResourceResult, error)
+}
+
+// Capabilities returns the capabilities the server announces.
+func (s *Server) Capabilities() ServerCapabilities {
+	var caps ServerCapabilities
+	if s.ListTools != nil {
+		caps.Tools = &ListCapability{}
+	}
+	if s.ListPrompts != nil {
+		caps.Prompts = &ListCapability{}
+	}
+	if s.ListResources != nil {
+		caps.Resources = &ListCapability{}
+	}
+	return caps
+}
+
+// Handle handles one message and returns the response, or nil for
+// notifications and responses.
+func (s *Server) Handle(ctx context.Context, msg *Message) *Message {
+	if !msg.IsRequest() {
+		return nil
+	}
+	result, err := s.dispatch(ctx, msg)
+	resp := &Message{JSONRPC: "2.0", ID: msg.ID}
+	if err != nil {
+		rpcErr, ok := err.(*Error)
+		if !ok {
+			rpcErr = &Error{Code: CodeInternalError, Message: err.Error()}
+		}
+		resp.Error = rpcErr
+		return resp
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		resp.Error = &Error{Code: CodeInternalError, Message: err.Error()}
+		return resp
+	}
+	resp.Result = data
+	return resp
+}
+
+func (s *Server) dispatch(ctx context.Context, msg *Message) (any, error) {
+	switch msg.Method {
+	case MethodInitialize:
+		var params InitializeParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		version := Version
+		if slices.Contains(SupportedVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		return &InitializeResult{
+			ProtocolVersion: version,
+			Capabilities:    s.Capabilities(),
+			ServerInfo:      s.Info,
+			Instructions:    s.Instructions,
+		}, nil
+	case MethodPing:
+		return struct{}{}, nil
+	case MethodToolsList:
+		if s.ListTools != nil {
+			tools, err := s.ListTools(ctx)
+			if tools == nil {
+				tools = []Tool{}
+			}
+			return &ListToolsResult{Tools: tools}, err
+		}
+	case MethodToolsCall:
+		if s.CallTool != nil {
+			var params CallToolParams
+			if err := decodeParams(msg, &params); err != nil {
+				return nil, err
+			}
+			return s.CallTool(ctx, params)
+		}
+	case MethodPromptsList:
+		if s.ListPrompts != nil {
+			prompts, err := s.ListPrompts(ctx)
+			if prompts == nil {
+				prompts = []Prompt{}
+			}
+			return &ListPromptsResult{Prompts: prompts}, err
+		}
+	case MethodPromptsGet:
+		if s.GetPrompt != nil {
+			var params GetPromptParams
+			if err := decodeParams(msg, &params); err != nil {
+				return nil, err
+			}
+			return s.GetPrompt(ctx, params)
+		}
+	case MethodResourcesList:
+		if s.ListResources != nil {
+			resources, err := s.ListResources(ctx)
+			if resources == nil {
+				resources = []Resource{}
+			}
+			return &ListResourcesResult{Resources: resources}, err
+		}
+	case MethodResourcesRead:
+		if s.ReadResource != nil {
+			var params ReadResourceParams
+			if err := decodeParams(msg, &params); err != nil {
+				return nil, err
+			}
+			return s.ReadResource(ctx, params)
+		}
+	}
+	return nil, &Error{Code: CodeMethodNotFound, Message: "method not found: " + msg.Method}
+}
+
+func decodeParams(msg *Message, v any) error {
+	if len(msg.Params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(msg.Params, v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+// InvalidParams returns an invalid params error, for handlers to report
+// unknown tools, prompts or resources and missing arguments.
+func InvalidParams(format string, args ...any) error {
+	return &Error{Code: CodeInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+// ServeStdio serves newline-delimited messages read from r, writing the
+// responses to w, until r ends or ctx is done.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	enc := json.NewEncoder(w)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			resp := &Message{JSONRPC: "2.0", ID: json.RawMessage("null"),
+				Error: &Error{Code: CodeParseError, Message: err.Error()}}
+			if err := enc.Encode(resp); err != nil {
+				return err
+			}
+			continue
+		}
+		if resp := s.Handle(ctx, &msg); resp != nil {
+			if err := enc.Encode(resp); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// ServeHTTP serves the streamable HTTP transport: each POST carries one
+// message and gets its response as JSON. Sessions are not tracked, and
+// the server does not open event streams.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.allowRequest(w, r) {
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+	case http.MethodDelete:
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var msg Message
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		writeJSON(w, &Message{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: &Error{Code: CodeParseError, Message: err.Error()}})
+		return
+	}
+	resp := s.Handle(r.Context(), &msg)
+	if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	writeJSON(w, resp)
+}
+
+// allowRequest rejects, with 403 Forbidden, requests from a non-loopback
+// Origin and requests for a Host other than the one served. The HTTP
+// transports are meant for local clients, and these checks keep web pages
+// from reaching them through DNS rebinding.
+func (s *Server) allowRequest(w http.ResponseWriter, r *http.Request) bool {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		u, err := url.Parse(origin)
+		if err != nil || u.Host == "" || !isLoopback(u.Hostname()) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return false
+		}
+	}
+	if !s.allowHost(r.Host) {
+		http.Error(w, "host not allowed", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// allowHost reports whether host is Addr, or a loopback host on the port of
+// Addr (on any port if Addr is empty).
+func (s *Server) allowHost(host string) bool {
+	if s.Addr != "" && strings.EqualFold(host, s.Addr) {
+		return true
+	}
+	name, port, err := net.SplitHostPort(host)
+	if err != nil {
+		name, port = host, ""
+	}
+	if s.Addr != "" {
+		_, addrPort, err := net.SplitHostPort(s.Addr)
+		if err != nil || port != addrPort {
+			return false
+		}
+	}
+	return isLoopback(name)
+}
+
+// isLoopback reports whether host is localhost or a loopback IP address.
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+func writeJSON(w http.ResponseWriter, msg *Message) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(msg)
+}
+
+// SSEHandler returns a handler for the legacy HTTP+SSE transport: a GET
+// opens an event stream and announces the endpoint that messages are
+// posted to, and responses are sent on the stream.
+func (s *Server) SSEHandler() http.Handler {
+	return &sseHandler{server: s, sessions: make(map[string]*sseSession)}
+}
+
+type sseHandler struct {
+	server *Server
+
+	mu       sync.Mutex
+	sessions map[string]*sseSession
+}
+
+// sseSession is an open event stream. Done is closed when the stream ends,
+// so that posts no longer wait to send on out.
+type sseSession struct {
+	out  chan *Message
+	done chan struct{}
+}
+
+func (h *sseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.server.allowRequest(w, r) {
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		h.stream(w, r)
+	case http.MethodPost:
+		h.post(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *sseHandler) stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	id := hex.EncodeToString(b)
+	session := &sseSession{out: make(chan *Message, 16), done: make(chan struct{})}
+	h.mu.Lock()
+	h.sessions[id] = session
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.sessions, id)
+		h.mu.Unlock()
+		close(session.done)
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprintf(w, "event: endpoint\ndata: %s?session=%s\n\n", r.URL.Path, id)
+	flusher.Flush()
+	for {
+		select {
+		case msg := <-session.out:
+			data, _ := json.Marshal(msg)
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (h *sseHandler) post(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	session, ok := h.sessions[r.URL.Query().Get("session")]
+	h.mu.Unlock()
+	if !ok {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+	var msg Message
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	if resp := h.server.Handle(r.Context(), &msg); resp != nil {
+		select {
+		case session.out <- resp:
+		case <-session.done:
+		case <-r.Context().Done():
+		}
+	}
+}
@@ -0,0 +1,536 @@
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+// ErrClosed is returned by transports that were closed, or whose server
+// exited or hung up.
+var ErrClosed = errors.New("mcp: connection closed")
+
+// Transport carries JSON-RPC messages between a client and a server.
+type Transport interface {
+	// RoundTrip sends a message. For requests it waits for the response
+	// with the same ID; for notifications it returns nil.
+	RoundTrip(ctx context.Context, msg *Message) (*Message, error)
+
+	// Close closes the connection, stopping the server process of a stdio
+	// transport.
+	Close() error
+}
+
+// Connect returns a client for a configured server, choosing the transport
+// from the server's configuration. Secret references in Env and Headers are
+// resolved from the environment and from files; other secret sources cannot
+// be resolved and are an error. Stdio servers are started immediately.
+func Connect(ctx context.Context, server core.Server) (*Client, error) {
+	if err := server.Validate(); err != nil {
+		return nil, err
+	}
+	var transport Transport
+	var err error
+	switch server.InferTransport() {
+	case core.TransportStdio:
+		transport, err = NewStdioTransport(server)
+	case core.TransportHTTP:
+		transport, err = NewHTTPTransport(server, nil)
+	case core.TransportSSE:
+		transport, err = NewSSETransport(ctx, server, nil)
+	default:
+		err = fmt.Errorf("%w: %q", core.ErrInvalidTransport, server.Transport)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(transport), nil
+}
+
+// ResolveSecrets returns value with its ${env:...} and ${file:...} secret
+// references replaced by their values.
+func ResolveSecrets(value string) (string, error) {
+	var errs []error
+	resolved := core.ReplaceSecretRefs(value, func(ref core.SecretRef) string {
+		switch ref.Source {
+		case core.SecretEnv:
+			return os.Getenv(ref.Name)
+		case core.SecretFile:
+			path := ref.Name
+			if strings.HasPrefix(path, "~/") {
+				if home, err := os.UserHomeDir(); err == nil {
+					path = filepath.Join(home, path[2:])
+				}
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("reading %s: %w", ref, err))
+				return ""
+			}
+			return strings.TrimSpace(string(data))
+		default:
+			errs = append(errs, fmt.Errorf("secret %s cannot be resolved", ref))
+			return ""
+		}
+	})
+	return resolved, errors.Join(errs...)
+}
+
+// stdioTransport runs a server process and exchanges newline-delimited
+// messages over its stdin and stdout.
+type stdioTransport struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	writeMu sync.Mutex
+	pending pendingResponses
+	done    chan struct{}
+	stderr  lockedBuffer
+}
+
+// NewStdioTransport starts the server's command with its Args, Env and Cwd.
+// The server's stderr is kept and included in errors when it exits.
+func NewStdioTransport(server core.Server) (Transport, error) {
+	cmd := exec.Command(server.Command, server.Args...)
+	cmd.Dir = server.Cwd
+	cmd.Env = os.Environ()
+	for _, key := range sortedKeys(server.Env) {
+		value, err := ResolveSecrets(server.Env[key])
+		if err != nil {
+			return nil, fmt.Errorf("env %s: %w", key, err)
+		}
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	t := &stdioTransport{cmd: cmd, done: make(chan struct{})}
+	t.pending.init()
+	cmd.Stderr = &t.stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting %s: %w", server.Command, err)
+	}
+	t.stdin = stdin
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			var msg Message
+			if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+				continue
+			}
+			t.pending.deliver(&msg)
+		}
+		_ = cmd.Wait()
+		close(t.done)
+	}()
+	return t, nil
+}
+
+func (t *stdioTransport) RoundTrip(ctx context.Context, msg *Message) (*Message, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var wait <-chan *Message
+	if msg.IsRequest() {
+		wait = t.pending.add(msg.ID)
+		defer t.pending.remove(msg.ID)
+	}
+
+	t.writeMu.Lock()
+	_, err = t.stdin.Write(append(data, '\n'))
+	t.writeMu.Unlock()
+	if err != nil {
+		return nil, t.exitError(ErrClosed)
+	}
+	if wait == nil {
+		return nil, nil
+	}
+
+	select {
+	case resp := <-wait:
+		return resp, nil
+	case <-t.done:
+		return nil, t.exitError(ErrClosed)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// exitError adds the server's stderr to err.
+func (t *stdioTransport) exitError(err error) error {
+	if stderr := strings.TrimSpace(t.stderr.String()); stderr != "" {
+		return fmt.Errorf("%w: %s", err, stderr)
+	}
+	return err
+}
+
+func (t *stdioTransport) Close() error {
+	_ = t.stdin.Close()
+	select {
+	case <-t.done:
+	default:
+		if t.cmd.Process != nil {
+			_ = t.cmd.Process.Kill()
+		}
+		<-t.done
+	}
+	return nil
+}
+
+// HeaderSessionID is the header carrying the session ID of the streamable
+// HTTP transport.
+const HeaderSessionID = "Mcp-Session-Id"
+
+// httpTransport posts each message to the server URL and reads the
+// response as JSON or as an event stream (streamable HTTP).
+type httpTransport struct {
+	url     string
+	headers http.Header
+	client  *http.Client
+
+	mu        sync.Mutex
+	sessionID string
+}
+
+// NewHTTPTransport returns a streamable HTTP transport for the server's URL,
+// sending its Headers and the bearer token from BearerTokenEnvVar. A nil
+// client uses http.DefaultClient.
+func NewHTTPTransport(server core.Server, client *http.Client) (Transport, error) {
+	headers, err := requestHeaders(server)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpTransport{url: server.URL, headers: headers, client: client}, nil
+}
+
+func (t *httpTransport) RoundTrip(ctx context.Context, msg *Message) (*Message, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = t.headers.Clone()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	t.mu.Lock()
+	if t.sessionID != "" {
+		req.Header.Set(HeaderSessionID, t.sessionID)
+	}
+	t.mu.Unlock()
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if id := resp.Header.Get(HeaderSessionID); id != "" {
+		t.mu.Lock()
+		t.sessionID = id
+		t.mu.Unlock()
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("%s: %s %s", t.url, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if !msg.IsRequest() {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		var found *Message
+		err := readEvents(resp.Body, func(event, data string) bool {
+			var m Message
+			if json.Unmarshal([]byte(data), &m) == nil && m.IsResponse() && bytes.Equal(m.ID, msg.ID) {
+				found = &m
+				return false
+			}
+			return true
+		})
+		if found != nil {
+			return found, nil
+		}
+		if err == nil {
+			err = ErrClosed
+		}
+		return nil, err
+	}
+	var out Message
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &out, nil
+}
+
+func (t *httpTransport) Close() error {
+	t.mu.Lock()
+	sessionID := t.sessionID
+	t.mu.Unlock()
+	if sessionID == "" {
+		return nil
+	}
+	req, err := http.NewRequest(http.MethodDelete, t.url, nil)
+	if err != nil {
+		return nil
+	}
+	req.Header = t.headers.Clone()
+	req.Header.Set(HeaderSessionID, sessionID)
+	if resp, err := t.client.Do(req); err == nil {
+		resp.Body.Close()
+	}
+	return nil
+}
+
+// sseTransport holds an event stream open to the server URL, posts
+// messages to the endpoint the server announces on it, and reads the
+// responses from the stream (the legacy HTTP+SSE transport).
+type sseTransport struct {
+	endpoint string
+	headers  http.Header
+	client   *http.Client
+	body     io.Closer
+
+	pending pendingResponses
+	done    chan struct{}
+	err     error
+}
+
+// NewSSETransport opens the event stream at the server's URL and waits for
+// the server to announce its message endpoint. A nil client uses
+// http.DefaultClient.
+func NewSSETransport(ctx context.Context, server core.Server, client *http.Client) (Transport, error) {
+	headers, err := requestHeaders(server)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	base, err := url.Parse(server.URL)
+	if err != nil {
+		return nil, err
+	}
+	// The stream outlives ctx, which only bounds the wait for the endpoint.
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = headers.Clone()
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", server.URL, resp.Status)
+	}
+
+	t := &sseTransport{headers: headers, client: client, body: resp.Body, done: make(chan struct{})}
+	t.pending.init()
+	endpoint := make(chan string, 1)
+	go func() {
+		t.err = readEvents(resp.Body, func(event, data string) bool {
+			switch event {
+			case "endpoint":
+				if ref, err := base.Parse(data); err == nil {
+					select {
+					case endpoint <- ref.String():
+					default:
+					}
+				}
+			case "", "message":
+				var msg Message
+				if json.Unmarshal([]byte(data), &msg) == nil {
+					t.pending.deliver(&msg)
+				}
+			}
+			return true
+		})
+		close(t.done)
+	}()
+
+	select {
+	case t.endpoint = <-endpoint:
+		return t, nil
+	case <-t.done:
+		return nil, fmt.Errorf("%s: stream closed before the endpoint event", server.URL)
+	case <-ctx.Done():
+		resp.Body.Close()
+		return nil, ctx.Err()
+	}
+}
+
+func (t *sseTransport) RoundTrip(ctx context.Context, msg *Message) (*Message, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var wait <-chan *Message
+	if msg.IsRequest() {
+		wait = t.pending.add(msg.ID)
+		defer t.pending.remove(msg.ID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = t.headers.Clone()
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s: %s", t.endpoint, resp.Status)
+	}
+	if wait == nil {
+		return nil, nil
+	}
+
+	select {
+	case resp := <-wait:
+		return resp, nil
+	case <-t.done:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *sseTransport) Close() error {
+	return t.body.Close()
+}
+
+// requestHeaders returns the server's Headers with secrets resolved, and an
+// Authorization header from BearerTokenEnvVar.
+func requestHeaders(server core.Server) (http.Header, error) {
+	headers := make(http.Header)
+	for _, key := range sortedKeys(server.Headers) {
+		value, err := ResolveSecrets(server.Headers[key])
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", key, err)
+		}
+		headers.Set(key, value)
+	}
+	if server.BearerTokenEnvVar != "" {
+		if token := os.Getenv(server.BearerTokenEnvVar); token != "" {
+			headers.Set("Authorization", "Bearer "+token)
+		}
+	}
+	return headers, nil
+}
+
+// readEvents reads a server-sent event stream, calling fn for each event
+// until it returns false or the stream ends.
+func readEvents(r io.Reader, fn func(event, data string) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	var event string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 && !fn(event, strings.Join(data, "\n")) {
+				return nil
+			}
+			event, data = "", nil
+		case strings.HasPrefix(line, ":"):
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if len(data) > 0 {
+		fn(event, strings.Join(data, "\n"))
+	}
+	return scanner.Err()
+}
+
+// pendingResponses routes responses read from a stream to the requests
+// waiting for them.
+type pendingResponses struct {
+	mu      sync.Mutex
+	waiting map[string]chan *Message
+}
+
+func (p *pendingResponses) init() {
+	p.waiting = make(map[string]chan *Message)
+}
+
+func (p *pendingResponses) add(id json.RawMessage) <-chan *Message {
+	ch := make(chan *Message, 1)
+	p.mu.Lock()
+	p.waiting[string(id)] = ch
+	p.mu.Unlock()
+	return ch
+}
+
+func (p *pendingResponses) remove(id json.RawMessage) {
+	p.mu.Lock()
+	delete(p.waiting, string(id))
+	p.mu.Unlock()
+}
+
+// deliver hands a response to its waiting request. Requests and
+// notifications from the server are dropped.
+func (p *pendingResponses) deliver(msg *Message) {
+	if !msg.IsResponse() {
+		return
+	}
+	p.mu.Lock()
+	ch := p.waiting[string(msg.ID)]
+	p.mu.Unlock()
+	if ch != nil {
+		ch <- msg
+	}
+}
+
+// lockedBuffer is a buffer that is safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,409 @@
+// Package proxy aggregates the servers of a canonical MCP config behind a
+// single MCP server.
+//
+// Start connects to every enabled server, starting stdio servers with
+// their command, and lists their tools, prompts and resources. The proxy
+// then offers them as its own, applying what some assistants ignore:
+//   - tools not in a server's EnabledTools (when set) or in its
+//     DisabledTools are hidden and cannot be called
+//   - each tool call is bounded by the server's ToolTimeoutSec; a call
+//     that times out returns an error result
+//
+// Tools and prompts are exposed as <server>__<name>; with PrefixCollisions
+// only names offered by more than one server are prefixed. Either can be
+// renamed per server. Resources keep their URIs:
+//
+//	p, err := proxy.Start(ctx, cfg, proxy.Options{})
+//	if err != nil {
+//	    return err
+//	}
+//	defer p.Close()
+//	p.Server().ServeStdio(ctx, os.Stdin, os.Stdout)
+//
+// AlwaysAllow is not applied: approving tool calls is up to the assistant.
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/agentplexus/assistantkit"
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/mcp/probe"
+	"github.com/agentplexus/assistantkit/mcp/protocol"
+)
+
+// DefaultSeparator joins server and tool names.
+const DefaultSeparator = "__"
+
+// PrefixMode decides which tool and prompt names are prefixed with their
+// server's name.
+type PrefixMode string
+
+const (
+	// PrefixAlways prefixes every name.
+	PrefixAlways PrefixMode = "always"
+
+	// PrefixCollisions prefixes only names offered by more than one server.
+	PrefixCollisions PrefixMode = "collisions"
+)
+
+// Options configure a proxy.
+type Options struct {
+	// Prefix decides which names are prefixed. Defaults to PrefixAlways.
+	Prefix PrefixMode
+
+	// Separator joins server and tool names. Defaults to DefaultSeparator.
+	Separator string
+
+	// Rename maps server names to renames of their tools and prompts, from
+	// the server's name to the exposed name. Renamed names are not
+	// prefixed.
+	Rename map[string]map[string]string
+
+	// StartupTimeout is the startup timeout for servers that do not set
+	// StartupTimeoutSec. Defaults to probe.DefaultStartupTimeout.
+	StartupTimeout time.Duration
+
+	// ToolTimeout is the timeout of each request for servers that do not
+	// set ToolTimeoutSec. Defaults to probe.DefaultToolTimeout.
+	ToolTimeout time.Duration
+}
+
+// Route is a downstream tool or prompt as the proxy exposes it.
+type Route struct {
+	// Name is the exposed name.
+	Name string `json:"name"`
+
+	// Server and Tool are the server's name and the name the server
+	// gives the tool or prompt.
+	Server string `json:"server"`
+	Tool   string `json:"tool"`
+}
+
+// Proxy is a running proxy.
+type Proxy struct {
+	downstreams map[string]*downstream
+
+	tools        []protocol.Tool
+	toolRoutes   map[string]Route
+	prompts      []protocol.Prompt
+	promptRoutes map[string]Route
+	resources    []protocol.Resource
+	resourceURIs map[string]string
+
+	// Errors holds the servers that could not be started, initialized or
+	// have their tools listed. They are left out of the proxy.
+	Errors map[string]error
+
+	// Warnings are failures of prompt and resource lists, duplicate
+	// resource URIs and renames of tools no server offers.
+	Warnings []string
+}
+
+// downstream is a connected server and what it offers.
+type downstream struct {
+	name        string
+	server      core.Server
+	client      *protocol.Client
+	toolTimeout time.Duration
+
+	capabilities protocol.ServerCapabilities
+
+	tools     []protocol.Tool
+	prompts   []protocol.Prompt
+	resources []protocol.Resource
+	warnings  []string
+	err       error
+}
+
+// Start connects to every enabled server of cfg and builds the proxy's
+// catalog. Servers that fail are recorded in Errors; an error is returned
+// only if the exposed names collide.
+func Start(ctx context.Context, cfg *core.Config, opts Options) (*Proxy, error) {
+	if opts.Separator == "" {
+		opts.Separator = DefaultSeparator
+	}
+	if opts.Prefix == "" {
+		opts.Prefix = PrefixAlways
+	}
+	if opts.Prefix != PrefixAlways && opts.Prefix != PrefixCollisions {
+		return nil, fmt.Errorf("unknown prefix mode: %s", opts.Prefix)
+	}
+
+	names := cfg.ServerNames()
+	sort.Strings(names)
+	var started []*downstream
+	var wg sync.WaitGroup
+	for _, name := range names {
+		server := cfg.Servers[name]
+		if !server.IsEnabled() {
+			continue
+		}
+		d := &downstream{name: name, server: server}
+		started = append(started, d)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.err = d.start(ctx, opts)
+		}()
+	}
+	wg.Wait()
+
+	p := &Proxy{
+		downstreams:  make(map[string]*downstream),
+		tools:        []protocol.Tool{},
+		prompts:      []protocol.Prompt{},
+		resources:    []protocol.Resource{},
+		toolRoutes:   make(map[string]Route),
+		promptRoutes: make(map[string]Route),
+		resourceURIs: make(map[string]string),
+		Errors:       make(map[string]error),
+	}
+	for _, d := range started {
+		if d.err != nil {
+			p.Errors[d.name] = d.err
+			if d.client != nil {
+				_ = d.client.Close()
+			}
+			continue
+		}
+		p.downstreams[d.name] = d
+		for _, w := range d.warnings {
+			p.Warnings = append(p.Warnings, d.name+": "+w)
+		}
+	}
+	if err := p.build(started, opts); err != nil {
+		_ = p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// start connects to the server and lists what it offers.
+func (d *downstream) start(ctx context.Context, opts Options) error {
+	startupTimeout, toolTimeout := probe.Timeouts(d.server, probe.Options{
+		StartupTimeout: opts.StartupTimeout,
+		ToolTimeout:    opts.ToolTimeout,
+	})
+	d.toolTimeout = toolTimeout
+
+	startupCtx, cancel := context.WithTimeout(ctx, startupTimeout)
+	defer cancel()
+	client, err := protocol.Connect(startupCtx, d.server)
+	if err != nil {
+		return probe.TimeoutError(err, "startup", startupTimeout)
+	}
+	d.client = client
+	result, err := client.Initialize(startupCtx)
+	if err != nil {
+		return probe.TimeoutError(err, "startup", startupTimeout)
+	}
+	d.capabilities = result.Capabilities
+
+	listCtx := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(ctx, d.toolTimeout)
+	}
+	if result.Capabilities.Tools != nil {
+		ctx, cancel := listCtx()
+		defer cancel()
+		if d.tools, err = client.ListTools(ctx); err != nil {
+			return probe.TimeoutError(err, "tool", d.toolTimeout)
+		}
+	}
+	if result.Capabilities.Prompts != nil {
+		ctx, cancel := listCtx()
+		defer cancel()
+		if d.prompts, err = client.ListPrompts(ctx); err != nil {
+			d.warnings = append(d.warnings, probe.TimeoutError(err, "tool", d.toolTimeout).Error())
+		}
+	}
+	if result.Capabilities.Resources != nil {
+		ctx, cancel := listCtx()
+		defer cancel()
+		if d.resources, err = client.ListResources(ctx); err != nil {
+			d.warnings = append(d.warnings, probe.TimeoutError(err, "tool", d.toolTimeout).Error())
+		}
+	}
+	return nil
+}
+
+// build names the tools, prompts and resources of the started servers.
+func (p *Proxy) build(started []*downstream, opts Options) error {
+	toolCounts := make(map[string]int)
+	promptCounts := make(map[string]int)
+	for _, d := range started {
+		if d.err != nil {
+			continue
+		}
+		d.tools = slices.DeleteFunc(d.tools, func(t protocol.Tool) bool { return !Allowed(d.server, t.Name) })
+		for _, tool := range d.tools {
+			toolCounts[tool.Name]++
+		}
+		for _, prompt := range d.prompts {
+			promptCounts[prompt.Name]++
+		}
+	}
+
+	name := func(d *downstream, original string, counts map[string]int) string {
+		if renamed, ok := opts.Rename[d.name][original]; ok {
+			return renamed
+		}
+		if opts.Prefix == PrefixCollisions && counts[original] == 1 {
+			return original
+		}
+		return d.name + opts.Separator + original
+	}
+
+	for _, d := range started {
+		if d.err != nil {
+			continue
+		}
+		for _, tool := range d.tools {
+			route := Route{Name: name(d, tool.Name, toolCounts), Server: d.name, Tool: tool.Name}
+			if prev, ok := p.toolRoutes[route.Name]; ok {
+				return fmt.Errorf("tool %s of server %s and tool %s of server %s are both exposed as %s",
+					prev.Tool, prev.Server, route.Tool, route.Server, route.Name)
+			}
+			p.toolRoutes[route.Name] = route
+			tool.Name = route.Name
+			p.tools = append(p.tools, tool)
+		}
+		for _, prompt := range d.prompts {
+			route := Route{Name: name(d, prompt.Name, promptCounts), Server: d.name, Tool: prompt.Name}
+			if prev, ok := p.promptRoutes[route.Name]; ok {
+				return fmt.Errorf("prompt %s of server %s and prompt %s of server %s are both exposed as %s",
+					prev.Tool, prev.Server, route.Tool, route.Server, route.Name)
+			}
+			p.promptRoutes[route.Name] = route
+			prompt.Name = route.Name
+			p.prompts = append(p.prompts, prompt)
+		}
+		for _, resource := range d.resources {
+			if server, ok := p.resourceURIs[resource.URI]; ok {
+				p.Warnings = append(p.Warnings, fmt.Sprintf("%s: resource %s is already offered by %s", d.name, resource.URI, server))
+				continue
+			}
+			p.resourceURIs[resource.URI] = d.name
+			p.resources = append(p.resources, resource)
+		}
+		for _, original := range sortedKeys(opts.Rename[d.name]) {
+			if !slices.ContainsFunc(d.tools, func(t protocol.Tool) bool { return t.Name == original }) &&
+				!slices.ContainsFunc(d.prompts, func(p protocol.Prompt) bool { return p.Name == original }) {
+				p.Warnings = append(p.Warnings, fmt.Sprintf("%s: cannot rename %s: no such tool or prompt", d.name, original))
+			}
+		}
+	}
+	return nil
+}
+
+// Allowed reports whether the server's EnabledTools and DisabledTools let
+// the proxy offer the tool.
+func Allowed(server core.Server, tool string) bool {
+	if len(server.EnabledTools) > 0 && !slices.Contains(server.EnabledTools, tool) {
+		return false
+	}
+	return !slices.Contains(server.DisabledTools, tool)
+}
+
+// Tools returns the routes of the exposed tools, in the order they are
+// listed.
+func (p *Proxy) Tools() []Route {
+	routes := make([]Route, 0, len(p.tools))
+	for _, tool := range p.tools {
+		routes = append(routes, p.toolRoutes[tool.Name])
+	}
+	return routes
+}
+
+// Servers returns the names of the servers the proxy is connected to.
+func (p *Proxy) Servers() []string {
+	return sortedKeys(p.downstreams)
+}
+
+// Close closes the connections to all servers, stopping stdio servers.
+func (p *Proxy) Close() error {
+	for _, d := range p.downstreams {
+		_ = d.client.Close()
+	}
+	return nil
+}
+
+// Server returns the MCP server that serves the proxy. It offers prompts
+// and resources only if a connected server does.
+func (p *Proxy) Server() *protocol.Server {
+	s := &protocol.Server{
+		Info:         protocol.Implementation{Name: "assistantkit-proxy", Version: assistantkit.Version},
+		Instructions: "Tools, prompts and resources of several MCP servers, named after their server.",
+		ListTools: func(ctx context.Context) ([]protocol.Tool, error) {
+			return p.tools, nil
+		},
+		CallTool: p.callTool,
+	}
+	for _, d := range p.downstreams {
+		if d.capabilities.Prompts != nil {
+			s.ListPrompts = func(ctx context.Context) ([]protocol.Prompt, error) { return p.prompts, nil }
+			s.GetPrompt = p.getPrompt
+		}
+		if d.capabilities.Resources != nil {
+			s.ListResources = func(ctx context.Context) ([]protocol.Resource, error) { return p.resources, nil }
+			s.ReadResource = p.readResource
+		}
+	}
+	return s
+}
+
+func (p *Proxy) callTool(ctx context.Context, params protocol.CallToolParams) (*protocol.CallToolResult, error) {
+	route, ok := p.toolRoutes[params.Name]
+	if !ok {
+		return nil, protocol.InvalidParams("unknown tool: %s", params.Name)
+	}
+	d := p.downstreams[route.Server]
+	ctx, cancel := context.WithTimeout(ctx, d.toolTimeout)
+	defer cancel()
+	result, err := d.client.CallTool(ctx, protocol.CallToolParams{Name: route.Tool, Arguments: params.Arguments})
+	if errors.Is(err, context.DeadlineExceeded) {
+		// Report the timeout to the model rather than as a protocol error.
+		return &protocol.CallToolResult{
+			Content: []protocol.Content{protocol.TextContent(fmt.Sprintf("%s: tool timeout of %s exceeded", params.Name, d.toolTimeout))},
+			IsError: true,
+		}, nil
+	}
+	return result, err
+}
+
+func (p *Proxy) getPrompt(ctx context.Context, params protocol.GetPromptParams) (*protocol.GetPromptResult, error) {
+	route, ok := p.promptRoutes[params.Name]
+	if !ok {
+		return nil, protocol.InvalidParams("unknown prompt: %s", params.Name)
+	}
+	d := p.downstreams[route.Server]
+	ctx, cancel := context.WithTimeout(ctx, d.toolTimeout)
+	defer cancel()
+	return d.client.GetPrompt(ctx, protocol.GetPromptParams{Name: route.Tool, Arguments: params.Arguments})
+}
+
+func (p *Proxy) readResource(ctx context.Context, params protocol.ReadResourceParams) (*protocol.ReadResourceResult, error) {
+	server, ok := p.resourceURIs[params.URI]
+	if !ok {
+		return nil, protocol.InvalidParams("unknown resource: %s", params.URI)
+	}
+	d := p.downstreams[server]
+	ctx, cancel := context.WithTimeout(ctx, d.toolTimeout)
+	defer cancel()
+	return d.client.ReadResource(ctx, params)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
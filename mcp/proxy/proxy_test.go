@@ -0,0 +1,219 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/mcp/mcptest"
+	"github.com/agentplexus/assistantkit/mcp/protocol"
+)
+
+func TestMain(m *testing.M) {
+	mcptest.Main()
+	os.Exit(m.Run())
+}
+
+// connect serves the proxy over HTTP and returns an initialized client.
+func connect(t *testing.T, p *Proxy) *protocol.Client {
+	t.Helper()
+	srv := httptest.NewServer(p.Server())
+	t.Cleanup(srv.Close)
+	client, err := protocol.Connect(context.Background(), core.Server{URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	if _, err := client.Initialize(context.Background()); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	return client
+}
+
+func start(t *testing.T, cfg *core.Config, opts Options) *Proxy {
+	t.Helper()
+	p, err := Start(context.Background(), cfg, opts)
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	t.Cleanup(func() { _ = p.Close() })
+	return p
+}
+
+func toolNames(t *testing.T, client *protocol.Client) []string {
+	t.Helper()
+	tools, err := client.ListTools(context.Background())
+	if err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestProxy(t *testing.T) {
+	files := mcptest.Stdio(t, mcptest.Options{Tools: []string{"read", "write", "delete"}, Prompts: []string{"summarize"}})
+	files.DisabledTools = []string{"delete"}
+	search := mcptest.HTTP(t, mcptest.Options{Tools: []string{"query", "index"}, Resources: []string{"readme"}})
+	search.EnabledTools = []string{"query"}
+	off := core.Server{Command: "does-not-run"}
+	off.SetEnabled(false)
+
+	cfg := core.NewConfig()
+	cfg.AddServer("files", files)
+	cfg.AddServer("search", search)
+	cfg.AddServer("off", off)
+	p := start(t, cfg, Options{})
+	if len(p.Errors) != 0 || len(p.Warnings) != 0 {
+		t.Fatalf("Expected no errors or warnings, got %v, %v", p.Errors, p.Warnings)
+	}
+	if got := p.Servers(); !reflect.DeepEqual(got, []string{"files", "search"}) {
+		t.Errorf("Expected the enabled servers, got %v", got)
+	}
+
+	client := connect(t, p)
+	ctx := context.Background()
+	if got := toolNames(t, client); !reflect.DeepEqual(got, []string{"files__read", "files__write", "search__query"}) {
+		t.Errorf("Expected filtered and prefixed tools, got %v", got)
+	}
+
+	result, err := client.CallTool(ctx, protocol.CallToolParams{Name: "files__read", Arguments: map[string]any{"path": "a"}})
+	if err != nil || result.Content[0].Text != `read {"path":"a"}` {
+		t.Errorf("Expected the call to reach files, got %+v, %v", result, err)
+	}
+	for _, hidden := range []string{"files__delete", "search__index", "read"} {
+		_, err := client.CallTool(ctx, protocol.CallToolParams{Name: hidden})
+		var rpcErr *protocol.Error
+		if !errors.As(err, &rpcErr) || rpcErr.Code != protocol.CodeInvalidParams {
+			t.Errorf("Expected %s to be unknown, got %v", hidden, err)
+		}
+	}
+
+	prompt, err := client.GetPrompt(ctx, protocol.GetPromptParams{Name: "files__summarize", Arguments: map[string]string{"k": "v"}})
+	if err != nil || prompt.Messages[0].Content.Text != "summarize k=v" {
+		t.Errorf("Expected the prompt to reach files, got %+v, %v", prompt, err)
+	}
+	resource, err := client.ReadResource(ctx, protocol.ReadResourceParams{URI: mcptest.URI("readme")})
+	if err != nil || resource.Contents[0].Text != "readme" {
+		t.Errorf("Expected the resource to reach search, got %+v, %v", resource, err)
+	}
+}
+
+func TestProxyRejectsForeignOrigin(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddServer("files", mcptest.Stdio(t, mcptest.Options{Tools: []string{"write"}}))
+	p := start(t, cfg, Options{})
+
+	call := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"files__write"}}`
+	for transport, handler := range map[string]http.Handler{"http": p.Server(), "sse": p.Server().SSEHandler()} {
+		srv := httptest.NewServer(handler)
+		req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(call))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Origin", "http://evil.example")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: post failed: %v", transport, err)
+		}
+		_ = resp.Body.Close()
+		srv.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("%s: expected 403 for a foreign origin, got %d", transport, resp.StatusCode)
+		}
+	}
+}
+
+func TestProxyNaming(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddServer("a", mcptest.HTTP(t, mcptest.Options{Tools: []string{"search", "fetch"}}))
+	cfg.AddServer("b", mcptest.HTTP(t, mcptest.Options{Tools: []string{"search", "list"}}))
+
+	p := start(t, cfg, Options{Prefix: PrefixCollisions})
+	expected := []Route{
+		{Name: "a__search", Server: "a", Tool: "search"},
+		{Name: "fetch", Server: "a", Tool: "fetch"},
+		{Name: "b__search", Server: "b", Tool: "search"},
+		{Name: "list", Server: "b", Tool: "list"},
+	}
+	if got := p.Tools(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	p = start(t, cfg, Options{Separator: ".", Rename: map[string]map[string]string{
+		"a": {"search": "web_search", "missing": "x"},
+	}})
+	if got := toolNames(t, connect(t, p)); !reflect.DeepEqual(got, []string{"web_search", "a.fetch", "b.search", "b.list"}) {
+		t.Errorf("Expected renamed and prefixed tools, got %v", got)
+	}
+	if len(p.Warnings) != 1 || !strings.Contains(p.Warnings[0], "cannot rename missing") {
+		t.Errorf("Expected a warning for the unknown rename, got %v", p.Warnings)
+	}
+
+	_, err := Start(context.Background(), cfg, Options{Rename: map[string]map[string]string{
+		"a": {"fetch": "b__list"},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "both exposed as b__list") {
+		t.Errorf("Expected a collision error, got %v", err)
+	}
+}
+
+func TestProxyTimeouts(t *testing.T) {
+	slow := mcptest.HTTP(t, mcptest.Options{Tools: []string{"wait"}, CallDelay: time.Second})
+	cfg := core.NewConfig()
+	cfg.AddServer("slow", slow)
+	p := start(t, cfg, Options{ToolTimeout: 50 * time.Millisecond})
+
+	result, err := connect(t, p).CallTool(context.Background(), protocol.CallToolParams{Name: "slow__wait"})
+	if err != nil {
+		t.Fatalf("Expected an error result, got %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "tool timeout of 50ms exceeded") {
+		t.Errorf("Expected a timeout result, got %+v", result)
+	}
+}
+
+func TestProxyErrors(t *testing.T) {
+	cfg := core.NewConfig()
+	cfg.AddServer("ok", mcptest.HTTP(t, mcptest.Options{Tools: []string{"ping"}}))
+	cfg.AddServer("broken", core.Server{Command: "assistantkit-no-such-command"})
+	p := start(t, cfg, Options{})
+	if _, ok := p.Errors["broken"]; !ok || len(p.Errors) != 1 {
+		t.Errorf("Expected broken to fail, got %v", p.Errors)
+	}
+	if got := toolNames(t, connect(t, p)); !reflect.DeepEqual(got, []string{"ok__ping"}) {
+		t.Errorf("Expected the working server's tools, got %v", got)
+	}
+
+	if _, err := Start(context.Background(), cfg, Options{Prefix: "sometimes"}); err == nil {
+		t.Error("Expected error for an unknown prefix mode")
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		server core.Server
+		tool   string
+		want   bool
+	}{
+		{core.Server{}, "any", true},
+		{core.Server{EnabledTools: []string{"a"}}, "a", true},
+		{core.Server{EnabledTools: []string{"a"}}, "b", false},
+		{core.Server{DisabledTools: []string{"a"}}, "a", false},
+		{core.Server{EnabledTools: []string{"a"}, DisabledTools: []string{"a"}}, "a", false},
+	}
+	for _, tt := range tests {
+		if got := Allowed(tt.server, tt.tool); got != tt.want {
+			t.Errorf("Allowed(%+v, %q) = %v, want %v", tt.server, tt.tool, got, tt.want)
+		}
+	}
+}
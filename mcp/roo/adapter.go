@@ -12,6 +12,7 @@ import (
 	"runtime"
 
 	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
 )
 
 const (
@@ -130,12 +131,12 @@ func (a *Adapter) ToCore(rooCfg *Config) *core.Config {
 		}
 
 		// Set transport type
-		switch server.Type {
-		case "stdio":
+		switch core.ParseTransport(server.Type) {
+		case core.TransportStdio:
 			coreServer.Transport = core.TransportStdio
-		case "http":
+		case core.TransportHTTP:
 			coreServer.Transport = core.TransportHTTP
-		case "sse":
+		case core.TransportSSE:
 			coreServer.Transport = core.TransportSSE
 		default:
 			if server.Command != "" {
@@ -161,14 +162,18 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 		rooServer := ServerConfig{
 			Command:     server.Command,
 			Args:        server.Args,
-			Env:         server.Env,
+			Env:         core.RenderEnvPlaceholders(name, core.FieldEnv, server.Env),
 			URL:         server.URL,
-			Headers:     server.Headers,
+			Headers:     core.RenderEnvPlaceholders(name, core.FieldHeaders, server.Headers),
 			AlwaysAllow: server.AlwaysAllow,
 			Disabled:    !server.IsEnabled(),
 		}
 
-		if server.Transport != "" {
+		// Older versions read remote servers without a type as SSE, so
+		// always name the Streamable HTTP transport.
+		if server.InferTransport() == core.TransportHTTP {
+			rooServer.Type = TypeStreamableHTTP
+		} else if server.Transport != "" {
 			rooServer.Type = server.Transport.String()
 		}
 
@@ -178,6 +183,24 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	return rooCfg
 }
 
+// Lossiness reports the canonical fields that the Roo Code format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldURL, core.FieldHeaders, core.FieldAlwaysAllow, core.FieldEnabled)
+	return append(losses, core.SecretLosses(AdapterName, cfg)...)
+}
+
+// Merge writes cfg into an existing Roo Code config, replacing only the
+// mcpServers key and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "mcpServers")
+}
+
 // init registers the adapter with the default registry.
 func init() {
 	core.Register(NewAdapter())
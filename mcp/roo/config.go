@@ -5,9 +5,12 @@ type Config struct {
 	MCPServers map[string]ServerConfig `json:"mcpServers"`
 }
 
+// TypeStreamableHTTP is Roo Code's type for the Streamable HTTP transport.
+const TypeStreamableHTTP = "streamable-http"
+
 // ServerConfig represents a Roo Code MCP server configuration.
 type ServerConfig struct {
-	// Type specifies the transport type.
+	// Type specifies the transport type: "stdio", "streamable-http" or "sse".
 	Type string `json:"type,omitempty"`
 
 	// --- STDIO Server Fields ---
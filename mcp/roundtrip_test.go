@@ -0,0 +1,294 @@
+package mcp
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"testing/quick"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+// serverFields lists every canonical Server field compared by the round-trip tests.
+var serverFields = []string{
+	core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+	core.FieldEnvFile, core.FieldCwd, core.FieldURL, core.FieldHeaders,
+	core.FieldBearerTokenEnvVar, core.FieldEnabledTools, core.FieldDisabledTools,
+	core.FieldAlwaysAllow, core.FieldEnabled, core.FieldStartupTimeoutSec,
+	core.FieldToolTimeoutSec, core.FieldNetworkTimeoutSec,
+	core.FieldOAuthClientID, core.FieldOAuthScopes, core.FieldOAuthCallbackPort,
+}
+
+var words = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot"}
+
+// randomConfig is a canonical config generated for property-based tests.
+type randomConfig struct {
+	cfg *Config
+}
+
+// Generate implements quick.Generator.
+func (randomConfig) Generate(r *rand.Rand, size int) reflect.Value {
+	cfg := NewConfig()
+	for i := 0; i < 1+r.Intn(3); i++ {
+		cfg.Servers[fmt.Sprintf("server-%d", i)] = randomServer(r)
+	}
+	if r.Intn(3) == 0 {
+		cfg.Inputs = []InputVariable{{
+			Type:        "promptString",
+			ID:          pick(r),
+			Description: pick(r),
+			Password:    r.Intn(2) == 0,
+		}}
+	}
+	return reflect.ValueOf(randomConfig{cfg: cfg})
+}
+
+func randomServer(r *rand.Rand) Server {
+	var s Server
+	if r.Intn(2) == 0 {
+		s.Command = pick(r)
+		if r.Intn(2) == 0 {
+			s.Transport = TransportStdio
+		}
+		s.Args = randomList(r)
+		s.Env = randomMap(r, "ENV_")
+		if r.Intn(3) == 0 {
+			s.EnvFile = ".env." + pick(r)
+		}
+		if r.Intn(3) == 0 {
+			s.Cwd = "/tmp/" + pick(r)
+		}
+	} else {
+		s.URL = "https://" + pick(r) + ".example.com/mcp"
+		s.Transport = []TransportType{"", TransportHTTP, TransportSSE}[r.Intn(3)]
+		s.Headers = randomMap(r, "X-")
+		if r.Intn(3) == 0 {
+			s.BearerTokenEnvVar = "TOKEN_" + pick(r)
+		}
+		if r.Intn(3) == 0 {
+			s.OAuth = &core.OAuth{Scopes: randomList(r)}
+			if r.Intn(2) == 0 {
+				s.OAuth.ClientID = "client-" + pick(r)
+			}
+			if r.Intn(2) == 0 {
+				s.OAuth.CallbackPort = 1024 + r.Intn(60000)
+			}
+		}
+	}
+	s.EnabledTools = randomList(r)
+	s.DisabledTools = randomList(r)
+	s.AlwaysAllow = randomList(r)
+	if n := r.Intn(3); n > 0 {
+		s.SetEnabled(n == 1)
+	}
+	s.StartupTimeoutSec = r.Intn(2) * (1 + r.Intn(120))
+	s.ToolTimeoutSec = r.Intn(2) * (1 + r.Intn(120))
+	s.NetworkTimeoutSec = r.Intn(2) * (1 + r.Intn(120))
+	return s
+}
+
+func pick(r *rand.Rand) string {
+	return words[r.Intn(len(words))]
+}
+
+func randomList(r *rand.Rand) []string {
+	var list []string
+	for i := 0; i < r.Intn(3); i++ {
+		list = append(list, pick(r))
+	}
+	return list
+}
+
+func randomMap(r *rand.Rand, prefix string) map[string]string {
+	n := r.Intn(3)
+	if n == 0 {
+		return nil
+	}
+	m := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		m[fmt.Sprintf("%s%d", prefix, i)] = pick(r)
+	}
+	return m
+}
+
+// fieldValue returns the value of a server field, normalized so that values
+// with the same meaning compare equal (e.g., an inferred transport, an
+// explicit enabled=true, or an empty list).
+func fieldValue(s Server, field string) interface{} {
+	switch field {
+	case core.FieldTransport:
+		return s.InferTransport()
+	case core.FieldCommand:
+		return s.Command
+	case core.FieldArgs:
+		return list(s.Args)
+	case core.FieldEnv:
+		return dict(s.Env)
+	case core.FieldEnvFile:
+		return s.EnvFile
+	case core.FieldCwd:
+		return s.Cwd
+	case core.FieldURL:
+		return s.URL
+	case core.FieldHeaders:
+		return dict(s.Headers)
+	case core.FieldBearerTokenEnvVar:
+		return s.BearerTokenEnvVar
+	case core.FieldEnabledTools:
+		return list(s.EnabledTools)
+	case core.FieldDisabledTools:
+		return list(s.DisabledTools)
+	case core.FieldAlwaysAllow:
+		return list(s.AlwaysAllow)
+	case core.FieldEnabled:
+		return s.IsEnabled()
+	case core.FieldStartupTimeoutSec:
+		return s.StartupTimeoutSec
+	case core.FieldToolTimeoutSec:
+		return s.ToolTimeoutSec
+	case core.FieldNetworkTimeoutSec:
+		return s.NetworkTimeoutSec
+	case core.FieldOAuthClientID, core.FieldOAuthScopes, core.FieldOAuthCallbackPort:
+		var oauth core.OAuth
+		if s.OAuth != nil {
+			oauth = *s.OAuth
+		}
+		switch field {
+		case core.FieldOAuthClientID:
+			return oauth.ClientID
+		case core.FieldOAuthScopes:
+			return list(oauth.Scopes)
+		default:
+			return oauth.CallbackPort
+		}
+	}
+	panic("unknown field " + field)
+}
+
+func list(l []string) []string {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+func dict(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+// checkRoundTrip asserts that Parse(Marshal(cfg)) preserves every field
+// that the adapter does not report as lost.
+func checkRoundTrip(adapter Adapter, cfg *Config) error {
+	lost := make(map[string]bool)
+	for _, loss := range core.Lossiness(adapter, cfg) {
+		lost[loss.Path] = true
+	}
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	got, err := adapter.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parse: %w\n%s", err, data)
+	}
+
+	if len(got.Servers) != len(cfg.Servers) {
+		return fmt.Errorf("expected %d servers, got %d", len(cfg.Servers), len(got.Servers))
+	}
+	for name, want := range cfg.Servers {
+		server, ok := got.Servers[name]
+		if !ok {
+			return fmt.Errorf("server %q missing after round trip", name)
+		}
+		for _, field := range serverFields {
+			path := "servers." + name + "." + field
+			if lost[path] {
+				continue
+			}
+			w, g := fieldValue(want, field), fieldValue(server, field)
+			if !reflect.DeepEqual(w, g) {
+				return fmt.Errorf("%s not reported as lost but changed: expected %v, got %v\n%s", path, w, g, data)
+			}
+		}
+	}
+
+	if !lost[core.FieldInputs] && len(cfg.Inputs) > 0 && !reflect.DeepEqual(cfg.Inputs, got.Inputs) {
+		return fmt.Errorf("inputs not reported as lost but changed: expected %v, got %v", cfg.Inputs, got.Inputs)
+	}
+	return nil
+}
+
+func TestRoundTripPreservesSupportedFields(t *testing.T) {
+	names := AdapterNames()
+	sort.Strings(names)
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			adapter, _ := GetAdapter(name)
+			if _, ok := adapter.(core.LossReporter); !ok {
+				t.Fatalf("Adapter %q does not implement LossReporter", name)
+			}
+
+			var failure error
+			property := func(rc randomConfig) bool {
+				failure = checkRoundTrip(adapter, rc.cfg)
+				return failure == nil
+			}
+			qc := &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(1))}
+			if err := quick.Check(property, qc); err != nil {
+				t.Errorf("Round trip failed: %v", failure)
+			}
+		})
+	}
+}
+
+func TestLossiness(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddServer("github", Server{
+		Command:     "npx",
+		AlwaysAllow: []string{"search"},
+	})
+	cfg.AddServer("remote", Server{
+		Transport: TransportSSE,
+		URL:       "https://example.com/sse",
+	})
+
+	tests := []struct {
+		tool     string
+		expected []string
+	}{
+		{"claude", []string{"claude: servers.github.alwaysAllow dropped"}},
+		{"cursor", []string{"cursor: servers.github.alwaysAllow dropped"}},
+		{"cline", nil},
+		{"windsurf", []string{
+			"windsurf: servers.github.alwaysAllow dropped",
+			"windsurf: servers.remote.transport degraded: sse transport is not supported and is read back as http",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			losses, err := Lossiness(cfg, tt.tool)
+			if err != nil {
+				t.Fatalf("Lossiness failed: %v", err)
+			}
+			var got []string
+			for _, loss := range losses {
+				got = append(got, loss.String())
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := Lossiness(cfg, "unknown"); err == nil {
+		t.Error("Expected error for unknown adapter")
+	}
+}
@@ -0,0 +1,172 @@
+package mcp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/mcp/codex"
+	"github.com/agentplexus/assistantkit/mcp/vscode"
+)
+
+// secretConfig returns a config whose secrets are all references.
+func secretConfig() *Config {
+	cfg := NewConfig()
+	cfg.AddServer("github", Server{
+		Command: "npx",
+		Env: map[string]string{
+			"GITHUB_TOKEN": "${env:GITHUB_TOKEN}",
+			"ORG_TOKEN":    "${keyring:github/org}",
+		},
+	})
+	cfg.AddServer("api", Server{
+		URL: "https://api.example.com/mcp",
+		Headers: map[string]string{
+			"Authorization": "Bearer ${env:API_TOKEN}",
+			"X-Api-Key":     "${cmd:op read op://dev/api/key}",
+		},
+	})
+	return cfg
+}
+
+func TestSecretRenderingPlaceholders(t *testing.T) {
+	for _, tool := range []string{"claude", "cursor", "windsurf", "cline", "roo", "kiro"} {
+		t.Run(tool, func(t *testing.T) {
+			adapter, _ := GetAdapter(tool)
+			data, err := adapter.Marshal(secretConfig())
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			got, err := adapter.Parse(data)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+
+			expectedEnv := map[string]string{"GITHUB_TOKEN": "${GITHUB_TOKEN}", "ORG_TOKEN": "${ORG_TOKEN}"}
+			if env := got.Servers["github"].Env; !reflect.DeepEqual(env, expectedEnv) {
+				t.Errorf("Expected env %v, got %v", expectedEnv, env)
+			}
+			expectedHeaders := map[string]string{"Authorization": "Bearer ${API_TOKEN}", "X-Api-Key": "${API_X_API_KEY}"}
+			if headers := got.Servers["api"].Headers; !reflect.DeepEqual(headers, expectedHeaders) {
+				t.Errorf("Expected headers %v, got %v", expectedHeaders, headers)
+			}
+		})
+	}
+}
+
+func TestSecretRenderingVSCode(t *testing.T) {
+	cfg := secretConfig()
+	cfg.AddInput(InputVariable{Type: "promptString", ID: "github-token", Description: "GitHub token", Password: true})
+
+	vscodeCfg := vscode.NewAdapter().FromCore(cfg)
+	if env := vscodeCfg.Servers["github"].Env; env["GITHUB_TOKEN"] != "${input:github-token}" || env["ORG_TOKEN"] != "${input:org-token}" {
+		t.Errorf("Unexpected env: %v", env)
+	}
+	if auth := vscodeCfg.Servers["api"].Headers["Authorization"]; auth != "Bearer ${input:api-token}" {
+		t.Errorf("Unexpected Authorization header: %q", auth)
+	}
+
+	var ids []string
+	for _, input := range vscodeCfg.Inputs {
+		ids = append(ids, input.ID)
+		if input.ID != "github-token" && (!input.Password || input.Type != "promptString") {
+			t.Errorf("Expected generated password input, got %+v", input)
+		}
+	}
+	expected := []string{"github-token", "api-token", "api-x-api-key", "org-token"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected inputs %v, got %v", expected, ids)
+	}
+}
+
+func TestSecretRenderingCodex(t *testing.T) {
+	cfg := secretConfig()
+	cfg.AddServer("other", Server{
+		URL:     "https://other.example.com/mcp",
+		Headers: map[string]string{"X-Team": "${env:TEAM}"},
+	})
+
+	codexCfg := codex.NewAdapter().FromCore(cfg)
+	github := codexCfg.MCPServers["github"]
+	if len(github.Env) != 0 || !reflect.DeepEqual(github.EnvVars, []string{"GITHUB_TOKEN", "ORG_TOKEN"}) {
+		t.Errorf("Expected env_vars only, got env %v, env_vars %v", github.Env, github.EnvVars)
+	}
+	api := codexCfg.MCPServers["api"]
+	if api.BearerTokenEnvVar != "API_TOKEN" {
+		t.Errorf("Expected bearer_token_env_var API_TOKEN, got %q", api.BearerTokenEnvVar)
+	}
+	if len(api.HTTPHeaders) != 0 || api.EnvHTTPHeaders["X-Api-Key"] != "API_X_API_KEY" {
+		t.Errorf("Expected env_http_headers, got http_headers %v, env_http_headers %v", api.HTTPHeaders, api.EnvHTTPHeaders)
+	}
+
+	// Environment references read back unchanged.
+	data, err := codex.NewAdapter().Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	got, err := codex.NewAdapter().Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if v := got.Servers["github"].Env["GITHUB_TOKEN"]; v != "${env:GITHUB_TOKEN}" {
+		t.Errorf("Expected env reference, got %q", v)
+	}
+	if v := got.Servers["other"].Headers["X-Team"]; v != "${env:TEAM}" {
+		t.Errorf("Expected header reference, got %q", v)
+	}
+	if got.Servers["api"].BearerTokenEnvVar != "API_TOKEN" {
+		t.Errorf("Expected bearer token env var, got %q", got.Servers["api"].BearerTokenEnvVar)
+	}
+}
+
+func TestSecretLossiness(t *testing.T) {
+	cfg := secretConfig()
+	cfg.AddServer("leaky", Server{Command: "npx", Env: map[string]string{"API_KEY": "sk-live-123"}})
+
+	tests := []struct {
+		tool     string
+		expected []string
+	}{
+		{"claude", []string{
+			"claude: servers.leaky.env exposed: API_KEY looks like a plaintext secret; use a reference such as ${env:API_KEY}",
+			"claude: servers.api.headers degraded: X-Api-Key: ${cmd:op read op://dev/api/key} is read from environment variable API_X_API_KEY",
+			"claude: servers.github.env degraded: ORG_TOKEN: ${keyring:github/org} is read from environment variable ORG_TOKEN",
+		}},
+		{"codex", []string{
+			"codex: servers.leaky.env exposed: API_KEY looks like a plaintext secret; use a reference such as ${env:API_KEY}",
+			"codex: servers.api.headers degraded: Authorization: Bearer ${env:API_TOKEN} is written as bearer_token_env_var API_TOKEN",
+			"codex: servers.api.headers degraded: X-Api-Key: ${cmd:op read op://dev/api/key} is read from environment variable API_X_API_KEY",
+			"codex: servers.github.env degraded: ORG_TOKEN: ${keyring:github/org} is read from environment variable ORG_TOKEN",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			losses, err := Lossiness(cfg, tt.tool)
+			if err != nil {
+				t.Fatalf("Lossiness failed: %v", err)
+			}
+			var got []string
+			for _, loss := range losses {
+				got = append(got, loss.String())
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	// Every adapter warns about the plaintext secret.
+	for _, tool := range SupportedTools() {
+		losses, _ := Lossiness(cfg, tool)
+		found := false
+		for _, loss := range losses {
+			if loss.Kind == "exposed" && strings.Contains(loss.Detail, "API_KEY") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected %s to warn about the plaintext API_KEY", tool)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Package specserver serves canonical specs over MCP, so that any
+// MCP-capable assistant can use a team's commands, skills and project
+// context without per-tool generation.
+//
+// Each command becomes a prompt, with the command's arguments as prompt
+// arguments. Getting a prompt checks required arguments and argument
+// patterns, fills in defaults, replaces {{name}} placeholders in the
+// instructions and lists the argument values after them. Each skill
+// becomes a resource holding its instructions, and each of its references
+// and assets a resource holding the file. The project context becomes a
+// resource holding its Markdown rendering:
+//
+//	specs, err := specserver.Load("specs", "CONTEXT.json")
+//	if err != nil {
+//	    return err
+//	}
+//	server := specserver.New(specs)
+//	server.ServeStdio(ctx, os.Stdin, os.Stdout)
+//
+// Resource URIs are skill://<skill>, skill://<skill>/<path> and
+// context://<name>.
+package specserver
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/agentplexus/assistantkit"
+	commands "github.com/agentplexus/assistantkit/commands/core"
+	contextcore "github.com/agentplexus/assistantkit/context/core"
+	"github.com/agentplexus/assistantkit/mcp/protocol"
+	skills "github.com/agentplexus/assistantkit/skills/core"
+)
+
+// Specs are the specs a server serves.
+type Specs struct {
+	Commands []*commands.Command
+	Skills   []*skills.Skill
+
+	// SkillDirs maps skill names to the directories their references and
+	// assets are relative to.
+	SkillDirs map[string]string
+
+	// Context is the project context, if any.
+	Context *contextcore.Context
+}
+
+// Load reads the commands and skills of a specs directory, and the project
+// context from contextPath if it is set. Missing commands/ and skills/
+// directories are skipped.
+//
+// A skill's references and assets are relative to skills/<name>/ if that
+// directory exists, and to skills/ otherwise.
+func Load(specsDir, contextPath string) (*Specs, error) {
+	specs := &Specs{SkillDirs: make(map[string]string)}
+
+	commandsDir := filepath.Join(specsDir, "commands")
+	if _, err := os.Stat(commandsDir); err == nil {
+		cmds, err := commands.ReadCanonicalDir(commandsDir)
+		if err != nil {
+			return nil, fmt.Errorf("loading commands: %w", err)
+		}
+		specs.Commands = cmds
+	}
+
+	skillsDir := filepath.Join(specsDir, "skills")
+	if _, err := os.Stat(skillsDir); err == nil {
+		skls, err := skills.ReadCanonicalDir(skillsDir)
+		if err != nil {
+			return nil, fmt.Errorf("loading skills: %w", err)
+		}
+		specs.Skills = skls
+		for _, skill := range skls {
+			dir := filepath.Join(skillsDir, skill.Name)
+			if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+				dir = skillsDir
+			}
+			specs.SkillDirs[skill.Name] = dir
+		}
+	}
+
+	if contextPath != "" {
+		ctx, err := contextcore.ReadFile(contextPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading context: %w", err)
+		}
+		specs.Context = ctx
+	}
+	return specs, nil
+}
+
+// New returns a server for the specs.
+func New(specs *Specs) *protocol.Server {
+	h := &handler{specs: specs, commands: make(map[string]*commands.Command)}
+	for _, cmd := range specs.Commands {
+		h.commands[cmd.Name] = cmd
+	}
+	return &protocol.Server{
+		Info:          protocol.Implementation{Name: "assistantkit", Version: assistantkit.Version},
+		Instructions:  "Prompts are the team's commands; resources are its skills, their references and assets, and the project context.",
+		ListPrompts:   h.listPrompts,
+		GetPrompt:     h.getPrompt,
+		ListResources: h.listResources,
+		ReadResource:  h.readResource,
+	}
+}
+
+type handler struct {
+	specs    *Specs
+	commands map[string]*commands.Command
+}
+
+func (h *handler) listPrompts(ctx context.Context) ([]protocol.Prompt, error) {
+	prompts := make([]protocol.Prompt, 0, len(h.specs.Commands))
+	for _, cmd := range h.specs.Commands {
+		prompts = append(prompts, Prompt(cmd))
+	}
+	return prompts, nil
+}
+
+// Prompt returns the prompt for a command.
+func Prompt(cmd *commands.Command) protocol.Prompt {
+	prompt := protocol.Prompt{Name: cmd.Name, Description: cmd.Description}
+	for _, arg := range cmd.Arguments {
+		desc := arg.Description
+		if desc == "" {
+			desc = arg.Hint
+		}
+		if arg.Default != "" {
+			desc = strings.TrimSpace(desc + fmt.Sprintf(" (default: %s)", arg.Default))
+		}
+		prompt.Arguments = append(prompt.Arguments, protocol.PromptArgument{
+			Name:        arg.Name,
+			Description: desc,
+			Required:    arg.Required,
+		})
+	}
+	return prompt
+}
+
+func (h *handler) getPrompt(ctx context.Context, params protocol.GetPromptParams) (*protocol.GetPromptResult, error) {
+	cmd, ok := h.commands[params.Name]
+	if !ok {
+		return nil, protocol.InvalidParams("unknown prompt: %s", params.Name)
+	}
+	text, err := Render(cmd, params.Arguments)
+	if err != nil {
+		return nil, protocol.InvalidParams("%v", err)
+	}
+	return &protocol.GetPromptResult{
+		Description: cmd.Description,
+		Messages:    []protocol.PromptMessage{{Role: "user", Content: protocol.TextContent(text)}},
+	}, nil
+}
+
+// placeholderPattern matches {{name}} argument placeholders.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)\s*\}\}`)
+
+// Render returns the text of a command's prompt for the given arguments.
+// Required arguments must be set and values must match argument patterns;
+// unset optional arguments take their defaults.
+func Render(cmd *commands.Command, args map[string]string) (string, error) {
+	values := make(map[string]string, len(cmd.Arguments))
+	for _, arg := range cmd.Arguments {
+		value, ok := args[arg.Name]
+		if !ok || value == "" {
+			if arg.Required {
+				return "", fmt.Errorf("missing required argument %q", arg.Name)
+			}
+			value = arg.Default
+		}
+		if value != "" && arg.Pattern != "" {
+			re, err := regexp.Compile(arg.Pattern)
+			if err != nil {
+				return "", fmt.Errorf("argument %q has an invalid pattern: %w", arg.Name, err)
+			}
+			if !re.MatchString(value) {
+				return "", fmt.Errorf("argument %q does not match %s", arg.Name, arg.Pattern)
+			}
+		}
+		values[arg.Name] = value
+	}
+	for name := range args {
+		if _, ok := values[name]; !ok {
+			return "", fmt.Errorf("unknown argument %q", name)
+		}
+	}
+
+	instructions := cmd.Instructions
+	if instructions == "" {
+		instructions = cmd.Description
+	}
+	var b strings.Builder
+	b.WriteString(placeholderPattern.ReplaceAllStringFunc(instructions, func(s string) string {
+		name := placeholderPattern.FindStringSubmatch(s)[1]
+		if value, ok := values[name]; ok {
+			return value
+		}
+		return s
+	}))
+	b.WriteString("\n")
+
+	if len(cmd.Process) > 0 {
+		b.WriteString("\n## Process\n\n")
+		for i, step := range cmd.Process {
+			fmt.Fprintf(&b, "%d. %s\n", i+1, step)
+		}
+	}
+	var set []string
+	for _, arg := range cmd.Arguments {
+		if values[arg.Name] != "" {
+			set = append(set, fmt.Sprintf("- %s: %s", arg.Name, values[arg.Name]))
+		}
+	}
+	if len(set) > 0 {
+		b.WriteString("\n## Arguments\n\n")
+		b.WriteString(strings.Join(set, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// SkillURI returns the URI of a skill's resource, or of one of its files if
+// path is set.
+func SkillURI(skill, path string) string {
+	if path == "" {
+		return "skill://" + skill
+	}
+	return "skill://" + skill + "/" + filepath.ToSlash(path)
+}
+
+// ContextURI returns the URI of the project context resource.
+func ContextURI(name string) string {
+	return "context://" + name
+}
+
+func (h *handler) listResources(ctx context.Context) ([]protocol.Resource, error) {
+	var resources []protocol.Resource
+	for _, skill := range h.specs.Skills {
+		resources = append(resources, protocol.Resource{
+			URI:         SkillURI(skill.Name, ""),
+			Name:        skill.Name,
+			Description: skill.Description,
+			MIMEType:    "text/markdown",
+		})
+		for _, file := range skillFiles(skill) {
+			resources = append(resources, protocol.Resource{
+				URI:      SkillURI(skill.Name, file),
+				Name:     skill.Name + "/" + filepath.ToSlash(file),
+				MIMEType: mimeType(file),
+			})
+		}
+	}
+	if c := h.specs.Context; c != nil {
+		resources = append(resources, protocol.Resource{
+			URI:         ContextURI(c.Name),
+			Name:        c.Name,
+			Description: "Project context",
+			MIMEType:    "text/markdown",
+		})
+	}
+	return resources, nil
+}
+
+func (h *handler) readResource(ctx context.Context, params protocol.ReadResourceParams) (*protocol.ReadResourceResult, error) {
+	uri := params.URI
+	if c := h.specs.Context; c != nil && uri == ContextURI(c.Name) {
+		return text(uri, "text/markdown", contextcore.Markdown(c)), nil
+	}
+	for _, skill := range h.specs.Skills {
+		if uri == SkillURI(skill.Name, "") {
+			return text(uri, "text/markdown", skill.Instructions), nil
+		}
+		for _, file := range skillFiles(skill) {
+			if uri == SkillURI(skill.Name, file) {
+				return h.readSkillFile(uri, skill.Name, file)
+			}
+		}
+	}
+	return nil, protocol.InvalidParams("unknown resource: %s", uri)
+}
+
+func (h *handler) readSkillFile(uri, skill, file string) (*protocol.ReadResourceResult, error) {
+	dir := h.specs.SkillDirs[skill]
+	path := filepath.Join(dir, file)
+	if rel, err := filepath.Rel(dir, path); err != nil || strings.HasPrefix(rel, "..") {
+		return nil, protocol.InvalidParams("resource %s is outside the skill directory", uri)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	mimeType := mimeType(file)
+	if utf8.Valid(data) {
+		return text(uri, mimeType, string(data)), nil
+	}
+	return &protocol.ReadResourceResult{Contents: []protocol.ResourceContents{
+		{URI: uri, MIMEType: mimeType, Blob: base64.StdEncoding.EncodeToString(data)},
+	}}, nil
+}
+
+// skillFiles returns a skill's references and assets, sorted.
+func skillFiles(skill *skills.Skill) []string {
+	files := append(append([]string(nil), skill.References...), skill.Assets...)
+	sort.Strings(files)
+	return files
+}
+
+func mimeType(file string) string {
+	switch ext := strings.ToLower(filepath.Ext(file)); ext {
+	case ".md", ".markdown":
+		return "text/markdown"
+	case "":
+		return "text/plain"
+	default:
+		if t := mime.TypeByExtension(ext); t != "" {
+			return t
+		}
+		return "application/octet-stream"
+	}
+}
+
+func text(uri, mimeType, s string) *protocol.ReadResourceResult {
+	return &protocol.ReadResourceResult{Contents: []protocol.ResourceContents{
+		{URI: uri, MIMEType: mimeType, Text: s},
+	}}
+}
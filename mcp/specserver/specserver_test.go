@@ -0,0 +1,155 @@
+package specserver
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	commands "github.com/agentplexus/assistantkit/commands/core"
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/mcp/protocol"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestServer(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"specs/commands/release.json": `{
+  "name": "release",
+  "description": "Cut a release",
+  "instructions": "Release version {{version}} to {{channel}}.",
+  "process": ["Tag", "Publish"],
+  "arguments": [
+    {"name": "version", "type": "string", "required": true, "pattern": "^v[0-9]"},
+    {"name": "channel", "type": "string", "default": "stable", "hint": "Release channel"}
+  ]
+}`,
+		"specs/skills/review/skill.json": `{
+  "name": "review",
+  "description": "Review code",
+  "instructions": "Review the diff.",
+  "references": ["checklist.md"],
+  "assets": ["logo.png"]
+}`,
+		"specs/skills/review/checklist.md": "- tests\n",
+		"specs/skills/review/logo.png":     "\x89PNG\x00\xff",
+		"CONTEXT.json":                     `{"name": "demo", "description": "A demo project", "language": "go"}`,
+	})
+	specs, err := Load(filepath.Join(dir, "specs"), filepath.Join(dir, "CONTEXT.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	srv := httptest.NewServer(New(specs))
+	defer srv.Close()
+	ctx := context.Background()
+	client, err := protocol.Connect(ctx, core.Server{URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer client.Close()
+	init, err := client.Initialize(ctx)
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if init.Capabilities.Prompts == nil || init.Capabilities.Resources == nil || init.Capabilities.Tools != nil {
+		t.Errorf("Expected prompts and resources capabilities, got %+v", init.Capabilities)
+	}
+
+	prompts, err := client.ListPrompts(ctx)
+	if err != nil || len(prompts) != 1 {
+		t.Fatalf("Expected one prompt, got %v, %v", prompts, err)
+	}
+	args := prompts[0].Arguments
+	if len(args) != 2 || !args[0].Required || args[1].Required || args[1].Description != "Release channel (default: stable)" {
+		t.Errorf("Unexpected prompt arguments: %+v", args)
+	}
+
+	result, err := client.GetPrompt(ctx, protocol.GetPromptParams{Name: "release", Arguments: map[string]string{"version": "v1.2.0"}})
+	if err != nil {
+		t.Fatalf("GetPrompt failed: %v", err)
+	}
+	expected := "Release version v1.2.0 to stable.\n\n## Process\n\n1. Tag\n2. Publish\n\n## Arguments\n\n- version: v1.2.0\n- channel: stable\n"
+	if got := result.Messages[0].Content.Text; got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+	_, err = client.GetPrompt(ctx, protocol.GetPromptParams{Name: "release"})
+	var rpcErr *protocol.Error
+	if !errors.As(err, &rpcErr) || !strings.Contains(rpcErr.Message, `missing required argument "version"`) {
+		t.Errorf("Expected missing argument error, got %v", err)
+	}
+
+	resources, err := client.ListResources(ctx)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	var uris []string
+	for _, r := range resources {
+		uris = append(uris, r.URI)
+	}
+	expectedURIs := "skill://review skill://review/checklist.md skill://review/logo.png context://demo"
+	if strings.Join(uris, " ") != expectedURIs {
+		t.Errorf("Expected %s, got %v", expectedURIs, uris)
+	}
+
+	reads := map[string]func(protocol.ResourceContents) bool{
+		"skill://review":              func(c protocol.ResourceContents) bool { return c.Text == "Review the diff." },
+		"skill://review/checklist.md": func(c protocol.ResourceContents) bool { return c.Text == "- tests\n" && c.MIMEType == "text/markdown" },
+		"skill://review/logo.png":     func(c protocol.ResourceContents) bool { return c.Blob != "" && c.MIMEType == "image/png" },
+		"context://demo":              func(c protocol.ResourceContents) bool { return strings.HasPrefix(c.Text, "# demo") },
+	}
+	for uri, check := range reads {
+		result, err := client.ReadResource(ctx, protocol.ReadResourceParams{URI: uri})
+		if err != nil {
+			t.Errorf("ReadResource %s failed: %v", uri, err)
+			continue
+		}
+		if !check(result.Contents[0]) {
+			t.Errorf("Unexpected contents of %s: %+v", uri, result.Contents[0])
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	cmd := &commands.Command{
+		Name:        "greet",
+		Description: "Say hello",
+		Arguments:   []commands.Argument{{Name: "name", Pattern: "^[A-Z]"}},
+	}
+	if text, err := Render(cmd, nil); err != nil || text != "Say hello\n" {
+		t.Errorf("Expected the description without arguments, got %q, %v", text, err)
+	}
+	if _, err := Render(cmd, map[string]string{"name": "ada"}); err == nil {
+		t.Error("Expected error for a value that does not match the pattern")
+	}
+	if _, err := Render(cmd, map[string]string{"nme": "Ada"}); err == nil {
+		t.Error("Expected error for an unknown argument")
+	}
+}
+
+func TestLoadEmpty(t *testing.T) {
+	specs, err := Load(t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(specs.Commands) != 0 || len(specs.Skills) != 0 || specs.Context != nil {
+		t.Errorf("Expected empty specs, got %+v", specs)
+	}
+}
@@ -0,0 +1,278 @@
+package mcp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+func syncSource() *Config {
+	cfg := NewConfig()
+	cfg.AddServer("github", Server{Command: "npx", Args: []string{"-y", "server-github"}})
+	cfg.AddServer("docs", Server{URL: "https://example.com/mcp"})
+	return cfg
+}
+
+func changes(result *SyncResult) []string {
+	var out []string
+	for _, c := range result.Changes {
+		s := c.Server + ":" + string(c.Kind)
+		if c.Conflict {
+			s += "!"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func readServers(t *testing.T, tool, path string) map[string]Server {
+	t.Helper()
+	adapter, _ := GetAdapter(tool)
+	cfg, err := adapter.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	return cfg.Servers
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSync(t *testing.T) {
+	dir := t.TempDir()
+	cursorPath := filepath.Join(dir, "cursor.json")
+	codexPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(cursorPath, []byte(`{"mcpServers": {"personal": {"command": "mine"}}, "theme": "dark"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(codexPath, []byte("model = \"o3\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	targets := []SyncTarget{{Tool: "cursor", Path: cursorPath}, {Tool: "codex", Path: codexPath}}
+	lock := core.NewLockfile()
+
+	// First sync adds the canonical servers and leaves others alone.
+	results, err := Sync(syncSource(), targets, lock, SyncOptions{Strategy: StrategyOurs})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	for _, r := range results {
+		if got := changes(r); !reflect.DeepEqual(got, []string{"docs:add", "github:add"}) {
+			t.Errorf("%s: expected docs and github added, got %v", r.Target.Tool, got)
+		}
+	}
+	if _, ok := readServers(t, "cursor", cursorPath)["personal"]; !ok {
+		t.Error("Expected unmanaged cursor server to be kept")
+	}
+	if data, _ := os.ReadFile(cursorPath); !strings.Contains(string(data), `"theme": "dark"`) {
+		t.Errorf("Expected unrelated settings to be kept, got %s", data)
+	}
+	if len(lock.Targets) != 2 {
+		t.Fatalf("Expected 2 lock targets, got %d", len(lock.Targets))
+	}
+
+	// A second sync is a no-op.
+	results, err = Sync(syncSource(), targets, lock, SyncOptions{Strategy: StrategyOurs})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	for _, r := range results {
+		if len(r.Changes) != 0 || r.Merge != nil {
+			t.Errorf("%s: expected no changes, got %v", r.Target.Tool, changes(r))
+		}
+	}
+
+	// Canonical changes are applied where the target was not edited.
+	source := syncSource()
+	source.RemoveServer("docs")
+	source.AddServer("github", Server{Command: "npx", Args: []string{"-y", "server-github@2"}})
+	results, err = Sync(source, targets[:1], lock, SyncOptions{Strategy: StrategyOurs})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if got := changes(results[0]); !reflect.DeepEqual(got, []string{"docs:remove", "github:update"}) {
+		t.Errorf("Expected docs removed and github updated, got %v", got)
+	}
+	servers := readServers(t, "cursor", cursorPath)
+	if _, ok := servers["docs"]; ok {
+		t.Error("Expected docs to be removed")
+	}
+	if _, ok := servers["personal"]; !ok {
+		t.Error("Expected unmanaged server to survive removal of synced servers")
+	}
+}
+
+func TestSyncConflicts(t *testing.T) {
+	setup := func(t *testing.T) (SyncTarget, *Lockfile) {
+		path := filepath.Join(t.TempDir(), ".mcp.json")
+		target := SyncTarget{Tool: "claude", Path: path}
+		lock := core.NewLockfile()
+		if _, err := Sync(syncSource(), []SyncTarget{target}, lock, SyncOptions{}); err != nil {
+			t.Fatalf("Sync failed: %v", err)
+		}
+		// Edit the github server by hand.
+		data, _ := os.ReadFile(path)
+		edited := strings.Replace(string(data), "server-github", "server-github-fork", 1)
+		if err := os.WriteFile(path, []byte(edited), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return target, lock
+	}
+
+	tests := []struct {
+		strategy SyncStrategy
+		resolver func(Conflict) (SyncStrategy, error)
+		expected string
+		kind     string
+	}{
+		{StrategyOurs, nil, "server-github", "github:update!"},
+		{StrategyTheirs, nil, "server-github-fork", "github:keep!"},
+		{StrategyPrompt, func(c Conflict) (SyncStrategy, error) {
+			if c.Server != "github" || c.Theirs.Args[1] != "server-github-fork" || c.Base.Args[1] != "server-github" {
+				t.Errorf("Unexpected conflict: %+v", c)
+			}
+			return StrategyTheirs, nil
+		}, "server-github-fork", "github:keep!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			target, lock := setup(t)
+			results, err := Sync(syncSource(), []SyncTarget{target}, lock, SyncOptions{Strategy: tt.strategy, Resolver: tt.resolver})
+			if err != nil {
+				t.Fatalf("Sync failed: %v", err)
+			}
+			if got := changes(results[0]); !reflect.DeepEqual(got, []string{tt.kind}) {
+				t.Errorf("Expected %s, got %v", tt.kind, got)
+			}
+			if got := readServers(t, "claude", target.Path)["github"].Args[1]; got != tt.expected {
+				t.Errorf("Expected github args %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("unresolved", func(t *testing.T) {
+		target, lock := setup(t)
+		_, err := Sync(syncSource(), []SyncTarget{target}, lock, SyncOptions{Strategy: StrategyPrompt})
+		if !errors.Is(err, core.ErrSyncConflict) {
+			t.Errorf("Expected ErrSyncConflict, got %v", err)
+		}
+	})
+}
+
+func TestSyncLockfileSecrets(t *testing.T) {
+	dir := t.TempDir()
+	target := SyncTarget{Tool: "claude", Path: filepath.Join(dir, ".mcp.json")}
+	lockPath := filepath.Join(dir, "mcp.lock.json")
+	source := NewConfig()
+	source.AddServer("github", Server{
+		Command: "npx",
+		Env:     map[string]string{"GITHUB_TOKEN": "ghp_plaintextsecret123"},
+	})
+	source.AddServer("docs", Server{URL: "https://example.com/mcp", Headers: map[string]string{"Authorization": "Bearer hunter2"}})
+
+	lock := core.NewLockfile()
+	if _, err := Sync(source, []SyncTarget{target}, lock, SyncOptions{Strategy: StrategyOurs}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if err := lock.WriteFile(lockPath); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(lockPath)
+	if strings.Contains(string(data), "ghp_plaintextsecret123") || strings.Contains(string(data), "hunter2") {
+		t.Fatalf("Expected no plaintext secrets in the lockfile, got %s", data)
+	}
+
+	// An unchanged target is up to date against the hashed lockfile.
+	lock, err := ReadLockfile(lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := Sync(source, []SyncTarget{target}, lock, SyncOptions{})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if got := changes(results[0]); len(got) != 0 {
+		t.Errorf("Expected no changes, got %v", got)
+	}
+
+	// A hand-edited token is still a conflict.
+	edited := strings.Replace(readFile(t, target.Path), "ghp_plaintextsecret123", "ghp_othertoken456", 1)
+	if err := os.WriteFile(target.Path, []byte(edited), 0600); err != nil {
+		t.Fatal(err)
+	}
+	results, err = Sync(source, []SyncTarget{target}, lock, SyncOptions{Strategy: StrategyTheirs})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if got := changes(results[0]); !reflect.DeepEqual(got, []string{"github:keep!"}) {
+		t.Errorf("Expected github conflict, got %v", got)
+	}
+}
+
+func TestSyncDryRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".mcp.json")
+	lock := core.NewLockfile()
+
+	results, err := Sync(syncSource(), []SyncTarget{{Tool: "claude", Path: path}}, lock, SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if results[0].Merge == nil || !strings.Contains(results[0].Merge.Diff, `+    "github": {`) {
+		t.Errorf("Expected diff for dry run, got %+v", results[0].Merge)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected dry run not to write the target")
+	}
+	if len(lock.Targets) != 0 {
+		t.Error("Expected dry run not to update the lockfile")
+	}
+}
+
+func TestSyncProxy(t *testing.T) {
+	source := syncSource()
+	source.AddServer("search", Server{Command: "search-server", DisabledTools: []string{"delete"}})
+	dir := t.TempDir()
+	cursorPath := filepath.Join(dir, "cursor.json")
+	codexPath := filepath.Join(dir, "config.toml")
+	targets := []SyncTarget{{Tool: "cursor", Path: cursorPath}, {Tool: "codex", Path: codexPath}}
+
+	_, err := Sync(source, targets, core.NewLockfile(), SyncOptions{Strategy: StrategyOurs, Proxy: "mcp.json"})
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	// Cursor drops disabledTools, so the server goes behind the proxy.
+	cursor := readServers(t, "cursor", cursorPath)
+	if _, ok := cursor["search"]; ok {
+		t.Error("Expected search to be proxied for cursor")
+	}
+	proxy, ok := cursor[core.ProxyServerName]
+	expectedArgs := []string{"mcp", "proxy", "--config", "mcp.json", "--from", "canonical", "--server", "search"}
+	if !ok || proxy.Command != "assistantkit" || !reflect.DeepEqual(proxy.Args, expectedArgs) {
+		t.Errorf("Expected proxy entry with args %v, got %+v", expectedArgs, proxy)
+	}
+	if _, ok := cursor["github"]; !ok {
+		t.Error("Expected github to be synced directly to cursor")
+	}
+
+	// Codex applies disabledTools itself.
+	codex := readServers(t, "codex", codexPath)
+	if _, ok := codex[core.ProxyServerName]; ok {
+		t.Error("Expected no proxy entry for codex")
+	}
+	if !reflect.DeepEqual(codex["search"].DisabledTools, []string{"delete"}) {
+		t.Errorf("Expected disabledTools for codex, got %+v", codex["search"])
+	}
+}
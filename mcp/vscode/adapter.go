@@ -2,7 +2,8 @@
 //
 // VS Code uses a different format than Claude:
 //   - Root key is "servers" (not "mcpServers")
-//   - Has "inputs" section for secret management
+//   - Has "inputs" section for secret management; secret references are
+//     written as ${input:id} placeholders with a generated password input
 //   - Requires explicit "type" field
 //   - Supports "envFile" for loading env files
 //
@@ -13,11 +14,15 @@ package vscode
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
 )
 
 const (
@@ -138,12 +143,12 @@ func (a *Adapter) ToCore(vscodeCfg *Config) *core.Config {
 		}
 
 		// Set transport type
-		switch server.Type {
-		case "stdio":
+		switch core.ParseTransport(server.Type) {
+		case core.TransportStdio:
 			coreServer.Transport = core.TransportStdio
-		case "http":
+		case core.TransportHTTP:
 			coreServer.Transport = core.TransportHTTP
-		case "sse":
+		case core.TransportSSE:
 			coreServer.Transport = core.TransportSSE
 		}
 
@@ -169,15 +174,17 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 		})
 	}
 
-	// Convert servers
-	for name, server := range cfg.Servers {
+	// Convert servers in name order so that generated inputs are stable
+	inputs := newInputSet(vscodeCfg)
+	for _, name := range sortedNames(cfg) {
+		server := cfg.Servers[name]
 		vscodeServer := ServerConfig{
 			Command: server.Command,
 			Args:    server.Args,
-			Env:     server.Env,
+			Env:     inputs.render(name, core.FieldEnv, server.Env),
 			EnvFile: server.EnvFile,
 			URL:     server.URL,
-			Headers: server.Headers,
+			Headers: inputs.render(name, core.FieldHeaders, server.Headers),
 		}
 
 		// VS Code requires explicit type
@@ -196,6 +203,108 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	return vscodeCfg
 }
 
+// inputSet generates a password input for each secret reference, since VS
+// Code prompts for secrets instead of reading them from the environment.
+type inputSet struct {
+	cfg *Config
+	ids map[string]bool
+}
+
+func newInputSet(cfg *Config) *inputSet {
+	ids := make(map[string]bool, len(cfg.Inputs))
+	for _, input := range cfg.Inputs {
+		ids[input.ID] = true
+	}
+	return &inputSet{cfg: cfg, ids: ids}
+}
+
+// render returns a copy of values with every secret reference replaced by an
+// ${input:id} placeholder, adding an input for ids not yet defined.
+func (s *inputSet) render(server, field string, values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	out := make(map[string]string, len(values))
+	for _, key := range sortedKeys(values) {
+		out[key] = core.ReplaceSecretRefs(values[key], func(ref core.SecretRef) string {
+			name := core.SecretVar(server, field, key, ref)
+			id := inputID(name)
+			if !s.ids[id] {
+				s.ids[id] = true
+				s.cfg.Inputs = append(s.cfg.Inputs, InputVariable{
+					Type:        "promptString",
+					ID:          id,
+					Description: fmt.Sprintf("%s (%s)", name, ref),
+					Password:    true,
+				})
+			}
+			return "${input:" + id + "}"
+		})
+	}
+	return out
+}
+
+// inputID converts an environment variable name to an input id,
+// e.g. GITHUB_TOKEN to github-token.
+func inputID(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+}
+
+// Lossiness reports the canonical fields that the VS Code format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv, core.FieldEnvFile,
+		core.FieldURL, core.FieldHeaders, core.FieldInputs)
+	losses = append(losses, core.PlaintextSecrets(AdapterName, cfg)...)
+
+	for _, name := range sortedNames(cfg) {
+		server := cfg.Servers[name]
+		for _, field := range []string{core.FieldEnv, core.FieldHeaders} {
+			values := server.Env
+			if field == core.FieldHeaders {
+				values = server.Headers
+			}
+			for _, key := range sortedKeys(values) {
+				for _, ref := range core.SecretRefs(values[key]) {
+					losses = append(losses, core.Loss{
+						Adapter: AdapterName,
+						Path:    "servers." + name + "." + field,
+						Kind:    core.LossDegraded,
+						Detail: fmt.Sprintf("%s: %s is written as ${input:%s} and prompted for",
+							key, ref, inputID(core.SecretVar(name, field, key, ref))),
+					})
+				}
+			}
+		}
+	}
+	return losses
+}
+
+func sortedNames(cfg *core.Config) []string {
+	names := cfg.ServerNames()
+	sort.Strings(names)
+	return names
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Merge writes cfg into an existing VS Code config, replacing only the
+// servers and inputs keys and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "servers", "inputs")
+}
+
 // WorkspaceConfigPath returns the workspace config path.
 func WorkspaceConfigPath() string {
 	return filepath.Join(WorkspaceConfigDir, ConfigFileName)
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 
 	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
 )
 
 const (
@@ -104,10 +105,10 @@ func (a *Adapter) ToCore(windsurfCfg *Config) *core.Config {
 		}
 
 		// Set transport type
-		switch server.Type {
-		case "stdio":
+		switch core.ParseTransport(server.Type) {
+		case core.TransportStdio:
 			coreServer.Transport = core.TransportStdio
-		case "http":
+		case core.TransportHTTP:
 			coreServer.Transport = core.TransportHTTP
 		default:
 			if server.Command != "" {
@@ -133,9 +134,9 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 		windsurfServer := ServerConfig{
 			Command:       server.Command,
 			Args:          server.Args,
-			Env:           server.Env,
+			Env:           core.RenderEnvPlaceholders(name, core.FieldEnv, server.Env),
 			ServerURL:     server.URL, // Note: URL -> serverUrl
-			Headers:       server.Headers,
+			Headers:       core.RenderEnvPlaceholders(name, core.FieldHeaders, server.Headers),
 			DisabledTools: server.DisabledTools,
 		}
 
@@ -149,6 +150,25 @@ func (a *Adapter) FromCore(cfg *core.Config) *Config {
 	return windsurfCfg
 }
 
+// Lossiness reports the canonical fields that the Windsurf format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldURL, core.FieldHeaders, core.FieldDisabledTools)
+	losses = append(losses, core.DegradedTransports(AdapterName, cfg, core.TransportStdio, core.TransportHTTP)...)
+	return append(losses, core.SecretLosses(AdapterName, cfg)...)
+}
+
+// Merge writes cfg into an existing Windsurf config, replacing only the
+// mcpServers key and keeping all other settings.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "mcpServers")
+}
+
 // ConfigPath returns the default Windsurf config path.
 func ConfigPath() (string, error) {
 	home, err := os.UserHomeDir()
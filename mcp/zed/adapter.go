@@ -0,0 +1,251 @@
+// Package zed provides an adapter for Zed MCP configuration.
+//
+// Zed calls MCP servers context servers and reads them from the
+// context_servers key of its settings.json, which is JSONC and holds all
+// other editor settings. Use Merge or mcp.MergeFile to update the servers
+// without replacing the rest of the file.
+//
+// Field mapping:
+//
+//	canonical       Zed
+//	command         command (older versions: command.path)
+//	args, env       args, env
+//	url, headers    url, headers (Streamable HTTP)
+//	enabled         enabled
+//
+// Servers provided by Zed extensions ("source": "extension") are not read,
+// and Merge keeps them.
+//
+// File locations:
+//   - Project: <project>/.zed/settings.json
+//   - User: ~/.config/zed/settings.json
+package zed
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+	"github.com/agentplexus/assistantkit/merge"
+)
+
+const (
+	// AdapterName is the identifier for this adapter.
+	AdapterName = "zed"
+
+	// ConfigFileName is the settings file name.
+	ConfigFileName = "settings.json"
+
+	// ProjectConfigDir is the project config directory.
+	ProjectConfigDir = ".zed"
+)
+
+// Adapter implements core.Adapter for Zed.
+type Adapter struct{}
+
+// NewAdapter creates a new Zed adapter.
+func NewAdapter() *Adapter {
+	return &Adapter{}
+}
+
+// Name returns the adapter name.
+func (a *Adapter) Name() string {
+	return AdapterName
+}
+
+// DefaultPaths returns the default config file paths for Zed.
+func (a *Adapter) DefaultPaths() []string {
+	paths := []string{
+		filepath.Join(ProjectConfigDir, ConfigFileName),
+	}
+	if path, err := UserConfigPath(); err == nil {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// Parse parses Zed settings data into the canonical format.
+func (a *Adapter) Parse(data []byte) (*core.Config, error) {
+	var zedCfg Config
+	if err := json.Unmarshal(merge.StripJSONC(data), &zedCfg); err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Err: err}
+	}
+	return a.ToCore(&zedCfg), nil
+}
+
+// Marshal converts canonical config to Zed format.
+func (a *Adapter) Marshal(cfg *core.Config) ([]byte, error) {
+	zedCfg := a.FromCore(cfg)
+	return json.MarshalIndent(zedCfg, "", "  ")
+}
+
+// ReadFile reads a Zed settings file.
+func (a *Adapter) ReadFile(path string) (*core.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &core.ParseError{Format: AdapterName, Path: path, Err: err}
+	}
+	cfg, err := a.Parse(data)
+	if err != nil {
+		if pe, ok := err.(*core.ParseError); ok {
+			pe.Path = path
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// WriteFile writes canonical config to a Zed settings file, replacing its
+// contents.
+func (a *Adapter) WriteFile(cfg *core.Config, path string) error {
+	data, err := a.Marshal(cfg)
+	if err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	// Ensure directory exists
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	if err := os.WriteFile(path, data, core.DefaultFileMode); err != nil {
+		return &core.WriteError{Format: AdapterName, Path: path, Err: err}
+	}
+	return nil
+}
+
+// ToCore converts Zed config to canonical format.
+func (a *Adapter) ToCore(zedCfg *Config) *core.Config {
+	cfg := core.NewConfig()
+
+	for name, server := range zedCfg.ContextServers {
+		if isExtension(server) {
+			continue
+		}
+		coreServer := core.Server{
+			Command: server.Command,
+			Args:    server.Args,
+			Env:     server.Env,
+			URL:     server.URL,
+			Headers: server.Headers,
+			Enabled: server.Enabled,
+		}
+
+		// Infer transport type
+		if server.Command != "" {
+			coreServer.Transport = core.TransportStdio
+		} else if server.URL != "" {
+			coreServer.Transport = core.TransportHTTP
+		}
+
+		cfg.Servers[name] = coreServer
+	}
+
+	return cfg
+}
+
+// FromCore converts canonical config to Zed format.
+func (a *Adapter) FromCore(cfg *core.Config) *Config {
+	zedCfg := NewConfig()
+
+	for name, server := range cfg.Servers {
+		zedServer := ServerConfig{
+			Source:  SourceCustom,
+			Command: server.Command,
+			Args:    server.Args,
+			Env:     core.RenderEnvPlaceholders(name, core.FieldEnv, server.Env),
+			URL:     server.URL,
+			Headers: core.RenderEnvPlaceholders(name, core.FieldHeaders, server.Headers),
+		}
+
+		if !server.IsEnabled() {
+			enabled := false
+			zedServer.Enabled = &enabled
+		}
+
+		zedCfg.ContextServers[name] = zedServer
+	}
+
+	return zedCfg
+}
+
+// isExtension reports whether a context server is provided by a Zed
+// extension rather than configured as an MCP server. Older versions mark
+// extension servers only by their lack of a command or URL.
+func isExtension(server ServerConfig) bool {
+	return server.Source == SourceExtension || (server.Command == "" && server.URL == "")
+}
+
+// Lossiness reports the canonical fields that the Zed format drops or degrades.
+func (a *Adapter) Lossiness(cfg *core.Config) []core.Loss {
+	losses := core.DroppedFields(AdapterName, cfg,
+		core.FieldTransport, core.FieldCommand, core.FieldArgs, core.FieldEnv,
+		core.FieldURL, core.FieldHeaders, core.FieldEnabled)
+	losses = append(losses, core.DegradedTransports(AdapterName, cfg, core.TransportStdio, core.TransportHTTP)...)
+	return append(losses, core.SecretLosses(AdapterName, cfg)...)
+}
+
+// Merge writes cfg into existing Zed settings, replacing only the
+// context_servers key and keeping all other settings, comments and the
+// servers provided by extensions.
+func (a *Adapter) Merge(cfg *core.Config, existing []byte) ([]byte, error) {
+	servers := make(map[string]any)
+	for name, server := range a.FromCore(cfg).ContextServers {
+		servers[name] = server
+	}
+	for name, raw := range extensionServers(existing) {
+		if _, ok := servers[name]; !ok {
+			servers[name] = raw
+		}
+	}
+	data, err := json.MarshalIndent(map[string]any{"context_servers": servers}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return merge.JSON(existing, data, "context_servers")
+}
+
+// extensionServers returns the extension servers in existing settings.
+func extensionServers(existing []byte) map[string]json.RawMessage {
+	var settings struct {
+		ContextServers map[string]json.RawMessage `json:"context_servers"`
+	}
+	if err := json.Unmarshal(merge.StripJSONC(existing), &settings); err != nil {
+		return nil
+	}
+	kept := make(map[string]json.RawMessage)
+	for name, raw := range settings.ContextServers {
+		var server ServerConfig
+		if err := json.Unmarshal(raw, &server); err == nil && isExtension(server) {
+			kept[name] = raw
+		}
+	}
+	return kept
+}
+
+// ProjectConfigPath returns the project settings path for a given project root.
+func ProjectConfigPath(projectRoot string) string {
+	return filepath.Join(projectRoot, ProjectConfigDir, ConfigFileName)
+}
+
+// UserConfigPath returns the user-level settings path.
+func UserConfigPath() (string, error) {
+	if runtime.GOOS == "windows" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "Zed", ConfigFileName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "zed", ConfigFileName), nil
+}
+
+// init registers the adapter with the default registry.
+func init() {
+	core.Register(NewAdapter())
+}
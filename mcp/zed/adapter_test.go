@@ -0,0 +1,232 @@
+package zed
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/mcp/core"
+)
+
+func TestAdapterName(t *testing.T) {
+	adapter := NewAdapter()
+	if adapter.Name() != "zed" {
+		t.Errorf("Expected name 'zed', got %q", adapter.Name())
+	}
+}
+
+func TestAdapterDefaultPaths(t *testing.T) {
+	adapter := NewAdapter()
+	paths := adapter.DefaultPaths()
+
+	if len(paths) == 0 {
+		t.Fatal("Expected at least one default path")
+	}
+	// First path should be project settings
+	if paths[0] != filepath.Join(ProjectConfigDir, ConfigFileName) {
+		t.Errorf("Expected project settings path first, got %q", paths[0])
+	}
+}
+
+func TestAdapterParse(t *testing.T) {
+	adapter := NewAdapter()
+
+	// Zed settings are JSONC
+	jsonData := []byte(`{
+		// Editor settings are not MCP servers
+		"theme": "One Dark",
+		"context_servers": {
+			"github": {
+				"source": "custom",
+				"command": "npx",
+				"args": ["-y", "@modelcontextprotocol/server-github"],
+				"env": {"GITHUB_TOKEN": "${GITHUB_TOKEN}"},
+			},
+			"legacy": {
+				"command": {"path": "node", "args": ["server.js"], "env": {"DEBUG": "1"}}
+			},
+			"remote": {
+				"url": "https://api.example.com/mcp",
+				"headers": {"Authorization": "Bearer ${API_TOKEN}"},
+				"enabled": false
+			},
+			"postgres": {
+				"source": "extension",
+				"settings": {"database_url": "postgres://localhost"}
+			}
+		}
+	}`)
+
+	cfg, err := adapter.Parse(jsonData)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(cfg.Servers) != 3 {
+		t.Errorf("Expected 3 servers without the extension server, got %d", len(cfg.Servers))
+	}
+
+	github, ok := cfg.GetServer("github")
+	if !ok {
+		t.Fatal("github server not found")
+	}
+	if github.Transport != core.TransportStdio {
+		t.Errorf("Expected stdio transport, got %v", github.Transport)
+	}
+	if github.Env["GITHUB_TOKEN"] != "${GITHUB_TOKEN}" {
+		t.Errorf("Expected GITHUB_TOKEN placeholder, got %q", github.Env["GITHUB_TOKEN"])
+	}
+
+	legacy, ok := cfg.GetServer("legacy")
+	if !ok {
+		t.Fatal("legacy server not found")
+	}
+	if legacy.Command != "node" || len(legacy.Args) != 1 || legacy.Env["DEBUG"] != "1" {
+		t.Errorf("Expected legacy command object to be read, got %+v", legacy)
+	}
+
+	remote, ok := cfg.GetServer("remote")
+	if !ok {
+		t.Fatal("remote server not found")
+	}
+	if remote.Transport != core.TransportHTTP {
+		t.Errorf("Expected http transport, got %v", remote.Transport)
+	}
+	if remote.IsEnabled() {
+		t.Error("Expected remote server to be disabled")
+	}
+}
+
+func TestAdapterMarshal(t *testing.T) {
+	adapter := NewAdapter()
+
+	enabled := false
+	cfg := core.NewConfig()
+	cfg.AddServer("test", core.Server{
+		Transport: core.TransportStdio,
+		Command:   "npx",
+		Args:      []string{"-y", "test-server"},
+		Env:       map[string]string{"API_KEY": "${keyring:test/api}"},
+		Enabled:   &enabled,
+	})
+
+	data, err := adapter.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, s := range []string{`"context_servers"`, `"source": "custom"`, `"enabled": false`, `"API_KEY": "${API_KEY}"`} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("Expected %s in:\n%s", s, data)
+		}
+	}
+
+	// Round-trip
+	cfg2, err := adapter.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse after marshal failed: %v", err)
+	}
+
+	server, ok := cfg2.GetServer("test")
+	if !ok {
+		t.Fatal("test not found after round-trip")
+	}
+	if server.Command != "npx" || len(server.Args) != 2 {
+		t.Errorf("Expected command and args to round-trip, got %+v", server)
+	}
+	if server.IsEnabled() {
+		t.Error("Expected server to be disabled")
+	}
+}
+
+func TestAdapterLossiness(t *testing.T) {
+	adapter := NewAdapter()
+
+	cfg := core.NewConfig()
+	cfg.AddServer("events", core.Server{
+		Transport:    core.TransportSSE,
+		URL:          "https://api.example.com/sse",
+		EnabledTools: []string{"read"},
+	})
+
+	paths := make(map[string]core.LossKind)
+	for _, loss := range adapter.Lossiness(cfg) {
+		paths[loss.Path] = loss.Kind
+	}
+	if paths["servers.events.transport"] != core.LossDegraded {
+		t.Errorf("Expected sse transport to be degraded, got %v", paths)
+	}
+	if paths["servers.events.enabledTools"] != core.LossDropped {
+		t.Errorf("Expected enabledTools to be dropped, got %v", paths)
+	}
+}
+
+func TestAdapterMergeKeepsExtensionServers(t *testing.T) {
+	adapter := NewAdapter()
+
+	existing := []byte(`{
+  // Keep this comment
+  "theme": "One Dark",
+  "context_servers": {
+    "postgres": {"source": "extension", "settings": {"database_url": "postgres://localhost"}},
+    "stale": {"command": "stale"}
+  }
+}
+`)
+
+	cfg := core.NewConfig()
+	cfg.AddServer("github", core.Server{Command: "npx"})
+
+	merged, err := adapter.Merge(cfg, existing)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	for _, s := range []string{"// Keep this comment", `"theme": "One Dark"`, `"postgres"`, `"database_url"`, `"github"`} {
+		if !strings.Contains(string(merged), s) {
+			t.Errorf("Expected %s in:\n%s", s, merged)
+		}
+	}
+	if strings.Contains(string(merged), "stale") {
+		t.Errorf("Expected stale server to be removed:\n%s", merged)
+	}
+}
+
+func TestAdapterReadWriteFile(t *testing.T) {
+	adapter := NewAdapter()
+	tmpDir := t.TempDir()
+	path := ProjectConfigPath(tmpDir)
+
+	cfg := core.NewConfig()
+	cfg.AddServer("file-test", core.Server{
+		Command: "echo",
+	})
+
+	if err := adapter.WriteFile(cfg, path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	loaded, err := adapter.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	if len(loaded.Servers) != 1 {
+		t.Errorf("Expected 1 server, got %d", len(loaded.Servers))
+	}
+}
+
+func TestAdapterReadFileNotFound(t *testing.T) {
+	adapter := NewAdapter()
+
+	_, err := adapter.ReadFile("/nonexistent/settings.json")
+	if err == nil {
+		t.Error("Expected error for nonexistent file")
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	cfg := NewConfig()
+
+	if cfg.ContextServers == nil {
+		t.Error("Expected ContextServers to be initialized")
+	}
+}
@@ -0,0 +1,82 @@
+package zed
+
+import "encoding/json"
+
+// Config represents the MCP section of a Zed settings.json file.
+type Config struct {
+	ContextServers map[string]ServerConfig `json:"context_servers"`
+}
+
+// SourceCustom and SourceExtension are the values of ServerConfig.Source.
+const (
+	SourceCustom    = "custom"
+	SourceExtension = "extension"
+)
+
+// ServerConfig represents a Zed context server.
+type ServerConfig struct {
+	// Source is "custom" for servers configured in settings, or "extension"
+	// for servers provided by a Zed extension.
+	Source string `json:"source,omitempty"`
+
+	// Enabled indicates whether the server is enabled. Defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// --- STDIO Server Fields ---
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+
+	// --- Remote Server Fields ---
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Settings are passed to extension servers.
+	Settings map[string]any `json:"settings,omitempty"`
+}
+
+// legacyCommand is the command object of older Zed versions.
+type legacyCommand struct {
+	Path string            `json:"path"`
+	Args []string          `json:"args,omitempty"`
+	Env  map[string]string `json:"env,omitempty"`
+}
+
+// UnmarshalJSON reads both the current form and the older form that nests
+// the command as {"path", "args", "env"}.
+func (s *ServerConfig) UnmarshalJSON(data []byte) error {
+	type plain ServerConfig
+	var raw struct {
+		plain
+		Command json.RawMessage `json:"command,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = ServerConfig(raw.plain)
+	if len(raw.Command) == 0 || string(raw.Command) == "null" {
+		return nil
+	}
+	if raw.Command[0] == '"' {
+		return json.Unmarshal(raw.Command, &s.Command)
+	}
+	var legacy legacyCommand
+	if err := json.Unmarshal(raw.Command, &legacy); err != nil {
+		return err
+	}
+	s.Command = legacy.Path
+	if len(s.Args) == 0 {
+		s.Args = legacy.Args
+	}
+	if len(s.Env) == 0 {
+		s.Env = legacy.Env
+	}
+	return nil
+}
+
+// NewConfig creates a new Zed config.
+func NewConfig() *Config {
+	return &Config{
+		ContextServers: make(map[string]ServerConfig),
+	}
+}
@@ -0,0 +1,158 @@
+package merge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// edit is one line of a line-based diff.
+type edit struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+// Diff returns a unified diff from old to new, labelled with path. It
+// returns an empty string if the contents are equal.
+func Diff(path string, old, new []byte) string {
+	if string(old) == string(new) {
+		return ""
+	}
+	edits := diffLines(splitLines(string(old)), splitLines(string(new)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", path, path)
+
+	oldLine, newLine := 1, 1
+	for i := 0; i < len(edits); {
+		// Find the next change.
+		j := i
+		for j < len(edits) && edits[j].op == ' ' {
+			j++
+		}
+		if j == len(edits) {
+			break
+		}
+		start := max(i, j-diffContext)
+		oldLine += start - i
+		newLine += start - i
+
+		// Extend the hunk until diffContext*2 unchanged lines separate changes.
+		end := j
+		for k := j; k < len(edits); k++ {
+			if edits[k].op != ' ' {
+				end = k + 1
+			} else if k-end >= 2*diffContext {
+				break
+			}
+		}
+		end = min(len(edits), end+diffContext)
+
+		oldCount, newCount := 0, 0
+		for _, e := range edits[start:end] {
+			if e.op != '+' {
+				oldCount++
+			}
+			if e.op != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldLine, oldCount), hunkRange(newLine, newCount))
+		for _, e := range edits[start:end] {
+			b.WriteByte(e.op)
+			b.WriteString(e.text)
+			b.WriteByte('\n')
+		}
+
+		oldLine += oldCount
+		newLine += newCount
+		i = end
+	}
+	return b.String()
+}
+
+// hunkRange formats the line range of a hunk.
+func hunkRange(line, count int) string {
+	if count == 0 {
+		line--
+	}
+	if count == 1 {
+		return fmt.Sprint(line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+// splitLines splits s into lines, without line terminators.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines returns the edits that turn a into b. Common leading and
+// trailing lines are matched directly; the remainder is diffed with a
+// longest common subsequence.
+func diffLines(a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []edit
+	for _, line := range a[:prefix] {
+		edits = append(edits, edit{' ', line})
+	}
+	edits = append(edits, lcsEdits(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, edit{' ', line})
+	}
+	return edits
+}
+
+// lcsEdits diffs a and b using a longest common subsequence table.
+func lcsEdits(a, b []string) []edit {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var edits []edit
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, edit{'-', a[i]})
+			i++
+		default:
+			edits = append(edits, edit{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, edit{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, edit{'+', b[j]})
+	}
+	return edits
+}
@@ -0,0 +1,39 @@
+package merge
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+	new := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nn\n"
+	expected := `--- config.json
++++ config.json
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -11,3 +11,4 @@
+ k
+ l
+ m
++n
+`
+	if got := Diff("config.json", []byte(old), []byte(new)); got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestDiffNewFile(t *testing.T) {
+	expected := "--- a.json\n+++ a.json\n@@ -0,0 +1,2 @@\n+{\n+}\n"
+	if got := Diff("a.json", nil, []byte("{\n}\n")); got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestDiffEqual(t *testing.T) {
+	if got := Diff("a.json", []byte("{}"), []byte("{}")); got != "" {
+		t.Errorf("Expected empty diff, got:\n%s", got)
+	}
+}
@@ -0,0 +1,257 @@
+package merge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// member locates a member of a JSON object by byte offset.
+type member struct {
+	key        string
+	start      int // offset of the key's opening quote
+	valueStart int
+	valueEnd   int
+}
+
+// object locates the top-level object of a JSON document.
+type object struct {
+	open    int // offset of '{'
+	close   int // offset of '}'
+	members []member
+}
+
+// JSON returns existing with the given top-level keys set to their values
+// in generated. Keys present in existing are updated in place, keys only in
+// generated are appended, and keys missing from generated are removed. All
+// other bytes of existing are kept, so unknown keys, key order and
+// formatting are preserved. Replaced values are re-indented to match
+// existing. Existing may be JSONC, with comments and trailing commas; they
+// are kept outside the replaced values. If existing is empty, generated is
+// returned unchanged.
+func JSON(existing, generated []byte, keys ...string) ([]byte, error) {
+	if len(bytes.TrimSpace(existing)) == 0 {
+		return generated, nil
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(generated, &values); err != nil {
+		return nil, fmt.Errorf("generated content: %w", err)
+	}
+
+	out := existing
+	for _, key := range keys {
+		obj, err := parseObject(StripJSONC(out))
+		if err != nil {
+			return nil, err
+		}
+		value, ok := values[key]
+		switch i := obj.index(key); {
+		case ok && i >= 0:
+			out = splice(out, obj.members[i].valueStart, obj.members[i].valueEnd, obj.format(out, value))
+		case ok:
+			out = obj.add(out, key, value)
+		case i >= 0:
+			out = obj.remove(out, i)
+		}
+	}
+	return out, nil
+}
+
+// parseObject locates the members of the top-level object in data.
+func parseObject(data []byte) (*object, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("existing content is not a JSON object")
+	}
+	obj := &object{open: int(dec.InputOffset()) - 1}
+
+	for dec.More() {
+		start := skipSeparators(data, int(dec.InputOffset()))
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("existing content: %w", err)
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("existing content: %w", err)
+		}
+		end := int(dec.InputOffset())
+		obj.members = append(obj.members, member{
+			key:        tok.(string),
+			start:      start,
+			valueStart: end - len(raw),
+			valueEnd:   end,
+		})
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("existing content: %w", err)
+	}
+	obj.close = int(dec.InputOffset()) - 1
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("existing content has data after the top-level object")
+	}
+	return obj, nil
+}
+
+// StripJSONC returns a copy of data with the comments and trailing commas
+// of JSONC, as written by VS Code and Zed, replaced by spaces so that it can
+// be decoded as JSON. Newlines are kept, so byte offsets and line numbers
+// match data.
+func StripJSONC(data []byte) []byte {
+	out := bytes.Clone(data)
+
+	// Blank comments, skipping over strings.
+	for i := 0; i < len(out); i++ {
+		switch {
+		case out[i] == '"':
+			i = stringEnd(out, i)
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := len(out)
+			if j := bytes.Index(out[i+2:], []byte("*/")); j >= 0 {
+				end = i + 2 + j + 2
+			}
+			for ; i < end; i++ {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			i--
+		}
+	}
+
+	// Blank commas followed only by whitespace and a closing bracket.
+	for i := 0; i < len(out); i++ {
+		switch out[i] {
+		case '"':
+			i = stringEnd(out, i)
+		case ',':
+			j := i + 1
+			for j < len(out) && bytes.IndexByte([]byte(" \t\n\r"), out[j]) >= 0 {
+				j++
+			}
+			if j < len(out) && (out[j] == '}' || out[j] == ']') {
+				out[i] = ' '
+			}
+		}
+	}
+	return out
+}
+
+// stringEnd returns the offset of the closing quote of the string that
+// starts at i, or the last offset of data if the string is not closed.
+func stringEnd(data []byte, i int) int {
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return len(data) - 1
+}
+
+// skipSeparators returns the offset of the first byte at or after i that is
+// not whitespace or a comma.
+func skipSeparators(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\n', '\r', ',':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func (o *object) index(key string) int {
+	for i, m := range o.members {
+		if m.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// multiline reports whether the object spans several lines.
+func (o *object) multiline(data []byte) bool {
+	return len(o.members) == 0 || bytes.ContainsRune(data[o.open:o.close], '\n')
+}
+
+// indent returns the indentation of the object's members.
+func (o *object) indent(data []byte) string {
+	if len(o.members) == 0 {
+		return "  "
+	}
+	start := o.members[0].start
+	i := start
+	for i > o.open+1 && (data[i-1] == ' ' || data[i-1] == '\t') {
+		i--
+	}
+	if i == start || data[i-1] != '\n' {
+		return "  "
+	}
+	return string(data[i:start])
+}
+
+// format renders value for a member of the object, matching its layout.
+func (o *object) format(data []byte, value json.RawMessage) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, value); err != nil {
+		return value
+	}
+	if !o.multiline(data) {
+		return buf.Bytes()
+	}
+	compact := buf.Bytes()
+	var indented bytes.Buffer
+	indent := o.indent(data)
+	if err := json.Indent(&indented, compact, indent, indent); err != nil {
+		return compact
+	}
+	return indented.Bytes()
+}
+
+// add appends a member to the object.
+func (o *object) add(data []byte, key string, value json.RawMessage) []byte {
+	name, _ := json.Marshal(key)
+	entry := string(name) + ": " + string(o.format(data, value))
+
+	if len(o.members) == 0 {
+		return splice(data, o.open+1, o.close, []byte("\n"+o.indent(data)+entry+"\n"))
+	}
+	sep := " "
+	if o.multiline(data) {
+		sep = "\n" + o.indent(data)
+	}
+	last := o.members[len(o.members)-1].valueEnd
+	return splice(data, last, last, []byte(","+sep+entry))
+}
+
+// remove deletes member i from the object, along with its separator.
+func (o *object) remove(data []byte, i int) []byte {
+	switch {
+	case len(o.members) == 1:
+		return splice(data, o.open+1, o.close, nil)
+	case i == len(o.members)-1:
+		return splice(data, o.members[i-1].valueEnd, o.members[i].valueEnd, nil)
+	default:
+		return splice(data, o.members[i].start, o.members[i+1].start, nil)
+	}
+}
+
+// splice returns data with data[start:end] replaced by repl.
+func splice(data []byte, start, end int, repl []byte) []byte {
+	out := make([]byte, 0, len(data)-(end-start)+len(repl))
+	out = append(out, data[:start]...)
+	out = append(out, repl...)
+	return append(out, data[end:]...)
+}
@@ -0,0 +1,177 @@
+package merge
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONReplacesManagedKeys(t *testing.T) {
+	existing := `{
+  "permissions": {
+    "allow": ["Bash(ls)"]
+  },
+  "hooks": {"Stop": []},
+  "model": "opus"
+}
+`
+	generated := `{"hooks": {"PreToolUse": [{"matcher": "Bash"}]}}`
+
+	got, err := JSON([]byte(existing), []byte(generated), "hooks")
+	if err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+	expected := `{
+  "permissions": {
+    "allow": ["Bash(ls)"]
+  },
+  "hooks": {
+    "PreToolUse": [
+      {
+        "matcher": "Bash"
+      }
+    ]
+  },
+  "model": "opus"
+}
+`
+	if string(got) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestJSONAddsAndRemovesKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  string
+		generated string
+		keys      []string
+		expected  string
+	}{
+		{
+			name:      "add to multi-line object",
+			existing:  "{\n\t\"model\": \"opus\"\n}\n",
+			generated: `{"mcpServers": {}}`,
+			keys:      []string{"mcpServers"},
+			expected:  "{\n\t\"model\": \"opus\",\n\t\"mcpServers\": {}\n}\n",
+		},
+		{
+			name:      "add to single-line object",
+			existing:  `{"model": "opus"}`,
+			generated: `{"mcpServers": {"a": {"command": "npx"}}}`,
+			keys:      []string{"mcpServers"},
+			expected:  `{"model": "opus", "mcpServers": {"a":{"command":"npx"}}}`,
+		},
+		{
+			name:      "add to empty object",
+			existing:  `{}`,
+			generated: `{"version": 1}`,
+			keys:      []string{"version"},
+			expected:  "{\n  \"version\": 1\n}",
+		},
+		{
+			name:      "remove first key",
+			existing:  "{\n  \"disableAllHooks\": true,\n  \"model\": \"opus\"\n}",
+			generated: `{}`,
+			keys:      []string{"disableAllHooks"},
+			expected:  "{\n  \"model\": \"opus\"\n}",
+		},
+		{
+			name:      "remove last key",
+			existing:  "{\n  \"model\": \"opus\",\n  \"disableAllHooks\": true\n}",
+			generated: `{}`,
+			keys:      []string{"disableAllHooks"},
+			expected:  "{\n  \"model\": \"opus\"\n}",
+		},
+		{
+			name:      "remove only key",
+			existing:  `{"disableAllHooks": true}`,
+			generated: `{}`,
+			keys:      []string{"disableAllHooks"},
+			expected:  `{}`,
+		},
+		{
+			name:      "unmanaged keys are ignored",
+			existing:  `{"model": "opus"}`,
+			generated: `{"model": "sonnet"}`,
+			keys:      []string{"hooks"},
+			expected:  `{"model": "opus"}`,
+		},
+		{
+			name:      "empty existing",
+			existing:  "",
+			generated: `{"hooks": {}}`,
+			keys:      []string{"hooks"},
+			expected:  `{"hooks": {}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSON([]byte(tt.existing), []byte(tt.generated), tt.keys...)
+			if err != nil {
+				t.Fatalf("JSON failed: %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+			if !json.Valid(got) {
+				t.Errorf("Merged content is not valid JSON:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestJSONInvalidExisting(t *testing.T) {
+	for _, existing := range []string{`[]`, `{"a": }`, `{"a": 1} {}`} {
+		if _, err := JSON([]byte(existing), []byte(`{}`), "a"); err == nil {
+			t.Errorf("Expected error for existing content %q", existing)
+		}
+	}
+}
+
+func TestJSONC(t *testing.T) {
+	existing := `// Zed settings
+{
+  "theme": "One Dark", // the theme
+  /* servers */
+  "context_servers": {
+    "old": {"command": "old"},
+  },
+}
+`
+	generated := `{"context_servers": {"new": {"command": "new"}}}`
+
+	got, err := JSON([]byte(existing), []byte(generated), "context_servers")
+	if err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+	expected := `// Zed settings
+{
+  "theme": "One Dark", // the theme
+  /* servers */
+  "context_servers": {
+    "new": {
+      "command": "new"
+    }
+  },
+}
+`
+	if string(got) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestStripJSONC(t *testing.T) {
+	input := "{\"a\": \"// not a comment\", /* b */ \"c\": [1, 2,], // d\n}"
+	got := StripJSONC([]byte(input))
+	if len(got) != len(input) {
+		t.Fatalf("Expected offsets to be kept, got length %d, want %d", len(got), len(input))
+	}
+	var v map[string]any
+	if err := json.Unmarshal(got, &v); err != nil {
+		t.Fatalf("Expected valid JSON, got %q: %v", got, err)
+	}
+	if v["a"] != "// not a comment" {
+		t.Errorf("Expected strings to be kept, got %v", v["a"])
+	}
+}
@@ -0,0 +1,67 @@
+// Package merge updates tool configuration files in place.
+//
+// Tool config files such as Claude's settings.json, ~/.claude.json and
+// Codex's config.toml hold many settings that assistantkit does not manage.
+// The functions in this package replace only the managed part of such a
+// file and leave everything else, including key order and comments,
+// byte-for-byte unchanged:
+//
+//   - JSON replaces, adds or removes top-level keys of a JSON or JSONC object
+//   - TOML replaces, adds or removes the subtables of a TOML table
+//   - YAML replaces, adds or removes top-level keys of a YAML mapping
+//   - Diff renders the change as a unified diff
+//   - File applies a merge function to a file on disk
+package merge
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// Result describes the outcome of merging into a file.
+type Result struct {
+	// Path is the path of the merged file.
+	Path string `json:"path"`
+
+	// Created is true if the file did not exist before the merge.
+	Created bool `json:"created,omitempty"`
+
+	// Changed is true if the merged content differs from the existing content.
+	Changed bool `json:"changed"`
+
+	// Diff is a unified diff from the existing to the merged content.
+	Diff string `json:"diff,omitempty"`
+}
+
+// File merges into the file at path. The existing content, or nil if the
+// file does not exist, is passed to fn and the returned content is written
+// back. Existing files keep their permissions; new files are created with
+// mode. If dryRun is true, the file is not written.
+func File(path string, mode fs.FileMode, dryRun bool, fn func(existing []byte) ([]byte, error)) (*Result, error) {
+	result := &Result{Path: path}
+
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		result.Created = true
+	} else if err != nil {
+		return nil, err
+	} else if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	merged, err := fn(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Diff = Diff(path, existing, merged)
+	result.Changed = result.Created || result.Diff != ""
+	if dryRun || !result.Changed {
+		return result, nil
+	}
+	if err := os.WriteFile(path, merged, mode); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,51 @@
+package merge
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	set := func(existing []byte) ([]byte, error) {
+		return JSON(existing, []byte(`{"hooks": {}}`), "hooks")
+	}
+
+	result, err := File(path, 0600, true, set)
+	if err != nil {
+		t.Fatalf("File failed: %v", err)
+	}
+	if !result.Created || !result.Changed {
+		t.Errorf("Expected created and changed result, got %+v", result)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected dry run not to write the file")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"model": "opus"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err = File(path, 0600, false, set)
+	if err != nil {
+		t.Fatalf("File failed: %v", err)
+	}
+	if result.Created || !result.Changed || result.Diff == "" {
+		t.Errorf("Expected changed result with diff, got %+v", result)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != `{"model": "opus", "hooks": {}}` {
+		t.Errorf("Unexpected content: %s", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0644 {
+		t.Errorf("Expected mode 0644 to be kept, got %v", info.Mode().Perm())
+	}
+
+	result, err = File(path, 0600, false, set)
+	if err != nil {
+		t.Fatalf("File failed: %v", err)
+	}
+	if result.Changed {
+		t.Errorf("Expected unchanged result, got %+v", result)
+	}
+}
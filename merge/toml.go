@@ -0,0 +1,262 @@
+package merge
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// section is a TOML table: a header line followed by its key/value lines.
+type section struct {
+	// leading holds the comment and blank lines directly above the header.
+	leading []string
+
+	// path is the key path of the table header; nil for the lines before
+	// the first header.
+	path []string
+
+	// lines holds the header line and the body.
+	lines []string
+}
+
+// TOML returns existing with the subtables of the given top-level table set
+// to those in generated. For a table such as "mcp_servers", each
+// [mcp_servers.<name>] block, including its nested tables, is replaced at
+// its existing position, blocks missing from generated are removed, and
+// new blocks are added after the last existing one. Other tables, comments
+// outside replaced blocks and formatting are preserved. If existing is
+// empty, generated is returned unchanged.
+func TOML(existing, generated []byte, table string) ([]byte, error) {
+	if len(bytes.TrimSpace(existing)) == 0 {
+		return generated, nil
+	}
+	if err := toml.Unmarshal(existing, new(map[string]interface{})); err != nil {
+		return nil, fmt.Errorf("existing content: %w", err)
+	}
+
+	current, err := splitTOML(existing)
+	if err != nil {
+		return nil, fmt.Errorf("existing content: %w", err)
+	}
+	gen, err := splitTOML(generated)
+	if err != nil {
+		return nil, fmt.Errorf("generated content: %w", err)
+	}
+
+	// Collect the generated blocks by name, in order.
+	var names []string
+	blocks := make(map[string][]string)
+	for _, s := range gen {
+		if len(s.path) < 2 || s.path[0] != table {
+			continue
+		}
+		name := s.path[1]
+		if _, ok := blocks[name]; !ok {
+			names = append(names, name)
+			blocks[name] = s.lines
+			continue
+		}
+		blocks[name] = append(append(blocks[name], s.leading...), s.lines...)
+	}
+
+	var out []string
+	insert := -1
+	done := make(map[string]bool)
+	for _, s := range current {
+		switch {
+		case len(s.path) == 0 || s.path[0] != table:
+			out = append(append(out, s.leading...), s.lines...)
+		case len(s.path) == 1:
+			out = append(append(out, s.leading...), s.lines...)
+			insert = len(out)
+		case done[s.path[1]]:
+			// Nested table of a block that was already replaced.
+		default:
+			name := s.path[1]
+			done[name] = true
+			if block, ok := blocks[name]; ok {
+				out = append(append(out, s.leading...), trimBlank(block)...)
+				insert = len(out)
+			}
+		}
+	}
+
+	var added []string
+	for _, name := range names {
+		if !done[name] {
+			added = append(append(added, ""), trimBlank(blocks[name])...)
+		}
+	}
+	if insert < 0 {
+		out = trimBlank(out)
+		if len(out) == 0 && len(added) > 0 {
+			added = added[1:]
+		}
+		out = append(out, added...)
+	} else {
+		out = append(out[:insert], append(added, out[insert:]...)...)
+	}
+
+	merged := strings.TrimRight(strings.Join(out, "\n"), "\n")
+	if merged != "" {
+		merged += "\n"
+	}
+	if err := toml.Unmarshal([]byte(merged), new(map[string]interface{})); err != nil {
+		return nil, fmt.Errorf("merged content: %w", err)
+	}
+	return []byte(merged), nil
+}
+
+// trimBlank returns lines without trailing blank lines.
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// splitTOML splits a TOML document into sections. Comment and blank lines
+// at the end of a table are attached to the next table as leading lines.
+func splitTOML(data []byte) ([]section, error) {
+	text := strings.TrimRight(string(data), "\n")
+	sections := []section{{}}
+	var multiline string // closing delimiter of an open multi-line string
+	depth := 0           // nesting of an open multi-line array
+
+	for _, line := range strings.Split(text, "\n") {
+		cur := &sections[len(sections)-1]
+		trimmed := strings.TrimSpace(line)
+
+		if multiline == "" && depth == 0 && strings.HasPrefix(trimmed, "[") {
+			path, err := parseHeader(trimmed)
+			if err != nil {
+				return nil, err
+			}
+			next := section{path: path, lines: []string{line}}
+			if cur.path != nil {
+				i := len(cur.lines)
+				for i > 1 && isTrivia(cur.lines[i-1]) {
+					i--
+				}
+				next.leading = cur.lines[i:]
+				cur.lines = cur.lines[:i]
+			}
+			sections = append(sections, next)
+			continue
+		}
+
+		cur.lines = append(cur.lines, line)
+		multiline, depth = scanValue(line, multiline, depth)
+	}
+	return sections, nil
+}
+
+// isTrivia reports whether line is blank or a comment.
+func isTrivia(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+// scanValue tracks multi-line strings and arrays across a body line. It
+// returns the delimiter of a multi-line string still open at the end of the
+// line, if any, and the nesting depth of open arrays.
+func scanValue(line, multiline string, depth int) (string, int) {
+	for i := 0; i < len(line); i++ {
+		if multiline != "" {
+			if strings.HasPrefix(line[i:], multiline) {
+				i += len(multiline) - 1
+				multiline = ""
+			} else if line[i] == '\\' && multiline == `"""` {
+				i++
+			}
+			continue
+		}
+		switch c := line[i]; {
+		case c == '#':
+			return multiline, depth
+		case strings.HasPrefix(line[i:], `"""`), strings.HasPrefix(line[i:], `'''`):
+			multiline = line[i : i+3]
+			i += 2
+		case c == '"' || c == '\'':
+			i = skipString(line, i)
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		}
+	}
+	return multiline, depth
+}
+
+// skipString returns the offset of the closing quote of the single-line
+// string starting at line[i].
+func skipString(line string, i int) int {
+	quote := line[i]
+	for i++; i < len(line); i++ {
+		if line[i] == '\\' && quote == '"' {
+			i++
+		} else if line[i] == quote {
+			return i
+		}
+	}
+	return i
+}
+
+// parseHeader returns the key path of a table or array-of-tables header.
+func parseHeader(line string) ([]string, error) {
+	s := strings.TrimPrefix(line, "[")
+	s = strings.TrimPrefix(s, "[")
+
+	var path []string
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			return nil, fmt.Errorf("invalid table header %q", line)
+		}
+		var key string
+		switch s[0] {
+		case '"', '\'':
+			end := skipString(s, 0)
+			if end >= len(s) {
+				return nil, fmt.Errorf("invalid table header %q", line)
+			}
+			key = s[1:end]
+			if s[0] == '"' {
+				if unquoted, err := unquoteBasic(s[:end+1]); err == nil {
+					key = unquoted
+				}
+			}
+			s = s[end+1:]
+		default:
+			end := strings.IndexFunc(s, func(r rune) bool {
+				return !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-')
+			})
+			if end <= 0 {
+				return nil, fmt.Errorf("invalid table header %q", line)
+			}
+			key, s = s[:end], s[end:]
+		}
+		path = append(path, key)
+
+		s = strings.TrimLeft(s, " \t")
+		if strings.HasPrefix(s, ".") {
+			s = s[1:]
+			continue
+		}
+		if strings.HasPrefix(s, "]") {
+			return path, nil
+		}
+		return nil, fmt.Errorf("invalid table header %q", line)
+	}
+}
+
+// unquoteBasic decodes a TOML basic string.
+func unquoteBasic(s string) (string, error) {
+	var v struct{ K string }
+	if err := toml.Unmarshal([]byte("K = "+s), &v); err != nil {
+		return "", err
+	}
+	return v.K, nil
+}
@@ -0,0 +1,138 @@
+package merge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTOMLReplacesManagedTables(t *testing.T) {
+	existing := `# Codex settings
+model = "o3"
+
+[profiles.fast]
+model = "o4-mini"
+
+# GitHub tools
+[mcp_servers.github]
+command = "npx"
+args = [
+  "-y",
+  "@modelcontextprotocol/server-github",
+]
+
+[mcp_servers.github.env]
+TOKEN = "old"
+
+# No longer used
+[mcp_servers.legacy]
+command = "legacy"
+
+[history]
+persistence = "none" # keep nothing
+`
+	generated := `[mcp_servers]
+[mcp_servers.fs]
+command = 'fs'
+
+[mcp_servers.github]
+command = 'npx'
+
+[mcp_servers.github.env]
+TOKEN = 'new'
+`
+	expected := `# Codex settings
+model = "o3"
+
+[profiles.fast]
+model = "o4-mini"
+
+# GitHub tools
+[mcp_servers.github]
+command = 'npx'
+
+[mcp_servers.github.env]
+TOKEN = 'new'
+
+[mcp_servers.fs]
+command = 'fs'
+
+[history]
+persistence = "none" # keep nothing
+`
+	got, err := TOML([]byte(existing), []byte(generated), "mcp_servers")
+	if err != nil {
+		t.Fatalf("TOML failed: %v", err)
+	}
+	if string(got) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestTOMLAppendsTable(t *testing.T) {
+	existing := "model = \"o3\"\n"
+	generated := "[mcp_servers]\n[mcp_servers.'my.server']\ncommand = 'npx'\n"
+	expected := "model = \"o3\"\n\n[mcp_servers.'my.server']\ncommand = 'npx'\n"
+
+	got, err := TOML([]byte(existing), []byte(generated), "mcp_servers")
+	if err != nil {
+		t.Fatalf("TOML failed: %v", err)
+	}
+	if string(got) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestTOMLIgnoresHeadersInValues(t *testing.T) {
+	existing := `[mcp_servers.a]
+command = "a"
+args = [
+[ "nested" ],
+]
+notes = """
+[mcp_servers.b]
+"""
+`
+	got, err := TOML([]byte(existing), []byte("[mcp_servers]\n"), "mcp_servers")
+	if err != nil {
+		t.Fatalf("TOML failed: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Expected all servers removed, got:\n%s", got)
+	}
+}
+
+func TestTOMLInvalidExisting(t *testing.T) {
+	if _, err := TOML([]byte("[a\nb = "), []byte(""), "mcp_servers"); err == nil {
+		t.Error("Expected error for invalid existing content")
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected []string
+	}{
+		{"[mcp_servers]", []string{"mcp_servers"}},
+		{"[mcp_servers.github.env]", []string{"mcp_servers", "github", "env"}},
+		{"[ mcp_servers . 'my.server' ] # comment", []string{"mcp_servers", "my.server"}},
+		{`[mcp_servers."git hub"]`, []string{"mcp_servers", "git hub"}},
+		{"[[profiles]]", []string{"profiles"}},
+	}
+
+	for _, tt := range tests {
+		got, err := parseHeader(tt.line)
+		if err != nil {
+			t.Errorf("parseHeader(%q) failed: %v", tt.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("parseHeader(%q): expected %v, got %v", tt.line, tt.expected, got)
+		}
+	}
+
+	for _, line := range []string{"[]", "[a.]", "[a b]", "['a]"} {
+		if _, err := parseHeader(line); err == nil {
+			t.Errorf("Expected error for header %q", line)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package merge
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlKeyPattern matches a top-level mapping key: an unindented plain,
+// single-quoted or double-quoted scalar followed by a colon.
+var yamlKeyPattern = regexp.MustCompile(`^("(?:[^"\\]|\\.)*"|'(?:[^']|'')*'|[^\s#'"\-][^:#]*?)\s*:(?:\s|$)`)
+
+// yamlBlock is a top-level key of a YAML mapping: its key line and the
+// indented lines below it.
+type yamlBlock struct {
+	key   string
+	start int // index of the key line
+	end   int // index after the last line of the value
+}
+
+// YAML returns existing with the given top-level keys of a YAML mapping set
+// to their values in generated. Keys present in existing are replaced in
+// place, keys only in generated are appended, and keys missing from
+// generated are removed. Each key is replaced as a block of lines, so
+// comments, other keys and formatting outside the replaced blocks are
+// preserved. If existing is empty, generated is returned unchanged.
+func YAML(existing, generated []byte, keys ...string) ([]byte, error) {
+	if len(bytes.TrimSpace(existing)) == 0 {
+		return generated, nil
+	}
+	if err := yaml.Unmarshal(existing, new(map[string]any)); err != nil {
+		return nil, fmt.Errorf("existing content: %w", err)
+	}
+	if err := yaml.Unmarshal(generated, new(map[string]any)); err != nil {
+		return nil, fmt.Errorf("generated content: %w", err)
+	}
+
+	gen := splitAfterLines(generated)
+	out := splitAfterLines(existing)
+	for _, key := range keys {
+		value, ok := findYAMLBlock(gen, key)
+		current, exists := findYAMLBlock(out, key)
+		var repl []string
+		if ok {
+			repl = gen[value.start:value.end]
+		}
+		switch {
+		case exists:
+			out = spliceLines(out, current.start, current.end, repl)
+		case ok:
+			if n := len(out); n > 0 && !strings.HasSuffix(out[n-1], "\n") {
+				out[n-1] += "\n"
+			}
+			out = append(out, repl...)
+		}
+	}
+	return []byte(strings.Join(out, "")), nil
+}
+
+// splitAfterLines splits data into lines, each with its line ending.
+func splitAfterLines(data []byte) []string {
+	out := strings.SplitAfter(string(data), "\n")
+	if out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// findYAMLBlock returns the block of the top-level key in lines.
+func findYAMLBlock(lines []string, key string) (yamlBlock, bool) {
+	for _, b := range yamlBlocks(lines) {
+		if b.key == key {
+			return b, true
+		}
+	}
+	return yamlBlock{}, false
+}
+
+// yamlBlocks returns the top-level keys in lines. A block ends with its
+// last indented line, so unindented comments and blank lines after it are
+// not part of it.
+func yamlBlocks(lines []string) []yamlBlock {
+	var blocks []yamlBlock
+	for i := 0; i < len(lines); i++ {
+		m := yamlKeyPattern.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		end := i + 1
+		for j := i + 1; j < len(lines); j++ {
+			line := lines[j]
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			if line[0] != ' ' && line[0] != '\t' {
+				break
+			}
+			end = j + 1
+		}
+		blocks = append(blocks, yamlBlock{key: unquoteYAMLKey(m[1]), start: i, end: end})
+		i = end - 1
+	}
+	return blocks
+}
+
+// unquoteYAMLKey returns the value of a quoted or plain key scalar.
+func unquoteYAMLKey(s string) string {
+	var key string
+	if err := yaml.Unmarshal([]byte(s), &key); err != nil {
+		return strings.TrimSpace(s)
+	}
+	return key
+}
+
+// spliceLines returns lines with lines[start:end] replaced by repl.
+func spliceLines(lines []string, start, end int, repl []string) []string {
+	out := make([]string, 0, len(lines)-(end-start)+len(repl))
+	out = append(out, lines[:start]...)
+	out = append(out, repl...)
+	return append(out, lines[end:]...)
+}
@@ -0,0 +1,94 @@
+package merge
+
+import "testing"
+
+func TestYAMLReplacesManagedKeys(t *testing.T) {
+	existing := `# Goose config
+GOOSE_PROVIDER: anthropic
+extensions:
+  developer:
+    type: builtin
+  old:
+    type: stdio
+    cmd: old
+
+# model settings
+GOOSE_MODEL: claude-sonnet-4
+`
+	generated := `extensions:
+  github:
+    type: stdio
+    cmd: npx
+`
+
+	got, err := YAML([]byte(existing), []byte(generated), "extensions")
+	if err != nil {
+		t.Fatalf("YAML failed: %v", err)
+	}
+	expected := `# Goose config
+GOOSE_PROVIDER: anthropic
+extensions:
+  github:
+    type: stdio
+    cmd: npx
+
+# model settings
+GOOSE_MODEL: claude-sonnet-4
+`
+	if string(got) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestYAMLAddsAndRemovesKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  string
+		generated string
+		expected  string
+	}{
+		{
+			name:      "add",
+			existing:  "name: assistant\n",
+			generated: "mcpServers:\n  - name: github\n",
+			expected:  "name: assistant\nmcpServers:\n  - name: github\n",
+		},
+		{
+			name:      "add without trailing newline",
+			existing:  "name: assistant",
+			generated: "mcpServers: []\n",
+			expected:  "name: assistant\nmcpServers: []\n",
+		},
+		{
+			name:      "remove",
+			existing:  "mcpServers:\n  - name: github\nname: assistant\n",
+			generated: "{}\n",
+			expected:  "name: assistant\n",
+		},
+		{
+			name:      "quoted key",
+			existing:  "\"mcpServers\": []\nname: assistant\n",
+			generated: "mcpServers:\n  - name: github\n",
+			expected:  "mcpServers:\n  - name: github\nname: assistant\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := YAML([]byte(tt.existing), []byte(tt.generated), "mcpServers")
+			if err != nil {
+				t.Fatalf("YAML failed: %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestYAMLInvalidExisting(t *testing.T) {
+	for _, existing := range []string{"- a\n- b\n", "a: [\n"} {
+		if _, err := YAML([]byte(existing), []byte("a: 1\n"), "a"); err == nil {
+			t.Errorf("Expected error for existing content %q", existing)
+		}
+	}
+}
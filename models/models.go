@@ -0,0 +1,197 @@
+// Package models resolves canonical agent models to the model IDs of each
+// platform.
+//
+// Agents name a canonical model (haiku, sonnet or opus), but each platform
+// needs a concrete ID: Kiro CLI expects claude-sonnet-4, AWS AgentCore a
+// Bedrock foundation model ID, and Codex and Gemini CLI use other model
+// families entirely. Each platform has a Table with its default mapping,
+// and a deployment target can override it:
+//
+//	id, err := models.Resolve("kiro", "sonnet", nil)                // "claude-sonnet-4"
+//	id, err = models.Resolve("kiro", "sonnet", map[string]string{
+//	    "sonnet": "claude-sonnet-4.5",
+//	})                                                               // "claude-sonnet-4.5"
+//	_, err = models.Resolve("gemini", "gpt-4o", nil)                // *UnknownModelError
+//
+// A model that is neither canonical, nor overridden, nor a model ID the
+// platform is known to accept is reported with an *UnknownModelError
+// rather than passed through silently.
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/agentplexus/assistantkit/agents/core"
+)
+
+// ErrUnknownModel is returned, wrapped in an *UnknownModelError, for models
+// a platform does not know.
+var ErrUnknownModel = errors.New("unknown model")
+
+// UnknownModelError reports a model that could not be resolved for a
+// platform.
+type UnknownModelError struct {
+	Platform string
+	Model    core.Model
+}
+
+func (e *UnknownModelError) Error() string {
+	return fmt.Sprintf("model %q is not a known %s model", e.Model, e.Platform)
+}
+
+func (e *UnknownModelError) Unwrap() error {
+	return ErrUnknownModel
+}
+
+// Table is a platform's model mapping.
+type Table struct {
+	// Platform is the platform name, matching the agents adapter names
+	// (e.g., "kiro", "aws-agentcore").
+	Platform string
+
+	// Models maps canonical models to the platform's model IDs.
+	Models map[core.Model]string
+
+	// Aliases maps other names the platform uses for the canonical models
+	// to them, so that they are read back as canonical. Keys are lowercase.
+	Aliases map[string]core.Model
+
+	// Prefixes are the prefixes of model IDs the platform accepts as is
+	// (e.g., "gemini-"). Such IDs are written unchanged.
+	Prefixes []string
+
+	// Known lists other model IDs the platform accepts as is.
+	Known []string
+}
+
+// Resolve returns the platform's model ID for a model: the mapped ID for a
+// canonical model, or the model itself if it is a model ID the platform
+// accepts. Other models are returned unchanged with an *UnknownModelError.
+func (t *Table) Resolve(model core.Model) (string, error) {
+	if id, ok := t.Models[model]; ok {
+		return id, nil
+	}
+	if t.Accepts(string(model)) {
+		return string(model), nil
+	}
+	return string(model), &UnknownModelError{Platform: t.Platform, Model: model}
+}
+
+// Write returns the platform's model ID for a model, passing unknown models
+// through unchanged. Adapters use it when marshaling.
+func (t *Table) Write(model core.Model) string {
+	id, _ := t.Resolve(model)
+	return id
+}
+
+// Canonical returns the canonical model for one of the platform's model
+// IDs, or the ID itself if it has no canonical equivalent. Adapters use it
+// when parsing.
+func (t *Table) Canonical(id string) core.Model {
+	lower := strings.ToLower(id)
+	for model, mapped := range t.Models {
+		if strings.ToLower(mapped) == lower {
+			return model
+		}
+	}
+	if model, ok := t.Aliases[lower]; ok {
+		return model
+	}
+	return core.Model(id)
+}
+
+// Accepts reports whether id is a model ID the platform accepts as is.
+func (t *Table) Accepts(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, mapped := range t.Models {
+		if id == mapped {
+			return true
+		}
+	}
+	for _, known := range t.Known {
+		if id == known {
+			return true
+		}
+	}
+	for _, prefix := range t.Prefixes {
+		if strings.HasPrefix(id, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// With returns a copy of the table with overrides applied. Override keys
+// are canonical models or any other model name used by agents; values are
+// the platform's model IDs, which are accepted as is.
+func (t *Table) With(overrides map[string]string) *Table {
+	if len(overrides) == 0 {
+		return t
+	}
+	out := &Table{
+		Platform: t.Platform,
+		Models:   make(map[core.Model]string, len(t.Models)+len(overrides)),
+		Aliases:  t.Aliases,
+		Prefixes: t.Prefixes,
+		Known:    t.Known,
+	}
+	for model, id := range t.Models {
+		out.Models[model] = id
+	}
+	for model, id := range overrides {
+		out.Models[core.Model(model)] = id
+	}
+	return out
+}
+
+var (
+	mu     sync.RWMutex
+	tables = make(map[string]*Table)
+)
+
+// Register adds a table to the registry, replacing any table for the same
+// platform.
+func Register(t *Table) {
+	mu.Lock()
+	defer mu.Unlock()
+	tables[t.Platform] = t
+}
+
+// Get returns the table for the named platform.
+func Get(platform string) (*Table, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	t, ok := tables[platform]
+	return t, ok
+}
+
+// Platforms returns the names of all platforms with a table, sorted.
+func Platforms() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve resolves a model for the named platform with the given overrides
+// applied. Platforms without a table pass every model through.
+func Resolve(platform string, model core.Model, overrides map[string]string) (string, error) {
+	if id, ok := overrides[string(model)]; ok {
+		return id, nil
+	}
+	t, ok := Get(platform)
+	if !ok {
+		return string(model), nil
+	}
+	return t.Resolve(model)
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agentplexus/assistantkit/agents/core"
+)
+
+func TestTableResolve(t *testing.T) {
+	tests := []struct {
+		table *Table
+		model core.Model
+		want  string
+		err   bool
+	}{
+		{Kiro, core.ModelSonnet, "claude-sonnet-4", false},
+		{Kiro, "claude-sonnet-4.5", "claude-sonnet-4.5", false},
+		{Kiro, "auto", "auto", false},
+		{Kiro, "gpt-4o", "gpt-4o", true},
+		{Codex, core.ModelOpus, "o1", false},
+		{Codex, "o3-mini", "o3-mini", false},
+		{Gemini, core.ModelHaiku, "gemini-2.0-flash", false},
+		{Gemini, "gemini-2.5-pro", "gemini-2.5-pro", false},
+		{Claude, core.ModelHaiku, "haiku", false},
+		{Claude, "inherit", "inherit", false},
+		{AWSAgentCore, core.ModelSonnet, AWSAgentCore.Models[core.ModelSonnet], false},
+		{AWSAgentCore, "us.anthropic.claude-sonnet-4-20250514-v1:0", "us.anthropic.claude-sonnet-4-20250514-v1:0", false},
+		{AWSAgentCore, "sonnet-4", "sonnet-4", true},
+	}
+	for _, tt := range tests {
+		got, err := tt.table.Resolve(tt.model)
+		if got != tt.want {
+			t.Errorf("%s.Resolve(%q) = %q, want %q", tt.table.Platform, tt.model, got, tt.want)
+		}
+		if (err != nil) != tt.err {
+			t.Errorf("%s.Resolve(%q) error = %v, want error %v", tt.table.Platform, tt.model, err, tt.err)
+		}
+		if err != nil && !errors.Is(err, ErrUnknownModel) {
+			t.Errorf("Expected ErrUnknownModel, got %v", err)
+		}
+	}
+}
+
+func TestTableCanonical(t *testing.T) {
+	tests := []struct {
+		table *Table
+		id    string
+		want  core.Model
+	}{
+		{Kiro, "claude-sonnet-4", core.ModelSonnet},
+		{Kiro, "Claude-4-Opus", core.ModelOpus},
+		{Codex, "gpt-4", core.ModelSonnet},
+		{Gemini, "flash", core.ModelHaiku},
+		{Gemini, "gemini-2.5-pro", "gemini-2.5-pro"},
+		{AgentKit, "claude-3-opus-20240229", core.ModelOpus},
+	}
+	for _, tt := range tests {
+		if got := tt.table.Canonical(tt.id); got != tt.want {
+			t.Errorf("%s.Canonical(%q) = %q, want %q", tt.table.Platform, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	if got := Kiro.Write(core.ModelOpus); got != "claude-opus-4" {
+		t.Errorf("Expected claude-opus-4, got %q", got)
+	}
+	// Writers pass unknown models through; lint and generate flag them.
+	if got := Gemini.Write("gpt-4o"); got != "gpt-4o" {
+		t.Errorf("Expected gpt-4o to pass through, got %q", got)
+	}
+}
+
+func TestResolveOverrides(t *testing.T) {
+	overrides := map[string]string{"sonnet": "claude-sonnet-4.5", "fast": "claude-haiku"}
+
+	if id, err := Resolve("kiro", core.ModelSonnet, overrides); err != nil || id != "claude-sonnet-4.5" {
+		t.Errorf("Expected override claude-sonnet-4.5, got %q, %v", id, err)
+	}
+	if id, err := Resolve("kiro", "fast", overrides); err != nil || id != "claude-haiku" {
+		t.Errorf("Expected override claude-haiku, got %q, %v", id, err)
+	}
+	if id, err := Resolve("kiro", core.ModelOpus, overrides); err != nil || id != "claude-opus-4" {
+		t.Errorf("Expected default claude-opus-4, got %q, %v", id, err)
+	}
+
+	_, err := Resolve("gemini", "fast", nil)
+	var unknown *UnknownModelError
+	if !errors.As(err, &unknown) || unknown.Platform != "gemini" || unknown.Model != "fast" {
+		t.Errorf("Expected UnknownModelError for gemini/fast, got %v", err)
+	}
+
+	// Platforms without a table pass every model through.
+	if id, err := Resolve("cursor", "anything", nil); err != nil || id != "anything" {
+		t.Errorf("Expected pass-through, got %q, %v", id, err)
+	}
+
+	table := Kiro.With(overrides)
+	if table == Kiro || Kiro.Models[core.ModelSonnet] != "claude-sonnet-4" {
+		t.Error("With should not modify the original table")
+	}
+	if id, _ := table.Resolve(core.ModelSonnet); id != "claude-sonnet-4.5" {
+		t.Errorf("Expected claude-sonnet-4.5, got %q", id)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	want := []string{"agentkit", "aws-agentcore", "claude", "codex", "gemini", "kiro"}
+	got := Platforms()
+	if len(got) != len(want) {
+		t.Fatalf("Expected platforms %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected platforms %v, got %v", want, got)
+			break
+		}
+	}
+	if table, ok := Get("kiro"); !ok || table != Kiro {
+		t.Error("Expected the kiro table to be registered")
+	}
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"github.com/agentplexus/assistantkit/agents/core"
+	multiagentspec "github.com/agentplexus/multi-agent-spec/sdk/go"
+)
+
+// Claude is the Claude Code table. Claude Code accepts the canonical names
+// directly, as well as full Claude model IDs.
+var Claude = &Table{
+	Platform: "claude",
+	Models: map[core.Model]string{
+		core.ModelHaiku:  "haiku",
+		core.ModelSonnet: "sonnet",
+		core.ModelOpus:   "opus",
+	},
+	Prefixes: []string{"claude-"},
+	Known:    []string{"inherit"},
+}
+
+// Kiro is the Kiro CLI table.
+var Kiro = &Table{
+	Platform: "kiro",
+	Models: map[core.Model]string{
+		core.ModelHaiku:  "claude-haiku",
+		core.ModelSonnet: "claude-sonnet-4",
+		core.ModelOpus:   "claude-opus-4",
+	},
+	Aliases: map[string]core.Model{
+		"claude-3-haiku":  core.ModelHaiku,
+		"claude-4-sonnet": core.ModelSonnet,
+		"claude-4-opus":   core.ModelOpus,
+	},
+	Prefixes: []string{"claude-"},
+	Known:    []string{"auto"},
+}
+
+// Codex is the OpenAI Codex CLI table.
+var Codex = &Table{
+	Platform: "codex",
+	Models: map[core.Model]string{
+		core.ModelHaiku:  "gpt-4o-mini",
+		core.ModelSonnet: "gpt-4o",
+		core.ModelOpus:   "o1",
+	},
+	Aliases: map[string]core.Model{
+		"gpt-4-mini": core.ModelHaiku,
+		"gpt-4":      core.ModelSonnet,
+		"o1-preview": core.ModelOpus,
+	},
+	Prefixes: []string{"gpt-", "o1-", "o3", "o4-", "codex-"},
+}
+
+// Gemini is the Gemini CLI table.
+var Gemini = &Table{
+	Platform: "gemini",
+	Models: map[core.Model]string{
+		core.ModelHaiku:  "gemini-2.0-flash",
+		core.ModelSonnet: "gemini-2.0-pro",
+		core.ModelOpus:   "gemini-2.0-ultra",
+	},
+	Aliases: map[string]core.Model{
+		"flash": core.ModelHaiku,
+		"pro":   core.ModelSonnet,
+		"ultra": core.ModelOpus,
+	},
+	Prefixes: []string{"gemini-"},
+}
+
+// AgentKit is the AgentKit local table, which uses full Anthropic model IDs.
+var AgentKit = &Table{
+	Platform: "agentkit",
+	Models: map[core.Model]string{
+		core.ModelHaiku:  "claude-3-haiku-20240307",
+		core.ModelSonnet: "claude-3-5-sonnet-20241022",
+		core.ModelOpus:   "claude-3-opus-20240229",
+	},
+	Prefixes: []string{"claude-"},
+}
+
+// AWSAgentCore is the AWS AgentCore table, which uses Bedrock foundation
+// model IDs, including cross-region inference profiles.
+var AWSAgentCore = &Table{
+	Platform: "aws-agentcore",
+	Models:   multiagentspec.BedrockModels,
+	Prefixes: []string{
+		"anthropic.", "amazon.", "meta.", "mistral.", "cohere.", "ai21.", "deepseek.",
+		"us.", "eu.", "apac.", "global.",
+	},
+}
+
+func init() {
+	Register(Claude)
+	Register(Kiro)
+	Register(Codex)
+	Register(Gemini)
+	Register(AgentKit)
+	Register(AWSAgentCore)
+}
@@ -118,6 +118,26 @@ func (a *Adapter) Marshal(skill *core.Skill) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// Lossiness reports the canonical fields that the Claude skill format drops or degrades.
+// The body holds the generated title and sections, which are read back as
+// instructions.
+func (a *Adapter) Lossiness(skill *core.Skill) []core.Loss {
+	losses := core.DroppedFields(a.Name(), skill,
+		core.FieldName, core.FieldDescription, core.FieldInstructions,
+		core.FieldScripts, core.FieldReferences, core.FieldAssets,
+		core.FieldTriggers, core.FieldDependencies)
+	if len(skill.Scripts) > 0 {
+		losses = append(losses, core.Degraded(a.Name(), skill, core.FieldScripts, "scripts are written as a scripts section and not read back"))
+	}
+	if len(skill.References) > 0 {
+		losses = append(losses, core.Degraded(a.Name(), skill, core.FieldReferences, "references are written as a references section and not read back"))
+	}
+	if len(skill.Assets) > 0 {
+		losses = append(losses, core.Degraded(a.Name(), skill, core.FieldAssets, "assets are written as an assets section and not read back"))
+	}
+	return append(losses, core.Degraded(a.Name(), skill, core.FieldInstructions, "the generated title and sections are read back as instructions"))
+}
+
 // ReadFile reads a Claude SKILL.md file and returns canonical Skill.
 func (a *Adapter) ReadFile(path string) (*core.Skill, error) {
 	data, err := os.ReadFile(path)
@@ -68,6 +68,16 @@ func (a *Adapter) Marshal(skill *core.Skill) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// Lossiness reports the canonical fields that the Codex skill format drops or degrades.
+func (a *Adapter) Lossiness(skill *core.Skill) []core.Loss {
+	losses := core.DroppedFields(a.Name(), skill,
+		core.FieldName, core.FieldDescription, core.FieldInstructions)
+	if skill.Instructions == "" && skill.Description != "" {
+		losses = append(losses, core.Degraded(a.Name(), skill, core.FieldInstructions, "the description is written as the instructions"))
+	}
+	return losses
+}
+
 // ReadFile reads a Codex SKILL.md file and returns canonical Skill.
 func (a *Adapter) ReadFile(path string) (*core.Skill, error) {
 	data, err := os.ReadFile(path)
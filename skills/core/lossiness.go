@@ -0,0 +1,131 @@
+package core
+
+import "fmt"
+
+// LossKind describes how a field is affected when written to a target format.
+type LossKind string
+
+const (
+	// LossDropped means the field is not written at all.
+	LossDropped LossKind = "dropped"
+
+	// LossDegraded means the field is written but reads back with a different value.
+	LossDegraded LossKind = "degraded"
+)
+
+// Canonical field names used in Loss paths. They match the JSON names of
+// the Skill fields.
+const (
+	FieldName         = "name"
+	FieldDescription  = "description"
+	FieldInstructions = "instructions"
+	FieldScripts      = "scripts"
+	FieldReferences   = "references"
+	FieldAssets       = "assets"
+	FieldTriggers     = "triggers"
+	FieldDependencies = "dependencies"
+)
+
+// Loss describes a canonical field that a target format cannot represent exactly.
+type Loss struct {
+	// Adapter is the name of the target adapter.
+	Adapter string `json:"adapter"`
+
+	// Skill is the name of the skill.
+	Skill string `json:"skill"`
+
+	// Path locates the field in the canonical skill (e.g., "triggers").
+	Path string `json:"path"`
+
+	// Kind is whether the field is dropped or degraded.
+	Kind LossKind `json:"kind"`
+
+	// Detail explains the loss.
+	Detail string `json:"detail,omitempty"`
+}
+
+// String returns a one-line description of the loss.
+func (l Loss) String() string {
+	s := fmt.Sprintf("%s: skill %s: %s %s", l.Adapter, l.Skill, l.Path, l.Kind)
+	if l.Detail != "" {
+		s += ": " + l.Detail
+	}
+	return s
+}
+
+// LossReporter is implemented by adapters that can report which fields of a
+// canonical skill they drop or degrade when marshaling.
+type LossReporter interface {
+	// Lossiness returns the losses incurred by marshaling skill with this adapter.
+	Lossiness(skill *Skill) []Loss
+}
+
+// Lossiness returns the losses incurred by marshaling skill with the given
+// adapter. It returns nil if the adapter does not implement LossReporter.
+func Lossiness(adapter Adapter, skill *Skill) []Loss {
+	if r, ok := adapter.(LossReporter); ok {
+		return r.Lossiness(skill)
+	}
+	return nil
+}
+
+// Lossiness returns the losses incurred by marshaling skill with the named adapter.
+func (r *Registry) Lossiness(skill *Skill, to string) ([]Loss, error) {
+	adapter, ok := r.GetAdapter(to)
+	if !ok {
+		return nil, fmt.Errorf("unknown adapter: %s", to)
+	}
+	return Lossiness(adapter, skill), nil
+}
+
+// SetFields returns the names of the fields that are set on the skill.
+func SetFields(skill *Skill) []string {
+	var fields []string
+	add := func(set bool, name string) {
+		if set {
+			fields = append(fields, name)
+		}
+	}
+	add(skill.Name != "", FieldName)
+	add(skill.Description != "", FieldDescription)
+	add(skill.Instructions != "", FieldInstructions)
+	add(len(skill.Scripts) > 0, FieldScripts)
+	add(len(skill.References) > 0, FieldReferences)
+	add(len(skill.Assets) > 0, FieldAssets)
+	add(len(skill.Triggers) > 0, FieldTriggers)
+	add(len(skill.Dependencies) > 0, FieldDependencies)
+	return fields
+}
+
+// DroppedFields returns a LossDropped entry for every field set on skill
+// that is not in supported.
+func DroppedFields(adapter string, skill *Skill, supported ...string) []Loss {
+	keep := make(map[string]bool, len(supported))
+	for _, f := range supported {
+		keep[f] = true
+	}
+
+	var losses []Loss
+	for _, field := range SetFields(skill) {
+		if !keep[field] {
+			losses = append(losses, Loss{
+				Adapter: adapter,
+				Skill:   skill.Name,
+				Path:    field,
+				Kind:    LossDropped,
+			})
+		}
+	}
+	return losses
+}
+
+// Degraded returns a LossDegraded entry for the given field.
+func Degraded(adapter string, skill *Skill, field, detail string) Loss {
+	return Loss{
+		Adapter: adapter,
+		Skill:   skill.Name,
+		Path:    field,
+		Kind:    LossDegraded,
+		Detail:  detail,
+	}
+}
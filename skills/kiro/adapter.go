@@ -88,6 +88,24 @@ func (a *Adapter) Marshal(skill *core.Skill) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// Lossiness reports the canonical fields that the Kiro steering format drops or degrades.
+// The title is the only metadata, so the description is read back as the title.
+func (a *Adapter) Lossiness(skill *core.Skill) []core.Loss {
+	losses := core.DroppedFields(a.Name(), skill,
+		core.FieldName, core.FieldDescription, core.FieldInstructions)
+	title := toTitleCase(skill.Name)
+	if back := toKebabCase(title); back != skill.Name {
+		losses = append(losses, core.Degraded(a.Name(), skill, core.FieldName, fmt.Sprintf("name %q is written as %q and read back as %q", skill.Name, title, back)))
+	}
+	if skill.Description != title {
+		losses = append(losses, core.Degraded(a.Name(), skill, core.FieldDescription, "the title is read back as the description"))
+	}
+	if skill.Description != "" && skill.Description != title {
+		losses = append(losses, core.Degraded(a.Name(), skill, core.FieldInstructions, "the description is read back as part of the instructions"))
+	}
+	return losses
+}
+
 // ReadFile reads a Kiro steering file and returns canonical Skill.
 func (a *Adapter) ReadFile(path string) (*core.Skill, error) {
 	data, err := os.ReadFile(path)
@@ -0,0 +1,184 @@
+package skills
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/agentplexus/assistantkit/skills/core"
+)
+
+var words = []string{"alpha", "bravo", "charlie", "delta", "echo"}
+
+// randomSkill is a canonical skill generated for property-based tests.
+type randomSkill struct {
+	skill *Skill
+}
+
+// Generate implements quick.Generator.
+func (randomSkill) Generate(r *rand.Rand, size int) reflect.Value {
+	skill := NewSkill("skill-"+pick(r), "Handles "+pick(r)+" requests")
+	if r.Intn(4) > 0 {
+		skill.Instructions = "Check the " + pick(r) + " files.\n\nSummarize the " + pick(r) + " changes."
+	}
+	skill.Scripts = paths(r, "scripts", ".sh")
+	skill.References = paths(r, "references", ".md")
+	skill.Assets = paths(r, "assets", ".json")
+	skill.Triggers = subset(r, words)
+	skill.Dependencies = subset(r, []string{"git", "go", "gh"})
+	return reflect.ValueOf(randomSkill{skill: skill})
+}
+
+func pick(r *rand.Rand) string {
+	return words[r.Intn(len(words))]
+}
+
+// subset returns a random subset of items, without duplicates, in random order.
+func subset(r *rand.Rand, items []string) []string {
+	var out []string
+	for _, i := range r.Perm(len(items))[:r.Intn(4)] {
+		out = append(out, items[i])
+	}
+	return out
+}
+
+// paths returns a random list of resource paths in dir.
+func paths(r *rand.Rand, dir, ext string) []string {
+	var out []string
+	for _, name := range subset(r, words) {
+		out = append(out, dir+"/"+name+ext)
+	}
+	return out
+}
+
+// fieldValue returns the value of a skill field, normalized so that values
+// with the same meaning compare equal.
+func fieldValue(skill *Skill, field string) interface{} {
+	switch field {
+	case core.FieldName:
+		return skill.Name
+	case core.FieldDescription:
+		return skill.Description
+	case core.FieldInstructions:
+		return strings.TrimSpace(skill.Instructions)
+	case core.FieldScripts:
+		return list(skill.Scripts)
+	case core.FieldReferences:
+		return list(skill.References)
+	case core.FieldAssets:
+		return list(skill.Assets)
+	case core.FieldTriggers:
+		return list(skill.Triggers)
+	case core.FieldDependencies:
+		return list(skill.Dependencies)
+	}
+	panic("unknown field " + field)
+}
+
+func list(l []string) []string {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+var skillFields = []string{
+	core.FieldName, core.FieldDescription, core.FieldInstructions, core.FieldScripts,
+	core.FieldReferences, core.FieldAssets, core.FieldTriggers, core.FieldDependencies,
+}
+
+// checkRoundTrip asserts that Parse(Marshal(skill)) preserves every field
+// that the adapter does not report as lost.
+func checkRoundTrip(adapter Adapter, skill *Skill) error {
+	lost := make(map[string]bool)
+	for _, loss := range core.Lossiness(adapter, skill) {
+		lost[loss.Path] = true
+	}
+
+	data, err := adapter.Marshal(skill)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	got, err := adapter.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parse: %w\n%s", err, data)
+	}
+
+	for _, field := range skillFields {
+		if lost[field] {
+			continue
+		}
+		w, g := fieldValue(skill, field), fieldValue(got, field)
+		if !reflect.DeepEqual(w, g) {
+			return fmt.Errorf("%s not reported as lost but changed: expected %v, got %v\n%s", field, w, g, data)
+		}
+	}
+	return nil
+}
+
+func TestRoundTripPreservesSupportedFields(t *testing.T) {
+	for _, name := range AdapterNames() {
+		t.Run(name, func(t *testing.T) {
+			adapter, _ := GetAdapter(name)
+			if _, ok := adapter.(core.LossReporter); !ok {
+				t.Fatalf("adapter %q does not implement LossReporter", name)
+			}
+
+			var failure error
+			property := func(rs randomSkill) bool {
+				failure = checkRoundTrip(adapter, rs.skill)
+				return failure == nil
+			}
+			qc := &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(1))}
+			if err := quick.Check(property, qc); err != nil {
+				t.Errorf("round trip failed: %v", failure)
+			}
+		})
+	}
+}
+
+func TestLossiness(t *testing.T) {
+	skill := NewSkill("review", "Reviews pull requests")
+	skill.Instructions = "Read the diff and comment on problems."
+	skill.AddScript("scripts/lint.sh")
+	skill.AddTrigger("review")
+
+	tests := []struct {
+		adapter  string
+		expected []string
+	}{
+		{"codex", []string{
+			"codex: skill review: scripts dropped",
+			"codex: skill review: triggers dropped",
+		}},
+		{"kiro", []string{
+			"kiro: skill review: scripts dropped",
+			"kiro: skill review: triggers dropped",
+			"kiro: skill review: description degraded: the title is read back as the description",
+			"kiro: skill review: instructions degraded: the description is read back as part of the instructions",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.adapter, func(t *testing.T) {
+			losses, err := Lossiness(skill, tt.adapter)
+			if err != nil {
+				t.Fatalf("Lossiness failed: %v", err)
+			}
+			var got []string
+			for _, loss := range losses {
+				got = append(got, loss.String())
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := Lossiness(skill, "unknown"); err == nil {
+		t.Error("expected error for unknown adapter")
+	}
+}
@@ -43,6 +43,7 @@ import (
 type (
 	Skill   = core.Skill
 	Adapter = core.Adapter
+	Loss    = core.Loss
 )
 
 // Re-export core functions
@@ -57,6 +58,12 @@ var (
 	WriteSkillsToDir   = core.WriteSkillsToDir
 )
 
+// Lossiness returns the fields of skill that the named adapter's format drops
+// or degrades. Example: Lossiness(skill, "kiro")
+func Lossiness(skill *Skill, to string) ([]Loss, error) {
+	return core.DefaultRegistry.Lossiness(skill, to)
+}
+
 // Re-export error types
 type (
 	ParseError   = core.ParseError
@@ -0,0 +1,36 @@
+// Package claude provides the Claude Code team orchestration renderer.
+package claude
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+func init() {
+	core.RegisterRenderer(&Renderer{})
+}
+
+// Renderer renders team orchestration as a Claude Code slash command.
+type Renderer struct{}
+
+// Name returns the renderer identifier.
+func (r *Renderer) Name() string {
+	return "claude"
+}
+
+// Render returns commands/<team>.md, a slash command holding the
+// instructions from Team.GenerateOrchestrationMD. Claude spawns each task's
+// agent with the Task tool, running the tasks of a parallel group at once.
+func (r *Renderer) Render(team *core.Team, cfg core.OrchestrationConfig) ([]core.Artifact, error) {
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.WriteString(fmt.Sprintf("description: %s\n", team.Summary()))
+	buf.WriteString("---\n\n")
+	buf.WriteString(team.GenerateOrchestrationMD(cfg))
+
+	return []core.Artifact{
+		{Path: "commands/" + team.Name + ".md", Content: buf.Bytes()},
+	}, nil
+}
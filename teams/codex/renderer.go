@@ -0,0 +1,123 @@
+// Package codex provides the OpenAI Codex CLI team orchestration renderer.
+package codex
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+func init() {
+	core.RegisterRenderer(&Renderer{})
+}
+
+// Renderer renders team orchestration as an AGENTS.md section and Codex
+// prompts. Codex has no subagents, so the prompts have Codex take on each
+// task's agent role in turn.
+type Renderer struct{}
+
+// Name returns the renderer identifier.
+func (r *Renderer) Name() string {
+	return "codex"
+}
+
+// Render returns:
+//   - an AGENTS.md section describing the team and its task order
+//   - prompts/<team>.md, which runs every task in order
+//   - prompts/<team>-<task>.md for each task, which runs that task alone
+func (r *Renderer) Render(team *core.Team, cfg core.OrchestrationConfig) ([]core.Artifact, error) {
+	groups := team.OrchestrationGroups(cfg)
+
+	artifacts := []core.Artifact{
+		{Path: "AGENTS.md", Section: "team-" + team.Name, Content: agentsSection(team, groups)},
+		{Path: "prompts/" + team.Name + ".md", Content: teamPrompt(team, groups, cfg)},
+	}
+	for _, group := range groups {
+		for _, task := range group {
+			artifacts = append(artifacts, core.Artifact{
+				Path:    fmt.Sprintf("prompts/%s-%s.md", team.Name, task.Name),
+				Content: taskPrompt(team, task, cfg),
+			})
+		}
+	}
+	return artifacts, nil
+}
+
+// agentsSection describes the team for AGENTS.md.
+func agentsSection(team *core.Team, groups [][]core.Task) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("## %s Team\n\n", team.DisplayName()))
+	buf.WriteString(team.Summary() + "\n\n")
+	buf.WriteString(fmt.Sprintf("Run the whole team with the `%s` prompt, or a single task with `%s-<task>`.\n\n", team.Name, team.Name))
+
+	buf.WriteString("| Step | Task | Agent | Requires | Subtasks |\n")
+	buf.WriteString("|------|------|-------|----------|----------|\n")
+	for i, group := range groups {
+		for _, task := range group {
+			requires := strings.Join(task.DependsOn, ", ")
+			if requires == "" {
+				requires = "-"
+			}
+			buf.WriteString(fmt.Sprintf("| %d | %s | %s | %s | %s |\n", i+1, task.Name, task.Agent, requires, core.SubtaskList(task)))
+		}
+	}
+	buf.WriteString("\n")
+	buf.WriteString("Run tasks one at a time, in step order, and do not start a task until the tasks it requires are GO. ")
+	buf.WriteString("A task is GO when all its required subtasks pass; failed optional subtasks are WARN.\n")
+	return buf.Bytes()
+}
+
+// teamPrompt runs every task of the team in order.
+func teamPrompt(team *core.Team, groups [][]core.Task, cfg core.OrchestrationConfig) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.WriteString(fmt.Sprintf("description: %s\n", team.Summary()))
+	buf.WriteString("---\n\n")
+	buf.WriteString(fmt.Sprintf("# %s Orchestration\n\n", team.DisplayName()))
+	buf.WriteString("Run the tasks below one at a time, in order. For each task, take on the role of its agent, ")
+	buf.WriteString("run its subtasks and record GO, WARN or NO-GO for each before moving on. ")
+	buf.WriteString("If a task is NO-GO, skip the tasks that require it.\n\n")
+
+	step := 0
+	for _, group := range groups {
+		for _, task := range group {
+			step++
+			buf.WriteString(fmt.Sprintf("## Step %d: %s\n\n", step, task.Name))
+			if len(task.DependsOn) > 0 {
+				buf.WriteString(fmt.Sprintf("**Requires:** %s (must be GO)\n\n", strings.Join(task.DependsOn, ", ")))
+			}
+			for _, note := range team.ExecutionNotes(task) {
+				buf.WriteString(note + "\n\n")
+			}
+			buf.WriteString(core.SubagentPrompt(task, cfg))
+			buf.WriteString("\n")
+		}
+	}
+
+	buf.WriteString("## Status Report\n\n")
+	buf.WriteString("When every task has run, report status in this format:\n\n")
+	buf.WriteString("```\n")
+	var tasks []core.Task
+	for _, group := range groups {
+		tasks = append(tasks, group...)
+	}
+	buf.WriteString(core.StatusReportTemplate(tasks))
+	buf.WriteString("```\n")
+	return buf.Bytes()
+}
+
+// taskPrompt runs a single task.
+func taskPrompt(team *core.Team, task core.Task, cfg core.OrchestrationConfig) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	description := task.Description
+	if description == "" {
+		description = fmt.Sprintf("Run the %s task of the %s team", task.Name, team.DisplayName())
+	}
+	buf.WriteString(fmt.Sprintf("description: %s\n", description))
+	buf.WriteString("---\n\n")
+	buf.WriteString(core.SubagentPrompt(task, cfg))
+	return buf.Bytes()
+}
@@ -0,0 +1,288 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// GraphConfig holds configuration for exporting the task graph.
+type GraphConfig struct {
+	// HighlightCriticalPath draws the edges of the critical path in bold red.
+	HighlightCriticalPath bool
+}
+
+// agentColors are the fill and stroke colours of task nodes, assigned to
+// agents in order of appearance.
+var agentColors = []struct{ fill, stroke string }{
+	{"#dbeafe", "#1d4ed8"},
+	{"#dcfce7", "#15803d"},
+	{"#fef3c7", "#b45309"},
+	{"#fce7f3", "#be185d"},
+	{"#ede9fe", "#6d28d9"},
+	{"#cffafe", "#0e7490"},
+	{"#fee2e2", "#b91c1c"},
+	{"#e5e7eb", "#374151"},
+}
+
+// criticalColor is the colour of critical path edges.
+const criticalColor = "#dc2626"
+
+// GenerateMermaid renders the task graph as a Mermaid flowchart. Each task
+// is a node, coloured by agent and listing its required and optional
+// subtasks, with an edge from each dependency to its dependents and
+// dashed edges to conditional tasks and fallbacks.
+func (t *Team) GenerateMermaid(cfg GraphConfig) string {
+	var buf bytes.Buffer
+	ids := t.nodeIDs()
+	agents := t.agentIndex()
+
+	buf.WriteString("flowchart TD\n")
+	for i, task := range t.Tasks {
+		lines := []string{"<b>" + html.EscapeString(task.Name) + "</b>", "<i>" + html.EscapeString(task.Agent) + "</i>"}
+		for _, line := range subtaskLines(task) {
+			lines = append(lines, html.EscapeString(line))
+		}
+		buf.WriteString(fmt.Sprintf("    %s[\"%s\"]\n", ids[i], strings.Join(lines, "<br/>")))
+	}
+
+	critical := t.criticalEdges(cfg)
+	var highlighted []string
+	for _, e := range t.edges() {
+		if critical[e] {
+			highlighted = append(highlighted, fmt.Sprint(e.index))
+		}
+		if e.label != "" {
+			buf.WriteString(fmt.Sprintf("    %s -.->|%s| %s\n", ids[e.from], e.label, ids[e.to]))
+		} else {
+			buf.WriteString(fmt.Sprintf("    %s --> %s\n", ids[e.from], ids[e.to]))
+		}
+	}
+
+	for i, color := range agentColors {
+		var nodes []string
+		for j, task := range t.Tasks {
+			if agents[task.Agent]%len(agentColors) == i {
+				nodes = append(nodes, ids[j])
+			}
+		}
+		if len(nodes) == 0 {
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("    classDef agent%d fill:%s,stroke:%s\n", i, color.fill, color.stroke))
+		buf.WriteString(fmt.Sprintf("    class %s agent%d\n", strings.Join(nodes, ","), i))
+	}
+	if len(highlighted) > 0 {
+		buf.WriteString(fmt.Sprintf("    linkStyle %s stroke:%s,stroke-width:3px\n", strings.Join(highlighted, ","), criticalColor))
+	}
+
+	return buf.String()
+}
+
+// GenerateDOT renders the task graph in the Graphviz DOT language, with
+// the same nodes and edges as GenerateMermaid.
+func (t *Team) GenerateDOT(cfg GraphConfig) string {
+	var buf bytes.Buffer
+	ids := t.nodeIDs()
+	agents := t.agentIndex()
+
+	buf.WriteString(fmt.Sprintf("digraph %s {\n", dotQuote(t.Name)))
+	buf.WriteString("    rankdir=TB;\n")
+	buf.WriteString("    node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	for i, task := range t.Tasks {
+		color := agentColors[agents[task.Agent]%len(agentColors)]
+		label := append([]string{task.Name, "(" + task.Agent + ")"}, subtaskLines(task)...)
+		buf.WriteString(fmt.Sprintf("    %s [label=%s, fillcolor=%s, color=%s];\n",
+			ids[i], dotQuote(strings.Join(label, "\n")), dotQuote(color.fill), dotQuote(color.stroke)))
+	}
+
+	critical := t.criticalEdges(cfg)
+	for _, e := range t.edges() {
+		var attrs []string
+		if e.label != "" {
+			attrs = append(attrs, "style=dashed", "label="+dotQuote(e.label))
+		}
+		if critical[e] {
+			attrs = append(attrs, "color="+dotQuote(criticalColor), "penwidth=3")
+		}
+		attrList := ""
+		if len(attrs) > 0 {
+			attrList = " [" + strings.Join(attrs, ", ") + "]"
+		}
+		buf.WriteString(fmt.Sprintf("    %s -> %s%s;\n", ids[e.from], ids[e.to], attrList))
+	}
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+// CriticalPath is the chain of dependent tasks with the longest estimated
+// duration.
+type CriticalPath struct {
+	// Tasks are the names of the tasks on the path, in execution order.
+	Tasks []string
+
+	// Duration is the sum of the maximum durations of the tasks.
+	Duration time.Duration
+}
+
+// CriticalPath returns the chain of tasks with the longest total
+// MaxDuration, following the same ordering as TopologicalSort: a task
+// comes after its dependencies, the task its condition checks and the
+// tasks it is the fallback for. When durations tie, the chain with more
+// tasks wins, so a team without timeouts yields its longest chain.
+func (t *Team) CriticalPath() (*CriticalPath, error) {
+	sorted, err := t.TopologicalSort()
+	if err != nil {
+		return nil, err
+	}
+
+	type node struct {
+		finish time.Duration
+		length int
+		prev   string
+	}
+	nodes := make(map[string]node)
+	var end string
+	for _, task := range sorted {
+		n := node{}
+		for _, dep := range t.orderingDependencies(task) {
+			d, ok := nodes[dep]
+			if ok && (d.finish > n.finish || d.finish == n.finish && d.length > n.length) {
+				n = node{finish: d.finish, length: d.length, prev: dep}
+			}
+		}
+		n.finish += task.MaxDuration()
+		n.length++
+		nodes[task.Name] = n
+
+		best := nodes[end]
+		if end == "" || n.finish > best.finish || n.finish == best.finish && n.length > best.length {
+			end = task.Name
+		}
+	}
+
+	path := &CriticalPath{Duration: nodes[end].finish}
+	for name := end; name != ""; name = nodes[name].prev {
+		path.Tasks = append([]string{name}, path.Tasks...)
+	}
+	return path, nil
+}
+
+// edge is a dependency between the tasks at two indexes of Team.Tasks.
+// Edges with a label are drawn dashed: "when" leads from the task a
+// condition checks and "on failure" from a task to its fallback.
+type edge struct {
+	from, to int
+	index    int
+	label    string
+}
+
+// edges returns the dependencies of the team in definition order,
+// followed by the condition and fallback edges of each task, ignoring
+// tasks that are not part of the team.
+func (t *Team) edges() []edge {
+	indexes := make(map[string]int)
+	for i, task := range t.Tasks {
+		indexes[task.Name] = i
+	}
+	var edges []edge
+	add := func(from string, to int, label string) {
+		if j, ok := indexes[from]; ok {
+			edges = append(edges, edge{from: j, to: to, index: len(edges), label: label})
+		}
+	}
+	for i, task := range t.Tasks {
+		for _, dep := range task.DependsOn {
+			add(dep, i, "")
+		}
+		if task.When != nil && task.When.Task != "" && !task.HasDependency(task.When.Task) {
+			add(task.When.Task, i, "when")
+		}
+		for _, source := range t.FallbackFor(task.Name) {
+			add(source, i, "on failure")
+		}
+	}
+	return edges
+}
+
+// criticalEdges returns the edges on the critical path, if cfg asks for
+// them and the graph has one.
+func (t *Team) criticalEdges(cfg GraphConfig) map[edge]bool {
+	critical := make(map[edge]bool)
+	if !cfg.HighlightCriticalPath {
+		return critical
+	}
+	path, err := t.CriticalPath()
+	if err != nil {
+		return critical
+	}
+	onPath := make(map[[2]string]bool)
+	for i := 1; i < len(path.Tasks); i++ {
+		onPath[[2]string{path.Tasks[i-1], path.Tasks[i]}] = true
+	}
+	for _, e := range t.edges() {
+		if onPath[[2]string{t.Tasks[e.from].Name, t.Tasks[e.to].Name}] {
+			critical[e] = true
+		}
+	}
+	return critical
+}
+
+// nodeIDs returns graph node identifiers for the tasks. Task names may
+// contain characters that are not valid in identifiers, so tasks are
+// numbered instead.
+func (t *Team) nodeIDs() []string {
+	ids := make([]string, len(t.Tasks))
+	for i := range t.Tasks {
+		ids[i] = fmt.Sprintf("task%d", i+1)
+	}
+	return ids
+}
+
+// agentIndex numbers agents in order of appearance in Agents, then Tasks.
+func (t *Team) agentIndex() map[string]int {
+	index := make(map[string]int)
+	add := func(agent string) {
+		if _, ok := index[agent]; !ok {
+			index[agent] = len(index)
+		}
+	}
+	for _, agent := range t.Agents {
+		add(agent)
+	}
+	for _, task := range t.Tasks {
+		add(task.Agent)
+	}
+	return index
+}
+
+// subtaskLines returns node label lines listing a task's required and
+// optional subtasks.
+func subtaskLines(task Task) []string {
+	var required, optional []string
+	for _, st := range task.Subtasks {
+		if st.Required {
+			required = append(required, st.Name)
+		} else {
+			optional = append(optional, st.Name)
+		}
+	}
+	var lines []string
+	if len(required) > 0 {
+		lines = append(lines, "required: "+strings.Join(required, ", "))
+	}
+	if len(optional) > 0 {
+		lines = append(lines, "optional: "+strings.Join(optional, ", "))
+	}
+	return lines
+}
+
+// dotQuote returns s as a quoted DOT string.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
@@ -16,9 +16,14 @@ type OrchestrationConfig struct {
 
 	// IncludeTasks limits generation to specific tasks (empty = all tasks).
 	IncludeTasks []string
+
+	// IncludeDiagram adds a Mermaid diagram of the task graph, with the
+	// critical path highlighted.
+	IncludeDiagram bool
 }
 
 // GenerateOrchestrationMD generates Claude Code orchestration instructions in Markdown.
+// Other platforms are rendered by the Renderer registered for them.
 func (t *Team) GenerateOrchestrationMD(cfg OrchestrationConfig) string {
 	var buf bytes.Buffer
 
@@ -39,28 +44,15 @@ func (t *Team) GenerateOrchestrationMD(cfg OrchestrationConfig) string {
 	}
 	buf.WriteString("\n---\n\n")
 
-	// Filter tasks if IncludeTasks is specified
-	tasks := t.Tasks
-	if len(cfg.IncludeTasks) > 0 {
-		includeSet := make(map[string]bool)
-		for _, name := range cfg.IncludeTasks {
-			includeSet[name] = true
-		}
-		var filtered []Task
-		for _, task := range tasks {
-			if includeSet[task.Name] {
-				filtered = append(filtered, task)
-			}
-		}
-		tasks = filtered
-	}
+	tasks := t.orchestrationTasks(cfg)
+	groups := t.OrchestrationGroups(cfg)
 
-	// Get parallel groups
-	tempTeam := &Team{Tasks: tasks}
-	groups, err := tempTeam.ParallelGroups()
-	if err != nil {
-		// Fall back to sequential if grouping fails
-		groups = [][]Task{tasks}
+	if cfg.IncludeDiagram {
+		tempTeam := &Team{Name: t.Name, Agents: t.Agents, Tasks: tasks}
+		buf.WriteString("## Task Graph\n\n")
+		buf.WriteString("```mermaid\n")
+		buf.WriteString(tempTeam.GenerateMermaid(GraphConfig{HighlightCriticalPath: true}))
+		buf.WriteString("```\n\n")
 	}
 
 	// Generate instructions for each group
@@ -77,7 +69,7 @@ func (t *Team) GenerateOrchestrationMD(cfg OrchestrationConfig) string {
 		}
 
 		for _, task := range group {
-			writeTaskInstructions(&buf, task, cfg)
+			writeTaskInstructions(&buf, task, t.ExecutionNotes(task), cfg)
 		}
 	}
 
@@ -86,14 +78,96 @@ func (t *Team) GenerateOrchestrationMD(cfg OrchestrationConfig) string {
 	buf.WriteString("## Expected Status Report\n\n")
 	buf.WriteString("After execution, report status in this format:\n\n")
 	buf.WriteString("```\n")
-	writeStatusReportTemplate(&buf, tasks)
+	buf.WriteString(StatusReportTemplate(tasks))
 	buf.WriteString("```\n")
 
 	return buf.String()
 }
 
+// orchestrationTasks returns the tasks selected by cfg.IncludeTasks, in
+// definition order.
+func (t *Team) orchestrationTasks(cfg OrchestrationConfig) []Task {
+	if len(cfg.IncludeTasks) == 0 {
+		return t.Tasks
+	}
+	includeSet := make(map[string]bool)
+	for _, name := range cfg.IncludeTasks {
+		includeSet[name] = true
+	}
+	var filtered []Task
+	for _, task := range t.Tasks {
+		if includeSet[task.Name] {
+			filtered = append(filtered, task)
+		}
+	}
+	return filtered
+}
+
+// OrchestrationGroups returns the tasks selected by cfg grouped by
+// execution wave, as ParallelGroups does. If the tasks cannot be grouped,
+// they form a single group in definition order.
+func (t *Team) OrchestrationGroups(cfg OrchestrationConfig) [][]Task {
+	tasks := t.orchestrationTasks(cfg)
+	tempTeam := &Team{Tasks: tasks}
+	groups, err := tempTeam.ParallelGroups()
+	if err != nil {
+		// Fall back to sequential if grouping fails
+		return [][]Task{tasks}
+	}
+	return groups
+}
+
+// SubagentPrompt returns the prompt that hands a task to its agent: where
+// to read the agent's instructions, the subtasks to run and the target
+// version.
+func SubagentPrompt(task Task, cfg OrchestrationConfig) string {
+	agentPath := task.Agent + ".md"
+	if cfg.AgentSpecsPath != "" {
+		agentPath = cfg.AgentSpecsPath + "/" + task.Agent + ".md"
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("You are the %s specialist. Read your instructions from %s\n", task.Agent, agentPath))
+	buf.WriteString("\n")
+	buf.WriteString("Execute the following subtasks and report Go/No-Go for each:\n")
+	for _, st := range task.Subtasks {
+		buf.WriteString(fmt.Sprintf("- %s\n", st.Name))
+	}
+	if cfg.Version != "" {
+		buf.WriteString("\n")
+		buf.WriteString(fmt.Sprintf("Target version: %s\n", cfg.Version))
+	}
+	return buf.String()
+}
+
+// DisplayName returns the team name in Title Case.
+func (t *Team) DisplayName() string {
+	return toTitle(t.Name)
+}
+
+// Summary returns the team description, or a sentence naming the team if
+// it has none.
+func (t *Team) Summary() string {
+	if t.Description != "" {
+		return t.Description
+	}
+	return fmt.Sprintf("Orchestrate the %s team", t.DisplayName())
+}
+
+// SubtaskList returns the names of a task's subtasks, marking optional ones.
+func SubtaskList(task Task) string {
+	names := make([]string, len(task.Subtasks))
+	for i, st := range task.Subtasks {
+		names[i] = st.Name
+		if !st.Required {
+			names[i] += " (optional)"
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
 // writeTaskInstructions writes instructions for a single task.
-func writeTaskInstructions(buf *bytes.Buffer, task Task, cfg OrchestrationConfig) {
+func writeTaskInstructions(buf *bytes.Buffer, task Task, notes []string, cfg OrchestrationConfig) {
 	buf.WriteString(fmt.Sprintf("### Task: %s\n\n", task.Name))
 
 	if task.Description != "" {
@@ -104,29 +178,19 @@ func writeTaskInstructions(buf *bytes.Buffer, task Task, cfg OrchestrationConfig
 	if len(task.DependsOn) > 0 {
 		buf.WriteString(fmt.Sprintf("**Requires:** %s (must be GO)\n\n", strings.Join(task.DependsOn, ", ")))
 	}
-
-	// Agent reference
-	agentPath := task.Agent + ".md"
-	if cfg.AgentSpecsPath != "" {
-		agentPath = cfg.AgentSpecsPath + "/" + task.Agent + ".md"
+	for _, note := range notes {
+		buf.WriteString(note + "\n\n")
 	}
 
 	buf.WriteString("**Instructions:**\n\n")
 	buf.WriteString(fmt.Sprintf("Use the Task tool to spawn subagent `%s`:\n\n", task.Agent))
 	buf.WriteString("```\n")
-	buf.WriteString(fmt.Sprintf("Task tool:\n"))
-	buf.WriteString(fmt.Sprintf("  subagent_type: general-purpose\n"))
+	buf.WriteString("Task tool:\n")
+	buf.WriteString("  subagent_type: general-purpose\n")
 	buf.WriteString(fmt.Sprintf("  description: \"%s\"\n", task.Description))
-	buf.WriteString(fmt.Sprintf("  prompt: |\n"))
-	buf.WriteString(fmt.Sprintf("    You are the %s specialist. Read your instructions from %s\n", task.Agent, agentPath))
-	buf.WriteString(fmt.Sprintf("    \n"))
-	buf.WriteString(fmt.Sprintf("    Execute the following subtasks and report Go/No-Go for each:\n"))
-	for _, st := range task.Subtasks {
-		buf.WriteString(fmt.Sprintf("    - %s\n", st.Name))
-	}
-	if cfg.Version != "" {
-		buf.WriteString(fmt.Sprintf("    \n"))
-		buf.WriteString(fmt.Sprintf("    Target version: %s\n", cfg.Version))
+	buf.WriteString("  prompt: |\n")
+	for _, line := range strings.Split(strings.TrimSuffix(SubagentPrompt(task, cfg), "\n"), "\n") {
+		buf.WriteString("    " + line + "\n")
 	}
 	buf.WriteString("```\n\n")
 
@@ -159,8 +223,10 @@ func writeTaskInstructions(buf *bytes.Buffer, task Task, cfg OrchestrationConfig
 	buf.WriteString("Optional subtasks report WARN on failure.\n\n")
 }
 
-// writeStatusReportTemplate writes the expected status report format.
-func writeStatusReportTemplate(buf *bytes.Buffer, tasks []Task) {
+// StatusReportTemplate returns the status report that agents are asked to
+// fill in for tasks, drawn as a box.
+func StatusReportTemplate(tasks []Task) string {
+	var buf bytes.Buffer
 	// Calculate max widths
 	maxTaskLen := 20
 	maxSubtaskLen := 18
@@ -203,6 +269,7 @@ func writeStatusReportTemplate(buf *bytes.Buffer, tasks []Task) {
 
 	buf.WriteString(fmt.Sprintf("║%s║\n", centerText("🚀 TEAM: GO 🚀", width)))
 	buf.WriteString(fmt.Sprintf("╚%s╝\n", border))
+	return buf.String()
 }
 
 // toTitle converts a kebab-case string to Title Case.
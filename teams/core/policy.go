@@ -0,0 +1,190 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Condition decides whether a task runs. Every check that is set must
+// hold; otherwise the task is skipped.
+type Condition struct {
+	// Task is an upstream task whose status is checked. The task with the
+	// condition runs after it, even if it does not depend on it.
+	Task string `json:"task,omitempty" yaml:"task,omitempty"`
+
+	// Status lists the statuses of Task that satisfy the condition.
+	// Defaults to GO and WARN.
+	Status []Status `json:"status,omitempty" yaml:"status,omitempty"`
+
+	// Input names a run input (e.g., "deps_changed") that must equal
+	// Equals, or be set to any value if Equals is empty.
+	Input string `json:"input,omitempty" yaml:"input,omitempty"`
+
+	// Equals is the value Input must have.
+	Equals string `json:"equals,omitempty" yaml:"equals,omitempty"`
+
+	// Command is a shell command that must exit with status 0
+	// (e.g., "! git diff --quiet origin/main -- go.mod go.sum").
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+// Statuses returns the statuses of Task that satisfy the condition.
+func (c *Condition) Statuses() []Status {
+	if len(c.Status) == 0 {
+		return []Status{StatusGo, StatusWarn}
+	}
+	return c.Status
+}
+
+// String describes the condition (e.g., "qa is GO or WARN and input
+// deps_changed is \"true\"").
+func (c *Condition) String() string {
+	var parts []string
+	if c.Task != "" {
+		statuses := make([]string, len(c.Statuses()))
+		for i, s := range c.Statuses() {
+			statuses[i] = string(s)
+		}
+		parts = append(parts, fmt.Sprintf("%s is %s", c.Task, strings.Join(statuses, " or ")))
+	}
+	if c.Input != "" {
+		if c.Equals != "" {
+			parts = append(parts, fmt.Sprintf("input %s is %q", c.Input, c.Equals))
+		} else {
+			parts = append(parts, fmt.Sprintf("input %s is set", c.Input))
+		}
+	}
+	if c.Command != "" {
+		parts = append(parts, fmt.Sprintf("`%s` succeeds", c.Command))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// RetryPolicy reruns a task whose required subtasks fail.
+type RetryPolicy struct {
+	// Count is the number of retries after the first attempt.
+	Count int `json:"count" yaml:"count"`
+
+	// Backoff is the wait in seconds before the first retry. The wait
+	// doubles before each further retry.
+	Backoff int `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+}
+
+// Delay returns the wait before the given retry, counting from 1.
+func (p *RetryPolicy) Delay(retry int) time.Duration {
+	if retry < 1 {
+		return 0
+	}
+	return time.Duration(p.Backoff) * time.Second << (retry - 1)
+}
+
+// FallbackFor returns the names of the tasks that name the given task as
+// their on_failure fallback, in definition order.
+func (t *Team) FallbackFor(name string) []string {
+	var sources []string
+	for _, task := range t.Tasks {
+		if task.OnFailure == name {
+			sources = append(sources, task.Name)
+		}
+	}
+	return sources
+}
+
+// orderingDependencies returns the tasks that must finish before task
+// starts: its dependencies, the task its condition checks and the tasks it
+// is the fallback for.
+func (t *Team) orderingDependencies(task Task) []string {
+	deps := append([]string{}, task.DependsOn...)
+	seen := make(map[string]bool)
+	for _, dep := range deps {
+		seen[dep] = true
+	}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			deps = append(deps, name)
+		}
+	}
+	if task.When != nil && task.When.Task != "" {
+		add(task.When.Task)
+	}
+	for _, source := range t.FallbackFor(task.Name) {
+		add(source)
+	}
+	return deps
+}
+
+// validatePolicies checks a task's condition, retry policy and fallback.
+func (t *Team) validatePolicies(task Task, taskNames map[string]bool) []*ValidationError {
+	var errs []*ValidationError
+	field := "tasks." + task.Name
+
+	if c := task.When; c != nil {
+		switch {
+		case c.Task == "" && c.Input == "" && c.Command == "":
+			errs = append(errs, &ValidationError{Field: field + ".when", Message: "condition needs a task, input or command"})
+		case c.Task == task.Name:
+			errs = append(errs, &ValidationError{Field: field + ".when", Message: "condition cannot check its own task"})
+		case c.Task != "" && !taskNames[c.Task]:
+			errs = append(errs, &ValidationError{Field: field + ".when", Message: "unknown task: " + c.Task})
+		}
+		if len(c.Status) > 0 && c.Task == "" {
+			errs = append(errs, &ValidationError{Field: field + ".when", Message: "status requires a task"})
+		}
+		for _, s := range c.Status {
+			switch s {
+			case StatusGo, StatusNoGo, StatusWarn, StatusSkip:
+			default:
+				errs = append(errs, &ValidationError{Field: field + ".when", Message: "invalid status: " + string(s)})
+			}
+		}
+		if c.Equals != "" && c.Input == "" {
+			errs = append(errs, &ValidationError{Field: field + ".when", Message: "equals requires an input"})
+		}
+	}
+
+	if p := task.Retry; p != nil && (p.Count < 0 || p.Backoff < 0) {
+		errs = append(errs, &ValidationError{Field: field + ".retry", Message: "retry count and backoff cannot be negative"})
+	}
+
+	if fallback := task.OnFailure; fallback != "" {
+		switch {
+		case fallback == task.Name:
+			errs = append(errs, &ValidationError{Field: field + ".on_failure", Message: "task cannot be its own fallback"})
+		case !taskNames[fallback]:
+			errs = append(errs, &ValidationError{Field: field + ".on_failure", Message: "unknown task: " + fallback})
+		}
+	}
+
+	return errs
+}
+
+// ExecutionNotes returns Markdown notes on how to run a task beyond its
+// dependencies: its condition, the tasks it is the fallback for, its retry
+// policy and what to do when it fails. Orchestration instructions list
+// them under the task.
+func (t *Team) ExecutionNotes(task Task) []string {
+	var notes []string
+	if task.When != nil {
+		notes = append(notes, fmt.Sprintf("**Run only if:** %s; otherwise mark the task SKIP", task.When))
+	}
+	if sources := t.FallbackFor(task.Name); len(sources) > 0 {
+		notes = append(notes, fmt.Sprintf("**Fallback for:** %s; run only if a required subtask of one of them fails, otherwise mark the task SKIP",
+			strings.Join(sources, ", ")))
+	}
+	if p := task.Retry; p != nil && p.Count > 0 {
+		note := fmt.Sprintf("**Retry:** if a required subtask fails, rerun the task up to %d more times", p.Count)
+		if p.Backoff > 0 {
+			note += fmt.Sprintf(", waiting %ds before the first retry and twice as long before each further one", p.Backoff)
+		}
+		notes = append(notes, note)
+	}
+	if task.ContinueOnError {
+		notes = append(notes, "**Continue on error:** report failed required subtasks as WARN, so tasks that require this one still run")
+	}
+	if task.OnFailure != "" {
+		notes = append(notes, fmt.Sprintf("**On failure:** if a required subtask fails, run %s", task.OnFailure))
+	}
+	return notes
+}
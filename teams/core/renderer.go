@@ -0,0 +1,152 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Artifact is a file generated to orchestrate a team on a platform.
+type Artifact struct {
+	// Path is the file path, relative to the output directory.
+	Path string
+
+	// Content is the file content, or the section content if Section is set.
+	Content []byte
+
+	// Section, if set, names a section of a file shared with other content,
+	// such as AGENTS.md. WriteArtifacts replaces only that section.
+	Section string
+}
+
+// Renderer generates the artifacts that let a platform's assistant
+// orchestrate a team.
+type Renderer interface {
+	// Name returns the platform identifier (e.g., "claude", "codex").
+	Name() string
+
+	// Render returns the orchestration artifacts for team.
+	Render(team *Team, cfg OrchestrationConfig) ([]Artifact, error)
+}
+
+// RendererRegistry manages renderer registration and lookup.
+type RendererRegistry struct {
+	mu        sync.RWMutex
+	renderers map[string]Renderer
+}
+
+// NewRendererRegistry creates a new renderer registry.
+func NewRendererRegistry() *RendererRegistry {
+	return &RendererRegistry{
+		renderers: make(map[string]Renderer),
+	}
+}
+
+// Register adds a renderer to the registry.
+func (r *RendererRegistry) Register(renderer Renderer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.renderers[renderer.Name()] = renderer
+}
+
+// GetRenderer returns a renderer by name.
+func (r *RendererRegistry) GetRenderer(name string) (Renderer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	renderer, ok := r.renderers[name]
+	return renderer, ok
+}
+
+// RendererNames returns all registered renderer names sorted alphabetically.
+func (r *RendererRegistry) RendererNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.renderers))
+	for name := range r.renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultRendererRegistry is the global renderer registry.
+var DefaultRendererRegistry = NewRendererRegistry()
+
+// RegisterRenderer adds a renderer to the default registry.
+func RegisterRenderer(renderer Renderer) {
+	DefaultRendererRegistry.Register(renderer)
+}
+
+// GetRenderer returns a renderer from the default registry.
+func GetRenderer(name string) (Renderer, bool) {
+	return DefaultRendererRegistry.GetRenderer(name)
+}
+
+// RendererNames returns renderer names from the default registry.
+func RendererNames() []string {
+	return DefaultRendererRegistry.RendererNames()
+}
+
+// WriteArtifacts writes artifacts under dir. A section artifact replaces
+// the section of the same name in an existing file, or is appended to it,
+// and the rest of the file is kept.
+func WriteArtifacts(dir string, artifacts []Artifact) error {
+	for _, artifact := range artifacts {
+		path := filepath.Join(dir, filepath.FromSlash(artifact.Path))
+		if err := os.MkdirAll(filepath.Dir(path), DefaultDirMode); err != nil {
+			return &WriteError{Path: path, Err: err}
+		}
+
+		data := artifact.Content
+		if artifact.Section != "" {
+			existing, err := os.ReadFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return &ReadError{Path: path, Err: err}
+			}
+			data = replaceSection(existing, artifact.Section, artifact.Content)
+		}
+		if err := os.WriteFile(path, data, DefaultFileMode); err != nil {
+			return &WriteError{Path: path, Err: err}
+		}
+	}
+	return nil
+}
+
+// replaceSection replaces the content between the markers of a named
+// section in data, or appends the section if data has none.
+func replaceSection(data []byte, name string, content []byte) []byte {
+	begin := []byte(fmt.Sprintf("<!-- BEGIN assistantkit:%s -->\n", name))
+	end := []byte(fmt.Sprintf("<!-- END assistantkit:%s -->\n", name))
+
+	var section bytes.Buffer
+	section.Write(begin)
+	section.Write(content)
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		section.WriteByte('\n')
+	}
+	section.Write(end)
+
+	if i := bytes.Index(data, begin); i >= 0 {
+		if j := bytes.Index(data[i:], end); j >= 0 {
+			var out bytes.Buffer
+			out.Write(data[:i])
+			out.Write(section.Bytes())
+			out.Write(data[i+j+len(end):])
+			return out.Bytes()
+		}
+	}
+
+	var out bytes.Buffer
+	out.Write(data)
+	if len(data) > 0 {
+		if data[len(data)-1] != '\n' {
+			out.WriteByte('\n')
+		}
+		out.WriteByte('\n')
+	}
+	out.Write(section.Bytes())
+	return out.Bytes()
+}
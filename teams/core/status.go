@@ -1,5 +1,7 @@
 package core
 
+import "time"
+
 // Status represents the result of a subtask or task.
 type Status string
 
@@ -60,10 +62,11 @@ func (s Status) IsPassing() bool {
 
 // SubtaskResult holds the result of a subtask execution.
 type SubtaskResult struct {
-	Name    string `json:"name"`
-	Status  Status `json:"status"`
-	Message string `json:"message,omitempty"`
-	Output  string `json:"output,omitempty"`
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Message  string        `json:"message,omitempty"`
+	Output   string        `json:"output,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"`
 }
 
 // TaskResult holds the result of a task execution.
@@ -72,14 +75,17 @@ type TaskResult struct {
 	Agent    string          `json:"agent"`
 	Status   Status          `json:"status"`
 	Subtasks []SubtaskResult `json:"subtasks,omitempty"`
+	Duration time.Duration   `json:"duration,omitempty"`
+	Attempts int             `json:"attempts,omitempty"` // Runs of the task, including retries
 }
 
 // TeamResult holds the result of a team execution.
 type TeamResult struct {
-	Name    string       `json:"name"`
-	Status  Status       `json:"status"`
-	Tasks   []TaskResult `json:"tasks"`
-	Version string       `json:"version,omitempty"` // Target version if applicable
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Tasks    []TaskResult  `json:"tasks"`
+	Version  string        `json:"version,omitempty"` // Target version if applicable
+	Duration time.Duration `json:"duration,omitempty"`
 }
 
 // ComputeTaskStatus computes the overall status from subtask results.
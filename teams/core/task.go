@@ -1,5 +1,7 @@
 package core
 
+import "time"
+
 // Task represents a unit of work assigned to an agent within a team.
 type Task struct {
 	// Name is the task identifier (e.g., "qa-validation", "docs-validation").
@@ -22,6 +24,21 @@ type Task struct {
 
 	// Outputs are data or artifacts produced by the task.
 	Outputs []string `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+
+	// When is a condition the task runs under; if it does not hold, the
+	// task is skipped.
+	When *Condition `json:"when,omitempty" yaml:"when,omitempty"`
+
+	// Retry reruns the task when a required subtask fails.
+	Retry *RetryPolicy `json:"retry,omitempty" yaml:"retry,omitempty"`
+
+	// ContinueOnError reports failed required subtasks as WARN instead of
+	// NO-GO, so tasks that depend on this one still run.
+	ContinueOnError bool `json:"continue_on_error,omitempty" yaml:"continue_on_error,omitempty"`
+
+	// OnFailure names a fallback task that runs only if a required subtask
+	// of this task fails. The fallback is skipped otherwise.
+	OnFailure string `json:"on_failure,omitempty" yaml:"on_failure,omitempty"`
 }
 
 // NewTask creates a new Task with the given name and agent.
@@ -44,6 +61,25 @@ func (t *Task) AddDependency(taskName string) *Task {
 	return t
 }
 
+// WithCondition sets the condition the task runs under.
+func (t *Task) WithCondition(c Condition) *Task {
+	t.When = &c
+	return t
+}
+
+// WithRetry sets the number of retries and the backoff in seconds before
+// the first retry.
+func (t *Task) WithRetry(count, backoff int) *Task {
+	t.Retry = &RetryPolicy{Count: count, Backoff: backoff}
+	return t
+}
+
+// WithFallback sets the task that runs if this task fails.
+func (t *Task) WithFallback(taskName string) *Task {
+	t.OnFailure = taskName
+	return t
+}
+
 // AddSubtask adds a subtask to the task.
 func (t *Task) AddSubtask(subtask Subtask) *Task {
 	t.Subtasks = append(t.Subtasks, subtask)
@@ -61,6 +97,16 @@ func (t *Task) HasDependencies() bool {
 	return len(t.DependsOn) > 0
 }
 
+// HasDependency returns true if this task depends on the named task.
+func (t *Task) HasDependency(taskName string) bool {
+	for _, dep := range t.DependsOn {
+		if dep == taskName {
+			return true
+		}
+	}
+	return false
+}
+
 // HasSubtasks returns true if this task has subtasks.
 func (t *Task) HasSubtasks() bool {
 	return len(t.Subtasks) > 0
@@ -77,6 +123,32 @@ func (t *Task) RequiredSubtaskCount() int {
 	return count
 }
 
+// EstimatedDuration returns the sum of the subtask timeouts. Subtasks run
+// in order, so this bounds how long the task takes; subtasks without a
+// timeout count as zero.
+func (t *Task) EstimatedDuration() time.Duration {
+	var d time.Duration
+	for _, st := range t.Subtasks {
+		d += time.Duration(st.Timeout) * time.Second
+	}
+	return d
+}
+
+// MaxDuration returns the longest the task can take: its
+// EstimatedDuration for the first attempt and every retry, plus the
+// backoff before each retry.
+func (t *Task) MaxDuration() time.Duration {
+	d := t.EstimatedDuration()
+	if t.Retry == nil || t.Retry.Count <= 0 {
+		return d
+	}
+	total := d
+	for retry := 1; retry <= t.Retry.Count; retry++ {
+		total += t.Retry.Delay(retry) + d
+	}
+	return total
+}
+
 // SubtaskNames returns the names of all subtasks.
 func (t *Task) SubtaskNames() []string {
 	names := make([]string, len(t.Subtasks))
@@ -112,21 +112,32 @@ func (t *Team) RequiredSubtaskCount() int {
 }
 
 // Validate checks if the team definition is valid.
+// It returns the first problem found; use ValidateAll to collect every problem.
 func (t *Team) Validate() error {
+	if errs := t.ValidateAll(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ValidateAll checks the team definition and returns every problem found.
+func (t *Team) ValidateAll() []*ValidationError {
+	var errs []*ValidationError
+
 	if t.Name == "" {
-		return &ValidationError{Field: "name", Message: "team name is required"}
+		errs = append(errs, &ValidationError{Field: "name", Message: "team name is required"})
 	}
 
 	if !t.Process.IsValid() {
-		return &ValidationError{Field: "process", Message: "invalid process type"}
+		errs = append(errs, &ValidationError{Field: "process", Message: "invalid process type"})
 	}
 
 	if t.Process == ProcessHierarchical && t.Manager == "" {
-		return &ValidationError{Field: "manager", Message: "manager is required for hierarchical process"}
+		errs = append(errs, &ValidationError{Field: "manager", Message: "manager is required for hierarchical process"})
 	}
 
 	if len(t.Tasks) == 0 {
-		return &ValidationError{Field: "tasks", Message: "at least one task is required"}
+		errs = append(errs, &ValidationError{Field: "tasks", Message: "at least one task is required"})
 	}
 
 	// Validate task dependencies
@@ -138,15 +149,16 @@ func (t *Team) Validate() error {
 	for _, task := range t.Tasks {
 		for _, dep := range task.DependsOn {
 			if !taskNames[dep] {
-				return &ValidationError{
+				errs = append(errs, &ValidationError{
 					Field:   "tasks." + task.Name + ".depends_on",
 					Message: "unknown dependency: " + dep,
-				}
+				})
 			}
 		}
+		errs = append(errs, t.validatePolicies(task, taskNames)...)
 	}
 
-	return nil
+	return errs
 }
 
 // ValidationError represents a validation error.
@@ -161,6 +173,7 @@ func (e *ValidationError) Error() string {
 
 // TopologicalSort returns tasks in dependency order.
 // Tasks with no dependencies come first, followed by tasks whose dependencies are satisfied.
+// A task also comes after the task its condition checks and the tasks it is the fallback for.
 func (t *Team) TopologicalSort() ([]Task, error) {
 	// Build dependency graph
 	inDegree := make(map[string]int)
@@ -170,7 +183,7 @@ func (t *Team) TopologicalSort() ([]Task, error) {
 		if _, exists := inDegree[task.Name]; !exists {
 			inDegree[task.Name] = 0
 		}
-		for _, dep := range task.DependsOn {
+		for _, dep := range t.orderingDependencies(task) {
 			inDegree[task.Name]++
 			dependents[dep] = append(dependents[dep], task.Name)
 		}
@@ -223,7 +236,7 @@ func (t *Team) ParallelGroups() ([][]Task, error) {
 	levels := make(map[string]int)
 	for _, task := range sorted {
 		maxDepLevel := -1
-		for _, dep := range task.DependsOn {
+		for _, dep := range t.orderingDependencies(task) {
 			if levels[dep] > maxDepLevel {
 				maxDepLevel = levels[dep]
 			}
@@ -239,14 +252,11 @@ func (t *Team) ParallelGroups() ([][]Task, error) {
 		}
 	}
 
+	// Keep tasks in definition order within each group
 	groups := make([][]Task, maxLevel+1)
-	taskMap := make(map[string]Task)
 	for _, task := range t.Tasks {
-		taskMap[task.Name] = task
-	}
-
-	for name, level := range levels {
-		groups[level] = append(groups[level], taskMap[name])
+		level := levels[task.Name]
+		groups[level] = append(groups[level], task)
 	}
 
 	return groups, nil
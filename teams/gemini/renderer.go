@@ -0,0 +1,81 @@
+// Package gemini provides the Gemini CLI team orchestration renderer.
+package gemini
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+	"github.com/pelletier/go-toml/v2"
+)
+
+func init() {
+	core.RegisterRenderer(&Renderer{})
+}
+
+// Renderer renders team orchestration as a Gemini CLI custom command that
+// delegates each task to its agent's subagent.
+type Renderer struct{}
+
+// Name returns the renderer identifier.
+func (r *Renderer) Name() string {
+	return "gemini"
+}
+
+// command is a Gemini CLI custom command file.
+type command struct {
+	Description string `toml:"description"`
+	Prompt      string `toml:"prompt,multiline"`
+}
+
+// Render returns commands/<team>.toml. Its prompt calls the subagent of
+// each task's agent, one step at a time; Gemini runs tool calls in turn,
+// so the tasks of a step run one after another.
+func (r *Renderer) Render(team *core.Team, cfg core.OrchestrationConfig) ([]core.Artifact, error) {
+	data, err := toml.Marshal(command{
+		Description: team.Summary(),
+		Prompt:      prompt(team, cfg),
+	})
+	if err != nil {
+		return nil, &core.MarshalError{Format: "gemini", Err: err}
+	}
+	return []core.Artifact{
+		{Path: "commands/" + team.Name + ".toml", Content: data},
+	}, nil
+}
+
+// prompt returns the orchestration prompt.
+func prompt(team *core.Team, cfg core.OrchestrationConfig) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("# %s Orchestration\n\n", team.DisplayName()))
+	buf.WriteString("Delegate each task below to the subagent named after its agent, passing the prompt given. ")
+	buf.WriteString("Run the steps in order and do not start a task until the tasks it requires are GO. ")
+	buf.WriteString("If a task is NO-GO, skip the tasks that require it.\n\n")
+
+	groups := team.OrchestrationGroups(cfg)
+	var tasks []core.Task
+	for i, group := range groups {
+		buf.WriteString(fmt.Sprintf("## Step %d\n\n", i+1))
+		for _, task := range group {
+			tasks = append(tasks, task)
+			buf.WriteString(fmt.Sprintf("### Task: %s (subagent `%s`)\n\n", task.Name, task.Agent))
+			if len(task.DependsOn) > 0 {
+				buf.WriteString(fmt.Sprintf("**Requires:** %s (must be GO)\n\n", strings.Join(task.DependsOn, ", ")))
+			}
+			for _, note := range team.ExecutionNotes(task) {
+				buf.WriteString(note + "\n\n")
+			}
+			buf.WriteString("Prompt:\n\n")
+			buf.WriteString(core.SubagentPrompt(task, cfg))
+			buf.WriteString("\n")
+		}
+	}
+
+	buf.WriteString("## Status Report\n\n")
+	buf.WriteString("When every task has run, report status in this format:\n\n")
+	buf.WriteString("```\n")
+	buf.WriteString(core.StatusReportTemplate(tasks))
+	buf.WriteString("```\n")
+	return buf.String()
+}
@@ -0,0 +1,97 @@
+// Package kiro provides the AWS Kiro CLI team orchestration renderer.
+package kiro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	agentkiro "github.com/agentplexus/assistantkit/agents/kiro"
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+func init() {
+	core.RegisterRenderer(&Renderer{})
+}
+
+// Renderer renders team orchestration as a Kiro steering file and an
+// orchestrator agent that hands each task off to its agent.
+type Renderer struct{}
+
+// Name returns the renderer identifier.
+func (r *Renderer) Name() string {
+	return "kiro"
+}
+
+// Render returns:
+//   - steering/<team>-orchestration.md, the orchestration instructions,
+//     included manually with #<team>-orchestration
+//   - agents/<team>-orchestrator.json, an agent that loads the steering
+//     file and swaps to each task's agent in turn
+func (r *Renderer) Render(team *core.Team, cfg core.OrchestrationConfig) ([]core.Artifact, error) {
+	steering := team.Name + "-orchestration"
+	orchestrator := team.Name + "-orchestrator"
+
+	agent := agentkiro.AgentConfig{
+		Name:        orchestrator,
+		Description: team.Summary(),
+		Prompt: fmt.Sprintf("You orchestrate the %s team. Follow the steering file %s.md: hand each task off to its agent, "+
+			"record its GO/NO-GO status and report the team status when every task has run.", team.DisplayName(), steering),
+		Resources: []string{"file://.kiro/steering/" + steering + ".md"},
+	}
+	data, err := json.MarshalIndent(agent, "", "  ")
+	if err != nil {
+		return nil, &core.MarshalError{Format: "kiro", Err: err}
+	}
+
+	return []core.Artifact{
+		{Path: "steering/" + steering + ".md", Content: steeringFile(team, orchestrator, cfg)},
+		{Path: "agents/" + orchestrator + ".json", Content: append(data, '\n')},
+	}, nil
+}
+
+// steeringFile returns the orchestration steering file. Kiro runs one
+// agent at a time, so each task is a handoff with /agent swap and back.
+func steeringFile(team *core.Team, orchestrator string, cfg core.OrchestrationConfig) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.WriteString("inclusion: manual\n")
+	buf.WriteString("---\n\n")
+	buf.WriteString(fmt.Sprintf("# %s Orchestration\n\n", team.DisplayName()))
+	buf.WriteString(team.Summary() + "\n\n")
+	buf.WriteString("Run the tasks below in order. For each task, hand off to its agent, ")
+	buf.WriteString(fmt.Sprintf("then return to `%s` and record GO, WARN or NO-GO before moving on. ", orchestrator))
+	buf.WriteString("If a task is NO-GO, skip the tasks that require it.\n\n")
+
+	var tasks []core.Task
+	step := 0
+	for _, group := range team.OrchestrationGroups(cfg) {
+		for _, task := range group {
+			step++
+			tasks = append(tasks, task)
+			buf.WriteString(fmt.Sprintf("## Step %d: %s\n\n", step, task.Name))
+			if len(task.DependsOn) > 0 {
+				buf.WriteString(fmt.Sprintf("**Requires:** %s (must be GO)\n\n", strings.Join(task.DependsOn, ", ")))
+			}
+			for _, note := range team.ExecutionNotes(task) {
+				buf.WriteString(note + "\n\n")
+			}
+			buf.WriteString(fmt.Sprintf("**Subtasks:** %s\n\n", core.SubtaskList(task)))
+			buf.WriteString("**Handoff:**\n\n")
+			buf.WriteString(fmt.Sprintf("1. Run `/agent swap %s`\n", task.Agent))
+			buf.WriteString("2. Give it this prompt:\n\n")
+			buf.WriteString("```\n")
+			buf.WriteString(core.SubagentPrompt(task, cfg))
+			buf.WriteString("```\n\n")
+			buf.WriteString(fmt.Sprintf("3. Run `/agent swap %s` and record the task status\n\n", orchestrator))
+		}
+	}
+
+	buf.WriteString("## Status Report\n\n")
+	buf.WriteString("When every task has run, report status in this format:\n\n")
+	buf.WriteString("```\n")
+	buf.WriteString(core.StatusReportTemplate(tasks))
+	buf.WriteString("```\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,89 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+// SchemaVersion is the version of the JSON report schema. Fields may be
+// added within a version; it changes when fields are removed or change
+// meaning.
+const SchemaVersion = 1
+
+// JSON report document types. Durations are in milliseconds and output is
+// cut to an excerpt.
+type (
+	jsonReport struct {
+		SchemaVersion int         `json:"schema_version"`
+		Team          string      `json:"team"`
+		Version       string      `json:"version,omitempty"`
+		Status        core.Status `json:"status"`
+		DurationMS    int64       `json:"duration_ms"`
+		Summary       Summary     `json:"summary"`
+		Blockers      []Blocker   `json:"blockers"`
+		Tasks         []jsonTask  `json:"tasks"`
+	}
+
+	jsonTask struct {
+		Name       string        `json:"name"`
+		Agent      string        `json:"agent"`
+		Status     core.Status   `json:"status"`
+		DurationMS int64         `json:"duration_ms"`
+		Attempts   int           `json:"attempts,omitempty"`
+		Subtasks   []jsonSubtask `json:"subtasks"`
+	}
+
+	jsonSubtask struct {
+		Name       string      `json:"name"`
+		Status     core.Status `json:"status"`
+		DurationMS int64       `json:"duration_ms"`
+		Message    string      `json:"message,omitempty"`
+		Output     string      `json:"output,omitempty"`
+	}
+)
+
+// WriteJSON writes a team result as an indented JSON document.
+func WriteJSON(w io.Writer, result *core.TeamResult, opts Options) error {
+	report := jsonReport{
+		SchemaVersion: SchemaVersion,
+		Team:          result.Name,
+		Version:       result.Version,
+		Status:        result.Status,
+		DurationMS:    result.Duration.Milliseconds(),
+		Summary:       Summarize(result),
+		Blockers:      Blockers(result),
+		Tasks:         []jsonTask{},
+	}
+	if report.Blockers == nil {
+		report.Blockers = []Blocker{}
+	}
+	for _, task := range result.Tasks {
+		jt := jsonTask{
+			Name:       task.Name,
+			Agent:      task.Agent,
+			Status:     task.Status,
+			DurationMS: task.Duration.Milliseconds(),
+			Attempts:   task.Attempts,
+			Subtasks:   []jsonSubtask{},
+		}
+		for _, st := range task.Subtasks {
+			js := jsonSubtask{
+				Name:       st.Name,
+				Status:     st.Status,
+				DurationMS: st.Duration.Milliseconds(),
+				Message:    st.Message,
+			}
+			if failed(st) {
+				js.Output = excerpt(st.Output, opts.OutputLines)
+			}
+			jt.Subtasks = append(jt.Subtasks, js)
+		}
+		report.Tasks = append(report.Tasks, jt)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -0,0 +1,113 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+// JUnit XML document types. Tasks are test suites and subtasks are test
+// cases; NO-GO subtasks fail, SKIP subtasks are skipped and WARN subtasks
+// pass with their message and output in system-out.
+type (
+	junitTestSuites struct {
+		XMLName  xml.Name         `xml:"testsuites"`
+		Name     string           `xml:"name,attr"`
+		Tests    int              `xml:"tests,attr"`
+		Failures int              `xml:"failures,attr"`
+		Skipped  int              `xml:"skipped,attr"`
+		Time     string           `xml:"time,attr"`
+		Suites   []junitTestSuite `xml:"testsuite"`
+	}
+
+	junitTestSuite struct {
+		Name     string          `xml:"name,attr"`
+		Tests    int             `xml:"tests,attr"`
+		Failures int             `xml:"failures,attr"`
+		Skipped  int             `xml:"skipped,attr"`
+		Time     string          `xml:"time,attr"`
+		Cases    []junitTestCase `xml:"testcase"`
+	}
+
+	junitTestCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Time      string        `xml:"time,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+		Skipped   *junitSkipped `xml:"skipped,omitempty"`
+		SystemOut string        `xml:"system-out,omitempty"`
+	}
+
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+
+	junitSkipped struct {
+		Message string `xml:"message,attr,omitempty"`
+	}
+)
+
+// WriteJUnit writes a team result as JUnit XML.
+func WriteJUnit(w io.Writer, result *core.TeamResult, opts Options) error {
+	doc := junitTestSuites{
+		Name: title(result),
+		Time: seconds(result.Duration),
+	}
+	for _, task := range result.Tasks {
+		suite := junitTestSuite{
+			Name: task.Name,
+			Time: seconds(task.Duration),
+		}
+		for _, st := range task.Subtasks {
+			tc := junitTestCase{
+				Name:      st.Name,
+				ClassName: result.Name + "." + task.Name,
+				Time:      seconds(st.Duration),
+			}
+			switch st.Status {
+			case core.StatusNoGo:
+				tc.Failure = &junitFailure{
+					Message: st.Message,
+					Type:    string(st.Status),
+					Text:    excerpt(st.Output, opts.OutputLines),
+				}
+				suite.Failures++
+			case core.StatusSkip, core.StatusPending:
+				tc.Skipped = &junitSkipped{Message: st.Message}
+				suite.Skipped++
+			case core.StatusWarn:
+				tc.SystemOut = fmt.Sprintf("%s: %s", st.Status, st.Message)
+				if st.Output != "" {
+					tc.SystemOut += "\n" + excerpt(st.Output, opts.OutputLines)
+				}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		suite.Tests = len(suite.Cases)
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Skipped += suite.Skipped
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// seconds formats a duration in seconds, as JUnit time attributes are.
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,93 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+// WriteMarkdown writes a team result as a GO/NO-GO release report: the
+// decision, the blockers, a table of tasks and, for each task, its
+// subtasks with excerpts of failed output.
+func WriteMarkdown(w io.Writer, result *core.TeamResult, opts Options) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# %s %s: %s\n\n", result.Status.Emoji(), title(result), result.Status)
+	fmt.Fprintf(bw, "**Decision: %s** (%s) in %s\n", decision(result.Status), Summarize(result), formatDuration(result.Duration))
+
+	if blockers := Blockers(result); len(blockers) > 0 {
+		fmt.Fprintf(bw, "\n## Blockers\n\n")
+		for _, b := range blockers {
+			fmt.Fprintf(bw, "- **%s / %s** (%s)", b.Task, b.Subtask, b.Agent)
+			if b.Message != "" {
+				fmt.Fprintf(bw, ": %s", b.Message)
+			}
+			fmt.Fprintln(bw)
+		}
+	}
+
+	fmt.Fprintf(bw, "\n## Tasks\n\n")
+	fmt.Fprintf(bw, "| Status | Task | Agent | Duration |\n")
+	fmt.Fprintf(bw, "|--------|------|-------|----------|\n")
+	for _, task := range result.Tasks {
+		fmt.Fprintf(bw, "| %s %s | %s | %s | %s |\n", task.Status.Emoji(), task.Status,
+			cell(task.Name), cell(task.Agent), formatDuration(task.Duration))
+	}
+
+	for _, task := range result.Tasks {
+		fmt.Fprintf(bw, "\n### %s %s\n\n", task.Status.Emoji(), task.Name)
+		fmt.Fprintf(bw, "| Status | Subtask | Duration | Message |\n")
+		fmt.Fprintf(bw, "|--------|---------|----------|---------|\n")
+		for _, st := range task.Subtasks {
+			fmt.Fprintf(bw, "| %s %s | %s | %s | %s |\n", st.Status.Emoji(), st.Status,
+				cell(st.Name), formatDuration(st.Duration), cell(st.Message))
+		}
+		for _, st := range task.Subtasks {
+			if !failed(st) || st.Output == "" {
+				continue
+			}
+			fmt.Fprintf(bw, "\n<details><summary>%s output</summary>\n\n", st.Name)
+			fence := codeFence(st.Output)
+			fmt.Fprintf(bw, "%s\n%s\n%s\n\n</details>\n", fence, excerpt(st.Output, opts.OutputLines), fence)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// decision returns the release decision for a team status.
+func decision(status core.Status) string {
+	switch status {
+	case core.StatusNoGo:
+		return "NO-GO"
+	case core.StatusWarn:
+		return "GO with warnings"
+	case core.StatusGo:
+		return "GO"
+	default:
+		return string(status)
+	}
+}
+
+// cell escapes text for a Markdown table cell.
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// codeFence returns a backtick fence longer than any run of backticks in s.
+func codeFence(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
@@ -0,0 +1,175 @@
+// Package report renders team results for release managers and CI.
+//
+// A result can be written as:
+//   - JSON with a stable, versioned schema
+//   - a Markdown GO/NO-GO release report
+//   - JUnit XML for CI test dashboards
+//   - a terminal table, optionally coloured
+//
+// Every format includes durations, the required subtasks that blocked the
+// team and excerpts of the output of failed subtasks.
+//
+//	result, _ := runner.Run(ctx, team, runner.Options{})
+//	err := report.Write(os.Stdout, result, report.FormatMarkdown, report.Options{})
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+// Format identifies an output format for team results.
+type Format string
+
+const (
+	// FormatJSON is a JSON document following SchemaVersion.
+	FormatJSON Format = "json"
+
+	// FormatMarkdown is a GO/NO-GO release report.
+	FormatMarkdown Format = "markdown"
+
+	// FormatJUnit is JUnit XML, with a test suite per task and a test case
+	// per subtask.
+	FormatJUnit Format = "junit"
+
+	// FormatTable is a table for terminals.
+	FormatTable Format = "table"
+)
+
+// DefaultOutputLines is the default number of lines kept in output
+// excerpts.
+const DefaultOutputLines = 20
+
+// Options configure a report.
+type Options struct {
+	// Color enables ANSI colours in the table format.
+	Color bool
+
+	// OutputLines is the number of lines kept from the end of a failed
+	// subtask's output. Defaults to DefaultOutputLines.
+	OutputLines int
+}
+
+// Write writes a team result to w in the given format.
+func Write(w io.Writer, result *core.TeamResult, format Format, opts Options) error {
+	switch format {
+	case FormatJSON:
+		return WriteJSON(w, result, opts)
+	case FormatMarkdown:
+		return WriteMarkdown(w, result, opts)
+	case FormatJUnit:
+		return WriteJUnit(w, result, opts)
+	case FormatTable, "":
+		return WriteTable(w, result, opts)
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+// Blocker is a required subtask that failed and blocks the team.
+type Blocker struct {
+	Task    string `json:"task"`
+	Agent   string `json:"agent"`
+	Subtask string `json:"subtask"`
+	Message string `json:"message,omitempty"`
+}
+
+// Blockers returns the NO-GO subtasks of a result, in task order.
+func Blockers(result *core.TeamResult) []Blocker {
+	var blockers []Blocker
+	for _, task := range result.Tasks {
+		for _, st := range task.Subtasks {
+			if st.Status.IsBlocking() {
+				blockers = append(blockers, Blocker{
+					Task:    task.Name,
+					Agent:   task.Agent,
+					Subtask: st.Name,
+					Message: st.Message,
+				})
+			}
+		}
+	}
+	return blockers
+}
+
+// Summary counts the tasks of a result by status.
+type Summary struct {
+	Go   int `json:"go"`
+	Warn int `json:"warn"`
+	NoGo int `json:"no_go"`
+	Skip int `json:"skip"`
+}
+
+// Summarize counts the tasks of a result by status.
+func Summarize(result *core.TeamResult) Summary {
+	var s Summary
+	for _, task := range result.Tasks {
+		switch task.Status {
+		case core.StatusGo:
+			s.Go++
+		case core.StatusWarn:
+			s.Warn++
+		case core.StatusNoGo:
+			s.NoGo++
+		case core.StatusSkip:
+			s.Skip++
+		}
+	}
+	return s
+}
+
+// String returns the non-zero counts, such as "3 GO, 1 NO-GO".
+func (s Summary) String() string {
+	var parts []string
+	for _, c := range []struct {
+		n      int
+		status core.Status
+	}{{s.Go, core.StatusGo}, {s.Warn, core.StatusWarn}, {s.NoGo, core.StatusNoGo}, {s.Skip, core.StatusSkip}} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.status))
+		}
+	}
+	if len(parts) == 0 {
+		return "no tasks"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// title returns the team name with its version, if any.
+func title(result *core.TeamResult) string {
+	if result.Version != "" {
+		return result.Name + " " + result.Version
+	}
+	return result.Name
+}
+
+// failed reports whether a subtask's output belongs in a report.
+func failed(st core.SubtaskResult) bool {
+	return st.Status == core.StatusNoGo || st.Status == core.StatusWarn
+}
+
+// excerpt returns the last n lines of output, or DefaultOutputLines when n
+// is not positive.
+func excerpt(output string, n int) string {
+	if n <= 0 {
+		n = DefaultOutputLines
+	}
+	output = strings.TrimRight(output, "\n")
+	lines := strings.Split(output, "\n")
+	if len(lines) <= n {
+		return output
+	}
+	return "...\n" + strings.Join(lines[len(lines)-n:], "\n")
+}
+
+// formatDuration rounds a duration for display.
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
@@ -0,0 +1,154 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+var sampleResult = &core.TeamResult{
+	Name:     "release",
+	Version:  "v1.2.0",
+	Status:   core.StatusNoGo,
+	Duration: 3200 * time.Millisecond,
+	Tasks: []core.TaskResult{
+		{
+			Name:     "qa",
+			Agent:    "qa-agent",
+			Status:   core.StatusNoGo,
+			Duration: 3 * time.Second,
+			Subtasks: []core.SubtaskResult{
+				{Name: "build", Status: core.StatusGo, Duration: time.Second},
+				{Name: "tests", Status: core.StatusNoGo, Message: "exit status 1", Output: "ok pkg/a\nFAIL pkg/b | x\nFAIL", Duration: 2 * time.Second},
+				{Name: "lint", Status: core.StatusWarn, Message: "exit status 3", Output: "unused variable"},
+			},
+		},
+		{
+			Name:   "publish",
+			Agent:  "release-agent",
+			Status: core.StatusSkip,
+			Subtasks: []core.SubtaskResult{
+				{Name: "upload", Status: core.StatusSkip, Message: "dependency qa did not pass"},
+			},
+		},
+	},
+}
+
+func TestBlockersAndSummary(t *testing.T) {
+	blockers := Blockers(sampleResult)
+	if len(blockers) != 1 || blockers[0].Task != "qa" || blockers[0].Subtask != "tests" {
+		t.Errorf("Expected qa/tests to block, got %+v", blockers)
+	}
+	if got := Summarize(sampleResult).String(); got != "1 NO-GO, 1 SKIP" {
+		t.Errorf("Unexpected summary %q", got)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sampleResult, FormatJSON, Options{}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	var report jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if report.SchemaVersion != SchemaVersion || report.Status != core.StatusNoGo || report.DurationMS != 3200 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if report.Summary.NoGo != 1 || len(report.Blockers) != 1 || len(report.Tasks) != 2 {
+		t.Errorf("Unexpected summary or blockers: %+v", report)
+	}
+	subtasks := report.Tasks[0].Subtasks
+	if subtasks[0].Output != "" || subtasks[1].Output == "" || subtasks[1].DurationMS != 2000 {
+		t.Errorf("Expected output only for failed subtasks: %+v", subtasks)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sampleResult, FormatMarkdown, Options{}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# 🔴 release v1.2.0: NO-GO",
+		"**Decision: NO-GO** (1 NO-GO, 1 SKIP) in 3.2s",
+		"- **qa / tests** (qa-agent): exit status 1",
+		"| 🔴 NO-GO | qa | qa-agent | 3s |",
+		"| 🟡 WARN | lint | 0s | exit status 3 |",
+		"<details><summary>tests output</summary>\n\n```\nok pkg/a\nFAIL pkg/b | x\nFAIL\n```",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected Markdown to contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sampleResult, FormatJUnit, Options{OutputLines: 1}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	var doc junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid XML: %v\n%s", err, buf.String())
+	}
+	if doc.Tests != 4 || doc.Failures != 1 || doc.Skipped != 1 || doc.Time != "3.200" {
+		t.Errorf("Unexpected totals: tests=%d failures=%d skipped=%d time=%s", doc.Tests, doc.Failures, doc.Skipped, doc.Time)
+	}
+	tests := doc.Suites[0].Cases[1]
+	if tests.ClassName != "release.qa" || tests.Failure == nil || tests.Failure.Message != "exit status 1" {
+		t.Fatalf("Expected tests to fail, got %+v", tests)
+	}
+	if tests.Failure.Text != "...\nFAIL" {
+		t.Errorf("Expected output excerpt, got %q", tests.Failure.Text)
+	}
+	if lint := doc.Suites[0].Cases[2]; lint.Failure != nil || !strings.HasPrefix(lint.SystemOut, "WARN: exit status 3") {
+		t.Errorf("Expected lint to pass with a warning, got %+v", lint)
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, sampleResult, FormatTable, Options{}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("Expected no colour:\n%s", out)
+	}
+	lines := strings.Split(out, "\n")
+	if !strings.HasPrefix(lines[0], "STATUS  TASK     SUBTASK  AGENT") {
+		t.Errorf("Unexpected header %q", lines[0])
+	}
+	for _, want := range []string{
+		"NO-GO   qa                qa-agent       3s",
+		"NO-GO            tests                   2s        exit status 1",
+		"Blocked by:\n  qa/tests: exit status 1",
+		"release v1.2.0: NO-GO (1 NO-GO, 1 SKIP) in 3.2s",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected table to contain %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := Write(&buf, sampleResult, FormatTable, Options{Color: true}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\x1b[31mNO-GO") {
+		t.Errorf("Expected NO-GO in red:\n%s", buf.String())
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, sampleResult, Format("html"), Options{}); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
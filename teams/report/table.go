@@ -0,0 +1,119 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+// ANSI escape sequences for the table format.
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiDim   = "\x1b[2m"
+)
+
+// statusColor returns the ANSI colour of a status.
+func statusColor(status core.Status) string {
+	switch status {
+	case core.StatusGo:
+		return "\x1b[32m"
+	case core.StatusNoGo:
+		return "\x1b[31m"
+	case core.StatusWarn:
+		return "\x1b[33m"
+	default:
+		return "\x1b[90m"
+	}
+}
+
+// WriteTable writes a team result as a table with a row per task and per
+// subtask, followed by the output of failed subtasks and the overall
+// status. With opts.Color, statuses are coloured.
+func WriteTable(w io.Writer, result *core.TeamResult, opts Options) error {
+	bw := bufio.NewWriter(w)
+	paint := func(code, s string) string {
+		if !opts.Color {
+			return s
+		}
+		return code + s + ansiReset
+	}
+
+	rows := [][]string{{"STATUS", "TASK", "SUBTASK", "AGENT", "DURATION", "MESSAGE"}}
+	statuses := []core.Status{""}
+	for _, task := range result.Tasks {
+		rows = append(rows, []string{string(task.Status), task.Name, "", task.Agent, formatDuration(task.Duration), ""})
+		statuses = append(statuses, task.Status)
+		for _, st := range task.Subtasks {
+			rows = append(rows, []string{string(st.Status), "", st.Name, "", formatDuration(st.Duration), firstLine(st.Message)})
+			statuses = append(statuses, st.Status)
+		}
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, c := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(c))
+		}
+	}
+	for r, row := range rows {
+		var line strings.Builder
+		for i, c := range row {
+			if i == len(row)-1 {
+				line.WriteString(c)
+				break
+			}
+			// Pad before colouring so escape sequences do not count
+			// towards the width.
+			padded := c + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c)+2)
+			switch {
+			case r == 0:
+				padded = paint(ansiBold, padded)
+			case i == 0:
+				padded = paint(statusColor(statuses[r]), padded)
+			}
+			line.WriteString(padded)
+		}
+		fmt.Fprintln(bw, strings.TrimRight(line.String(), " "))
+	}
+
+	for _, task := range result.Tasks {
+		for _, st := range task.Subtasks {
+			if !failed(st) || st.Output == "" {
+				continue
+			}
+			fmt.Fprintf(bw, "\n%s %s\n", paint(statusColor(st.Status), string(st.Status)), task.Name+"/"+st.Name)
+			for _, line := range strings.Split(excerpt(st.Output, opts.OutputLines), "\n") {
+				fmt.Fprintf(bw, "  %s\n", paint(ansiDim, line))
+			}
+		}
+	}
+
+	if blockers := Blockers(result); len(blockers) > 0 {
+		fmt.Fprintf(bw, "\nBlocked by:\n")
+		for _, b := range blockers {
+			fmt.Fprintf(bw, "  %s/%s", b.Task, b.Subtask)
+			if b.Message != "" {
+				fmt.Fprintf(bw, ": %s", firstLine(b.Message))
+			}
+			fmt.Fprintln(bw)
+		}
+	}
+
+	fmt.Fprintf(bw, "\n%s: %s (%s) in %s\n", title(result),
+		paint(ansiBold+statusColor(result.Status), string(result.Status)),
+		Summarize(result), formatDuration(result.Duration))
+	return bw.Flush()
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,219 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	goruntime "runtime"
+	"strings"
+	"time"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+// maxMatches is the number of pattern matches listed in a subtask's output.
+const maxMatches = 20
+
+// runCommand runs a subtask's command and returns its combined output and
+// a failure message, which is empty when the command passes.
+func runCommand(ctx context.Context, st core.Subtask, dir string, env []string, timeout time.Duration) (string, string) {
+	cmd := shellCommand(ctx, st.Command)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	// Do not wait for background processes holding the output pipes open
+	// once the command is killed.
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	output := out.String()
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return output, fmt.Sprintf("timed out after %s", timeout)
+	case ctx.Err() != nil:
+		return output, "canceled"
+	case errors.As(err, &exitErr):
+		return output, fmt.Sprintf("exit status %d", exitErr.ExitCode())
+	case err != nil:
+		return output, err.Error()
+	}
+
+	if st.Pattern != "" {
+		re, err := regexp.Compile(st.Pattern)
+		if err != nil {
+			return output, fmt.Sprintf("invalid pattern: %v", err)
+		}
+		if loc := re.FindStringIndex(output); loc != nil {
+			return output, fmt.Sprintf("output matches %q: %s", st.Pattern, lineAt(output, loc[0]))
+		}
+	}
+	return output, ""
+}
+
+// shellCommand returns a command that runs a command line through the
+// system shell.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if goruntime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// lineAt returns the trimmed line of s containing offset i.
+func lineAt(s string, i int) string {
+	start := strings.LastIndexByte(s[:i], '\n') + 1
+	end := strings.IndexByte(s[i:], '\n')
+	if end < 0 {
+		return strings.TrimSpace(s[start:])
+	}
+	return strings.TrimSpace(s[start : i+end])
+}
+
+// searchFiles searches the files selected by a subtask's Files glob for its
+// Pattern. It returns the matches as file:line: text and a failure message,
+// which is empty when nothing matches. Without Files every file is
+// searched. Hidden directories, such as .git, and binary files are skipped.
+func searchFiles(ctx context.Context, st core.Subtask, dir string) ([]string, string) {
+	re, err := regexp.Compile(st.Pattern)
+	if err != nil {
+		return nil, fmt.Sprintf("invalid pattern: %v", err)
+	}
+	glob := filepath.ToSlash(st.Files)
+	if glob == "" {
+		glob = "**"
+	}
+
+	var matches []string
+	count := 0
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel != "." && strings.HasPrefix(d.Name(), ".") && !strings.HasPrefix(glob, d.Name()+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !matchGlob(glob, rel) {
+			return nil
+		}
+		n, lines, err := searchFile(p, rel, re)
+		if err != nil {
+			return err
+		}
+		count += n
+		for _, line := range lines {
+			if len(matches) < maxMatches {
+				matches = append(matches, line)
+			}
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return matches, "timed out searching files"
+	case err != nil:
+		return matches, err.Error()
+	case count > 0:
+		if count > len(matches) {
+			matches = append(matches, fmt.Sprintf("... and %d more", count-len(matches)))
+		}
+		return matches, fmt.Sprintf("%d match(es) for %q", count, st.Pattern)
+	}
+	return nil, ""
+}
+
+// searchFile returns the number of lines of a file matching re and the
+// first maxMatches of them. Binary files are not searched.
+func searchFile(p, rel string, re *regexp.Regexp) (int, []string, error) {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return 0, nil, err
+	}
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return 0, nil, nil
+	}
+
+	count := 0
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for n := 1; scanner.Scan(); n++ {
+		if !re.Match(scanner.Bytes()) {
+			continue
+		}
+		count++
+		if len(lines) < maxMatches {
+			lines = append(lines, fmt.Sprintf("%s:%d: %s", rel, n, strings.TrimSpace(scanner.Text())))
+		}
+	}
+	return count, lines, scanner.Err()
+}
+
+// matchGlob reports whether a slash-separated path matches a glob. Glob
+// segments are matched with path.Match, and a "**" segment matches any
+// number of directories.
+func matchGlob(glob, name string) bool {
+	return matchSegments(strings.Split(glob, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(glob, name []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			glob = glob[1:]
+			if len(glob) == 0 {
+				return true
+			}
+			for i := range name {
+				if matchSegments(glob, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(glob[0], name[0]); !ok {
+			return false
+		}
+		glob, name = glob[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// checkFile returns a failure message if a subtask's File does not exist.
+func checkFile(file, dir string) string {
+	p := file
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(dir, p)
+	}
+	if _, err := os.Stat(p); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Sprintf("%s does not exist", file)
+		}
+		return err.Error()
+	}
+	return ""
+}
@@ -0,0 +1,412 @@
+// Package runner executes team definitions locally and reports GO/NO-GO
+// status without an LLM in the loop.
+//
+// Run walks the team's parallel groups in order. The tasks of a group run
+// concurrently, up to a worker limit, and each task runs its subtasks in
+// order. A subtask passes when every check it defines passes:
+//   - Command runs through the system shell and must exit with status 0;
+//     with Pattern, its output must also not match the pattern
+//   - Pattern without Command must not match any line of the files
+//     selected by the Files glob
+//   - File must exist
+//
+// A failing subtask is NO-GO when it is required and WARN otherwise.
+// Commands are bounded by the subtask's Timeout. Tasks whose dependencies
+// are NO-GO, or were skipped for that reason, are skipped.
+//
+// Tasks also follow their failure policies:
+//   - a task whose When condition does not hold is skipped
+//   - a task with a Retry policy is rerun, after its backoff, while a
+//     required subtask fails; failed optional subtasks alone (WARN) are
+//     not retried
+//   - a task with ContinueOnError reports failed required subtasks as
+//     WARN, so its dependents still run
+//   - a task named by another task's OnFailure runs only if a required
+//     subtask of that task failed
+//
+// Run returns once every task has run or been skipped:
+//
+//	result, err := runner.Run(ctx, team, runner.Options{Workers: 4})
+//	if err != nil {
+//	    return err
+//	}
+//	if result.Status.IsBlocking() {
+//	    os.Exit(1)
+//	}
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	goruntime "runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+// DefaultTimeout is the timeout for subtasks that do not set one.
+const DefaultTimeout = 10 * time.Minute
+
+// DefaultMaxOutput is the default number of bytes of output kept per
+// subtask.
+const DefaultMaxOutput = 64 * 1024
+
+// Options configure a run.
+type Options struct {
+	// Dir is the directory commands run in and relative files and globs
+	// are resolved against. Defaults to the current directory.
+	Dir string
+
+	// Workers is the maximum number of tasks that run at the same time.
+	// Defaults to the number of CPUs.
+	Workers int
+
+	// Timeout is the timeout for subtasks that do not set one. Defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+
+	// Env holds extra environment variables ("KEY=value") for commands, in
+	// addition to the current environment.
+	Env []string
+
+	// Version is the target version, overriding the team's. Commands see
+	// it as ASSISTANTKIT_VERSION.
+	Version string
+
+	// Inputs are the values that task conditions check (e.g.,
+	// {"deps_changed": "true"}).
+	Inputs map[string]string
+
+	// MaxOutput is the number of bytes kept from the end of each
+	// subtask's output. Defaults to DefaultMaxOutput.
+	MaxOutput int
+
+	// Progress, if set, is called with each task's result as soon as the
+	// task finishes. Calls are not concurrent.
+	Progress func(core.TaskResult)
+}
+
+// Run executes the team and returns the status of every task, in the order
+// the tasks are defined. It fails only if the team is invalid; failed
+// checks are reported in the result.
+func Run(ctx context.Context, team *core.Team, opts Options) (*core.TeamResult, error) {
+	if err := team.Validate(); err != nil {
+		return nil, err
+	}
+	groups, err := team.ParallelGroups()
+	if err != nil {
+		return nil, err
+	}
+	if opts.Dir == "" {
+		opts.Dir = "."
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = goruntime.NumCPU()
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.MaxOutput <= 0 {
+		opts.MaxOutput = DefaultMaxOutput
+	}
+	if opts.Version == "" {
+		opts.Version = team.Version
+	}
+
+	r := &run{
+		team:    team,
+		opts:    opts,
+		results: make(map[string]core.TaskResult),
+		blocked: make(map[string]bool),
+		failed:  make(map[string]bool),
+	}
+	start := time.Now()
+	for _, group := range groups {
+		r.runGroup(ctx, group)
+	}
+
+	result := &core.TeamResult{
+		Name:     team.Name,
+		Version:  opts.Version,
+		Duration: time.Since(start),
+	}
+	for _, task := range team.Tasks {
+		result.Tasks = append(result.Tasks, r.results[task.Name])
+	}
+	result.Status = core.ComputeTeamStatus(result.Tasks)
+	return result, nil
+}
+
+// run holds the state of one execution.
+type run struct {
+	team *core.Team
+	opts Options
+
+	mu      sync.Mutex
+	results map[string]core.TaskResult
+
+	// blocked holds the tasks skipped because a dependency did not pass.
+	blocked map[string]bool
+
+	// failed holds the tasks with a failed required subtask, including
+	// those that continue on error.
+	failed map[string]bool
+}
+
+// runGroup runs the tasks of one parallel group, at most opts.Workers at a
+// time.
+func (r *run) runGroup(ctx context.Context, group []core.Task) {
+	sem := make(chan struct{}, r.opts.Workers)
+	var wg sync.WaitGroup
+	for _, task := range group {
+		if dep := r.failedDependency(task); dep != "" {
+			r.finish(skipTask(task, fmt.Sprintf("dependency %s did not pass", dep)), true)
+			continue
+		}
+		if sources := r.team.FallbackFor(task.Name); len(sources) > 0 && !r.anyFailed(sources) {
+			r.finish(skipTask(task, fmt.Sprintf("fallback for %s, which did not fail", strings.Join(sources, ", "))), false)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if reason := r.unmetCondition(ctx, task); reason != "" {
+				r.finish(skipTask(task, reason), false)
+				return
+			}
+			r.finish(r.runTask(ctx, task), false)
+		}()
+	}
+	wg.Wait()
+}
+
+// failedDependency returns the first dependency of task that is NO-GO or
+// was itself skipped because of a failed dependency.
+func (r *run) failedDependency(task core.Task) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, dep := range task.DependsOn {
+		if r.results[dep].Status.IsBlocking() || r.blocked[dep] {
+			return dep
+		}
+	}
+	return ""
+}
+
+// anyFailed reports whether any of the named tasks had a failed required
+// subtask.
+func (r *run) anyFailed(names []string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range names {
+		if r.failed[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// unmetCondition returns why the condition of task does not hold, or ""
+// if it holds or the task has none.
+func (r *run) unmetCondition(ctx context.Context, task core.Task) string {
+	c := task.When
+	if c == nil {
+		return ""
+	}
+	if c.Task != "" {
+		r.mu.Lock()
+		status := r.results[c.Task].Status
+		r.mu.Unlock()
+		if !slices.Contains(c.Statuses(), status) {
+			return fmt.Sprintf("condition not met: %s is %s", c.Task, status)
+		}
+	}
+	if c.Input != "" {
+		value := r.opts.Inputs[c.Input]
+		if value == "" || c.Equals != "" && value != c.Equals {
+			return fmt.Sprintf("condition not met: input %s is %q", c.Input, value)
+		}
+	}
+	if c.Command != "" {
+		ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+		defer cancel()
+		cmd := shellCommand(ctx, c.Command)
+		cmd.Dir = r.opts.Dir
+		cmd.Env = append(os.Environ(), r.env(task)...)
+		if err := cmd.Run(); err != nil {
+			return fmt.Sprintf("condition not met: %s: %v", c.Command, err)
+		}
+	}
+	return ""
+}
+
+// finish records a task result and reports progress.
+func (r *run) finish(result core.TaskResult, blocked bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results[result.Name] = result
+	r.blocked[result.Name] = blocked
+	if r.opts.Progress != nil {
+		r.opts.Progress(result)
+	}
+}
+
+// runTask runs a task, retrying it as its Retry policy allows, and applies
+// its ContinueOnError policy.
+func (r *run) runTask(ctx context.Context, task core.Task) core.TaskResult {
+	start := time.Now()
+	result := r.runAttempt(ctx, task)
+	attempts := 1
+	if task.Retry != nil {
+		for retry := 1; retry <= task.Retry.Count && result.Status == core.StatusNoGo; retry++ {
+			if !sleep(ctx, task.Retry.Delay(retry)) {
+				break
+			}
+			result = r.runAttempt(ctx, task)
+			attempts++
+		}
+	}
+	result.Attempts = attempts
+	result.Duration = time.Since(start)
+
+	if result.Status.IsBlocking() {
+		r.mu.Lock()
+		r.failed[task.Name] = true
+		r.mu.Unlock()
+		if task.ContinueOnError {
+			for i, st := range result.Subtasks {
+				if st.Status.IsBlocking() {
+					result.Subtasks[i].Status = core.StatusWarn
+					result.Subtasks[i].Message = strings.TrimPrefix(st.Message+"; continued on error", "; ")
+				}
+			}
+			result.Status = core.ComputeTaskStatus(result.Subtasks)
+		}
+	}
+	return result
+}
+
+// sleep waits for d, returning false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// runAttempt runs the subtasks of a task in order.
+func (r *run) runAttempt(ctx context.Context, task core.Task) core.TaskResult {
+	result := core.TaskResult{Name: task.Name, Agent: task.Agent}
+	env := r.env(task)
+	for _, st := range task.Subtasks {
+		if ctx.Err() != nil {
+			result.Subtasks = append(result.Subtasks, core.SubtaskResult{
+				Name:    st.Name,
+				Status:  core.StatusSkip,
+				Message: "canceled",
+			})
+			continue
+		}
+		result.Subtasks = append(result.Subtasks, r.runSubtask(ctx, st, env))
+	}
+	result.Status = core.ComputeTaskStatus(result.Subtasks)
+	return result
+}
+
+// env returns the environment variables that a task's commands see in
+// addition to the current environment.
+func (r *run) env(task core.Task) []string {
+	return append([]string{
+		"ASSISTANTKIT_TEAM=" + r.team.Name,
+		"ASSISTANTKIT_TASK=" + task.Name,
+		"ASSISTANTKIT_VERSION=" + r.opts.Version,
+	}, r.opts.Env...)
+}
+
+// runSubtask runs every check of a subtask.
+func (r *run) runSubtask(ctx context.Context, st core.Subtask, env []string) core.SubtaskResult {
+	result := core.SubtaskResult{Name: st.Name}
+	if st.Command == "" && st.Pattern == "" && st.File == "" {
+		result.Status = core.StatusSkip
+		result.Message = "no command, pattern or file to check"
+		return result
+	}
+
+	timeout := r.opts.Timeout
+	if st.Timeout > 0 {
+		timeout = time.Duration(st.Timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	var failures []string
+	var output []string
+	if st.Command != "" {
+		out, failure := runCommand(ctx, st, r.opts.Dir, env, timeout)
+		output = append(output, out)
+		if failure != "" {
+			failures = append(failures, failure)
+		}
+	} else if st.Pattern != "" {
+		matches, failure := searchFiles(ctx, st, r.opts.Dir)
+		output = append(output, matches...)
+		if failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+	if st.File != "" {
+		if failure := checkFile(st.File, r.opts.Dir); failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+	result.Duration = time.Since(start)
+	result.Output = tail(strings.TrimSpace(strings.Join(output, "\n")), r.opts.MaxOutput)
+
+	switch {
+	case len(failures) == 0:
+		result.Status = core.StatusGo
+	case st.Required:
+		result.Status = core.StatusNoGo
+	default:
+		result.Status = core.StatusWarn
+	}
+	result.Message = strings.Join(failures, "; ")
+	return result
+}
+
+// skipTask returns a result that skips every subtask of task.
+func skipTask(task core.Task, reason string) core.TaskResult {
+	result := core.TaskResult{Name: task.Name, Agent: task.Agent, Status: core.StatusSkip}
+	for _, st := range task.Subtasks {
+		result.Subtasks = append(result.Subtasks, core.SubtaskResult{
+			Name:    st.Name,
+			Status:  core.StatusSkip,
+			Message: reason,
+		})
+	}
+	return result
+}
+
+// tail returns the last max bytes of s, starting at a line boundary when
+// there is one.
+func tail(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	s = s[len(s)-max:]
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[i+1:]
+	}
+	return "...\n" + s
+}
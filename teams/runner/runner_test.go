@@ -0,0 +1,389 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/assistantkit/teams/core"
+)
+
+func skipOnWindows(t *testing.T) {
+	t.Helper()
+	if goruntime.GOOS == "windows" {
+		t.Skip("subtask commands use sh")
+	}
+}
+
+func subtask(name string) core.Subtask {
+	return *core.NewSubtask(name)
+}
+
+func TestRunStatuses(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "CHANGELOG.md"), []byte("# Changelog\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	team := core.NewTeam("release", core.ProcessParallel)
+	qa := core.NewTask("qa", "qa-agent")
+	qa.AddSubtasks(
+		*core.NewSubtask("build").WithCommand("echo building"),
+		*core.NewSubtask("lint").WithCommand("echo lint failed; exit 3").Optional(),
+		*core.NewSubtask("changelog").WithFile("CHANGELOG.md"),
+	)
+	docs := core.NewTask("docs", "docs-agent")
+	docs.AddSubtasks(
+		*core.NewSubtask("readme").WithFile("README.md"),
+	)
+	team.AddTask(*qa).AddTask(*docs)
+
+	result, err := Run(context.Background(), team, Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.Status != core.StatusNoGo {
+		t.Errorf("Expected team NO-GO, got %s", result.Status)
+	}
+	if len(result.Tasks) != 2 || result.Tasks[0].Name != "qa" || result.Tasks[1].Name != "docs" {
+		t.Fatalf("Expected tasks in definition order, got %+v", result.Tasks)
+	}
+
+	qaResult := result.Tasks[0]
+	if qaResult.Status != core.StatusWarn {
+		t.Errorf("Expected qa WARN, got %s", qaResult.Status)
+	}
+	want := []core.Status{core.StatusGo, core.StatusWarn, core.StatusGo}
+	for i, st := range qaResult.Subtasks {
+		if st.Status != want[i] {
+			t.Errorf("Expected %s to be %s, got %s (%s)", st.Name, want[i], st.Status, st.Message)
+		}
+	}
+	if lint := qaResult.Subtasks[1]; lint.Message != "exit status 3" || lint.Output != "lint failed" {
+		t.Errorf("Expected exit status and output, got %q %q", lint.Message, lint.Output)
+	}
+
+	if docsResult := result.Tasks[1]; docsResult.Status != core.StatusNoGo || docsResult.Subtasks[0].Message != "README.md does not exist" {
+		t.Errorf("Expected missing README to be NO-GO, got %+v", docsResult)
+	}
+}
+
+func TestRunPattern(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.go":          "package main\n\nfunc main() {}\n",
+		"pkg/util.go":      "package pkg\n\n// TODO: remove\nfunc f() {}\n",
+		"pkg/util_test.go": "package pkg\n// TODO: test\n",
+		".git/TODO":        "TODO\n",
+		"docs/notes.md":    "TODO\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		subtask core.Subtask
+		status  core.Status
+		output  string
+	}{
+		{"glob match", *core.NewSubtask("todo").WithPattern("TODO").WithFiles("**/*.go"), core.StatusNoGo, "pkg/util.go:3: // TODO: remove\npkg/util_test.go:2: // TODO: test"},
+		{"no match", *core.NewSubtask("todo").WithPattern("FIXME").WithFiles("**/*.go"), core.StatusGo, ""},
+		{"top level only", *core.NewSubtask("todo").WithPattern("TODO").WithFiles("*.go"), core.StatusGo, ""},
+		{"all files", *core.NewSubtask("todo").WithPattern("^TODO$"), core.StatusNoGo, "docs/notes.md:1: TODO"},
+		{"command output", *core.NewSubtask("vet").WithCommand("echo ok; echo 'WARNING: deprecated'").WithPattern("WARNING"), core.StatusNoGo, "ok\nWARNING: deprecated"},
+		{"invalid pattern", *core.NewSubtask("bad").WithPattern("(").Optional(), core.StatusWarn, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team := core.NewTeam("checks", core.ProcessSequential)
+			team.AddTask(*core.NewTask("scan", "qa").AddSubtask(tt.subtask))
+
+			result, err := Run(context.Background(), team, Options{Dir: dir})
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			got := result.Tasks[0].Subtasks[0]
+			if got.Status != tt.status {
+				t.Errorf("Expected %s, got %s (%s)", tt.status, got.Status, got.Message)
+			}
+			if got.Output != tt.output {
+				t.Errorf("Expected output %q, got %q", tt.output, got.Output)
+			}
+		})
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	skipOnWindows(t)
+	st := subtask("slow")
+	st.Command = "sleep 5"
+	st.Timeout = 1
+
+	team := core.NewTeam("slow", core.ProcessSequential)
+	team.AddTask(*core.NewTask("wait", "qa").AddSubtask(st))
+
+	start := time.Now()
+	result, err := Run(context.Background(), team, Options{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("Expected the command to be killed after 1s, took %s", elapsed)
+	}
+	got := result.Tasks[0].Subtasks[0]
+	if got.Status != core.StatusNoGo || got.Message != "timed out after 1s" {
+		t.Errorf("Expected timeout NO-GO, got %s %q", got.Status, got.Message)
+	}
+}
+
+func TestRunSkipsDependentsOfFailedTasks(t *testing.T) {
+	skipOnWindows(t)
+	team := core.NewTeam("release", core.ProcessParallel)
+	team.AddTask(*core.NewTask("build", "qa").AddSubtask(*core.NewSubtask("compile").WithCommand("exit 1")))
+	team.AddTask(*core.NewTask("lint", "qa").AddSubtask(*core.NewSubtask("vet").WithCommand("exit 1").Optional()))
+	team.AddTask(*core.NewTask("package", "release").AddDependency("build").AddSubtask(*core.NewSubtask("tar").WithCommand("echo tar")))
+	team.AddTask(*core.NewTask("publish", "release").AddDependency("package").AddSubtask(*core.NewSubtask("upload").WithCommand("echo upload")))
+	team.AddTask(*core.NewTask("docs", "docs").AddDependency("lint").AddSubtask(*core.NewSubtask("site").WithCommand("echo site")))
+
+	result, err := Run(context.Background(), team, Options{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	want := map[string]core.Status{
+		"build":   core.StatusNoGo,
+		"lint":    core.StatusWarn,
+		"package": core.StatusSkip,
+		"publish": core.StatusSkip,
+		"docs":    core.StatusGo,
+	}
+	for _, task := range result.Tasks {
+		if task.Status != want[task.Name] {
+			t.Errorf("Expected %s to be %s, got %s", task.Name, want[task.Name], task.Status)
+		}
+	}
+	if msg := result.Tasks[3].Subtasks[0].Message; msg != "dependency package did not pass" {
+		t.Errorf("Expected skip reason, got %q", msg)
+	}
+}
+
+func TestRunWorkerLimit(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+	team := core.NewTeam("parallel", core.ProcessParallel)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		// Each task records how many tasks were running when it started.
+		cmd := "n=$(ls running | wc -l); touch running/" + name + "; echo $n > seen-" + name + "; sleep 0.3; rm running/" + name
+		team.AddTask(*core.NewTask(name, "qa").AddSubtask(*core.NewSubtask("run").WithCommand(cmd)))
+	}
+	if err := os.Mkdir(filepath.Join(dir, "running"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var progress []string
+	opts := Options{
+		Dir:     dir,
+		Workers: 2,
+		Progress: func(result core.TaskResult) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress = append(progress, result.Name)
+		},
+	}
+	result, err := Run(context.Background(), team, opts)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Status != core.StatusGo {
+		t.Fatalf("Expected GO, got %s: %+v", result.Status, result.Tasks)
+	}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		data, _ := os.ReadFile(filepath.Join(dir, "seen-"+name))
+		if n := strings.TrimSpace(string(data)); n != "0" && n != "1" {
+			t.Errorf("Expected at most 2 tasks at a time, task %s saw %s running", name, n)
+		}
+	}
+	if len(progress) != 4 {
+		t.Errorf("Expected progress for 4 tasks, got %v", progress)
+	}
+}
+
+func TestRunEnvironment(t *testing.T) {
+	skipOnWindows(t)
+	team := core.NewTeam("release", core.ProcessSequential)
+	team.Version = "v1.0.0"
+	team.AddTask(*core.NewTask("tag", "release").AddSubtask(*core.NewSubtask("env").WithCommand(`echo "$ASSISTANTKIT_TEAM $ASSISTANTKIT_TASK $ASSISTANTKIT_VERSION $EXTRA"`)))
+
+	result, err := Run(context.Background(), team, Options{Dir: t.TempDir(), Version: "v1.1.0", Env: []string{"EXTRA=yes"}})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if out := result.Tasks[0].Subtasks[0].Output; out != "release tag v1.1.0 yes" {
+		t.Errorf("Expected team, task, version and extra env, got %q", out)
+	}
+	if result.Version != "v1.1.0" {
+		t.Errorf("Expected version v1.1.0, got %q", result.Version)
+	}
+}
+
+func TestRunInvalidTeam(t *testing.T) {
+	team := core.NewTeam("cycle", core.ProcessSequential)
+	team.AddTask(*core.NewTask("a", "qa").AddDependency("b"))
+	team.AddTask(*core.NewTask("b", "qa").AddDependency("a"))
+
+	if _, err := Run(context.Background(), team, Options{}); err == nil {
+		t.Error("Expected error for circular dependencies")
+	}
+}
+
+func TestRunSkipsEmptySubtasks(t *testing.T) {
+	team := core.NewTeam("manual", core.ProcessSequential)
+	team.AddTask(*core.NewTask("review", "qa").AddSubtask(subtask("read-diff")))
+
+	result, err := Run(context.Background(), team, Options{})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Status != core.StatusSkip {
+		t.Errorf("Expected SKIP, got %s", result.Status)
+	}
+}
+
+func TestRunRetries(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+	team := core.NewTeam("release", core.ProcessSequential)
+	// The check fails until its third run.
+	team.AddTask(*core.NewTask("integration", "qa").WithRetry(3, 0).
+		AddSubtask(*core.NewSubtask("e2e").WithCommand("echo run >> runs; test $(wc -l < runs) -ge 3")))
+	team.AddTask(*core.NewTask("flaky", "qa").WithRetry(1, 0).
+		AddSubtask(*core.NewSubtask("e2e").WithCommand("exit 1")))
+	// Only an optional subtask fails, so the task is WARN and not retried.
+	team.AddTask(*core.NewTask("lint", "qa").WithRetry(3, 30).
+		AddSubtask(*core.NewSubtask("style").WithCommand("exit 1").Optional()))
+
+	result, err := Run(context.Background(), team, Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := result.Tasks[0]; got.Status != core.StatusGo || got.Attempts != 3 {
+		t.Errorf("Expected GO after 3 attempts, got %s after %d", got.Status, got.Attempts)
+	}
+	if got := result.Tasks[1]; got.Status != core.StatusNoGo || got.Attempts != 2 {
+		t.Errorf("Expected NO-GO after 2 attempts, got %s after %d", got.Status, got.Attempts)
+	}
+	if got := result.Tasks[2]; got.Status != core.StatusWarn || got.Attempts != 1 {
+		t.Errorf("Expected WARN after 1 attempt, got %s after %d", got.Status, got.Attempts)
+	}
+}
+
+func TestRunFailurePolicies(t *testing.T) {
+	skipOnWindows(t)
+	team := core.NewTeam("release", core.ProcessParallel)
+	integration := core.NewTask("integration", "qa").WithFallback("report-flake")
+	integration.ContinueOnError = true
+	team.AddTask(*integration.AddSubtask(*core.NewSubtask("e2e").WithCommand("exit 1")))
+	team.AddTask(*core.NewTask("report-flake", "qa").AddSubtask(*core.NewSubtask("issue").WithCommand("echo filed")))
+	team.AddTask(*core.NewTask("publish", "release").AddDependency("integration").WithFallback("rollback").
+		AddSubtask(*core.NewSubtask("upload").WithCommand("echo upload")))
+	team.AddTask(*core.NewTask("rollback", "release").AddSubtask(*core.NewSubtask("revert").WithCommand("echo revert")))
+
+	result, err := Run(context.Background(), team, Options{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	want := map[string]core.Status{
+		"integration":  core.StatusWarn,
+		"report-flake": core.StatusGo,
+		"publish":      core.StatusGo,
+		"rollback":     core.StatusSkip,
+	}
+	for _, task := range result.Tasks {
+		if task.Status != want[task.Name] {
+			t.Errorf("Expected %s to be %s, got %s", task.Name, want[task.Name], task.Status)
+		}
+	}
+	if msg := result.Tasks[0].Subtasks[0].Message; msg != "exit status 1; continued on error" {
+		t.Errorf("Expected continued on error message, got %q", msg)
+	}
+	if msg := result.Tasks[3].Subtasks[0].Message; msg != "fallback for publish, which did not fail" {
+		t.Errorf("Expected fallback skip reason, got %q", msg)
+	}
+	if result.Status != core.StatusWarn {
+		t.Errorf("Expected team WARN, got %s", result.Status)
+	}
+}
+
+func TestRunConditions(t *testing.T) {
+	skipOnWindows(t)
+	team := core.NewTeam("release", core.ProcessParallel)
+	team.AddTask(*core.NewTask("qa", "qa").AddSubtask(*core.NewSubtask("tests").WithCommand("exit 1")))
+	team.AddTask(*core.NewTask("security", "security").WithCondition(core.Condition{Input: "deps_changed", Equals: "true"}).
+		AddSubtask(*core.NewSubtask("vulns").WithCommand("echo scanned")))
+	team.AddTask(*core.NewTask("license", "security").WithCondition(core.Condition{Input: "license_changed"}).
+		AddSubtask(*core.NewSubtask("scan").WithCommand("echo scanned")))
+	team.AddTask(*core.NewTask("triage", "qa").WithCondition(core.Condition{Task: "qa", Status: []core.Status{core.StatusNoGo}}).
+		AddSubtask(*core.NewSubtask("notify").WithCommand("echo notified")))
+	team.AddTask(*core.NewTask("docs", "docs").WithCondition(core.Condition{Command: "test \"$ASSISTANTKIT_TASK\" = other"}).
+		AddSubtask(*core.NewSubtask("site").WithCommand("echo site")))
+
+	result, err := Run(context.Background(), team, Options{
+		Dir:    t.TempDir(),
+		Inputs: map[string]string{"deps_changed": "true"},
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	want := map[string]core.Status{
+		"qa":       core.StatusNoGo,
+		"security": core.StatusGo,
+		"license":  core.StatusSkip,
+		"triage":   core.StatusGo,
+		"docs":     core.StatusSkip,
+	}
+	for _, task := range result.Tasks {
+		if task.Status != want[task.Name] {
+			t.Errorf("Expected %s to be %s, got %s", task.Name, want[task.Name], task.Status)
+		}
+	}
+	if msg := result.Tasks[2].Subtasks[0].Message; msg != `condition not met: input license_changed is ""` {
+		t.Errorf("Expected condition skip reason, got %q", msg)
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		glob, name string
+		want       bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "pkg/util.go", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "a/b/c.go", true},
+		{"pkg/**", "pkg/a/b.txt", true},
+		{"pkg/**/*_test.go", "pkg/util_test.go", true},
+		{"pkg/**/*_test.go", "cmd/util_test.go", false},
+		{"**", "anything/at/all", true},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.glob, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.glob, tt.name, got, tt.want)
+		}
+	}
+}
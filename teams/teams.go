@@ -6,6 +6,8 @@
 //   - Tasks with agent assignments
 //   - Subtasks with Go/No-Go status tracking
 //   - Task dependencies for execution ordering
+//   - Failure policies: when conditions, retries with backoff,
+//     continue_on_error and on_failure fallback tasks
 //
 // Example usage:
 //
@@ -37,10 +39,29 @@
 //	        }
 //	    }
 //	}
+//
+// Team.GenerateMermaid and Team.GenerateDOT export the task graph, and
+// Team.CriticalPath finds the longest chain of tasks, using subtask
+// timeouts as duration estimates.
+//
+// Orchestration renderers turn a team into instructions for a platform's
+// assistant: a slash command for Claude Code, an AGENTS.md section and
+// prompts for Codex, a custom command for Gemini CLI, and a steering file
+// with an orchestrator agent for Kiro.
+//
+// The runner subpackage executes a team's subtasks locally and reports
+// GO/NO-GO status; the report subpackage renders the results as JSON,
+// Markdown, JUnit XML or a terminal table.
 package teams
 
 import (
 	"github.com/agentplexus/assistantkit/teams/core"
+
+	// Import renderers for side-effect registration
+	_ "github.com/agentplexus/assistantkit/teams/claude"
+	_ "github.com/agentplexus/assistantkit/teams/codex"
+	_ "github.com/agentplexus/assistantkit/teams/gemini"
+	_ "github.com/agentplexus/assistantkit/teams/kiro"
 )
 
 // Re-export core types for convenience.
@@ -52,6 +73,10 @@ type (
 	Status  = core.Status
 	Adapter = core.Adapter
 
+	// Failure policies
+	Condition   = core.Condition
+	RetryPolicy = core.RetryPolicy
+
 	// Result types
 	TeamResult    = core.TeamResult
 	TaskResult    = core.TaskResult
@@ -59,6 +84,12 @@ type (
 
 	// Orchestration
 	OrchestrationConfig = core.OrchestrationConfig
+	Renderer            = core.Renderer
+	Artifact            = core.Artifact
+
+	// Task graph
+	GraphConfig  = core.GraphConfig
+	CriticalPath = core.CriticalPath
 )
 
 // Re-export process constants.
@@ -95,6 +126,11 @@ var (
 	ParseYAML     = core.ParseYAML
 	ParseJSON     = core.ParseJSON
 
+	// Orchestration renderers
+	GetRenderer    = core.GetRenderer
+	RendererNames  = core.RendererNames
+	WriteArtifacts = core.WriteArtifacts
+
 	// Status computation
 	ComputeTaskStatus = core.ComputeTaskStatus
 	ComputeTeamStatus = core.ComputeTeamStatus
@@ -1,7 +1,13 @@
 package teams
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
 )
 
 func TestNewTeam(t *testing.T) {
@@ -74,6 +80,26 @@ func TestTeamValidation(t *testing.T) {
 	}
 }
 
+func TestTeamValidateAll(t *testing.T) {
+	team := NewTeam("", ProcessHierarchical)
+	team.AddTask(Task{Name: "task1", Agent: "agent1", DependsOn: []string{"missing"}})
+
+	errs := team.ValidateAll()
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 errors, got %d: %v", len(errs), errs)
+	}
+	fields := []string{"name", "manager", "tasks.task1.depends_on"}
+	for i, field := range fields {
+		if errs[i].Field != field {
+			t.Errorf("expected error %d on field '%s', got '%s'", i, field, errs[i].Field)
+		}
+	}
+
+	if err := team.Validate(); err == nil || err.Error() != errs[0].Error() {
+		t.Errorf("expected Validate to return first error, got %v", err)
+	}
+}
+
 func TestTopologicalSort(t *testing.T) {
 	team := NewTeam("test-team", ProcessParallel)
 	team.AddTask(Task{Name: "task-a", Agent: "agent1"})
@@ -179,3 +205,385 @@ func TestSubtaskTypes(t *testing.T) {
 		t.Errorf("expected type 'file', got '%s'", st.Type())
 	}
 }
+
+func graphTeam() *Team {
+	team := NewTeam("release-team", ProcessParallel)
+	team.AddTask(Task{Name: "qa", Agent: "qa-agent", Subtasks: []Subtask{
+		{Name: "build", Required: true, Timeout: 60},
+		{Name: "tests", Required: true, Timeout: 300},
+		{Name: "lint", Timeout: 120},
+	}})
+	team.AddTask(Task{Name: "docs", Agent: "docs-agent", Subtasks: []Subtask{
+		{Name: "readme", Required: true, Timeout: 30},
+	}})
+	team.AddTask(Task{Name: "security", Agent: "qa-agent", Subtasks: []Subtask{
+		{Name: "audit", Required: true, Timeout: 420},
+	}})
+	team.AddTask(Task{Name: "release", Agent: "release-agent", DependsOn: []string{"qa", "docs"}, Subtasks: []Subtask{
+		{Name: "tag", Required: true, Timeout: 10},
+	}})
+	return team
+}
+
+func TestCriticalPath(t *testing.T) {
+	path, err := graphTeam().CriticalPath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// qa (8m) + release (10s) is longer than security (7m) alone
+	if strings.Join(path.Tasks, ",") != "qa,release" {
+		t.Errorf("expected qa,release, got %v", path.Tasks)
+	}
+	if path.Duration != 8*time.Minute+10*time.Second {
+		t.Errorf("expected 8m10s, got %s", path.Duration)
+	}
+
+	// Without timeouts, the longest chain wins
+	team := NewTeam("chain", ProcessSequential)
+	team.AddTask(Task{Name: "a", Agent: "x"})
+	team.AddTask(Task{Name: "b", Agent: "x", DependsOn: []string{"a"}})
+	team.AddTask(Task{Name: "c", Agent: "x"})
+	path, err = team.CriticalPath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(path.Tasks, ",") != "a,b" || path.Duration != 0 {
+		t.Errorf("expected a,b with no duration, got %v %s", path.Tasks, path.Duration)
+	}
+
+	team.AddTask(Task{Name: "d", Agent: "x", DependsOn: []string{"e"}})
+	team.AddTask(Task{Name: "e", Agent: "x", DependsOn: []string{"d"}})
+	if _, err := team.CriticalPath(); err == nil {
+		t.Error("expected error for circular dependencies")
+	}
+}
+
+func TestCriticalPathPolicies(t *testing.T) {
+	timed := func(seconds int) Subtask {
+		return Subtask{Name: "check", Required: true, Timeout: seconds}
+	}
+	team := NewTeam("policies", ProcessParallel)
+	team.AddTask(*NewTask("a", "x").AddSubtask(timed(60)))
+	team.AddTask(*NewTask("b", "x").WithRetry(2, 5).AddSubtask(timed(20)))
+	team.AddTask(*NewTask("c", "x").WithCondition(Condition{Task: "a"}).AddSubtask(timed(30)))
+	team.AddTask(*NewTask("d", "x").AddDependency("b").AddSubtask(timed(10)))
+
+	// b runs up to 3 times, waiting 5s and 10s between attempts
+	if got := team.GetTask("b").MaxDuration(); got != 75*time.Second {
+		t.Errorf("expected b to take at most 75s, got %s", got)
+	}
+
+	// c runs after a through its condition: a,c (90s) beats b,d (85s)
+	path, err := team.CriticalPath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(path.Tasks, ",") != "a,c" || path.Duration != 90*time.Second {
+		t.Errorf("expected a,c in 1m30s, got %v in %s", path.Tasks, path.Duration)
+	}
+	if mermaid := team.GenerateMermaid(GraphConfig{HighlightCriticalPath: true}); !strings.Contains(mermaid, "task1 -.->|when| task3") ||
+		!strings.Contains(mermaid, "linkStyle 0 stroke:") {
+		t.Errorf("expected the when edge to be highlighted:\n%s", mermaid)
+	}
+
+	team.GetTask("c").When = nil
+	if path, _ := team.CriticalPath(); strings.Join(path.Tasks, ",") != "b,d" || path.Duration != 85*time.Second {
+		t.Errorf("expected b,d in 1m25s, got %v in %s", path.Tasks, path.Duration)
+	}
+}
+
+func TestGenerateMermaid(t *testing.T) {
+	out := graphTeam().GenerateMermaid(GraphConfig{HighlightCriticalPath: true})
+	for _, want := range []string{
+		"flowchart TD\n",
+		`task1["<b>qa</b><br/><i>qa-agent</i><br/>required: build, tests<br/>optional: lint"]`,
+		"task1 --> task4\n",
+		"task2 --> task4\n",
+		"class task1,task3 agent0\n",
+		"class task2 agent1\n",
+		"linkStyle 0 stroke:#dc2626,stroke-width:3px\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected Mermaid to contain %q:\n%s", want, out)
+		}
+	}
+	if out := graphTeam().GenerateMermaid(GraphConfig{}); strings.Contains(out, "linkStyle") {
+		t.Errorf("expected no highlighted edges:\n%s", out)
+	}
+}
+
+func TestGenerateDOT(t *testing.T) {
+	out := graphTeam().GenerateDOT(GraphConfig{HighlightCriticalPath: true})
+	for _, want := range []string{
+		`digraph "release-team" {`,
+		`task1 [label="qa\n(qa-agent)\nrequired: build, tests\noptional: lint", fillcolor="#dbeafe", color="#1d4ed8"];`,
+		`task1 -> task4 [color="#dc2626", penwidth=3];`,
+		"task2 -> task4;\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected DOT to contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestOrchestrationDiagram(t *testing.T) {
+	team := graphTeam()
+	if out := team.GenerateOrchestrationMD(OrchestrationConfig{}); strings.Contains(out, "```mermaid") {
+		t.Error("expected no diagram by default")
+	}
+	out := team.GenerateOrchestrationMD(OrchestrationConfig{IncludeDiagram: true})
+	if !strings.Contains(out, "## Task Graph\n\n```mermaid\nflowchart TD\n") {
+		t.Errorf("expected embedded Mermaid diagram:\n%s", out)
+	}
+}
+
+func TestRenderers(t *testing.T) {
+	team := graphTeam()
+	team.Version = "v1.2.0"
+	cfg := OrchestrationConfig{Version: team.Version, AgentSpecsPath: "specs/agents"}
+
+	if got := strings.Join(RendererNames(), ","); got != "claude,codex,gemini,kiro" {
+		t.Fatalf("expected claude, codex, gemini and kiro renderers, got %s", got)
+	}
+
+	tests := []struct {
+		renderer string
+		paths    []string
+		contains map[string]string
+	}{
+		{
+			renderer: "claude",
+			paths:    []string{"commands/release-team.md"},
+			contains: map[string]string{
+				"commands/release-team.md": "---\ndescription: Orchestrate the Release Team team\n---\n\n# Release Team Orchestration",
+			},
+		},
+		{
+			renderer: "codex",
+			paths:    []string{"AGENTS.md", "prompts/release-team.md", "prompts/release-team-qa.md", "prompts/release-team-docs.md", "prompts/release-team-security.md", "prompts/release-team-release.md"},
+			contains: map[string]string{
+				"AGENTS.md":                  "| 2 | release | release-agent | qa, docs | tag |",
+				"prompts/release-team.md":    "## Step 4: release\n\n**Requires:** qa, docs (must be GO)\n\nYou are the release-agent specialist. Read your instructions from specs/agents/release-agent.md",
+				"prompts/release-team-qa.md": "description: Run the qa task of the Release Team team",
+			},
+		},
+		{
+			renderer: "gemini",
+			paths:    []string{"commands/release-team.toml"},
+			contains: map[string]string{
+				"commands/release-team.toml": "### Task: qa (subagent `qa-agent`)",
+			},
+		},
+		{
+			renderer: "kiro",
+			paths:    []string{"steering/release-team-orchestration.md", "agents/release-team-orchestrator.json"},
+			contains: map[string]string{
+				"steering/release-team-orchestration.md": "1. Run `/agent swap qa-agent`",
+				"agents/release-team-orchestrator.json":  `"file://.kiro/steering/release-team-orchestration.md"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.renderer, func(t *testing.T) {
+			renderer, ok := GetRenderer(tt.renderer)
+			if !ok {
+				t.Fatalf("renderer %s not registered", tt.renderer)
+			}
+			artifacts, err := renderer.Render(team, cfg)
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			var paths []string
+			contents := make(map[string]string)
+			for _, a := range artifacts {
+				paths = append(paths, a.Path)
+				contents[a.Path] = string(a.Content)
+			}
+			if strings.Join(paths, ",") != strings.Join(tt.paths, ",") {
+				t.Errorf("expected artifacts %v, got %v", tt.paths, paths)
+			}
+			for path, want := range tt.contains {
+				if !strings.Contains(contents[path], want) {
+					t.Errorf("expected %s to contain %q:\n%s", path, want, contents[path])
+				}
+			}
+		})
+	}
+}
+
+func TestGeminiRendererTOML(t *testing.T) {
+	renderer, _ := GetRenderer("gemini")
+	artifacts, err := renderer.Render(graphTeam(), OrchestrationConfig{})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	var cmd struct {
+		Description string `toml:"description"`
+		Prompt      string `toml:"prompt"`
+	}
+	if err := toml.Unmarshal(artifacts[0].Content, &cmd); err != nil {
+		t.Fatalf("invalid TOML: %v\n%s", err, artifacts[0].Content)
+	}
+	if cmd.Description == "" || !strings.HasPrefix(cmd.Prompt, "# Release Team Orchestration") {
+		t.Errorf("unexpected command: %+v", cmd)
+	}
+}
+
+func TestWriteArtifactsSection(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "AGENTS.md")
+	if err := os.WriteFile(path, []byte("# Project\n\nBuild with make."), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(content string) string {
+		t.Helper()
+		err := WriteArtifacts(dir, []Artifact{
+			{Path: "AGENTS.md", Section: "team-release", Content: []byte(content)},
+			{Path: "prompts/release.md", Content: []byte("prompt\n")},
+		})
+		if err != nil {
+			t.Fatalf("WriteArtifacts failed: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	want := "# Project\n\nBuild with make.\n\n<!-- BEGIN assistantkit:team-release -->\nfirst\n<!-- END assistantkit:team-release -->\n"
+	if got := write("first\n"); got != want {
+		t.Errorf("expected section to be appended:\n%q\ngot:\n%q", want, got)
+	}
+	want = strings.Replace(want, "first", "second", 1)
+	if got := write("second"); got != want {
+		t.Errorf("expected section to be replaced:\n%q\ngot:\n%q", want, got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "prompts", "release.md")); err != nil {
+		t.Errorf("expected prompt to be written: %v", err)
+	}
+}
+
+// policyTeam returns a release team with a conditional security task, a
+// retried integration check and a rollback fallback.
+func policyTeam() *Team {
+	team := NewTeam("release-team", ProcessParallel)
+	team.AddTask(*NewTask("qa", "qa-agent").AddSubtask(*NewSubtask("tests").WithCommand("go test ./...")))
+	team.AddTask(*NewTask("security", "security-agent").
+		WithCondition(Condition{Command: "! git diff --quiet origin/main -- go.mod go.sum"}).
+		AddSubtask(*NewSubtask("vulns").WithCommand("govulncheck ./...")))
+	integration := NewTask("integration", "qa-agent").WithRetry(2, 10).AddDependency("qa")
+	integration.ContinueOnError = true
+	team.AddTask(*integration.AddSubtask(*NewSubtask("e2e").WithCommand("make e2e")))
+	team.AddTask(*NewTask("publish", "release-agent").AddDependency("integration").WithFallback("rollback").
+		AddSubtask(*NewSubtask("upload").WithCommand("make publish")))
+	team.AddTask(*NewTask("rollback", "release-agent").AddSubtask(*NewSubtask("revert").WithCommand("make rollback")))
+	team.AddTask(*NewTask("announce", "release-agent").WithCondition(Condition{Task: "security", Status: []Status{StatusGo}}).
+		AddSubtask(*NewSubtask("post").WithCommand("make announce")))
+	return team
+}
+
+func TestPolicyValidation(t *testing.T) {
+	if err := policyTeam().Validate(); err != nil {
+		t.Fatalf("expected valid team, got %v", err)
+	}
+
+	team := NewTeam("test-team", ProcessParallel)
+	team.AddTask(Task{Name: "a", Agent: "agent", When: &Condition{}})
+	team.AddTask(Task{Name: "b", Agent: "agent", When: &Condition{Task: "missing"}})
+	team.AddTask(Task{Name: "c", Agent: "agent", When: &Condition{Input: "x", Status: []Status{"DONE"}}})
+	team.AddTask(Task{Name: "d", Agent: "agent", Retry: &RetryPolicy{Count: -1}})
+	team.AddTask(Task{Name: "e", Agent: "agent", OnFailure: "e"})
+	team.AddTask(Task{Name: "f", Agent: "agent", OnFailure: "missing"})
+
+	want := []string{
+		"tasks.a.when: condition needs a task, input or command",
+		"tasks.b.when: unknown task: missing",
+		"tasks.c.when: status requires a task",
+		"tasks.c.when: invalid status: DONE",
+		"tasks.d.retry: retry count and backoff cannot be negative",
+		"tasks.e.on_failure: task cannot be its own fallback",
+		"tasks.f.on_failure: unknown task: missing",
+	}
+	errs := team.ValidateAll()
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(errs), errs)
+	}
+	for i, err := range errs {
+		if err.Error() != want[i] {
+			t.Errorf("expected error %q, got %q", want[i], err.Error())
+		}
+	}
+}
+
+func TestPolicyOrdering(t *testing.T) {
+	groups, err := policyTeam().ParallelGroups()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, group := range groups {
+		var names []string
+		for _, task := range group {
+			names = append(names, task.Name)
+		}
+		got = append(got, strings.Join(names, ","))
+	}
+	// rollback runs after publish, announce after security
+	want := "qa,security|integration,announce|publish|rollback"
+	if strings.Join(got, "|") != want {
+		t.Errorf("expected groups %s, got %s", want, strings.Join(got, "|"))
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := RetryPolicy{Count: 3, Backoff: 5}
+	for retry, want := range []time.Duration{0, 5 * time.Second, 10 * time.Second, 20 * time.Second} {
+		if got := p.Delay(retry); got != want {
+			t.Errorf("expected retry %d to wait %s, got %s", retry, want, got)
+		}
+	}
+}
+
+func TestPolicyOrchestration(t *testing.T) {
+	team := policyTeam()
+	out := team.GenerateOrchestrationMD(OrchestrationConfig{})
+	for _, want := range []string{
+		"### Task: security\n\n**Run only if:** `! git diff --quiet origin/main -- go.mod go.sum` succeeds; otherwise mark the task SKIP\n\n",
+		"**Retry:** if a required subtask fails, rerun the task up to 2 more times, waiting 10s before the first retry and twice as long before each further one\n\n",
+		"**Continue on error:** report failed required subtasks as WARN",
+		"**On failure:** if a required subtask fails, run rollback\n\n",
+		"**Fallback for:** publish; run only if a required subtask of one of them fails, otherwise mark the task SKIP\n\n",
+		"**Run only if:** security is GO; otherwise mark the task SKIP",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected orchestration to contain %q:\n%s", want, out)
+		}
+	}
+
+	for _, name := range RendererNames() {
+		renderer, _ := GetRenderer(name)
+		artifacts, err := renderer.Render(team, OrchestrationConfig{})
+		if err != nil {
+			t.Fatalf("%s: Render failed: %v", name, err)
+		}
+		var content string
+		for _, a := range artifacts {
+			content += string(a.Content)
+		}
+		if !strings.Contains(content, "**Fallback for:** publish") {
+			t.Errorf("%s: expected fallback note in artifacts", name)
+		}
+	}
+
+	mermaid := team.GenerateMermaid(GraphConfig{})
+	for _, want := range []string{"task4 -.->|on failure| task5", "task2 -.->|when| task6"} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("expected Mermaid to contain %q:\n%s", want, mermaid)
+		}
+	}
+	if dot := team.GenerateDOT(GraphConfig{}); !strings.Contains(dot, `task4 -> task5 [style=dashed, label="on failure"];`) {
+		t.Errorf("expected dashed DOT edge:\n%s", dot)
+	}
+}
@@ -0,0 +1,109 @@
+package vocab
+
+// claudeMCP is the MCP naming shared by Claude Code, Codex and Gemini CLI.
+var claudeMCP = MCPNaming{
+	Prefix:       MCPPrefix,
+	Separator:    MCPSeparator,
+	All:          MCPAll,
+	ServerSuffix: MCPSeparator + ".*",
+}
+
+// Claude is Claude Code's vocabulary, which the canonical names follow.
+var Claude = &Platform{
+	Name: "claude",
+	Tools: []Mapping{
+		{Read, []string{Read}},
+		{Write, []string{Write}},
+		{Edit, []string{Edit}},
+		{MultiEdit, []string{MultiEdit}},
+		{NotebookEdit, []string{NotebookEdit}},
+		{Glob, []string{Glob}},
+		{Grep, []string{Grep}},
+		{LS, []string{LS}},
+		{Bash, []string{Bash}},
+		{WebSearch, []string{WebSearch}},
+		{WebFetch, []string{WebFetch}},
+		{Task, []string{Task}},
+		{TodoWrite, []string{TodoWrite}},
+	},
+	MCP: claudeMCP,
+}
+
+// Codex is the OpenAI Codex CLI vocabulary. Codex edits files with a
+// single apply_patch tool and reads them through the shell.
+var Codex = &Platform{
+	Name: "codex",
+	Tools: []Mapping{
+		{Bash, []string{"Bash", "shell"}},
+		{Write, []string{"apply_patch"}},
+		{Edit, []string{"apply_patch"}},
+		{MultiEdit, []string{"apply_patch"}},
+		{WebSearch, []string{"web_search"}},
+		{TodoWrite, []string{"update_plan"}},
+	},
+	MCP: claudeMCP,
+}
+
+// Gemini is the Gemini CLI vocabulary.
+var Gemini = &Platform{
+	Name: "gemini",
+	Tools: []Mapping{
+		{Read, []string{"read_file", "read_many_files"}},
+		{Write, []string{"write_file"}},
+		{Edit, []string{"replace"}},
+		{Glob, []string{"glob"}},
+		{Grep, []string{"search_file_content", "grep"}},
+		{LS, []string{"list_directory"}},
+		{Bash, []string{"run_shell_command"}},
+		{WebSearch, []string{"google_web_search"}},
+		{WebFetch, []string{"web_fetch"}},
+		{TodoWrite, []string{"write_todos"}},
+	},
+	MCP: claudeMCP,
+}
+
+// Kiro-only tools, named in the canonical style.
+const (
+	KiroCode        = "Code"
+	KiroAWS         = "AWS"
+	KiroIntrospect  = "Introspect"
+	KiroReportIssue = "ReportIssue"
+	KiroKnowledge   = "Knowledge"
+	KiroThinking    = "Thinking"
+	KiroTodoList    = "TodoList"
+	KiroDelegate    = "Delegate"
+)
+
+// Kiro is the Kiro CLI vocabulary. Kiro also accepts the short names read,
+// write and shell, and names MCP tools @<server>/<tool>, with @<server>
+// selecting all of a server's tools.
+var Kiro = &Platform{
+	Name: "kiro",
+	Tools: []Mapping{
+		{Read, []string{"fs_read", "read"}},
+		{Write, []string{"fs_write", "write"}},
+		{Edit, []string{"fs_write"}},
+		{Bash, []string{"execute_bash", "shell"}},
+		{Grep, []string{"grep"}},
+		{Glob, []string{"glob"}},
+		{WebSearch, []string{"web_search"}},
+		{WebFetch, []string{"web_fetch"}},
+		{Task, []string{"use_subagent"}},
+		{KiroCode, []string{"code"}},
+		{KiroAWS, []string{"use_aws"}},
+		{KiroIntrospect, []string{"introspect"}},
+		{KiroReportIssue, []string{"report_issue"}},
+		{KiroKnowledge, []string{"knowledge"}},
+		{KiroThinking, []string{"thinking"}},
+		{KiroTodoList, []string{"todo_list"}},
+		{KiroDelegate, []string{"delegate"}},
+	},
+	MCP: MCPNaming{Prefix: "@", Separator: "/"},
+}
+
+func init() {
+	Register(Claude)
+	Register(Codex)
+	Register(Gemini)
+	Register(Kiro)
+}
@@ -0,0 +1,326 @@
+// Package vocab provides the canonical tool vocabulary and translates tool
+// names between AI assistants.
+//
+// Agents, hook matchers and tool allow-lists refer to the tools an
+// assistant can call, and every assistant names them differently: Claude
+// Code's Bash is Gemini CLI's run_shell_command and Kiro's execute_bash.
+// The canonical names are Claude Code's, as used by multi-agent-spec, and
+// MCP tools are canonically named mcp__<server>__<tool>.
+//
+// Each supported assistant has a Platform with a bidirectional mapping
+// table:
+//
+//	name, ok := vocab.Gemini.Native("Bash")                 // "run_shell_command", true
+//	name, ok = vocab.Kiro.Canonical("@github/create_issue") // "mcp__github__create_issue", true
+//	name, ok = vocab.Translate("fs_write", "kiro", "gemini") // "write_file", true
+//
+// Names that have no equivalent on a platform are passed through unchanged
+// and reported as unknown, so callers can warn about them.
+package vocab
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Canonical tool names.
+const (
+	Read         = "Read"
+	Write        = "Write"
+	Edit         = "Edit"
+	MultiEdit    = "MultiEdit"
+	NotebookEdit = "NotebookEdit"
+	Glob         = "Glob"
+	Grep         = "Grep"
+	LS           = "LS"
+	Bash         = "Bash"
+	WebSearch    = "WebSearch"
+	WebFetch     = "WebFetch"
+	Task         = "Task"
+	TodoWrite    = "TodoWrite"
+)
+
+// Canonical MCP tool naming: mcp__<server>__<tool>.
+const (
+	// MCPPrefix starts every canonical MCP tool name.
+	MCPPrefix = "mcp__"
+
+	// MCPSeparator separates the server and tool names.
+	MCPSeparator = "__"
+
+	// MCPAll is the canonical matcher that selects every MCP tool.
+	MCPAll = "mcp__.*"
+)
+
+// Mapping maps a canonical tool to a platform's names for it.
+type Mapping struct {
+	// Tool is the canonical tool name.
+	Tool string
+
+	// Names are the platform's names for the tool. The first is written;
+	// all are read.
+	Names []string
+}
+
+// MCPNaming describes how a platform names MCP tools.
+type MCPNaming struct {
+	// Prefix starts every MCP tool name (e.g., "mcp__" or "@").
+	Prefix string
+
+	// Separator separates the server and tool names (e.g., "__" or "/").
+	Separator string
+
+	// All is the hook matcher that selects every MCP tool, or "" if the
+	// platform has none.
+	All string
+
+	// ServerSuffix follows Prefix and a server name in a hook matcher that
+	// selects all of the server's tools (e.g., "__.*" for "mcp__github__.*").
+	ServerSuffix string
+}
+
+// Platform is an assistant's tool vocabulary.
+type Platform struct {
+	// Name is the platform name, matching the adapter names (e.g., "kiro").
+	Name string
+
+	// Tools maps canonical tools to the platform's names. When two
+	// canonical tools share a name, the first is read back.
+	Tools []Mapping
+
+	// MCP describes how the platform names MCP tools.
+	MCP MCPNaming
+
+	once      sync.Once
+	native    map[string]string
+	canonical map[string]string
+}
+
+// index builds the lookup tables on first use.
+func (p *Platform) index() {
+	p.once.Do(func() {
+		p.native = make(map[string]string, len(p.Tools))
+		p.canonical = make(map[string]string, len(p.Tools))
+		for _, m := range p.Tools {
+			if len(m.Names) == 0 {
+				continue
+			}
+			if _, ok := p.native[m.Tool]; !ok {
+				p.native[m.Tool] = m.Names[0]
+			}
+			for _, name := range m.Names {
+				if _, ok := p.canonical[name]; !ok {
+					p.canonical[name] = m.Tool
+				}
+			}
+		}
+	})
+}
+
+// Has reports whether the platform has the canonical tool. Every MCP tool
+// exists on every platform.
+func (p *Platform) Has(tool string) bool {
+	_, ok := p.Native(tool)
+	return ok
+}
+
+// Native returns the platform's name for a canonical tool. Unknown tools
+// are returned unchanged with false.
+func (p *Platform) Native(tool string) (string, bool) {
+	p.index()
+	if name, ok := p.native[tool]; ok {
+		return name, true
+	}
+	if server, name, ok := splitMCP(tool, MCPPrefix, MCPSeparator); ok {
+		return p.mcpName(server, name), true
+	}
+	return tool, false
+}
+
+// Canonical returns the canonical name for one of the platform's tools.
+// Unknown tools are returned unchanged with false.
+func (p *Platform) Canonical(name string) (string, bool) {
+	p.index()
+	if tool, ok := p.canonical[name]; ok {
+		return tool, true
+	}
+	if server, tool, ok := splitMCP(name, p.MCP.Prefix, p.MCP.Separator); ok {
+		return canonicalMCP(server, tool), true
+	}
+	return name, false
+}
+
+// NativeList translates a list of canonical tools, dropping duplicates
+// that arise when several tools share a name. It also returns the tools
+// the platform does not have, which are kept unchanged.
+func (p *Platform) NativeList(tools []string) (names, unknown []string) {
+	return translateList(tools, p.Native)
+}
+
+// CanonicalList translates a list of the platform's tools to canonical
+// names, like NativeList in reverse.
+func (p *Platform) CanonicalList(names []string) (tools, unknown []string) {
+	return translateList(names, p.Canonical)
+}
+
+// NativeMatcher translates a canonical hook matcher, a tool name or an
+// alternation of tool names such as "Write|Edit", to the platform's names.
+// The empty matcher and "*" select every tool and are kept; MCP patterns
+// ("mcp__.*", "mcp__github__.*") are translated where the platform can
+// express them. Other regular expressions are kept unchanged. Alternatives
+// the platform has no name for are kept unchanged and returned as unknown.
+func (p *Platform) NativeMatcher(matcher string) (string, []string) {
+	return translateMatcher(matcher, func(alt string) (string, bool) {
+		switch {
+		case alt == MCPAll:
+			if p.MCP.All == "" {
+				return alt, false
+			}
+			return p.MCP.All, true
+		case strings.HasPrefix(alt, MCPPrefix) && strings.HasSuffix(alt, MCPSeparator+".*"):
+			server := strings.TrimSuffix(strings.TrimPrefix(alt, MCPPrefix), MCPSeparator+".*")
+			return p.MCP.Prefix + server + p.MCP.ServerSuffix, true
+		}
+		return translateAlt(alt, p.Native)
+	})
+}
+
+// CanonicalMatcher translates one of the platform's hook matchers to
+// canonical names, like NativeMatcher in reverse.
+func (p *Platform) CanonicalMatcher(matcher string) (string, []string) {
+	return translateMatcher(matcher, func(alt string) (string, bool) {
+		if p.MCP.All != "" && alt == p.MCP.All {
+			return MCPAll, true
+		}
+		if server, ok := strings.CutPrefix(alt, p.MCP.Prefix); ok && p.MCP.Prefix != "" {
+			if server, ok = strings.CutSuffix(server, p.MCP.ServerSuffix); ok && server != "" && !strings.Contains(server, p.MCP.Separator) {
+				return MCPPrefix + server + MCPSeparator + ".*", true
+			}
+		}
+		return translateAlt(alt, p.Canonical)
+	})
+}
+
+// mcpName returns the platform's name for an MCP tool. An empty tool name
+// refers to the whole server.
+func (p *Platform) mcpName(server, tool string) string {
+	if tool == "" {
+		return p.MCP.Prefix + server
+	}
+	return p.MCP.Prefix + server + p.MCP.Separator + tool
+}
+
+// canonicalMCP returns the canonical name for an MCP tool.
+func canonicalMCP(server, tool string) string {
+	if tool == "" {
+		return MCPPrefix + server
+	}
+	return MCPPrefix + server + MCPSeparator + tool
+}
+
+// splitMCP splits an MCP tool name into server and tool names. A name with
+// no tool part ("mcp__github", "@github") refers to the whole server.
+func splitMCP(name, prefix, separator string) (server, tool string, ok bool) {
+	if prefix == "" {
+		return "", "", false
+	}
+	rest, ok := strings.CutPrefix(name, prefix)
+	if !ok || rest == "" || strings.ContainsAny(rest, "*|()") {
+		return "", "", false
+	}
+	server, tool, _ = strings.Cut(rest, separator)
+	return server, tool, server != ""
+}
+
+// translateList translates each name, dropping duplicate results.
+func translateList(names []string, translate func(string) (string, bool)) (out, unknown []string) {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		t, ok := translate(name)
+		if !ok {
+			unknown = append(unknown, name)
+		}
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out, unknown
+}
+
+// translateAlt translates a matcher alternative, keeping regular expressions
+// that are not tool names unchanged.
+func translateAlt(alt string, translate func(string) (string, bool)) (string, bool) {
+	name, ok := translate(alt)
+	if !ok && strings.ContainsAny(alt, `.*+?[](){}^$\`) {
+		return alt, true
+	}
+	return name, ok
+}
+
+// translateMatcher translates each alternative of a matcher, dropping
+// duplicate results.
+func translateMatcher(matcher string, translate func(string) (string, bool)) (string, []string) {
+	if matcher == "" || matcher == "*" {
+		return matcher, nil
+	}
+	alts, unknown := translateList(strings.Split(matcher, "|"), translate)
+	return strings.Join(alts, "|"), unknown
+}
+
+var (
+	mu        sync.RWMutex
+	platforms = make(map[string]*Platform)
+)
+
+// Register adds a platform to the registry, replacing any platform with
+// the same name.
+func Register(p *Platform) {
+	mu.Lock()
+	defer mu.Unlock()
+	platforms[p.Name] = p
+}
+
+// Get returns the named platform.
+func Get(name string) (*Platform, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	p, ok := platforms[name]
+	return p, ok
+}
+
+// Names returns the names of all registered platforms, sorted.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(platforms))
+	for name := range platforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Translate translates a tool name from one platform's vocabulary to
+// another's. An empty platform name stands for the canonical vocabulary.
+// Unknown platforms and tools are returned unchanged with false.
+func Translate(name, from, to string) (string, bool) {
+	tool, ok := name, true
+	if from != "" {
+		p, found := Get(from)
+		if !found {
+			return name, false
+		}
+		tool, ok = p.Canonical(name)
+	}
+	if to == "" {
+		return tool, ok
+	}
+	p, found := Get(to)
+	if !found {
+		return name, false
+	}
+	native, known := p.Native(tool)
+	return native, ok && known
+}
@@ -0,0 +1,152 @@
+package vocab
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNative(t *testing.T) {
+	tests := []struct {
+		platform *Platform
+		tool     string
+		want     string
+		ok       bool
+	}{
+		{Claude, Bash, "Bash", true},
+		{Gemini, Bash, "run_shell_command", true},
+		{Gemini, Read, "read_file", true},
+		{Kiro, Edit, "fs_write", true},
+		{Codex, Write, "apply_patch", true},
+		{Gemini, "mcp__github__create_issue", "mcp__github__create_issue", true},
+		{Kiro, "mcp__github__create_issue", "@github/create_issue", true},
+		{Kiro, "mcp__github", "@github", true},
+		{Gemini, Task, Task, false},
+		{Claude, KiroAWS, KiroAWS, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.platform.Native(tt.tool)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s.Native(%q) = %q, %v, expected %q, %v", tt.platform.Name, tt.tool, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		platform *Platform
+		name     string
+		want     string
+		ok       bool
+	}{
+		{Gemini, "run_shell_command", Bash, true},
+		{Gemini, "read_many_files", Read, true},
+		{Kiro, "shell", Bash, true},
+		{Kiro, "fs_write", Write, true},
+		{Kiro, "use_aws", KiroAWS, true},
+		{Codex, "apply_patch", Write, true},
+		{Kiro, "@github/create_issue", "mcp__github__create_issue", true},
+		{Kiro, "@github", "mcp__github", true},
+		{Claude, "mcp__github__create_issue", "mcp__github__create_issue", true},
+		{Gemini, "save_memory", "save_memory", false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.platform.Canonical(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s.Canonical(%q) = %q, %v, expected %q, %v", tt.platform.Name, tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestLists(t *testing.T) {
+	names, unknown := Kiro.NativeList([]string{Read, Write, Edit, Bash, NotebookEdit, "mcp__git"})
+	if want := []string{"fs_read", "fs_write", "execute_bash", NotebookEdit, "@git"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+	if want := []string{NotebookEdit}; !reflect.DeepEqual(unknown, want) {
+		t.Errorf("expected unknown %v, got %v", want, unknown)
+	}
+
+	tools, unknown := Gemini.CanonicalList([]string{"read_file", "read_many_files", "replace"})
+	if want := []string{Read, Edit}; !reflect.DeepEqual(tools, want) {
+		t.Errorf("expected %v, got %v", want, tools)
+	}
+	if len(unknown) != 0 {
+		t.Errorf("expected no unknown tools, got %v", unknown)
+	}
+}
+
+func TestMatchers(t *testing.T) {
+	tests := []struct {
+		platform  *Platform
+		canonical string
+		native    string
+		unknown   []string
+	}{
+		{Gemini, "", "", nil},
+		{Gemini, "*", "*", nil},
+		{Gemini, "Write|Edit", "write_file|replace", nil},
+		{Gemini, "mcp__.*", "mcp__.*", nil},
+		{Gemini, "mcp__github__.*", "mcp__github__.*", nil},
+		{Kiro, "Bash", "execute_bash", nil},
+		{Kiro, "mcp__github__.*", "@github", nil},
+		{Kiro, "mcp__github__create_issue", "@github/create_issue", nil},
+		{Kiro, "mcp__.*", "mcp__.*", []string{"mcp__.*"}},
+		{Codex, "Read|Bash", "Read|Bash", []string{"Read"}},
+		{Claude, "Notebook.*", "Notebook.*", nil},
+		{Claude, "AWS", "AWS", []string{"AWS"}},
+	}
+	for _, tt := range tests {
+		native, unknown := tt.platform.NativeMatcher(tt.canonical)
+		if native != tt.native || !reflect.DeepEqual(unknown, tt.unknown) {
+			t.Errorf("%s.NativeMatcher(%q) = %q, %v, expected %q, %v", tt.platform.Name, tt.canonical, native, unknown, tt.native, tt.unknown)
+		}
+		if tt.unknown != nil {
+			continue
+		}
+		if back, unknown := tt.platform.CanonicalMatcher(native); back != tt.canonical || unknown != nil {
+			t.Errorf("%s.CanonicalMatcher(%q) = %q, %v, expected %q", tt.platform.Name, native, back, unknown, tt.canonical)
+		}
+	}
+
+	// Write and Edit share a Kiro name, so the alternation collapses.
+	if native, _ := Kiro.NativeMatcher("Write|Edit"); native != "fs_write" {
+		t.Errorf("expected fs_write, got %q", native)
+	}
+	if back, _ := Kiro.CanonicalMatcher("shell"); back != Bash {
+		t.Errorf("expected Bash, got %q", back)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name, from, to string
+		want           string
+		ok             bool
+	}{
+		{"fs_write", "kiro", "gemini", "write_file", true},
+		{"run_shell_command", "gemini", "", Bash, true},
+		{Bash, "", "kiro", "execute_bash", true},
+		{"@git/status", "kiro", "claude", "mcp__git__status", true},
+		{"use_aws", "kiro", "gemini", KiroAWS, false},
+		{Bash, "", "unknown", Bash, false},
+	}
+	for _, tt := range tests {
+		got, ok := Translate(tt.name, tt.from, tt.to)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Translate(%q, %q, %q) = %q, %v, expected %q, %v", tt.name, tt.from, tt.to, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	want := []string{"claude", "codex", "gemini", "kiro"}
+	if got := Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if p, ok := Get("kiro"); !ok || p != Kiro {
+		t.Error("expected Kiro platform")
+	}
+	if _, ok := Get("unknown"); ok {
+		t.Error("expected unknown platform to be missing")
+	}
+}